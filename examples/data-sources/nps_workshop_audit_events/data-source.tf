@@ -0,0 +1,28 @@
+# Fail the plan's checks if anyone but Terraform's API key changed a rule in
+# the last day.
+check "no_out_of_band_rule_changes" {
+  data "nps_workshop_audit_events" "rule_changes" {
+    exclude_actors = ["apikey:terraform"]
+    event_types    = ["AUDIT_EVENT_RULE_UPSERT", "AUDIT_EVENT_RULE_DELETE"]
+    start_time     = timeadd(plantimestamp(), "-24h")
+  }
+
+  assert {
+    condition = length(data.nps_workshop_audit_events.rule_changes.events) == 0
+
+    error_message = join("\n", [
+      for e in data.nps_workshop_audit_events.rule_changes.events :
+      "${e.timestamp} ${e.actor} ${e.event} ${e.resource}"
+    ])
+  }
+}
+
+data "nps_workshop_audit_events" "alice" {
+  actors     = ["user:alice@example.com"]
+  start_time = "2026-01-01T00:00:00Z"
+  limit      = 100
+}
+
+output "alice_changes" {
+  value = [for e in data.nps_workshop_audit_events.alice.events : "${e.event} ${e.resource}"]
+}
@@ -0,0 +1,12 @@
+data "nps_workshop_cel_environment" "santa" {}
+
+# Expressions that only use these variables have their results cached by Santa.
+locals {
+  cacheable_cel_variables = [
+    for v in data.nps_workshop_cel_environment.santa.variables : v.name if v.cacheable
+  ]
+}
+
+output "cacheable_cel_variables" {
+  value = local.cacheable_cel_variables
+}
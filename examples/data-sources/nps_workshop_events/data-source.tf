@@ -0,0 +1,28 @@
+data "nps_workshop_events" "recent_blocks" {
+  match = {
+    decision = "BLOCK_BINARY"
+  }
+  start_time = "2024-01-01T00:00:00Z"
+  page_size  = 50
+}
+
+output "blocked_hashes" {
+  value = distinct([for e in data.nps_workshop_events.recent_blocks.events : e.sha256])
+}
+
+# Window through a large result set across runs by feeding next_page_token
+# back in as page_token.
+variable "events_page_token" {
+  type    = string
+  default = null
+}
+
+data "nps_workshop_events" "window" {
+  start_time = "2024-01-01T00:00:00Z"
+  page_size  = 1000
+  page_token = var.events_page_token
+}
+
+output "events_next_page_token" {
+  value = data.nps_workshop_events.window.next_page_token
+}
@@ -0,0 +1,16 @@
+data "nps_workshop_file_access_rules" "all" {
+  limit = 5000
+}
+
+# Every file access rule that watches a path under a user's ~/.ssh.
+output "ssh_rules" {
+  value = [
+    for r in data.nps_workshop_file_access_rules.all.rules : r.key
+    if anytrue([for p in concat(r.path_literals, r.path_prefixes) : strcontains(p, "/.ssh")])
+  ]
+}
+
+# Look a rule up by its natural key, the same key terraform import accepts.
+output "protect_ssh_keys_id" {
+  value = try(data.nps_workshop_file_access_rules.all.rules_by_key["global/protect-ssh-keys"].id, null)
+}
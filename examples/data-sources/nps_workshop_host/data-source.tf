@@ -0,0 +1,7 @@
+data "nps_workshop_host" "ci_builder" {
+  serial = "C02XK0AAJG5H"
+}
+
+output "ci_builder_tags" {
+  value = data.nps_workshop_host.ci_builder.tags
+}
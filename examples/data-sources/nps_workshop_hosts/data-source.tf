@@ -0,0 +1,12 @@
+data "nps_workshop_hosts" "old_santa" {
+  filter = "santa_version < \"2025.1\""
+}
+
+output "hosts_needing_upgrade" {
+  value = [for h in data.nps_workshop_hosts.old_santa.hosts : h.hostname]
+}
+
+# Look a host up by its serial number.
+output "ci_builder_tags" {
+  value = try(data.nps_workshop_hosts.old_santa.hosts_by_serial["C02XK0AAJG5H"].tags, [])
+}
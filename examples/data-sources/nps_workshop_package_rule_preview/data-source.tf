@@ -0,0 +1,22 @@
+data "nps_workshop_package_rule_preview" "wget_1x" {
+  source         = "PACKAGE_SOURCE_HOMEBREW"
+  name           = "wget"
+  rule_type      = "SIGNINGID"
+  version_regexp = "^1\\."
+}
+
+resource "nps_workshop_package_rule" "wget_1x" {
+  tag            = "global"
+  source         = "PACKAGE_SOURCE_HOMEBREW"
+  name           = "wget"
+  policy         = "ALLOWLIST"
+  rule_type      = "SIGNINGID"
+  version_regexp = "^1\\."
+
+  lifecycle {
+    precondition {
+      condition     = data.nps_workshop_package_rule_preview.wget_1x.status == "PACKAGE_STATUS_RULES_AVAILABLE" && data.nps_workshop_package_rule_preview.wget_1x.identifier_count > 0
+      error_message = "wget 1.x has no identifiers in GAL yet."
+    }
+  }
+}
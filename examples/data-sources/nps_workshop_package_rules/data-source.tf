@@ -0,0 +1,25 @@
+data "nps_workshop_package_rules" "homebrew" {
+  source = "PACKAGE_SOURCE_HOMEBREW"
+  tag    = "global"
+}
+
+output "covered_homebrew_packages" {
+  value = sort([for r in data.nps_workshop_package_rules.homebrew.rules : r.name])
+}
+
+# Warn when a package this module is about to add already has a rule on the
+# tag, e.g. one created in the Workshop UI, instead of creating a duplicate.
+variable "new_packages" {
+  type    = set(string)
+  default = ["jq", "ripgrep"]
+}
+
+check "no_duplicate_package_rules" {
+  assert {
+    condition = alltrue([
+      for name in var.new_packages :
+      !contains(keys(data.nps_workshop_package_rules.homebrew.rules_by_key), "PACKAGE_SOURCE_HOMEBREW:${name}@global")
+    ])
+    error_message = "Some of var.new_packages already have a Homebrew package rule on the global tag."
+  }
+}
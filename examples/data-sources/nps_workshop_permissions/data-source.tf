@@ -0,0 +1,5 @@
+data "nps_workshop_permissions" "all" {}
+
+output "read_permissions" {
+  value = [for p in data.nps_workshop_permissions.all.permissions : p if startswith(p, "read:")]
+}
@@ -0,0 +1,19 @@
+# Typically used from a .tftest.hcl file, with assertions on the results.
+data "nps_workshop_rule_test" "chrome" {
+  client_mode = "LOCKDOWN"
+
+  rules = [
+    { identifier = "EQHXZ8M8AV", rule_type = "TEAMID", policy = "ALLOWLIST" },
+    { identifier = "EQHXZ8M8AV:com.google.Keystone", rule_type = "SIGNINGID", policy = "BLOCKLIST" },
+  ]
+
+  samples = [
+    { name = "chrome", team_id = "EQHXZ8M8AV", signing_id = "EQHXZ8M8AV:com.google.Chrome" },
+    { name = "keystone", team_id = "EQHXZ8M8AV", signing_id = "EQHXZ8M8AV:com.google.Keystone" },
+    { name = "unknown", sha256 = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855" },
+  ]
+}
+
+output "decisions" {
+  value = { for r in data.nps_workshop_rule_test.chrome.results : r.name => r.decision }
+}
@@ -0,0 +1,13 @@
+data "nps_workshop_rules" "global_blocklist" {
+  filter = "tag = \"global\" AND policy = \"BLOCKLIST\""
+  limit  = 5000
+}
+
+output "blocked_signing_ids" {
+  value = [for r in data.nps_workshop_rules.global_blocklist.rules : r.identifier if r.rule_type == "SIGNINGID"]
+}
+
+# Look a rule up by its natural key, the same key terraform import accepts.
+output "curl_rule_id" {
+  value = try(data.nps_workshop_rules.global_blocklist.rules_by_key["SIGNINGID:platform:com.apple.curl@global"].id, null)
+}
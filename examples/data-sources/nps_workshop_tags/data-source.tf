@@ -0,0 +1,9 @@
+data "nps_workshop_tags" "all" {}
+
+# Tags nothing uses any more.
+output "unused_tags" {
+  value = [
+    for t in data.nps_workshop_tags.all.tags : t.name
+    if t.rule_count + t.file_access_rule_count + t.host_count + t.group_count + t.sync_settings_count + t.exception_count == 0
+  ]
+}
@@ -0,0 +1,21 @@
+resource "nps_workshop_rule" "allowed_teams" {
+  for_each = toset(["EQHXZ8M8AV", "UBF8T346G9"])
+
+  identifier = each.key
+  rule_type  = "TEAMID"
+  policy     = "ALLOWLIST"
+  tag        = "global"
+}
+
+# Warn about global rules someone created outside Terraform.
+check "no_unmanaged_global_rules" {
+  data "nps_workshop_unmanaged_rules" "global" {
+    filter       = "tag = \"global\""
+    managed_keys = [for r in nps_workshop_rule.allowed_teams : "${r.rule_type}:${r.identifier}@${r.tag}"]
+  }
+
+  assert {
+    condition     = length(data.nps_workshop_unmanaged_rules.global.rules) == 0
+    error_message = "Unmanaged global rules: ${join(", ", keys(data.nps_workshop_unmanaged_rules.global.rules_by_key))}"
+  }
+}
@@ -0,0 +1,7 @@
+data "nps_workshop_user" "alice" {
+  username = "alice@example.com"
+}
+
+output "alice_groups" {
+  value = data.nps_workshop_user.alice.groups
+}
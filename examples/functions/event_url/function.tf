@@ -0,0 +1,7 @@
+resource "nps_workshop_rule" "example" {
+  identifier = "EQHXZ8M8AV"
+  rule_type  = "TEAMID"
+  policy     = "BLOCKLIST"
+  tag        = "global"
+  custom_url = provider::nps::event_url("https://help.example.com/blocked", "file_sha", "machine_id")
+}
@@ -0,0 +1,15 @@
+variable "blocked_certificates" {
+  type = list(string)
+  default = [
+    "5A:1B:3C:8D:2E:4F:60:71:82:93:A4:B5:C6:D7:E8:F9:0A:1B:2C:3D:4E:5F:60:71:82:93:A4:B5:C6:D7:E8:F9",
+  ]
+}
+
+resource "nps_workshop_rule" "blocked_certificate" {
+  for_each = toset([for c in var.blocked_certificates : provider::nps::normalize_sha256(c)])
+
+  identifier = each.value
+  rule_type  = "CERTIFICATE"
+  policy     = "BLOCKLIST"
+  tag        = "global"
+}
@@ -0,0 +1,13 @@
+resource "nps_workshop_rule" "chrome" {
+  identifier = provider::nps::signing_id("EQHXZ8M8AV", "com.google.Chrome")
+  rule_type  = "SIGNINGID"
+  policy     = "ALLOWLIST"
+  tag        = "global"
+}
+
+resource "nps_workshop_rule" "curl" {
+  identifier = provider::nps::signing_id("platform", "com.apple.curl")
+  rule_type  = "SIGNINGID"
+  policy     = "BLOCKLIST"
+  tag        = "global"
+}
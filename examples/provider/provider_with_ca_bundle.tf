@@ -0,0 +1,4 @@
+provider "nps" {
+  endpoint       = "workshop.corp.example.com"
+  ca_bundle_file = "/etc/ssl/corp-root-ca.pem"
+}
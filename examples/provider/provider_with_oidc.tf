@@ -0,0 +1,6 @@
+# In a GitHub Actions job with `permissions: id-token: write`.
+provider "nps" {
+  endpoint       = "api.tenant.workshop.cloud"
+  auth           = "oidc"
+  oidc_token_url = "https://sts.example.com/oauth2/token"
+}
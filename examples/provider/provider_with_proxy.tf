@@ -0,0 +1,4 @@
+provider "nps" {
+  endpoint  = "api.tenant.workshop.cloud"
+  proxy_url = "http://proxy.example.com:3128"
+}
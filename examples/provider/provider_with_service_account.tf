@@ -0,0 +1,8 @@
+provider "nps" {
+  endpoint = "api.tenant.workshop.cloud"
+  service_account = {
+    issuer    = "terraform-ci@tenant.workshop.cloud"
+    key_file  = "/run/secrets/workshop-service-account.pem"
+    token_url = "https://idp.example.com/oauth2/token"
+  }
+}
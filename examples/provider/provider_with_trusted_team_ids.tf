@@ -0,0 +1,11 @@
+# The registry is a module in its own repository, whose owners review changes
+# to it. Every workspace that manages rules reads it from there.
+module "team_id_registry" {
+  source = "git::https://git.example.com/security/santa-registry.git//team_ids?ref=v3"
+}
+
+provider "nps" {
+  endpoint                 = "api.tenant.workshop.cloud"
+  trusted_team_ids         = module.team_id_registry.trusted_team_ids
+  untrusted_team_id_action = "error"
+}
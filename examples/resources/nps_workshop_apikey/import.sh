@@ -0,0 +1,3 @@
+# Import by key name. The secret is only available when a key is created, so
+# it is null in the state of an imported key.
+terraform import nps_workshop_apikey.ci ci
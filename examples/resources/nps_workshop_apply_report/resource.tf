@@ -0,0 +1,10 @@
+resource "nps_workshop_apply_report" "rules" {
+  tags        = ["eng", "global"]
+  output_file = "${path.root}/rule-report.txt"
+
+  # Run the report on every apply, after the rules it counts.
+  triggers = {
+    applied_at = timestamp()
+  }
+  depends_on = [nps_workshop_rule.yes]
+}
@@ -0,0 +1 @@
+terraform import nps_workshop_approval_workflow.engineering engineering
@@ -0,0 +1,26 @@
+# Engineers vote on software: three votes approve it for the requester, and
+# ten approve it for the whole tag.
+resource "nps_workshop_approval_workflow" "engineering" {
+  tag                 = "engineering"
+  approval_rule_type  = "SIGNINGID"
+  notification_method = "NOTIFICATION_METHOD_SLACK"
+
+  social_voting = {
+    local_threshold             = 3
+    tag_threshold               = 10
+    tags_to_consider_votes_from = ["engineering"]
+    require_justification       = true
+  }
+}
+
+# Finance users need two approvals from the security team.
+resource "nps_workshop_approval_workflow" "finance" {
+  tag                = "finance"
+  approval_rule_type = "BINARY"
+
+  designated_approver = {
+    approver_tag = "security"
+    threshold    = 2
+    chat_channel = "#software-approvals"
+  }
+}
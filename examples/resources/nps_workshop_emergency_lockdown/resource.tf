@@ -0,0 +1,17 @@
+resource "nps_workshop_emergency_lockdown" "incident" {
+  tags    = ["dev", "prod"]
+  confirm = "I understand this locks down every host in these tags"
+  reason  = "INC-1234"
+
+  blocklist = [
+    {
+      identifier = "EQHXZ8M8AV:com.example.dropper"
+      rule_type  = "SIGNINGID"
+      custom_msg = "Blocked during incident INC-1234"
+    },
+    {
+      identifier = "a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f90"
+      rule_type  = "BINARY"
+    },
+  ]
+}
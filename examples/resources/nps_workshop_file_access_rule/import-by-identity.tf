@@ -0,0 +1,9 @@
+# Import several rules at once into a resource with the same for_each keys.
+import {
+  for_each = toset(["global/ProtectSSHKeys", "dev/ProtectBrowserData"])
+  to       = nps_workshop_file_access_rule.protected[each.key]
+  identity = {
+    tag  = split("/", each.key)[0]
+    name = split("/", each.key)[1]
+  }
+}
@@ -1 +1,5 @@
+# Import by rule ID.
 terraform import nps_workshop_file_access_rule.example 12345
+
+# Rule names are unique per tag, so a rule can also be imported as <tag>/<name>.
+terraform import nps_workshop_file_access_rule.example global/ProtectSSHKeys
@@ -0,0 +1,7 @@
+# Promote a rule piloted on one tag to production. Changes to the pilot rule
+# are copied on the next apply.
+resource "nps_workshop_file_access_rule_clone" "ssh_keys" {
+  source_tag  = "pilot"
+  source_name = "protect-ssh-keys"
+  target_tag  = "production"
+}
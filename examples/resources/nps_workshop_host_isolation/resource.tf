@@ -0,0 +1,9 @@
+resource "nps_workshop_tag" "isolated" {
+  name = "isolated"
+}
+
+resource "nps_workshop_host_isolation" "laptop" {
+  machine_id    = "A1B2C3D4-E5F6-7890-ABCD-EF1234567890"
+  isolation_tag = nps_workshop_tag.isolated.name
+  reason        = "INC-1234"
+}
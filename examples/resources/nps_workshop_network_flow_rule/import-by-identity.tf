@@ -0,0 +1,7 @@
+import {
+  to = nps_workshop_network_flow_rule.block_spacemolt
+  identity = {
+    tag  = "global"
+    name = "block-spacemolt"
+  }
+}
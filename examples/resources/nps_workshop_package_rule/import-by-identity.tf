@@ -0,0 +1,8 @@
+import {
+  to = nps_workshop_package_rule.example
+  identity = {
+    tag    = "global"
+    source = "PACKAGE_SOURCE_HOMEBREW"
+    name   = "wget"
+  }
+}
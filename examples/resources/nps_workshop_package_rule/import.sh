@@ -0,0 +1 @@
+terraform import nps_workshop_package_rule.example 12345
@@ -0,0 +1 @@
+terraform import nps_workshop_role.viewer viewer
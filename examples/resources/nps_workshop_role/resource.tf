@@ -0,0 +1,9 @@
+resource "nps_workshop_role" "viewer" {
+  name = "viewer"
+  permissions = [
+    "read:events",
+    "read:hosts",
+    "read:rules",
+    "read:tags",
+  ]
+}
@@ -0,0 +1,8 @@
+import {
+  to = nps_workshop_rule.test
+  identity = {
+    rule_type  = "SIGNINGID"
+    identifier = "platform:com.apple.curl"
+    tag        = "global"
+  }
+}
@@ -1,2 +1,6 @@
+# Import by rule ID.
 terraform import nps_workshop_rule.test 90216EA0-B60E-42EF-996A-37212B8F378D
 
+# Rule IDs change whenever a rule is updated, so a rule can also be imported
+# by its rule type, identifier, and tag, as RULE_TYPE:identifier@tag.
+terraform import nps_workshop_rule.test SIGNINGID:platform:com.apple.curl@global
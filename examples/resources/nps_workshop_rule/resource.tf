@@ -20,3 +20,15 @@ resource "nps_workshop_rule" "yes" {
     host_count = 50
   }
 }
+
+# An exception for a single machine, scoped with a host: tag.
+data "nps_workshop_host" "ci_builder" {
+  serial = "C02XK0AAJG5H"
+}
+
+resource "nps_workshop_rule" "ci_builder_team" {
+  identifier = "EQHXZ8M8AV"
+  rule_type  = "TEAMID"
+  policy     = "ALLOWLIST"
+  tag        = "host:${data.nps_workshop_host.ci_builder.machine_id}"
+}
@@ -2,6 +2,7 @@ resource "nps_workshop_sync_settings" "dev_settings" {
   tag = "dev"
 
   client_mode = "MONITOR"
+  batch_size  = 100
 
   # Set to an empty string to explicitly clear a value inherited from a
   # lower-precedence tag. Omit the attribute entirely to leave it unset.
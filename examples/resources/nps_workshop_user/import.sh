@@ -0,0 +1 @@
+terraform import nps_workshop_user.breakglass breakglass@example.com
@@ -0,0 +1,6 @@
+resource "nps_workshop_user" "breakglass" {
+  username         = "breakglass@example.com"
+  manager_email    = "ciso@example.com"
+  department_name  = "Security"
+  cost_center_name = "IT"
+}
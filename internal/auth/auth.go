@@ -4,12 +4,15 @@ package auth
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"os/user"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -19,59 +22,293 @@ import (
 )
 
 const (
-	// Path, relative to the user's home directory, where the token should be stored.
-	tokenFilePathSuffix = ".config/tf_nps_token.json"
+	// Directory, relative to the user's home directory, where tokens are
+	// stored. Each endpoint gets its own file so that aliased providers
+	// pointing at different Workshop instances don't overwrite each other's
+	// tokens.
+	tokenDirSuffix = ".config/nps/tokens"
+
+	// Path, relative to the user's home directory, where tokens were stored
+	// before they were kept per endpoint. The file doesn't record which
+	// endpoint issued its token, so it is never sent: an endpoint without a
+	// token of its own asks for -login, which then removes the old file.
+	legacyTokenFileSuffix = ".config/tf_nps_token.json"
 )
 
+// tokenFilePath returns the path of the token file for the given endpoint.
+// Characters that can't appear in a file name (the port separator, and any
+// path separators) are replaced so e.g. "localhost:8080" maps to
+// "localhost_8080.json".
+//
+// WORKSHOP_TOKEN_FILE overrides the path entirely, for environments such as
+// minimal containers where no home directory can be found. It is the same
+// file for every endpoint, so aliased providers for different endpoints that
+// log in with tokens can't share a process environment that sets it.
+// Otherwise tokens live under $XDG_CONFIG_HOME/nps/tokens, falling back to
+// ~/.config/nps/tokens.
+func tokenFilePath(serverURL string) (string, error) {
+	if p := os.Getenv("WORKSHOP_TOKEN_FILE"); p != "" {
+		return p, nil
+	}
+
+	name := strings.NewReplacer(":", "_", "/", "_", "\\", "_").Replace(serverURL) + ".json"
+	if dir := os.Getenv("XDG_CONFIG_HOME"); filepath.IsAbs(dir) {
+		return filepath.Join(dir, "nps", "tokens", name), nil
+	}
+
+	home, err := homeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, tokenDirSuffix, name), nil
+}
+
+// legacyTokenFilePath returns the path tokens were stored at before they were
+// kept per endpoint, or "" if WORKSHOP_TOKEN_FILE is set or there is no home
+// directory.
+func legacyTokenFilePath() string {
+	if os.Getenv("WORKSHOP_TOKEN_FILE") != "" {
+		return ""
+	}
+	home, err := homeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, legacyTokenFileSuffix)
+}
+
+// currentUser looks up the user database; tests replace it to simulate a
+// container whose user has no passwd entry.
+var currentUser = user.Current
+
+// homeDir returns the user's home directory, from HOME or, failing that, the
+// user database.
+func homeDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		usr, uerr := currentUser()
+		if uerr != nil || usr.HomeDir == "" {
+			//lint:ignore ST1005 This error is directly presented to the user without
+			// any prefix so we need to capitalize it.
+			return "", fmt.Errorf("Unable to determine a home directory to store login tokens in. Set HOME or XDG_CONFIG_HOME, set WORKSHOP_TOKEN_FILE to an explicit token path, or use an API key instead")
+		}
+		home = usr.HomeDir
+	}
+	return home, nil
+}
+
+// Errors GetAndStoreToken returns when the user doesn't complete the login,
+// so that the login command can exit with a distinct status for each.
+var (
+	ErrLoginExpired = errors.New("the device code expired before the login was authorized")
+	ErrLoginDenied  = errors.New("the login was denied")
+)
+
+// LoginOptions configures the login command.
+type LoginOptions struct {
+	// JSON reports progress on stdout as JSON lines (see loginEvent) for
+	// scripts to present, and opens no browser.
+	JSON bool
+	// NoBrowser prints the authorization URL without opening a browser.
+	NoBrowser bool
+	// Timeout, if positive, is how long to wait for the user to authorize
+	// the login, after which it fails with ErrLoginExpired.
+	Timeout time.Duration
+}
+
 // Used by the login command to retrieve and store a device access token.
-func GetAndStoreToken(ctx context.Context, serverURL string) error {
-	cfg, _, err := createConfig(ctx, serverURL)
+func GetAndStoreToken(ctx context.Context, serverURL string, opts LoginOptions) error {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+	out := loginOutput{w: os.Stdout, json: opts.JSON, noBrowser: opts.NoBrowser}
+	err := getAndStoreToken(ctx, serverURL, out)
+	if err != nil {
+		out.event(loginEvent{Status: "error", Error: err.Error(), Reason: loginErrorReason(err)})
+	}
+	return err
+}
+
+// loginErrorReason returns the reason in the error event for err.
+func loginErrorReason(err error) string {
+	switch {
+	case errors.Is(err, ErrLoginExpired):
+		return "expired"
+	case errors.Is(err, ErrLoginDenied):
+		return "denied"
+	}
+	return ""
+}
+
+func getAndStoreToken(ctx context.Context, serverURL string, out loginOutput) error {
+	cfg, _, err := createConfig(ctx, serverURL, nil)
+	if err != nil {
+		return err
+	}
+	tokenPath, err := tokenFilePath(serverURL)
 	if err != nil {
 		return err
 	}
+	return deviceLogin(ctx, cfg, tokenPath, out)
+}
 
-	deviceAuthResp, err := cfg.DeviceAuth(context.Background())
+// Logout deletes the token the login command stored for serverURL, and
+// reports whether there was one. The token isn't revoked server-side: the
+// login service offers device clients no revocation endpoint, so a copy of
+// the refresh token stays valid until it expires.
+func Logout(serverURL string) (bool, error) {
+	tokenPath, err := tokenFilePath(serverURL)
 	if err != nil {
-		return fmt.Errorf("failed to request device authorization: %v", err)
+		return false, err
+	}
+	if err := deleteTokenFromFile(tokenPath); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to delete token file: %v", err)
 	}
+	return true, nil
+}
 
-	browser.OpenURL(deviceAuthResp.VerificationURIComplete)
+// deviceLogin runs the device flow for cfg and writes the token to tokenPath.
+func deviceLogin(ctx context.Context, cfg *oauth2.Config, tokenPath string, out loginOutput) error {
+	deviceAuthResp, err := cfg.DeviceAuth(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to request device authorization: %v", err)
+	}
 
-	fmt.Println("Attempting to automatically open the SSO authorization page in your default browser.")
-	fmt.Println("If the browser does not open or you wish to use a different device to authorize this request, open the following URL:")
-	fmt.Println()
-	fmt.Printf("%s\n", deviceAuthResp.VerificationURIComplete)
-	fmt.Println()
-	fmt.Println("Waiting for token...")
+	if out.json {
+		ev := loginEvent{
+			Status:                  "authorization_required",
+			VerificationURI:         deviceAuthResp.VerificationURI,
+			VerificationURIComplete: deviceAuthResp.VerificationURIComplete,
+			UserCode:                deviceAuthResp.UserCode,
+		}
+		if !deviceAuthResp.Expiry.IsZero() {
+			ev.ExpiresAt = deviceAuthResp.Expiry.UTC().Format(time.RFC3339)
+		}
+		out.event(ev)
+
+		// Report each poll of the token endpoint.
+		polls := 0
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			polls++
+			out.event(loginEvent{Status: "polling", Attempt: polls})
+			return http.DefaultTransport.RoundTrip(req)
+		})})
+	} else if out.noBrowser {
+		fmt.Fprintln(out.w, "To authorize this request, open the following URL on any device:")
+		fmt.Fprintln(out.w)
+		fmt.Fprintf(out.w, "%s\n", deviceAuthResp.VerificationURIComplete)
+		fmt.Fprintln(out.w)
+		fmt.Fprintf(out.w, "and confirm the code %s.\n", deviceAuthResp.UserCode)
+		fmt.Fprintln(out.w)
+		fmt.Fprintln(out.w, "Waiting for token...")
+	} else {
+		browser.OpenURL(deviceAuthResp.VerificationURIComplete)
+
+		fmt.Fprintln(out.w, "Attempting to automatically open the SSO authorization page in your default browser.")
+		fmt.Fprintln(out.w, "If the browser does not open or you wish to use a different device to authorize this request, open the following URL:")
+		fmt.Fprintln(out.w)
+		fmt.Fprintf(out.w, "%s\n", deviceAuthResp.VerificationURIComplete)
+		fmt.Fprintln(out.w)
+		fmt.Fprintln(out.w, "Waiting for token...")
+	}
 
-	// This will block until the user has authorized the request or the device
-	// code expires.
+	// This will block until the user has authorized the request, the device
+	// code expires, or ctx is done.
 	token, err := cfg.DeviceAccessToken(ctx, deviceAuthResp)
 	if err != nil {
+		// DeviceAccessToken gives up with the context's error when the
+		// device code expires.
+		var rerr *oauth2.RetrieveError
+		errors.As(err, &rerr)
+		switch {
+		case rerr != nil && rerr.ErrorCode == "access_denied":
+			return ErrLoginDenied
+		case rerr != nil && rerr.ErrorCode == "expired_token", errors.Is(err, context.DeadlineExceeded):
+			return ErrLoginExpired
+		}
 		return fmt.Errorf("failed to get device access token: %v", err)
 	}
 
 	addTokenExpiry(token)
 
 	// Write the token out to the file so it's ready for use in RPC requests.
-	if err := writeTokenToFile(token); err != nil {
+	if err := writeTokenToFile(tokenPath, token); err != nil {
 		return fmt.Errorf("failed to write token to file: %v", err)
 	}
+	if p := legacyTokenFilePath(); p != "" {
+		if err := deleteTokenFromFile(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+			fmt.Fprintf(os.Stderr, "Failed to remove the token file of an earlier version at %s: %v\n", p, err)
+		}
+	}
 
-	fmt.Println("Successfully logged in")
+	if out.json {
+		out.event(loginEvent{Status: "success", TokenFile: tokenPath})
+	} else {
+		fmt.Fprintln(out.w, "Successfully logged in")
+	}
 	return nil
 }
 
+// loginEvent is a line of -login-json output. Status is one of:
+//   - "authorization_required": the user must visit VerificationURI and enter
+//     UserCode, or visit VerificationURIComplete, before ExpiresAt.
+//   - "polling": the token endpoint was asked, for the Attempt'th time,
+//     whether the user has authorized the request.
+//   - "success": the token was stored in TokenFile.
+//   - "error": the login failed with Error. This is always the last line.
+//     Reason is "expired" if the device code expired or the login timed out
+//     first, "denied" if the user refused the login, and empty otherwise.
+type loginEvent struct {
+	Status                  string `json:"status"`
+	VerificationURI         string `json:"verification_uri,omitempty"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	UserCode                string `json:"user_code,omitempty"`
+	ExpiresAt               string `json:"expires_at,omitempty"`
+	Attempt                 int    `json:"attempt,omitempty"`
+	TokenFile               string `json:"token_file,omitempty"`
+	Error                   string `json:"error,omitempty"`
+	Reason                  string `json:"reason,omitempty"`
+}
+
+// loginOutput is where the login command reports progress.
+type loginOutput struct {
+	w         io.Writer
+	json      bool
+	noBrowser bool
+}
+
+// event writes ev as a JSON line, in JSON mode only.
+func (o loginOutput) event(ev loginEvent) {
+	if !o.json {
+		return
+	}
+	b, _ := json.Marshal(ev)
+	o.w.Write(append(b, '\n'))
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
 // Used by the provider to retrieve a usable credentials.PerRPCCredentials call option.
 // The required credentials come from:
 //  1. The WORKSHOP_API_KEY environment variable
 //  2. The input key from the Terraform provider config
-//  3. A valid token stored in the user's home directory
+//  3. A valid token stored in the user's home directory for serverURL
 //
 // If no valid credentials are found, an error is returned advising the user to run
 // the provider binary with the -login flag so that a new device access token can be
 // retrieved.
-func APIKeyOrToken(ctx context.Context, inputKey, serverURL string) (credentials.PerRPCCredentials, error) {
+//
+// httpClient, if non-nil, makes the HTTP requests for tokens, e.g. through a
+// proxy. Otherwise http.DefaultClient does, which follows HTTPS_PROXY and
+// NO_PROXY.
+func APIKeyOrToken(ctx context.Context, inputKey, serverURL string, httpClient *http.Client) (credentials.PerRPCCredentials, error) {
 	// First try to get an API key from the environment.
 	if e := os.Getenv("WORKSHOP_API_KEY"); e != "" {
 		return apiKeyAuthorizer(e), nil
@@ -82,16 +319,35 @@ func APIKeyOrToken(ctx context.Context, inputKey, serverURL string) (credentials
 		return apiKeyAuthorizer(inputKey), nil
 	}
 
-	cfg, insecure, err := createConfig(ctx, serverURL)
+	cfg, insecure, err := createConfig(ctx, serverURL, httpClient)
 	if err != nil {
 		return nil, err
 	}
 
 	// Those failed, let's see if there's a valid token?
-	token := apiTokenFromFile(ctx)
+	tokenPath, err := tokenFilePath(serverURL)
+	if err != nil {
+		return nil, err
+	}
+	token := apiTokenFromFile(ctx, tokenPath)
 	if token != nil {
-		tflog.Info(ctx, "Using existing API token from file")
-		return oauthRPCCreds{ts: cfg.TokenSource(context.Background(), token), insecure: insecure, serverURL: serverURL}, nil
+		tflog.Info(ctx, "Using existing API token from file", map[string]any{"path": tokenPath})
+		// The token source refreshes with the client in its context.
+		tsCtx := context.Background()
+		if httpClient != nil {
+			tsCtx = context.WithValue(tsCtx, oauth2.HTTPClient, httpClient)
+		}
+		return oauthRPCCreds{ts: cfg.TokenSource(tsCtx, token), insecure: insecure, serverURL: serverURL, tokenPath: tokenPath}, nil
+	}
+
+	// A token stored by an earlier version could have been issued by any
+	// endpoint, so rather than sending it here, say why it isn't used.
+	if p := legacyTokenFilePath(); p != "" {
+		if _, err := os.Stat(p); err == nil {
+			//lint:ignore ST1005 This error is directly presented to the user without
+			// any prefix so we need to capitalize it.
+			return nil, fmt.Errorf("Not logged in. Tokens are now stored per endpoint, and the token at %s from an earlier version isn't used because it doesn't record which endpoint issued it. Run the following to login:\n\n\t%s -login %s", p, os.Args[0], serverURL)
+		}
 	}
 
 	//lint:ignore ST1005 This error is directly presented to the user without
@@ -99,7 +355,11 @@ func APIKeyOrToken(ctx context.Context, inputKey, serverURL string) (credentials
 	return nil, fmt.Errorf("Not logged in. Run the following to login:\n\n\t%s -login %s", os.Args[0], serverURL)
 }
 
-func createConfig(_ context.Context, endpoint string) (*oauth2.Config, bool, error) {
+func createConfig(_ context.Context, endpoint string, httpClient *http.Client) (*oauth2.Config, bool, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
 	insecure := false
 	if endpoint == "localhost:8080" {
 		insecure = true
@@ -108,7 +368,7 @@ func createConfig(_ context.Context, endpoint string) (*oauth2.Config, bool, err
 		endpoint = fmt.Sprintf("https://%s/.well-known/workos-client-id", endpoint)
 	}
 
-	resp, err := http.Get(endpoint)
+	resp, err := httpClient.Get(endpoint)
 	if err != nil || resp.StatusCode != 200 {
 		return nil, insecure, fmt.Errorf("failed to get client ID from endpoint: %v", err)
 	}
@@ -127,11 +387,7 @@ func createConfig(_ context.Context, endpoint string) (*oauth2.Config, bool, err
 	}, insecure, nil
 }
 
-func apiTokenFromFile(ctx context.Context) *oauth2.Token {
-	usr, _ := user.Current()
-	dir := usr.HomeDir
-	filePath := filepath.Join(dir, tokenFilePathSuffix)
-
+func apiTokenFromFile(ctx context.Context, filePath string) *oauth2.Token {
 	fileContent, err := os.ReadFile(filePath)
 	if err != nil {
 		tflog.Error(ctx, "Failed to read API token from file", map[string]any{"err": err})
@@ -152,10 +408,10 @@ func apiTokenFromFile(ctx context.Context) *oauth2.Token {
 	return &t
 }
 
-func writeTokenToFile(token *oauth2.Token) error {
-	usr, _ := user.Current()
-	dir := usr.HomeDir
-	filePath := filepath.Join(dir, tokenFilePathSuffix)
+func writeTokenToFile(filePath string, token *oauth2.Token) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
+		return err
+	}
 
 	b, err := json.Marshal(token)
 	if err != nil {
@@ -165,10 +421,7 @@ func writeTokenToFile(token *oauth2.Token) error {
 	return os.WriteFile(filePath, b, 0600)
 }
 
-func deleteTokenFromFile() error {
-	usr, _ := user.Current()
-	dir := usr.HomeDir
-	filePath := filepath.Join(dir, tokenFilePathSuffix)
+func deleteTokenFromFile(filePath string) error {
 	return os.Remove(filePath)
 }
 
@@ -188,13 +441,14 @@ func (k apiKeyAuthorizer) RequireTransportSecurity() bool {
 type oauthRPCCreds struct {
 	ts        oauth2.TokenSource
 	serverURL string
+	tokenPath string
 	insecure  bool
 }
 
 func (o oauthRPCCreds) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
 	token, err := o.ts.Token()
 	if err != nil {
-		if err := deleteTokenFromFile(); err != nil {
+		if err := deleteTokenFromFile(o.tokenPath); err != nil {
 			tflog.Error(ctx, "Failed to delete token from file", map[string]any{"err": err})
 		}
 		return nil, fmt.Errorf("%w. Run the following to login:\n\n\t%s -login %s", err, os.Args[0], o.serverURL)
@@ -204,13 +458,12 @@ func (o oauthRPCCreds) GetRequestMetadata(ctx context.Context, uri ...string) (m
 	addTokenExpiry(token)
 
 	// Write the token to the file.
-	writeTokenToFile(token)
+	if err := writeTokenToFile(o.tokenPath, token); err != nil {
+		tflog.Warn(ctx, "Failed to write refreshed token to file", map[string]any{"err": err, "path": o.tokenPath})
+	}
 
-	if !o.insecure {
-		ri, _ := credentials.RequestInfoFromContext(ctx)
-		if err = credentials.CheckSecurityLevel(ri.AuthInfo, credentials.PrivacyAndIntegrity); err != nil {
-			return nil, fmt.Errorf("unable to transfer TokenSource PerRPCCredentials: %v", err)
-		}
+	if err := checkSecurityLevel(ctx, o.insecure); err != nil {
+		return nil, err
 	}
 
 	return map[string]string{
@@ -222,6 +475,18 @@ func (o oauthRPCCreds) RequireTransportSecurity() bool {
 	return !o.insecure
 }
 
+// checkSecurityLevel refuses to send a token over a connection without TLS,
+// unless insecure. gRPC reports the connection's security level. The Connect
+// transport doesn't go through gRPC, and enforces TLS itself.
+func checkSecurityLevel(ctx context.Context, insecure bool) error {
+	if ri, ok := credentials.RequestInfoFromContext(ctx); ok && !insecure {
+		if err := credentials.CheckSecurityLevel(ri.AuthInfo, credentials.PrivacyAndIntegrity); err != nil {
+			return fmt.Errorf("unable to transfer TokenSource PerRPCCredentials: %v", err)
+		}
+	}
+	return nil
+}
+
 // addTokenExpiry adds the expiry time to the token if it's not already set.
 // The response from WorkOS doesn't include an expiry time so we have to parse
 // the access token JWT ourselves and add the expiry to the "outer" token. This
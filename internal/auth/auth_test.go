@@ -0,0 +1,170 @@
+// Copyright 2026 North Pole Security, Inc.
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// setTokenHome points token storage at a temporary home directory.
+func setTokenHome(t *testing.T) string {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("WORKSHOP_TOKEN_FILE", "")
+	return home
+}
+
+func TestTokenFilePathPerEndpoint(t *testing.T) {
+	home := setTokenHome(t)
+
+	for endpoint, want := range map[string]string{
+		"api.example.workshop.cloud": "api.example.workshop.cloud.json",
+		"localhost:8080":             "localhost_8080.json",
+		"proxy.example.com/workshop": "proxy.example.com_workshop.json",
+		`host\tenant`:                "host_tenant.json",
+	} {
+		got, err := tokenFilePath(endpoint)
+		if err != nil {
+			t.Fatalf("tokenFilePath(%q): %v", endpoint, err)
+		}
+		if want := filepath.Join(home, ".config/nps/tokens", want); got != want {
+			t.Errorf("tokenFilePath(%q) = %q, want %q", endpoint, got, want)
+		}
+	}
+
+	a, _ := tokenFilePath("a.workshop.cloud")
+	b, _ := tokenFilePath("b.workshop.cloud")
+	if a == b {
+		t.Errorf("distinct endpoints share the token file %q", a)
+	}
+}
+
+func TestAPIKeyOrTokenIgnoresLegacyToken(t *testing.T) {
+	ctx := context.Background()
+	home := setTokenHome(t)
+	t.Setenv("WORKSHOP_API_KEY", "")
+
+	legacy := filepath.Join(home, ".config/tf_nps_token.json")
+	if err := writeTokenToFile(legacy, &oauth2.Token{AccessToken: "legacy", TokenType: "Bearer", Expiry: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("client-id"))
+	}))
+	defer srv.Close()
+	endpoint := strings.TrimPrefix(srv.URL, "https://")
+
+	// The old file doesn't say which endpoint issued its token, so it must
+	// never be sent to this one.
+	creds, err := APIKeyOrToken(ctx, "", endpoint, srv.Client())
+	if err == nil {
+		t.Fatalf("APIKeyOrToken() with only a legacy token = %v, want a not logged in error", creds)
+	}
+	if !strings.Contains(err.Error(), legacy) || !strings.Contains(err.Error(), "-login "+endpoint) {
+		t.Errorf("error = %q, want it to name the legacy file and the -login command", err)
+	}
+	if _, err := os.Stat(legacy); err != nil {
+		t.Errorf("legacy token file was touched: %v", err)
+	}
+	tokenPath, _ := tokenFilePath(endpoint)
+	if _, err := os.Stat(tokenPath); !os.IsNotExist(err) {
+		t.Errorf("endpoint token file was created: %v", err)
+	}
+}
+
+func TestLogoutKeepsLegacyToken(t *testing.T) {
+	home := setTokenHome(t)
+	legacy := filepath.Join(home, ".config/tf_nps_token.json")
+	if err := writeTokenToFile(legacy, &oauth2.Token{AccessToken: "legacy"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if deleted, err := Logout("api.example.com"); err != nil || deleted {
+		t.Fatalf("Logout() = %v, %v; want nothing deleted", deleted, err)
+	}
+	if _, err := os.Stat(legacy); err != nil {
+		t.Errorf("Logout deleted the legacy token file of another endpoint: %v", err)
+	}
+}
+
+func TestTokenFilePathResolution(t *testing.T) {
+	home := t.TempDir()
+	tests := []struct {
+		name      string
+		tokenFile string
+		xdg       string
+		home      string
+		user      *user.User
+		want      string
+		wantErr   string
+	}{
+		{
+			name:      "WORKSHOP_TOKEN_FILE wins",
+			tokenFile: "/run/secrets/token.json",
+			xdg:       "/xdg",
+			home:      home,
+			want:      "/run/secrets/token.json",
+		},
+		{
+			name: "XDG_CONFIG_HOME",
+			xdg:  "/xdg",
+			home: home,
+			want: "/xdg/nps/tokens/api.example.com.json",
+		},
+		{
+			name: "relative XDG_CONFIG_HOME is ignored",
+			xdg:  "relative",
+			home: home,
+			want: filepath.Join(home, ".config/nps/tokens/api.example.com.json"),
+		},
+		{
+			name: "HOME",
+			home: home,
+			want: filepath.Join(home, ".config/nps/tokens/api.example.com.json"),
+		},
+		{
+			name: "passwd entry without HOME",
+			user: &user.User{HomeDir: "/home/ci"},
+			want: "/home/ci/.config/nps/tokens/api.example.com.json",
+		},
+		{
+			name:    "no home directory",
+			wantErr: "Unable to determine a home directory to store login tokens in. Set HOME or XDG_CONFIG_HOME, set WORKSHOP_TOKEN_FILE to an explicit token path, or use an API key instead",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("WORKSHOP_TOKEN_FILE", tt.tokenFile)
+			t.Setenv("XDG_CONFIG_HOME", tt.xdg)
+			t.Setenv("HOME", tt.home)
+			currentUser = func() (*user.User, error) {
+				if tt.user == nil {
+					return nil, user.UnknownUserIdError(1000)
+				}
+				return tt.user, nil
+			}
+			t.Cleanup(func() { currentUser = user.Current })
+
+			got, err := tokenFilePath("api.example.com")
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("tokenFilePath() = %q, %v; want error %q", got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("tokenFilePath() = %q, %v; want %q", got, err, tt.want)
+			}
+		})
+	}
+}
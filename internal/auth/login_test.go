@@ -0,0 +1,167 @@
+// Copyright 2026 North Pole Security, Inc.
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestDeviceLoginJSON(t *testing.T) {
+	polls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"device_code":               "dev-code",
+			"user_code":                 "ABCD-EFGH",
+			"verification_uri":          "https://login.example.com/device",
+			"verification_uri_complete": "https://login.example.com/device?code=ABCD-EFGH",
+			"expires_in":                300,
+			"interval":                  1,
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		w.Header().Set("Content-Type", "application/json")
+		if polls == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]any{"error": "authorization_pending"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"access_token": "tok", "refresh_token": "refresh", "token_type": "Bearer"})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	cfg := &oauth2.Config{
+		ClientID: "client",
+		Endpoint: oauth2.Endpoint{DeviceAuthURL: srv.URL + "/device", TokenURL: srv.URL + "/token"},
+	}
+	tokenPath := filepath.Join(t.TempDir(), "token.json")
+	var buf bytes.Buffer
+	if err := deviceLogin(context.Background(), cfg, tokenPath, loginOutput{w: &buf, json: true}); err != nil {
+		t.Fatalf("deviceLogin() error: %v", err)
+	}
+
+	var events []loginEvent
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var ev loginEvent
+		if err := dec.Decode(&ev); err != nil {
+			t.Fatalf("output isn't JSON lines: %v", err)
+		}
+		events = append(events, ev)
+	}
+
+	var statuses []string
+	for _, ev := range events {
+		statuses = append(statuses, ev.Status)
+	}
+	want := []string{"authorization_required", "polling", "polling", "success"}
+	if len(statuses) != len(want) {
+		t.Fatalf("statuses = %v, want %v", statuses, want)
+	}
+	for i := range want {
+		if statuses[i] != want[i] {
+			t.Fatalf("statuses = %v, want %v", statuses, want)
+		}
+	}
+	if ev := events[0]; ev.UserCode != "ABCD-EFGH" || ev.VerificationURI != "https://login.example.com/device" || ev.ExpiresAt == "" {
+		t.Errorf("authorization_required event = %+v", ev)
+	}
+	if events[2].Attempt != 2 {
+		t.Errorf("second polling event attempt = %d, want 2", events[2].Attempt)
+	}
+	if events[3].TokenFile != tokenPath {
+		t.Errorf("token_file = %q, want %q", events[3].TokenFile, tokenPath)
+	}
+	if _, err := os.Stat(tokenPath); err != nil {
+		t.Errorf("token wasn't stored: %v", err)
+	}
+}
+
+func TestDeviceLoginFailures(t *testing.T) {
+	for _, c := range []struct {
+		name       string
+		tokenError string // returned by the token endpoint; "" keeps authorization pending
+		timeout    time.Duration
+		want       error
+		reason     string
+	}{
+		{name: "denied", tokenError: "access_denied", want: ErrLoginDenied, reason: "denied"},
+		{name: "expired", tokenError: "expired_token", want: ErrLoginExpired, reason: "expired"},
+		{name: "timeout", timeout: 100 * time.Millisecond, want: ErrLoginExpired, reason: "expired"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(map[string]any{
+					"device_code":      "dev-code",
+					"user_code":        "ABCD-EFGH",
+					"verification_uri": "https://login.example.com/device",
+					"expires_in":       300,
+					"interval":         1,
+				})
+			})
+			mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+				code := c.tokenError
+				if code == "" {
+					code = "authorization_pending"
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(map[string]any{"error": code})
+			})
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			cfg := &oauth2.Config{
+				ClientID: "client",
+				Endpoint: oauth2.Endpoint{DeviceAuthURL: srv.URL + "/device", TokenURL: srv.URL + "/token"},
+			}
+			ctx := context.Background()
+			if c.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, c.timeout)
+				defer cancel()
+			}
+			err := deviceLogin(ctx, cfg, filepath.Join(t.TempDir(), "token.json"), loginOutput{w: io.Discard, json: true})
+			if !errors.Is(err, c.want) {
+				t.Fatalf("deviceLogin() error = %v, want %v", err, c.want)
+			}
+			if got := loginErrorReason(err); got != c.reason {
+				t.Errorf("reason = %q, want %q", got, c.reason)
+			}
+		})
+	}
+}
+
+func TestLogout(t *testing.T) {
+	tokenPath := filepath.Join(t.TempDir(), "token.json")
+	t.Setenv("WORKSHOP_TOKEN_FILE", tokenPath)
+	if err := writeTokenToFile(tokenPath, &oauth2.Token{AccessToken: "tok"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if deleted, err := Logout("api.example.com"); err != nil || !deleted {
+		t.Fatalf("Logout() = %v, %v; want the token deleted", deleted, err)
+	}
+	if _, err := os.Stat(tokenPath); !os.IsNotExist(err) {
+		t.Errorf("token file still exists: %v", err)
+	}
+	if deleted, err := Logout("api.example.com"); err != nil || deleted {
+		t.Errorf("second Logout() = %v, %v; want nothing to delete", deleted, err)
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright 2026 North Pole Security, Inc.
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc/credentials"
+)
+
+// Environment variables that supply a CI platform's OIDC ID token.
+const (
+	// GitHub Actions jobs with the id-token: write permission can request
+	// tokens from this URL, authenticating with the request token.
+	githubTokenURLEnv     = "ACTIONS_ID_TOKEN_REQUEST_URL"
+	githubRequestTokenEnv = "ACTIONS_ID_TOKEN_REQUEST_TOKEN"
+
+	// oidcTokenEnv holds a ready-made ID token, e.g. from a GitLab CI/CD job's
+	// id_tokens, whose audience is set in the pipeline.
+	oidcTokenEnv = "WORKSHOP_OIDC_TOKEN"
+)
+
+// OIDC configures workload identity federation: the provider exchanges the
+// CI platform's OIDC ID token for a short-lived Workshop token using OAuth 2.0
+// token exchange (RFC 8693), so pipelines need no stored secret.
+type OIDC struct {
+	// Audience is requested for GitHub Actions tokens. It defaults to the
+	// Workshop endpoint.
+	Audience string
+	// TokenURL is the token endpoint of the authorization server that trusts
+	// the CI platform's ID tokens, where the ID token is exchanged. Workshop
+	// itself has no token endpoint, so it is required.
+	TokenURL string
+}
+
+// OIDCCredentials returns credentials that authenticate to serverURL with the
+// CI platform's identity. It fails unless the environment has an ID token
+// source, so a misconfigured pipeline fails provider configuration.
+//
+// httpClient, if non-nil, makes the token requests; see APIKeyOrToken.
+func OIDCCredentials(ctx context.Context, o OIDC, serverURL string, httpClient *http.Client) (credentials.PerRPCCredentials, error) {
+	if o.TokenURL == "" {
+		return nil, errors.New("OIDC authentication needs a token URL")
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if o.Audience == "" {
+		o.Audience = serverURL
+	}
+
+	idToken, err := oidcIDTokenSource(httpClient, o.Audience)
+	if err != nil {
+		return nil, err
+	}
+	src := &oidcTokenSource{httpClient: httpClient, tokenURL: o.TokenURL, idToken: idToken}
+	return tokenSourceCreds{
+		ts:       oauth2.ReuseTokenSource(nil, src),
+		kind:     "OIDC",
+		insecure: serverURL == "localhost:8080",
+	}, nil
+}
+
+// oidcIDTokenSource returns a function that gets a fresh ID token from the
+// environment's source: GitHub Actions' token service, or WORKSHOP_OIDC_TOKEN.
+func oidcIDTokenSource(httpClient *http.Client, audience string) (func() (string, error), error) {
+	if reqURL := os.Getenv(githubTokenURLEnv); reqURL != "" {
+		reqToken := os.Getenv(githubRequestTokenEnv)
+		return func() (string, error) { return githubIDToken(httpClient, reqURL, reqToken, audience) }, nil
+	}
+	if token := os.Getenv(oidcTokenEnv); token != "" {
+		return func() (string, error) { return token, nil }, nil
+	}
+	return nil, fmt.Errorf("no OIDC ID token available: in GitHub Actions, grant the job the id-token: write permission; elsewhere, set %s", oidcTokenEnv)
+}
+
+// githubIDToken requests an ID token for audience from GitHub Actions.
+func githubIDToken(httpClient *http.Client, reqURL, reqToken, audience string) (string, error) {
+	u, err := url.Parse(reqURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s: %w", githubTokenURLEnv, err)
+	}
+	q := u.Query()
+	q.Set("audience", audience)
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+reqToken)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to request a GitHub Actions ID token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to request a GitHub Actions ID token: %s", resp.Status)
+	}
+	var body struct {
+		Value string `json:"value"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil || body.Value == "" {
+		return "", errors.New("GitHub Actions returned no ID token")
+	}
+	return body.Value, nil
+}
+
+// oidcTokenSource exchanges a fresh ID token for a Workshop token each time
+// it is asked; oauth2.ReuseTokenSource asks only when the last has expired.
+type oidcTokenSource struct {
+	httpClient *http.Client
+	tokenURL   string
+	idToken    func() (string, error)
+}
+
+func (s *oidcTokenSource) Token() (*oauth2.Token, error) {
+	idToken, err := s.idToken()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.httpClient.PostForm(s.tokenURL, url.Values{
+		"grant_type":         {"urn:ietf:params:oauth:grant-type:token-exchange"},
+		"subject_token":      {idToken},
+		"subject_token_type": {"urn:ietf:params:oauth:token-type:id_token"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange the ID token: %w", err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the token exchange response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token exchange failed: %s: %s", resp.Status, strings.TrimSpace(string(b)))
+	}
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(b, &body); err != nil || body.AccessToken == "" {
+		return nil, errors.New("token exchange returned no access token")
+	}
+	token := &oauth2.Token{AccessToken: body.AccessToken, TokenType: body.TokenType}
+	if body.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(body.ExpiresIn) * time.Second)
+	} else {
+		addTokenExpiry(token)
+	}
+	return token, nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 North Pole Security, Inc.
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOIDCCredentialsGitHub(t *testing.T) {
+	exchanges := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/id-token", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer request-token" {
+			t.Errorf("ID token request Authorization = %q", got)
+		}
+		if got := r.URL.Query().Get("audience"); got != "workshop.example.com" {
+			t.Errorf("audience = %q, want the endpoint", got)
+		}
+		json.NewEncoder(w).Encode(map[string]string{"value": "github-id-token"})
+	})
+	mux.HandleFunc("/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		exchanges++
+		if got := r.FormValue("grant_type"); got != "urn:ietf:params:oauth:grant-type:token-exchange" {
+			t.Errorf("grant_type = %q", got)
+		}
+		if got := r.FormValue("subject_token"); got != "github-id-token" {
+			t.Errorf("subject_token = %q", got)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"access_token": "workshop-token", "token_type": "Bearer", "expires_in": 600})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	t.Setenv(githubTokenURLEnv, srv.URL+"/id-token?api-version=2.0")
+	t.Setenv(githubRequestTokenEnv, "request-token")
+
+	creds, err := OIDCCredentials(context.Background(), OIDC{TokenURL: srv.URL + "/oauth2/token"}, "workshop.example.com", nil)
+	if err != nil {
+		t.Fatalf("OIDCCredentials() error: %v", err)
+	}
+	for range 2 {
+		md, err := creds.GetRequestMetadata(context.Background())
+		if err != nil {
+			t.Fatalf("GetRequestMetadata() error: %v", err)
+		}
+		if md["authorization"] != "Bearer workshop-token" {
+			t.Errorf("authorization = %q", md["authorization"])
+		}
+	}
+	if exchanges != 1 {
+		t.Errorf("exchanged %d times, want the token reused until it expires", exchanges)
+	}
+}
+
+func TestOIDCCredentialsTokenFromEnv(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.FormValue("subject_token"); got != "gitlab-id-token" {
+			t.Errorf("subject_token = %q", got)
+		}
+		http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	t.Setenv(githubTokenURLEnv, "")
+	t.Setenv(oidcTokenEnv, "gitlab-id-token")
+
+	creds, err := OIDCCredentials(context.Background(), OIDC{TokenURL: srv.URL}, "workshop.example.com", nil)
+	if err != nil {
+		t.Fatalf("OIDCCredentials() error: %v", err)
+	}
+	if _, err := creds.GetRequestMetadata(context.Background()); err == nil {
+		t.Error("GetRequestMetadata() succeeded though the exchange was refused")
+	}
+}
+
+func TestOIDCCredentialsNoToken(t *testing.T) {
+	t.Setenv(githubTokenURLEnv, "")
+	t.Setenv(oidcTokenEnv, "")
+	if _, err := OIDCCredentials(context.Background(), OIDC{TokenURL: "https://sts.example.com/token"}, "workshop.example.com", nil); err == nil {
+		t.Error("OIDCCredentials() succeeded without an ID token source")
+	}
+}
+
+func TestOIDCCredentialsNoTokenURL(t *testing.T) {
+	t.Setenv(githubTokenURLEnv, "")
+	t.Setenv(oidcTokenEnv, "gitlab-id-token")
+	if _, err := OIDCCredentials(context.Background(), OIDC{}, "workshop.example.com", nil); err == nil {
+		t.Error("OIDCCredentials() succeeded without a token URL")
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 North Pole Security, Inc.
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/jwt"
+	"google.golang.org/grpc/credentials"
+)
+
+// ServiceAccount identifies a service account that authenticates without a
+// browser: the provider signs a JWT with the account's private key and
+// exchanges it for a short-lived access token using the JWT bearer grant
+// (RFC 7523). Tokens are kept in memory only and fetched again on expiry.
+type ServiceAccount struct {
+	// Issuer is the account's identifier, sent as the JWT's iss and sub.
+	Issuer string
+	// KeyFile is the path of the account's PEM-encoded RSA private key.
+	KeyFile string
+	// KeyID, if set, is sent as the JWT's kid so the server can tell which
+	// of the account's keys signed it.
+	KeyID string
+	// TokenURL is the token endpoint of the authorization server that issued
+	// the account, where the JWT is exchanged. Workshop itself has no token
+	// endpoint, so it is required.
+	TokenURL string
+}
+
+// ServiceAccountCredentials returns credentials that authenticate to
+// serverURL as sa. The key file is read once, here, so a missing or invalid
+// key fails provider configuration rather than the first request.
+//
+// httpClient, if non-nil, makes the token requests; see APIKeyOrToken.
+func ServiceAccountCredentials(ctx context.Context, sa ServiceAccount, serverURL string, httpClient *http.Client) (credentials.PerRPCCredentials, error) {
+	if sa.TokenURL == "" {
+		return nil, errors.New("a service account needs a token URL")
+	}
+	key, err := os.ReadFile(sa.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service account key: %w", err)
+	}
+
+	insecure := serverURL == "localhost:8080"
+	cfg := &jwt.Config{
+		Email:        sa.Issuer,
+		Subject:      sa.Issuer,
+		PrivateKey:   key,
+		PrivateKeyID: sa.KeyID,
+		TokenURL:     sa.TokenURL,
+	}
+	// The token source only parses the key when it first signs, so check it
+	// now.
+	if err := checkRSAKey(key); err != nil {
+		return nil, fmt.Errorf("invalid service account key %s: %w", sa.KeyFile, err)
+	}
+
+	tsCtx := context.Background()
+	if httpClient != nil {
+		tsCtx = context.WithValue(tsCtx, oauth2.HTTPClient, httpClient)
+	}
+	return tokenSourceCreds{ts: cfg.TokenSource(tsCtx), kind: "service account", insecure: insecure}, nil
+}
+
+// checkRSAKey reports whether key is a PEM-encoded RSA private key in PKCS #1
+// or PKCS #8 form, the forms the JWT signer accepts.
+func checkRSAKey(key []byte) error {
+	block, _ := pem.Decode(key)
+	if block == nil {
+		return errors.New("no PEM data found")
+	}
+	if _, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return err
+	}
+	if _, ok := parsed.(*rsa.PrivateKey); !ok {
+		return fmt.Errorf("got a %T, want an RSA private key", parsed)
+	}
+	return nil
+}
+
+// tokenSourceCreds is a PerRPCCredentials implementation that uses a
+// TokenSource for a non-interactive login, such as a service account's. Unlike
+// oauthRPCCreds there is no token file to update or login to suggest: a
+// failure is the credential's or the server's.
+type tokenSourceCreds struct {
+	ts oauth2.TokenSource
+	// kind names the credential in errors.
+	kind     string
+	insecure bool
+}
+
+func (s tokenSourceCreds) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	token, err := s.ts.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get a %s token: %w", s.kind, err)
+	}
+	if err := checkSecurityLevel(ctx, s.insecure); err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		"authorization": token.Type() + " " + token.AccessToken,
+	}, nil
+}
+
+func (s tokenSourceCreds) RequireTransportSecurity() bool {
+	return !s.insecure
+}
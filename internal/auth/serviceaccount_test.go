@@ -0,0 +1,118 @@
+// Copyright 2026 North Pole Security, Inc.
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(p, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func writeKey(t *testing.T, block *pem.Block) string {
+	t.Helper()
+	return writeFile(t, string(pem.EncodeToMemory(block)))
+}
+
+func TestServiceAccountCredentials(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile := writeKey(t, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	var claims jwt.MapClaims
+	var kid any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.FormValue("grant_type"); got != "urn:ietf:params:oauth:grant-type:jwt-bearer" {
+			t.Errorf("grant_type = %q", got)
+		}
+		tok, err := jwt.Parse(r.FormValue("assertion"), func(*jwt.Token) (any, error) { return &key.PublicKey, nil })
+		if err != nil {
+			t.Errorf("assertion doesn't verify: %v", err)
+			http.Error(w, "bad assertion", http.StatusBadRequest)
+			return
+		}
+		claims, kid = tok.Claims.(jwt.MapClaims), tok.Header["kid"]
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"access_token": "short-lived", "token_type": "Bearer", "expires_in": 300})
+	}))
+	defer srv.Close()
+
+	creds, err := ServiceAccountCredentials(context.Background(), ServiceAccount{
+		Issuer:   "ci@example.com",
+		KeyFile:  keyFile,
+		KeyID:    "key-1",
+		TokenURL: srv.URL,
+	}, "workshop.example.com", nil)
+	if err != nil {
+		t.Fatalf("ServiceAccountCredentials() error: %v", err)
+	}
+	md, err := creds.GetRequestMetadata(context.Background())
+	if err != nil {
+		t.Fatalf("GetRequestMetadata() error: %v", err)
+	}
+	if md["authorization"] != "Bearer short-lived" {
+		t.Errorf("authorization = %q", md["authorization"])
+	}
+	if claims["iss"] != "ci@example.com" || claims["sub"] != "ci@example.com" || claims["aud"] != srv.URL {
+		t.Errorf("claims = %v", claims)
+	}
+	if kid != "key-1" {
+		t.Errorf("kid = %v, want key-1", kid)
+	}
+}
+
+func TestServiceAccountCredentialsInvalidKey(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecDER, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, keyFile := range map[string]string{
+		"missing":   filepath.Join(t.TempDir(), "nope.pem"),
+		"not PEM":   writeFile(t, "not a key"),
+		"ECDSA key": writeKey(t, &pem.Block{Type: "PRIVATE KEY", Bytes: ecDER}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ServiceAccountCredentials(context.Background(), ServiceAccount{Issuer: "ci", KeyFile: keyFile, TokenURL: "https://idp.example.com/token"}, "workshop.example.com", nil)
+			if err == nil {
+				t.Error("ServiceAccountCredentials() accepted an unusable key")
+			}
+		})
+	}
+}
+
+func TestServiceAccountCredentialsNoTokenURL(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile := writeKey(t, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if _, err := ServiceAccountCredentials(context.Background(), ServiceAccount{Issuer: "ci", KeyFile: keyFile}, "workshop.example.com", nil); err == nil {
+		t.Error("ServiceAccountCredentials() succeeded without a token URL")
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2026 North Pole Security, Inc.
+
+// Command scaffold generates the starting point for a new Workshop resource
+// from the API message it manages: the resource with its schema, model, CRUD
+// methods and import, an acceptance test, and an example configuration.
+//
+// It is run through go generate in internal/provider:
+//
+//	SCAFFOLD_MESSAGE=TelemetryQuery go generate ./internal/provider
+//
+// or directly, with flags:
+//
+//	go run ./internal/cmd/scaffold -message RiskEngineException -rpc Exception
+//
+// Without a message it does nothing, so go generate ./... is unaffected. The
+// generated code builds but is not finished: every decision the descriptor
+// can't settle is marked TODO(scaffold). Existing files are never overwritten.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	_ "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	_ "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+const (
+	apiPackage = "workshop.v1"
+	apiService = apiPackage + ".WorkshopService"
+)
+
+func main() {
+	var message, rpc, name, key, dir string
+	flag.StringVar(&message, "message", os.Getenv("SCAFFOLD_MESSAGE"), "the API message the resource manages, e.g. TelemetryQuery (default $SCAFFOLD_MESSAGE)")
+	flag.StringVar(&rpc, "rpc", os.Getenv("SCAFFOLD_RPC"), "the noun in the message's RPC names, e.g. Exception for CreateException (default the message name)")
+	flag.StringVar(&name, "name", os.Getenv("SCAFFOLD_NAME"), "the resource name without the nps_workshop_ prefix (default the message name in snake case)")
+	flag.StringVar(&key, "key", os.Getenv("SCAFFOLD_KEY"), "the field that identifies the object (default id, or name if there is no id)")
+	flag.StringVar(&dir, "dir", ".", "the repository root")
+	flag.Parse()
+
+	if message == "" {
+		return
+	}
+	opts, err := resolve(message, rpc, name, key)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := run(opts, dir); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// resolve looks up message and fills in the defaults of the other options.
+func resolve(message, rpc, name, key string) (options, error) {
+	var opts options
+	d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(apiPackage + "." + message))
+	if err != nil {
+		return opts, fmt.Errorf("finding message %s: %w", message, err)
+	}
+	md, ok := d.(protoreflect.MessageDescriptor)
+	if !ok {
+		return opts, fmt.Errorf("%s is not a message", d.FullName())
+	}
+	d, err = protoregistry.GlobalFiles.FindDescriptorByName(apiService)
+	if err != nil {
+		return opts, fmt.Errorf("finding %s: %w", apiService, err)
+	}
+
+	opts = options{message: md, service: d.(protoreflect.ServiceDescriptor), rpc: rpc, name: name, key: key}
+	if opts.rpc == "" {
+		opts.rpc = message
+	}
+	if opts.name == "" {
+		opts.name = snakeCase(opts.rpc)
+	}
+	if opts.key == "" {
+		opts.key = "name"
+		if md.Fields().ByName("id") != nil {
+			opts.key = "id"
+		}
+	}
+	return opts, nil
+}
+
+// run generates the files for opts under the repository root dir.
+func run(opts options, dir string) error {
+	r, err := analyse(opts)
+	if err != nil {
+		return err
+	}
+	res, err := generateResource(r)
+	if err != nil {
+		return err
+	}
+	test, err := generateTest(r)
+	if err != nil {
+		return err
+	}
+	files := map[string][]byte{
+		filepath.Join("internal", "provider", "resource_"+r.name+".go"):               res,
+		filepath.Join("internal", "provider", "resource_"+r.name+"_test.go"):          test,
+		filepath.Join("examples", "resources", "nps_workshop_"+r.name, "resource.tf"): generateExample(r),
+	}
+	for p := range files {
+		if _, err := os.Stat(filepath.Join(dir, p)); err == nil {
+			return fmt.Errorf("%s already exists", p)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	for p, b := range files {
+		p = filepath.Join(dir, p)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(p, b, 0o644); err != nil {
+			return err
+		}
+		log.Printf("wrote %s", p)
+	}
+	for _, e := range r.enums() {
+		log.Printf("pin the values of %s in pinnedEnums (internal/provider/enum_pins.go); enumValues panics until it is", e)
+	}
+	log.Printf("register New%sResource in NPSProvider.Resources and resolve the TODO(scaffold) comments", r.typeName)
+	return nil
+}
+
+// snakeCase converts a Go or proto message name to snake case, e.g.
+// TelemetryQuery to telemetry_query.
+func snakeCase(s string) string {
+	var b strings.Builder
+	for i, c := range s {
+		if 'A' <= c && c <= 'Z' {
+			// Start a word at an upper case letter, except inside an
+			// acronym (CELEnvironment is cel_environment).
+			if i > 0 && (!isUpper(s[i-1]) || i+1 < len(s) && isASCIILower(s[i+1])) {
+				b.WriteByte('_')
+			}
+			c += 'a' - 'A'
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+func isUpper(c byte) bool { return 'A' <= c && c <= 'Z' }
@@ -0,0 +1,724 @@
+// Copyright 2026 North Pole Security, Inc.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// options describes the resource to scaffold.
+type options struct {
+	// message is the API message the resource models.
+	message protoreflect.MessageDescriptor
+	// service provides the RPCs the resource's CRUD methods call.
+	service protoreflect.ServiceDescriptor
+	// rpc is the noun in the RPC names, e.g. Exception for CreateException.
+	// It defaults to the message name, but some RPCs use a shorter noun than
+	// their message (CreateException takes a RiskEngineException).
+	rpc string
+	// name is the resource name without the nps_workshop_ prefix.
+	name string
+	// key is the field Read, Delete, and import look the object up by.
+	key string
+}
+
+// field is a message field the resource models as an attribute.
+type field struct {
+	desc protoreflect.FieldDescriptor
+	// attr is the Terraform attribute name and goName the model field name.
+	attr, goName string
+	// schemaType is the schema attribute constructor, e.g. String for
+	// schema.StringAttribute, and modelType the model field's type.
+	schemaType, modelType string
+	// computed fields are set by the server and never sent.
+	computed bool
+	list     bool
+}
+
+// resource is the analysed form of options the generators work from.
+type resource struct {
+	options
+	typeName string // e.g. TelemetryQuery, the Go type prefix
+	fields   []field
+	skipped  []string // fields that aren't modelled, with why
+	key      *field
+}
+
+func analyse(opts options) (*resource, error) {
+	r := &resource{options: opts, typeName: goCamelCase(opts.name)}
+	fields := opts.message.Fields()
+	for i := range fields.Len() {
+		fd := fields.Get(i)
+		f, why := modelField(fd)
+		if why != "" {
+			r.skipped = append(r.skipped, fmt.Sprintf("%s (%s)", fd.Name(), why))
+			continue
+		}
+		r.fields = append(r.fields, f)
+	}
+	for i := range r.fields {
+		if r.fields[i].attr == opts.key {
+			r.key = &r.fields[i]
+		}
+	}
+	if r.key == nil || r.key.list || (r.key.schemaType != "String" && r.key.schemaType != "Int64") {
+		return nil, fmt.Errorf("%s has no string or integer field %q to use as the key; pass -key", opts.message.FullName(), opts.key)
+	}
+	return r, nil
+}
+
+// modelField maps fd to an attribute, or says why it can't.
+func modelField(fd protoreflect.FieldDescriptor) (field, string) {
+	name := string(fd.Name())
+	f := field{desc: fd, attr: name, goName: goCamelCase(name)}
+	if fd.IsMap() {
+		return f, "map"
+	}
+	if fd.ContainingOneof() != nil && !fd.ContainingOneof().IsSynthetic() {
+		return f, "oneof " + string(fd.ContainingOneof().Name())
+	}
+	if fd.IsList() {
+		if fd.Kind() != protoreflect.StringKind {
+			return f, "repeated " + fd.Kind().String()
+		}
+		f.schemaType, f.modelType, f.list = "List", "types.List", true
+		return f, ""
+	}
+	switch fd.Kind() {
+	case protoreflect.StringKind, protoreflect.EnumKind:
+		f.schemaType, f.modelType = "String", "types.String"
+	case protoreflect.BoolKind:
+		f.schemaType, f.modelType = "Bool", "types.Bool"
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		f.schemaType, f.modelType = "Int64", "types.Int64"
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		f.schemaType, f.modelType = "Float64", "types.Float64"
+	case protoreflect.MessageKind:
+		if fd.Message().FullName() != "google.protobuf.Timestamp" {
+			return f, "message " + string(fd.Message().FullName())
+		}
+		f.schemaType, f.modelType, f.computed = "String", "types.String", true
+	default:
+		return f, fd.Kind().String()
+	}
+	// Server-assigned fields, by the API's naming conventions.
+	if name == "id" || strings.HasSuffix(name, "_by") || strings.HasPrefix(name, "current_") {
+		f.computed = true
+	}
+	return f, ""
+}
+
+// goType is the Go type of a singular scalar or enum field.
+func goType(fd protoreflect.FieldDescriptor) string {
+	switch fd.Kind() {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return "int32"
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return "int64"
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return "uint32"
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return "uint64"
+	case protoreflect.FloatKind:
+		return "float32"
+	case protoreflect.DoubleKind:
+		return "float64"
+	case protoreflect.EnumKind:
+		return "apipb." + goIdent(fd.Enum())
+	}
+	return ""
+}
+
+// goIdent is the generated Go name of a message or enum, which nests with
+// underscores, e.g. Rule_BlockReason.
+func goIdent(d protoreflect.Descriptor) string {
+	name := strings.TrimPrefix(string(d.FullName()), string(d.ParentFile().Package())+".")
+	return goCamelCase(name)
+}
+
+// toProto is the expression converting the model value of f in data to the
+// Go value a setter takes.
+func (f field) toProto(data string) string {
+	v := data + "." + f.goName
+	switch f.schemaType {
+	case "String":
+		if f.desc.Kind() == protoreflect.EnumKind {
+			t := goType(f.desc)
+			return fmt.Sprintf("%s(%s_value[%s.ValueString()])", t, t, v)
+		}
+		return v + ".ValueString()"
+	case "Bool":
+		return v + ".ValueBool()"
+	case "Int64":
+		if t := goType(f.desc); t != "int64" {
+			return fmt.Sprintf("%s(%s.ValueInt64())", t, v)
+		}
+		return v + ".ValueInt64()"
+	case "Float64":
+		if t := goType(f.desc); t != "float64" {
+			return fmt.Sprintf("%s(%s.ValueFloat64())", t, v)
+		}
+		return v + ".ValueFloat64()"
+	}
+	return v
+}
+
+// writeSet writes the statements setting f on the message m from data.
+// Optional values are only set when configured.
+func (f field) writeSet(b *bytes.Buffer, m, data string, required bool) {
+	if f.list {
+		fmt.Fprintf(b, "\tif !%s.%s.IsNull() {\n", data, f.goName)
+		fmt.Fprintf(b, "\t\tvar %s []string\n", lowerFirst(f.goName))
+		fmt.Fprintf(b, "\t\tdiags.Append(%s.%s.ElementsAs(ctx, &%s, false)...)\n", data, f.goName, lowerFirst(f.goName))
+		fmt.Fprintf(b, "\t\t%s.Set%s(%s)\n", m, f.goName, lowerFirst(f.goName))
+		fmt.Fprintf(b, "\t}\n")
+		return
+	}
+	if required {
+		fmt.Fprintf(b, "\t%s.Set%s(%s)\n", m, f.goName, f.toProto(data))
+		return
+	}
+	if f.computed {
+		// A server-assigned key, unknown until Create returns it.
+		fmt.Fprintf(b, "\tif !%[1]s.%[2]s.IsNull() && !%[1]s.%[2]s.IsUnknown() {\n", data, f.goName)
+	} else {
+		fmt.Fprintf(b, "\tif !%s.%s.IsNull() {\n", data, f.goName)
+	}
+	fmt.Fprintf(b, "\t\t%s.Set%s(%s)\n", m, f.goName, f.toProto(data))
+	fmt.Fprintf(b, "\t}\n")
+}
+
+// sent reports whether f is sent to the server: configurable fields are, and
+// so is the key once the server has assigned it, so Update can name the object.
+func (f field) sent(r *resource) bool {
+	return !f.computed || f.attr == r.key.attr
+}
+
+// writeGet writes the statements setting f in data from the message m.
+// Empty optional values are stored as null, as Read does elsewhere.
+func (f field) writeGet(b *bytes.Buffer, data, m string, required bool) {
+	get := fmt.Sprintf("%s.Get%s()", m, f.goName)
+	dst := data + "." + f.goName
+	switch {
+	case f.list:
+		fmt.Fprintf(b, "\tif len(%s) == 0 {\n\t\t%s = types.ListNull(types.StringType)\n\t} else {\n", get, dst)
+		fmt.Fprintf(b, "\t\tl, d := types.ListValueFrom(ctx, types.StringType, %s)\n\t\tdiags.Append(d...)\n\t\t%s = l\n\t}\n", get, dst)
+	case f.desc.Kind() == protoreflect.MessageKind:
+		fmt.Fprintf(b, "\tif ts := %s; ts != nil {\n\t\t%s = types.StringValue(ts.AsTime().UTC().Format(time.RFC3339))\n\t} else {\n\t\t%s = types.StringNull()\n\t}\n", get, dst, dst)
+	case f.desc.Kind() == protoreflect.EnumKind:
+		fmt.Fprintf(b, "\t%s = types.StringValue(%s.String())\n", dst, get)
+	case f.schemaType == "String":
+		if required {
+			fmt.Fprintf(b, "\t%s = types.StringValue(%s)\n", dst, get)
+		} else {
+			fmt.Fprintf(b, "\tif v := %s; v != \"\" {\n\t\t%s = types.StringValue(v)\n\t} else {\n\t\t%s = types.StringNull()\n\t}\n", get, dst, dst)
+		}
+	case f.schemaType == "Bool":
+		fmt.Fprintf(b, "\t%s = types.BoolValue(%s)\n", dst, get)
+	case f.schemaType == "Int64":
+		fmt.Fprintf(b, "\t%s = types.Int64Value(int64(%s))\n", dst, get)
+	case f.schemaType == "Float64":
+		fmt.Fprintf(b, "\t%s = types.Float64Value(float64(%s))\n", dst, get)
+	}
+}
+
+// enums lists the full names of the enums r's attributes validate against,
+// which enumValues only accepts once they're in pinnedEnums.
+func (r *resource) enums() []string {
+	var names []string
+	for _, f := range r.fields {
+		if f.desc.Kind() == protoreflect.EnumKind {
+			if n := string(f.desc.Enum().FullName()); !slices.Contains(names, n) {
+				names = append(names, n)
+			}
+		}
+	}
+	return names
+}
+
+// generateResource returns the source of resource_<name>.go.
+func generateResource(r *resource) ([]byte, error) {
+	var b bytes.Buffer
+	t := r.typeName
+	human := strings.ReplaceAll(r.name, "_", " ")
+
+	fmt.Fprintf(&b, `// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/northpolesec/terraform-provider-nps/internal/filter"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// Scaffolded from %[3]s; resolve every TODO(scaffold) before review.
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &%[1]sResource{}
+var _ resource.ResourceWithImportState = &%[1]sResource{}
+
+func New%[1]sResource() resource.Resource {
+	return &%[1]sResource{}
+}
+
+// %[1]sResource defines the resource implementation.
+type %[1]sResource struct {
+	client svcpb.WorkshopServiceClient
+}
+
+`, t, human, r.message.FullName())
+
+	fmt.Fprintf(&b, "// %sResourceModel describes the resource data model.\n", t)
+	if len(r.skipped) > 0 {
+		fmt.Fprintf(&b, "//\n// TODO(scaffold): not modelled: %s.\n", strings.Join(r.skipped, ", "))
+	}
+	fmt.Fprintf(&b, "type %sResourceModel struct {\n", t)
+	for _, f := range r.fields {
+		fmt.Fprintf(&b, "\t%s %s `tfsdk:%q`\n", f.goName, f.modelType, f.attr)
+	}
+	b.WriteString("}\n\n")
+
+	fmt.Fprintf(&b, `func (r *%[1]sResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workshop_%[2]s"
+}
+
+func (r *%[1]sResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// TODO(scaffold): Describe the resource and the permissions it needs.
+		MarkdownDescription: "The `+"`nps_workshop_%[2]s`"+` resource manages %[4]s %[3]s.",
+
+		Attributes: map[string]schema.Attribute{
+`, t, r.name, human, article(human))
+	for _, f := range r.fields {
+		writeAttribute(&b, r, f)
+	}
+	b.WriteString("\t\t},\n\t}\n}\n\n")
+
+	fmt.Fprintf(&b, `func (r *%[1]sResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(*NPSProviderResourceData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			codeInternal.summary("Unexpected Resource Configure Type"),
+			fmt.Sprintf("Expected NPSProviderResourceData, got: %%T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = pd.Client
+}
+
+`, t)
+
+	// Create and Update send the model; a resource with only one of the two
+	// RPCs is an upsert and uses it for both.
+	for _, op := range []struct{ method, plan, verb string }{
+		{"Create", "Plan", "create"},
+		{"Update", "Plan", "update"},
+	} {
+		fmt.Fprintf(&b, "func (r *%sResource) %s(ctx context.Context, req resource.%sRequest, resp *resource.%sResponse) {\n", t, op.method, op.method, op.method)
+		fmt.Fprintf(&b, "\tvar data %sResourceModel\n\n\t// Read Terraform plan data into the model\n\tresp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)\n\n\tif resp.Diagnostics.HasError() {\n\t\treturn\n\t}\n\n", t)
+		rpcs := []string{"Create" + r.rpc, "Update" + r.rpc}
+		if op.method == "Update" {
+			rpcs = []string{"Update" + r.rpc, "Create" + r.rpc}
+		}
+		writeSend(&b, r, rpcs, op.verb)
+		b.WriteString("\n\t// Save data into Terraform state\n\tresp.Diagnostics.Append(resp.State.Set(ctx, &data)...)\n}\n\n")
+	}
+
+	fmt.Fprintf(&b, "func (r *%sResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {\n", t)
+	fmt.Fprintf(&b, "\tvar data %sResourceModel\n\n\t// Read Terraform prior state data into the model\n\tresp.Diagnostics.Append(req.State.Get(ctx, &data)...)\n\n\tif resp.Diagnostics.HasError() {\n\t\treturn\n\t}\n\n", t)
+	writeRead(&b, r, human)
+	b.WriteString("\n\t// Save updated data into Terraform state\n\tresp.Diagnostics.Append(resp.State.Set(ctx, &data)...)\n}\n\n")
+
+	fmt.Fprintf(&b, "func (r *%sResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {\n", t)
+	fmt.Fprintf(&b, "\tvar data %sResourceModel\n\n\t// Read Terraform prior state data into the model\n\tresp.Diagnostics.Append(req.State.Get(ctx, &data)...)\n\n\tif resp.Diagnostics.HasError() {\n\t\treturn\n\t}\n\n", t)
+	writeDelete(&b, r, human)
+	b.WriteString("}\n\n")
+
+	fmt.Fprintf(&b, `func (r *%sResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+`, t)
+	if r.key.schemaType == "Int64" {
+		fmt.Fprintf(&b, `	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(codeInvalidConfig.summary("Invalid Import ID"), fmt.Sprintf("Expected a numeric %[1]s, got %%q", req.ID))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(%[1]q), id)...)
+}
+
+`, r.key.attr)
+	} else {
+		fmt.Fprintf(&b, "\tresource.ImportStatePassthroughID(ctx, path.Root(%q), req, resp)\n}\n\n", r.key.attr)
+	}
+
+	fmt.Fprintf(&b, "// build%[1]s converts data to the API message, leaving out the fields the\n// server sets.\nfunc build%[1]s(ctx context.Context, data %[1]sResourceModel, diags *diag.Diagnostics) *apipb.%[2]s {\n\tm := &apipb.%[2]s{}\n", t, goIdent(r.message))
+	for _, f := range r.fields {
+		if f.sent(r) {
+			f.writeSet(&b, "m", "data", f.attr == r.key.attr && !f.computed)
+		}
+	}
+	b.WriteString("\treturn m\n}\n\n")
+
+	fmt.Fprintf(&b, "// apply%[1]sProto overwrites data with the values of m.\nfunc apply%[1]sProto(ctx context.Context, data *%[1]sResourceModel, m *apipb.%[2]s, diags *diag.Diagnostics) {\n", t, goIdent(r.message))
+	for _, f := range r.fields {
+		f.writeGet(&b, "data", "m", f.attr == r.key.attr)
+	}
+	b.WriteString("}\n")
+
+	// The scaffold uses "strconv" only for integer keys; add it before
+	// pruning rather than templating the import list.
+	src := bytes.Replace(b.Bytes(), []byte("\t\"fmt\"\n"), []byte("\t\"fmt\"\n\t\"strconv\"\n"), 1)
+	return finish(src)
+}
+
+// writeAttribute writes the schema attribute for f.
+func writeAttribute(b *bytes.Buffer, r *resource, f field) {
+	isKey := f.attr == r.key.attr
+	fmt.Fprintf(b, "\t\t\t%q: schema.%sAttribute{\n", f.attr, f.schemaType)
+	fmt.Fprintf(b, "\t\t\t\t// TODO(scaffold): Describe this attribute.\n\t\t\t\tMarkdownDescription: %q,\n", "The "+strings.ReplaceAll(f.attr, "_", " ")+".")
+	if f.list {
+		b.WriteString("\t\t\t\tElementType:         types.StringType,\n")
+	}
+	switch {
+	case f.computed:
+		b.WriteString("\t\t\t\tComputed:            true,\n")
+	case isKey:
+		b.WriteString("\t\t\t\tRequired:            true,\n")
+	default:
+		b.WriteString("\t\t\t\tOptional:            true,\n")
+	}
+	if f.desc.Kind() == protoreflect.EnumKind {
+		fmt.Fprintf(b, "\t\t\t\tValidators: []validator.String{\n\t\t\t\t\toneOf(enumValues(%s(0).Descriptor())...),\n\t\t\t\t},\n", goType(f.desc))
+	}
+	if isKey {
+		// The key never changes: a server-assigned key keeps its value, and a
+		// configured one can only change by replacing the object.
+		pm, mod := "String", "stringplanmodifier"
+		if f.schemaType == "Int64" {
+			pm, mod = "Int64", "int64planmodifier"
+		}
+		if f.computed {
+			fmt.Fprintf(b, "\t\t\t\tPlanModifiers: []planmodifier.%s{\n\t\t\t\t\t%s.UseStateForUnknown(),\n\t\t\t\t},\n", pm, mod)
+		} else {
+			fmt.Fprintf(b, "\t\t\t\tPlanModifiers: []planmodifier.%s{\n\t\t\t\t\t%s.RequiresReplace(),\n\t\t\t\t},\n", pm, mod)
+		}
+	}
+	b.WriteString("\t\t\t},\n")
+}
+
+// writeSend writes the call sending data with the first of rpcs the service
+// has, and the handling of its response.
+func writeSend(b *bytes.Buffer, r *resource, rpcs []string, verb string) {
+	human := strings.ReplaceAll(r.name, "_", " ")
+	for _, rpc := range rpcs {
+		m := r.service.Methods().ByName(protoreflect.Name(rpc))
+		if m == nil {
+			continue
+		}
+		fmt.Fprintf(b, "\tapiReq := &apipb.%s{}\n", goIdent(m.Input()))
+		if fd := fieldOfType(m.Input(), r.message); fd != nil {
+			fmt.Fprintf(b, "\tapiReq.Set%s(build%s(ctx, data, &resp.Diagnostics))\n", goCamelCase(string(fd.Name())), r.typeName)
+		} else {
+			// A request with the message's fields inlined.
+			var sent []field
+			for _, f := range r.fields {
+				if rf := m.Input().Fields().ByName(f.desc.Name()); rf != nil && rf.Kind() == f.desc.Kind() && f.sent(r) {
+					sent = append(sent, f)
+				}
+			}
+			if slices.ContainsFunc(sent, func(f field) bool { return f.list }) {
+				b.WriteString("\tdiags := &resp.Diagnostics\n")
+			}
+			for _, f := range sent {
+				f.writeSet(b, "apiReq", "data", f.attr == r.key.attr && !f.computed)
+			}
+		}
+		fmt.Fprintf(b, "\tif resp.Diagnostics.HasError() {\n\t\treturn\n\t}\n\n")
+		var apply bytes.Buffer
+		writeResponse(&apply, r, m.Output(), "ret")
+		ret := "ret"
+		if apply.Len() == 0 {
+			ret = "_"
+		}
+		fmt.Fprintf(b, "\t%s, err := r.client.%s(ctx, apiReq)\n\tif err != nil {\n\t\tresp.Diagnostics.AddError(clientError(err), fmt.Sprintf(\"Failed to %s %s: %%v\", err))\n\t\treturn\n\t}\n", ret, rpc, verb, human)
+		b.Write(apply.Bytes())
+		if fieldOfType(m.Output(), r.message) == nil && slices.ContainsFunc(r.fields, func(f field) bool { return f.computed && f.attr != r.key.attr }) {
+			fmt.Fprintf(b, "\t// TODO(scaffold): %s doesn't return the %s, so computed attributes\n\t// are still unknown; read it back or mark them UseStateForUnknown.\n", m.Output().Name(), human)
+		}
+		return
+	}
+	fmt.Fprintf(b, "\t// TODO(scaffold): the service has none of %s.\n\tresp.Diagnostics.AddError(codeInternal.summary(\"Not Implemented\"), \"The Workshop API can't %s %s %s.\")\n\treturn\n", strings.Join(rpcs, ", "), verb, article(human), human)
+}
+
+// writeResponse writes the statements copying what a response reports about
+// the object into data: the whole message if the response carries it, or any
+// fields it shares with the model, such as a server-assigned ID. It writes
+// nothing when the response reports nothing.
+func writeResponse(b *bytes.Buffer, r *resource, out protoreflect.MessageDescriptor, ret string) {
+	if fd := fieldOfType(out, r.message); fd != nil {
+		fmt.Fprintf(b, "\tapply%sProto(ctx, &data, %s.Get%s(), &resp.Diagnostics)\n", r.typeName, ret, goCamelCase(string(fd.Name())))
+		return
+	}
+	for _, f := range r.fields {
+		if rf := out.Fields().ByName(f.desc.Name()); rf != nil && rf.Kind() == f.desc.Kind() && !rf.IsList() && !f.list {
+			f.writeGet(b, "data", ret, true)
+		}
+	}
+}
+
+// writeRead writes the lookup of the object by its key: Get<rpc> if the
+// service has one that takes the key, otherwise List<rpc>s with a filter.
+func writeRead(b *bytes.Buffer, r *resource, human string) {
+	key := r.key
+	if m := r.service.Methods().ByName(protoreflect.Name("Get" + r.rpc)); m != nil {
+		if kf := m.Input().Fields().ByName(key.desc.Name()); kf != nil {
+			if out := fieldOfType(m.Output(), r.message); out != nil {
+				fmt.Fprintf(b, "\tapiReq := &apipb.%s{}\n\tapiReq.Set%s(%s)\n", goIdent(m.Input()), goCamelCase(string(kf.Name())), key.toProto("data"))
+				fmt.Fprintf(b, "\tret, err := r.client.Get%s(ctx, apiReq)\n", r.rpc)
+				fmt.Fprintf(b, "\tif status.Code(err) == codes.NotFound {\n\t\t// The object was deleted outside Terraform; remove it from the state\n\t\t// so Terraform will offer to create it.\n\t\tresp.State.RemoveResource(ctx)\n\t\treturn\n\t}\n")
+				fmt.Fprintf(b, "\tif err != nil {\n\t\tresp.Diagnostics.AddError(clientError(err), fmt.Sprintf(\"Failed to get %s: %%v\", err))\n\t\treturn\n\t}\n\n", human)
+				fmt.Fprintf(b, "\tapply%sProto(ctx, &data, ret.Get%s(), &resp.Diagnostics)\n", r.typeName, goCamelCase(string(out.Name())))
+				return
+			}
+		}
+	}
+	for _, name := range []string{"List" + r.rpc + "s", "List" + r.rpc + "es", "List" + r.rpc} {
+		m := r.service.Methods().ByName(protoreflect.Name(name))
+		if m == nil || m.Input().Fields().ByName("filter") == nil {
+			continue
+		}
+		out := fieldOfType(m.Output(), r.message)
+		if out == nil || !out.IsList() {
+			continue
+		}
+		fmt.Fprintf(b, "\tapiReq := &apipb.%s{}\n\tapiReq.SetFilter(filter.Eq(%q, %s).String())\n", goIdent(m.Input()), key.attr, key.toProto("data"))
+		if m.Input().Fields().ByName("page_size") != nil {
+			b.WriteString("\tapiReq.SetPageSize(1)\n")
+		}
+		fmt.Fprintf(b, "\tret, err := r.client.%s(ctx, apiReq)\n\tif err != nil {\n\t\tresp.Diagnostics.AddError(clientError(err), fmt.Sprintf(\"Failed to look up %s: %%v\", err))\n\t\treturn\n\t}\n", name, human)
+		fmt.Fprintf(b, "\tif len(ret.Get%[1]s()) == 0 {\n\t\t// The object was deleted outside Terraform; remove it from the state\n\t\t// so Terraform will offer to create it.\n\t\tresp.State.RemoveResource(ctx)\n\t\treturn\n\t}\n\n\tapply%[2]sProto(ctx, &data, ret.Get%[1]s()[0], &resp.Diagnostics)\n", goCamelCase(string(out.Name())), r.typeName)
+		return
+	}
+	fmt.Fprintf(b, "\t// TODO(scaffold): the service has no Get%[1]s or List%[1]ss RPC to look the object up with.\n\tresp.Diagnostics.AddError(codeInternal.summary(\"Not Implemented\"), \"The Workshop API can't read %[3]s %[2]s.\")\n\treturn\n", r.rpc, human, article(human))
+}
+
+// writeDelete writes the Delete<rpc> call, treating NotFound as done.
+func writeDelete(b *bytes.Buffer, r *resource, human string) {
+	m := r.service.Methods().ByName(protoreflect.Name("Delete" + r.rpc))
+	if m == nil {
+		fmt.Fprintf(b, "\t// TODO(scaffold): the service has no Delete%s RPC.\n\tresp.Diagnostics.AddError(codeInternal.summary(\"Not Implemented\"), \"The Workshop API can't delete %s %s.\")\n", r.rpc, article(human), human)
+		return
+	}
+	fmt.Fprintf(b, "\tapiReq := &apipb.%s{}\n", goIdent(m.Input()))
+	if kf := m.Input().Fields().ByName(r.key.desc.Name()); kf != nil {
+		fmt.Fprintf(b, "\tapiReq.Set%s(%s)\n", goCamelCase(string(kf.Name())), r.key.toProto("data"))
+	} else {
+		fmt.Fprintf(b, "\t// TODO(scaffold): %s has no %s field; identify the object to delete.\n", m.Input().Name(), r.key.attr)
+	}
+	fmt.Fprintf(b, "\t_, err := r.client.Delete%s(ctx, apiReq)\n\tif err != nil && !isDeleteNoOp(err) {\n\t\tresp.Diagnostics.AddError(clientError(err), fmt.Sprintf(\"Failed to delete %s: %%v\", err))\n\t\treturn\n\t}\n", r.rpc, human)
+}
+
+// fieldOfType returns the field of m whose type is msg, if any.
+func fieldOfType(m, msg protoreflect.MessageDescriptor) protoreflect.FieldDescriptor {
+	fields := m.Fields()
+	for i := range fields.Len() {
+		if fd := fields.Get(i); fd.Message() != nil && fd.Message().FullName() == msg.FullName() {
+			return fd
+		}
+	}
+	return nil
+}
+
+// generateTest returns the source of resource_<name>_test.go, an acceptance
+// test in the style of the existing ones.
+func generateTest(r *resource) ([]byte, error) {
+	var b bytes.Buffer
+	t := r.typeName
+	fmt.Fprintf(&b, `// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc%[1]s(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAcc%[1]sResourceConfig("test"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("nps_workshop_%[2]s.test", %[3]q),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "nps_workshop_%[2]s.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAcc%[1]sResourceConfig(value string) string {
+	// TODO(scaffold): Set the attributes the API requires.
+	return fmt.Sprintf(`+"`"+`
+provider "nps" {
+  endpoint = "localhost:8080"
+}
+
+resource "nps_workshop_%[2]s" "test" {
+%[4]s}
+`+"`"+`, value)
+}
+`, t, r.name, r.key.attr, exampleAttributes(r, "%[1]q"))
+	return finish(b.Bytes())
+}
+
+// generateExample returns examples/resources/nps_workshop_<name>/resource.tf.
+func generateExample(r *resource) []byte {
+	return []byte(fmt.Sprintf("# TODO(scaffold): Show a realistic configuration.\nresource \"nps_workshop_%s\" \"example\" {\n%s}\n", r.name, exampleAttributes(r, `"example"`)))
+}
+
+// exampleAttributes lists the configurable string attributes, set to value.
+func exampleAttributes(r *resource, value string) string {
+	var b strings.Builder
+	width := 0
+	var names []string
+	for _, f := range r.fields {
+		if f.computed || f.schemaType != "String" || f.desc.Kind() == protoreflect.EnumKind {
+			continue
+		}
+		names = append(names, f.attr)
+		width = max(width, len(f.attr))
+	}
+	for _, n := range names {
+		fmt.Fprintf(&b, "  %-*s = %s\n", width, n, value)
+	}
+	return b.String()
+}
+
+// finish removes unused imports from src and formats it.
+func finish(src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("generated invalid Go: %w\n%s", err, src)
+	}
+	used := map[string]bool{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok {
+				used[id.Name] = true
+			}
+		}
+		return true
+	})
+	// Drop the lines of unused imports, one import per line.
+	unused := map[int]bool{}
+	for _, is := range file.Imports {
+		p, _ := strconv.Unquote(is.Path.Value)
+		name := p[strings.LastIndex(p, "/")+1:]
+		if is.Name != nil {
+			name = is.Name.Name
+		}
+		if !used[name] {
+			unused[fset.Position(is.Pos()).Line] = true
+		}
+	}
+	lines := bytes.SplitAfter(src, []byte("\n"))
+	var out bytes.Buffer
+	for i, l := range lines {
+		if !unused[i+1] {
+			out.Write(l)
+		}
+	}
+	return format.Source(out.Bytes())
+}
+
+// article is the indefinite article for noun.
+func article(noun string) string {
+	if strings.ContainsRune("aeiou", rune(noun[0])) {
+		return "an"
+	}
+	return "a"
+}
+
+func lowerFirst(s string) string {
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// goCamelCase converts a proto name to the Go name protoc-gen-go gives it,
+// following google.golang.org/protobuf/internal/strs.GoCamelCase.
+func goCamelCase(s string) string {
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '.' && i+1 < len(s) && isASCIILower(s[i+1]):
+			// Skip over '.' in ".{{lowercase}}".
+		case c == '.':
+			b = append(b, '_')
+		case c == '_' && (i == 0 || s[i-1] == '.'):
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(s) && isASCIILower(s[i+1]):
+			// Skip over '_' in "_{{lowercase}}".
+		case isASCIIDigit(c):
+			b = append(b, c)
+		default:
+			if isASCIILower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			for ; i+1 < len(s) && isASCIILower(s[i+1]); i++ {
+				b = append(b, s[i+1])
+			}
+		}
+	}
+	return string(b)
+}
+
+func isASCIILower(c byte) bool { return 'a' <= c && c <= 'z' }
+func isASCIIDigit(c byte) bool { return '0' <= c && c <= '9' }
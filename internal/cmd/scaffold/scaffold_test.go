@@ -0,0 +1,128 @@
+// Copyright 2026 North Pole Security, Inc.
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		message, rpc, key string
+		// want are snippets the resource must contain.
+		want []string
+	}{
+		{
+			message: "TelemetryQuery",
+			want: []string{
+				"type TelemetryQueryResourceModel struct",
+				`resp.TypeName = req.ProviderTypeName + "_workshop_telemetry_query"`,
+				"r.client.GetTelemetryQuery(ctx, apiReq)",
+				"r.client.DeleteTelemetryQuery(ctx, apiReq)",
+				"stringplanmodifier.UseStateForUnknown()",
+				"data.CreatedAt = types.StringValue(ts.AsTime().UTC().Format(time.RFC3339))",
+			},
+		},
+		{
+			message: "RiskEngineException",
+			rpc:     "Exception",
+			want: []string{
+				"apiReq.SetException(buildException(ctx, data, &resp.Diagnostics))",
+				`apiReq.SetFilter(filter.Eq("id", data.Id.ValueString()).String())`,
+				"func buildException(ctx context.Context, data ExceptionResourceModel, diags *diag.Diagnostics) *apipb.RiskEngineException",
+			},
+		},
+		{
+			message: "Rule",
+			key:     "rule_id",
+			want: []string{
+				"oneOf(enumValues(apipb.Policy(0).Descriptor())...)",
+				`m.SetPolicy(apipb.Policy(apipb.Policy_value[data.Policy.ValueString()]))`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			opts, err := resolve(tt.message, tt.rpc, "", tt.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			dir := t.TempDir()
+			if err := run(opts, dir); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, p := range []string{
+				filepath.Join("internal", "provider", "resource_"+opts.name+".go"),
+				filepath.Join("internal", "provider", "resource_"+opts.name+"_test.go"),
+			} {
+				src, err := os.ReadFile(filepath.Join(dir, p))
+				if err != nil {
+					t.Fatal(err)
+				}
+				formatted, err := format.Source(src)
+				if err != nil {
+					t.Fatalf("%s doesn't parse: %v", p, err)
+				}
+				if !bytes.Equal(src, formatted) {
+					t.Errorf("%s isn't gofmt'd", p)
+				}
+			}
+			src, _ := os.ReadFile(filepath.Join(dir, "internal", "provider", "resource_"+opts.name+".go"))
+			for _, w := range tt.want {
+				if !strings.Contains(string(src), w) {
+					t.Errorf("resource doesn't contain %q", w)
+				}
+			}
+			if _, err := os.Stat(filepath.Join(dir, "examples", "resources", "nps_workshop_"+opts.name, "resource.tf")); err != nil {
+				t.Error(err)
+			}
+
+			// A second run must not overwrite what the first wrote.
+			if err := run(opts, dir); err == nil {
+				t.Error("run overwrote existing files")
+			}
+		})
+	}
+}
+
+func TestGenerateUnknownKey(t *testing.T) {
+	opts, err := resolve("TelemetryQuery", "", "", "nope")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := run(opts, t.TempDir()); err == nil {
+		t.Error("run accepted a key that isn't a field")
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	for in, want := range map[string]string{
+		"TelemetryQuery": "telemetry_query",
+		"Exception":      "exception",
+		"CELEnvironment": "cel_environment",
+		"APIKey":         "api_key",
+	} {
+		if got := snakeCase(in); got != want {
+			t.Errorf("snakeCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestGoCamelCase(t *testing.T) {
+	for in, want := range map[string]string{
+		"plugin_uuid":      "PluginUuid",
+		"custom_url":       "CustomUrl",
+		"Rule.BlockReason": "Rule_BlockReason",
+		"current_version":  "CurrentVersion",
+		"sha256":           "Sha256",
+	} {
+		if got := goCamelCase(in); got != want {
+			t.Errorf("goCamelCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright 2026 North Pole Security, Inc.
+
+// Package connect implements unary RPCs over the Connect protocol, for
+// networks that allow HTTPS but not gRPC's HTTP/2 trailers.
+//
+// ClientConn satisfies grpc.ClientConnInterface, so the generated gRPC client
+// stubs can use it unchanged. Streaming RPCs are not supported.
+package connect
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxErrorBodySize bounds how much of an error response is read.
+const maxErrorBodySize = 1 << 20
+
+// ClientConn sends unary RPCs to a Connect server as HTTP POSTs with binary
+// protobuf bodies.
+type ClientConn struct {
+	baseURL     string
+	client      *http.Client
+	creds       credentials.PerRPCCredentials
+	interceptor grpc.UnaryClientInterceptor
+	userAgent   string
+}
+
+// NewClient returns a ClientConn for the server at baseURL, e.g.
+// "https://workshop.example.com". client sends the requests; nil means a
+// client like http.DefaultClient, which follows the proxy environment
+// variables. creds, if non-nil, supplies request headers such as
+// Authorization. interceptors run around every call, outermost first, and
+// receive a nil *grpc.ClientConn.
+func NewClient(baseURL string, client *http.Client, creds credentials.PerRPCCredentials, interceptors ...grpc.UnaryClientInterceptor) *ClientConn {
+	if client == nil {
+		client = &http.Client{}
+	}
+	return &ClientConn{
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+		client:      client,
+		creds:       creds,
+		interceptor: chain(interceptors),
+	}
+}
+
+// WithUserAgent sets the User-Agent header of every request to userAgent,
+// instead of the HTTP client's default, and returns c.
+func (c *ClientConn) WithUserAgent(userAgent string) *ClientConn {
+	c.userAgent = userAgent
+	return c
+}
+
+// Invoke sends a unary RPC. method is the full gRPC method name, e.g.
+// "/workshop.v1.WorkshopService/ListRules", which is also its Connect path.
+// Call options are ignored.
+func (c *ClientConn) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	if c.interceptor == nil {
+		return c.invoke(ctx, method, args, reply)
+	}
+	return c.interceptor(ctx, method, args, reply, nil, func(ctx context.Context, method string, args, reply any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		return c.invoke(ctx, method, args, reply)
+	}, opts...)
+}
+
+func (c *ClientConn) invoke(ctx context.Context, method string, args, reply any) error {
+	in, ok := args.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "connect: request %T is not a protobuf message", args)
+	}
+	out, ok := reply.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "connect: response %T is not a protobuf message", reply)
+	}
+
+	body, err := proto.Marshal(in)
+	if err != nil {
+		return status.Errorf(codes.Internal, "connect: failed to marshal request: %v", err)
+	}
+
+	url := c.baseURL + method
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return status.Errorf(codes.Internal, "connect: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/proto")
+	req.Header.Set("Connect-Protocol-Version", "1")
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		ms := max(time.Until(deadline).Milliseconds(), 1)
+		req.Header.Set("Connect-Timeout-Ms", strconv.FormatInt(ms, 10))
+	}
+	if c.creds != nil {
+		md, err := c.creds.GetRequestMetadata(ctx, url)
+		if err != nil {
+			return status.Errorf(codes.Unauthenticated, "connect: failed to get credentials: %v", err)
+		}
+		for k, v := range md {
+			req.Header.Set(k, v)
+		}
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return status.FromContextError(ctxErr).Err()
+		}
+		return status.Errorf(codes.Unavailable, "connect: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return decodeError(resp)
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "connect: failed to read response: %v", err)
+	}
+	if err := proto.Unmarshal(b, out); err != nil {
+		return status.Errorf(codes.Internal, "connect: failed to unmarshal response: %v", err)
+	}
+	return nil
+}
+
+// NewStream always fails: the provider only makes unary calls.
+func (c *ClientConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, status.Errorf(codes.Unimplemented, "connect: streaming RPC %s is not supported", method)
+}
+
+// Close releases idle HTTP connections.
+func (c *ClientConn) Close() error {
+	c.client.CloseIdleConnections()
+	return nil
+}
+
+// decodeError converts a Connect error response to a gRPC status error. The
+// body is JSON such as {"code": "not_found", "message": "..."}; a body that
+// isn't, e.g. from a proxy, is mapped from the HTTP status code.
+func decodeError(resp *http.Response) error {
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+
+	var wire struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(b, &wire) == nil {
+		if code, ok := codeByName[wire.Code]; ok {
+			return status.Error(code, wire.Message)
+		}
+	}
+	return status.Error(httpStatusCode(resp.StatusCode), fmt.Sprintf("connect: HTTP %s", resp.Status))
+}
+
+// codeByName maps Connect's error code names, the snake_case form of the gRPC
+// code names, to gRPC codes.
+var codeByName = func() map[string]codes.Code {
+	m := map[string]codes.Code{}
+	for c := codes.OK; c <= codes.Unauthenticated; c++ {
+		var b strings.Builder
+		for i, r := range c.String() {
+			if i > 0 && r >= 'A' && r <= 'Z' {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r)
+		}
+		m[strings.ToLower(b.String())] = c
+	}
+	return m
+}()
+
+// httpStatusCode maps an HTTP status to a gRPC code as the Connect protocol
+// specifies for responses without a Connect error body.
+func httpStatusCode(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.Internal
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.Unimplemented
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return codes.Unavailable
+	default:
+		return codes.Unknown
+	}
+}
+
+// chain combines interceptors into one, with the first outermost.
+func chain(interceptors []grpc.UnaryClientInterceptor) grpc.UnaryClientInterceptor {
+	if len(interceptors) == 0 {
+		return nil
+	}
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		next := invoker
+		for i := len(interceptors) - 1; i > 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				return interceptor(ctx, method, req, reply, cc, inner, opts...)
+			}
+		}
+		return interceptors[0](ctx, method, req, reply, cc, next, opts...)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 North Pole Security, Inc.
+package connect
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+type staticCreds map[string]string
+
+func (c staticCreds) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return c, nil
+}
+
+func (c staticCreds) RequireTransportSecurity() bool { return false }
+
+func TestInvoke(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/workshop.v1.WorkshopService/ListTags" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		if r.Header.Get("Content-Type") != "application/proto" || r.Header.Get("Connect-Protocol-Version") != "1" {
+			t.Errorf("headers = %v", r.Header)
+		}
+		if r.Header.Get("Authorization") != "secret" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			io.WriteString(w, `{"code": "unauthenticated", "message": "bad key"}`)
+			return
+		}
+		if got := r.Header.Get("User-Agent"); got != "deploy-pipeline" {
+			t.Errorf("User-Agent = %q", got)
+		}
+		b, _ := io.ReadAll(r.Body)
+		req := &apipb.ListTagsRequest{}
+		if err := proto.Unmarshal(b, req); err != nil {
+			t.Fatalf("failed to unmarshal request: %v", err)
+		}
+		out, _ := proto.Marshal(apipb.ListTagsResponse_builder{
+			Tags: []*apipb.TagStats{apipb.TagStats_builder{Tag: req.GetFilter()}.Build()},
+		}.Build())
+		w.Header().Set("Content-Type", "application/proto")
+		w.Write(out)
+	}))
+	defer srv.Close()
+
+	var calls []string
+	logger := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		calls = append(calls, method)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	conn := NewClient(srv.URL+"/", nil, staticCreds{"Authorization": "secret"}, logger).WithUserAgent("deploy-pipeline")
+	ret, err := svcpb.NewWorkshopServiceClient(conn).ListTags(context.Background(), apipb.ListTagsRequest_builder{Filter: proto.String("echo")}.Build())
+	if err != nil {
+		t.Fatalf("ListTags() error = %v", err)
+	}
+	if got := ret.GetTags()[0].GetTag(); got != "echo" {
+		t.Errorf("tag = %q, want echo", got)
+	}
+	if len(calls) != 1 || calls[0] != "/workshop.v1.WorkshopService/ListTags" {
+		t.Errorf("interceptor calls = %v", calls)
+	}
+
+	conn = NewClient(srv.URL, nil, staticCreds{"Authorization": "wrong"})
+	_, err = svcpb.NewWorkshopServiceClient(conn).ListTags(context.Background(), &apipb.ListTagsRequest{})
+	if s := status.Convert(err); s.Code() != codes.Unauthenticated || s.Message() != "bad key" {
+		t.Errorf("ListTags() error = %v, want Unauthenticated: bad key", err)
+	}
+}
+
+func TestDecodeErrorWithoutConnectBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "upstream unavailable", http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	_, err := svcpb.NewWorkshopServiceClient(NewClient(srv.URL, nil, nil)).ListTags(context.Background(), &apipb.ListTagsRequest{})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("ListTags() error = %v, want Unavailable", err)
+	}
+}
+
+func TestCodeByName(t *testing.T) {
+	for name, want := range map[string]codes.Code{
+		"canceled":            codes.Canceled,
+		"not_found":           codes.NotFound,
+		"deadline_exceeded":   codes.DeadlineExceeded,
+		"failed_precondition": codes.FailedPrecondition,
+		"unauthenticated":     codes.Unauthenticated,
+	} {
+		if got, ok := codeByName[name]; !ok || got != want {
+			t.Errorf("codeByName[%q] = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2026 North Pole Security, Inc.
+
+// Package filter builds filter expressions for Workshop's List RPCs.
+//
+// Values are always written as literals, so a name containing a quote or a
+// backslash matches that name rather than changing the expression.
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Expr is a filter expression. The zero Expr matches everything and renders
+// as the empty string.
+type Expr struct {
+	s string
+	// compound is set for AND and OR expressions, which need parentheses
+	// when nested.
+	compound bool
+}
+
+// String renders e as a filter string for a List RPC.
+func (e Expr) String() string { return e.s }
+
+// IsEmpty reports whether e is the zero Expr.
+func (e Expr) IsEmpty() bool { return e.s == "" }
+
+// Eq matches field equal to value.
+func Eq(field string, value any) Expr { return Cmp(field, "=", value) }
+
+// Ne matches field not equal to value.
+func Ne(field string, value any) Expr { return Cmp(field, "!=", value) }
+
+// Cmp compares field with value using op, e.g. ">=". Integers are written as
+// numbers; every other value is written as a quoted string, formatting
+// non-strings with fmt.Sprint first so that enum values match by name.
+func Cmp(field, op string, value any) Expr {
+	return Expr{s: field + " " + op + " " + literal(value)}
+}
+
+// Raw wraps a filter written by a practitioner, such as a data source's filter
+// argument. It is parenthesized so that combining it can't change its
+// meaning.
+func Raw(s string) Expr {
+	if s == "" {
+		return Expr{}
+	}
+	return Expr{s: "(" + s + ")"}
+}
+
+// And matches when every expression matches. Empty expressions are skipped.
+func And(exprs ...Expr) Expr { return join(" AND ", exprs) }
+
+// Or matches when any expression matches. Empty expressions are skipped.
+func Or(exprs ...Expr) Expr { return join(" OR ", exprs) }
+
+func join(sep string, exprs []Expr) Expr {
+	var parts []string
+	for _, e := range exprs {
+		switch {
+		case e.IsEmpty():
+			continue
+		case e.compound:
+			parts = append(parts, "("+e.s+")")
+		default:
+			parts = append(parts, e.s)
+		}
+	}
+	switch len(parts) {
+	case 0:
+		return Expr{}
+	case 1:
+		// A lone operand keeps its own form, without parentheses.
+		for _, e := range exprs {
+			if !e.IsEmpty() {
+				return e
+			}
+		}
+	}
+	return Expr{s: strings.Join(parts, sep), compound: true}
+}
+
+// Quote returns s as a filter string literal.
+func Quote(s string) string {
+	var b strings.Builder
+	b.Grow(len(s) + 2)
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"', '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+func literal(value any) string {
+	switch v := value.(type) {
+	case string:
+		return Quote(v)
+	case int:
+		return strconv.Itoa(v)
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	default:
+		return Quote(fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 North Pole Security, Inc.
+package filter
+
+import (
+	"testing"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+func TestExpr(t *testing.T) {
+	tests := []struct {
+		name string
+		expr Expr
+		want string
+	}{
+		{
+			name: "empty",
+			expr: And(Expr{}, Or()),
+			want: "",
+		},
+		{
+			name: "string",
+			expr: Eq("tag", "global"),
+			want: `tag = "global"`,
+		},
+		{
+			name: "escaped string",
+			expr: Eq("name", `say "hi" \ bye`+"\n"),
+			want: `name = "say \"hi\" \\ bye\n"`,
+		},
+		{
+			name: "injection stays a literal",
+			expr: Eq("tag", `x" OR tag != "`),
+			want: `tag = "x\" OR tag != \""`,
+		},
+		{
+			name: "integer",
+			expr: Eq("rule_id", int64(42)),
+			want: `rule_id = 42`,
+		},
+		{
+			name: "enum",
+			expr: Eq("rule_type", apipb.RuleType_TEAMID),
+			want: `rule_type = "TEAMID"`,
+		},
+		{
+			name: "comparison",
+			expr: Cmp("execution_time", ">=", "2024-01-01T00:00:00Z"),
+			want: `execution_time >= "2024-01-01T00:00:00Z"`,
+		},
+		{
+			name: "lone operand",
+			expr: Or(Expr{}, Eq("rule_id", 5)),
+			want: `rule_id = 5`,
+		},
+		{
+			name: "nested",
+			expr: Or(Eq("rule_id", 5), And(Eq("name", "ssh"), Eq("tag", "global"))),
+			want: `rule_id = 5 OR (name = "ssh" AND tag = "global")`,
+		},
+		{
+			name: "raw",
+			expr: And(Raw(`decision = "BLOCK_BINARY" OR decision = "ALLOW_BINARY"`), Ne("team_id", "")),
+			want: `(decision = "BLOCK_BINARY" OR decision = "ALLOW_BINARY") AND team_id != ""`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.expr.String(); got != tt.want {
+				t.Errorf("String() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
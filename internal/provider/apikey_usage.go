@@ -0,0 +1,65 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/northpolesec/terraform-provider-nps/internal/filter"
+	"google.golang.org/protobuf/proto"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// permissionAuditEvents maps each permission scope to the audit events that
+// the RPCs requiring it emit. A key used a scope if the audit log has one of
+// these events with the key as the actor. Scopes only required by RPCs that
+// emit nothing, which is most reads, are missing: their use can't be seen.
+var permissionAuditEvents = func() map[string][]apipb.AuditEvent {
+	out := map[string][]apipb.AuditEvent{}
+	for m := range workshopMethods() {
+		emitted, _ := proto.GetExtension(m.Options(), apipb.E_AuditEvent).(*apipb.AuditEventEmitted)
+		scope, event := methodPermission(m).GetPermission(), emitted.GetEvent()
+		if scope == "" || event == apipb.AuditEvent_AUDIT_EVENT_UNSPECIFIED || slices.Contains(out[scope], event) {
+			continue
+		}
+		out[scope] = append(out[scope], event)
+	}
+	return out
+}()
+
+// unusedPermissions returns the permissions of the key named name that it
+// hasn't used since since, in the order given. Permissions whose use the audit
+// log doesn't record are never reported. Each remaining permission costs one
+// count-only audit log query.
+func unusedPermissions(ctx context.Context, client svcpb.WorkshopServiceClient, name string, permissions []string, since time.Time) ([]string, error) {
+	unused := []string{}
+	for _, p := range permissions {
+		events := permissionAuditEvents[p]
+		if len(events) == 0 {
+			continue
+		}
+		eventClauses := make([]filter.Expr, 0, len(events))
+		for _, e := range events {
+			eventClauses = append(eventClauses, filter.Eq("event", e.String()))
+		}
+		ret, err := client.ListAuditEvents(ctx, apipb.ListAuditEventsRequest_builder{
+			Filter: proto.String(filter.And(
+				filter.Eq("actor", "apikey:"+name),
+				filter.Cmp("timestamp", ">=", since.UTC().Format(time.RFC3339)),
+				filter.Or(eventClauses...),
+			).String()),
+			CountOnly: proto.Bool(true),
+		}.Build())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p, err)
+		}
+		if ret.GetCount() == 0 {
+			unused = append(unused, p)
+		}
+	}
+	return unused, nil
+}
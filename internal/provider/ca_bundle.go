@@ -0,0 +1,21 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"crypto/x509"
+	"errors"
+)
+
+// caCertPool returns the system's trusted CAs plus the PEM-encoded
+// certificates in bundle, so that a Workshop instance signed by a private CA
+// and a login service with a public certificate both verify.
+func caCertPool(bundle string) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM([]byte(bundle)) {
+		return nil, errors.New("CA bundle contains no PEM-encoded certificates")
+	}
+	return pool, nil
+}
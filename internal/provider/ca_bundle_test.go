@@ -0,0 +1,45 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCACertPool(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+	bundle := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}))
+
+	// The test server's CA isn't in the system pool, so the default client
+	// rejects it.
+	if _, err := http.Get(srv.URL); err == nil {
+		t.Fatal("the default client trusted the test server's certificate")
+	}
+
+	pool, err := caCertPool(bundle)
+	if err != nil {
+		t.Fatalf("caCertPool() error: %v", err)
+	}
+	resp, err := newHTTPClient("", pool).Get(srv.URL)
+	if err != nil {
+		t.Fatalf("request with the CA bundle failed: %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestCACertPoolInvalid(t *testing.T) {
+	for _, bundle := range []string{"not a certificate", "-----BEGIN CERTIFICATE-----\nAAAA\n-----END CERTIFICATE-----\n"} {
+		if _, err := caCertPool(bundle); err == nil {
+			t.Errorf("caCertPool(%q) accepted a bundle without certificates", bundle)
+		}
+	}
+}
+
+func TestNewHTTPClientDefault(t *testing.T) {
+	if c := newHTTPClient("", nil); c != nil {
+		t.Errorf("newHTTPClient() = %v, want nil to use the default client", c)
+	}
+}
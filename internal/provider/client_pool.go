@@ -0,0 +1,312 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/northpolesec/terraform-provider-nps/internal/auth"
+	"github.com/northpolesec/terraform-provider-nps/internal/connect"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+)
+
+// connOptions are the provider settings that shape a connection besides its
+// endpoint and credential.
+type connOptions struct {
+	// transport is transportGRPC or transportConnect.
+	transport string
+	// proxyURL, if set, is the HTTP proxy every request goes through;
+	// otherwise HTTPS_PROXY and NO_PROXY apply.
+	proxyURL string
+	// caBundle, if set, is PEM-encoded certificates trusted in addition to
+	// the system's.
+	caBundle string
+	// serviceAccount, if set, authenticates instead of the API key.
+	serviceAccount *auth.ServiceAccount
+	// oidc, if set, authenticates with the CI platform's OIDC identity
+	// instead of the API key.
+	oidc *auth.OIDC
+	// userAgent, if set, is sent as the User-Agent of every RPC.
+	userAgent string
+	// grpc tunes gRPC transport connections.
+	grpc grpcTuning
+}
+
+// authOIDC is the provider's auth value for OIDC workload identity.
+const authOIDC = "oidc"
+
+// clientKey identifies a pooled connection. The credential and proxy URL are
+// hashed so raw API keys and proxy passwords aren't kept around as map keys;
+// an empty credential means the stored login token for the endpoint, and an
+// empty proxy the proxy environment variables. The CA bundle is hashed too,
+// only to keep the key small.
+type clientKey struct {
+	endpoint   string
+	credential string
+	transport  string
+	proxy      string
+	caBundle   string
+	userAgent  string
+	grpc       grpcTuning
+}
+
+func newClientKey(endpoint, apiKey string, opts connOptions) clientKey {
+	credential := apiKey
+	if sa := opts.serviceAccount; sa != nil {
+		credential = strings.Join([]string{"service_account", sa.Issuer, sa.KeyFile, sa.KeyID, sa.TokenURL}, "\x00")
+	}
+	if o := opts.oidc; o != nil {
+		credential = strings.Join([]string{authOIDC, o.Audience, o.TokenURL}, "\x00")
+	}
+	return clientKey{
+		endpoint:   endpoint,
+		credential: hashSecret(credential),
+		transport:  opts.transport,
+		proxy:      hashSecret(opts.proxyURL),
+		caBundle:   hashSecret(opts.caBundle),
+		userAgent:  opts.userAgent,
+		grpc:       opts.grpc,
+	}
+}
+
+func hashSecret(s string) string {
+	if s == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// Transports the provider can reach Workshop over.
+const (
+	transportGRPC    = "grpc"
+	transportConnect = "connect"
+)
+
+// pooledConn is a connection the pool can hand to the generated client stubs:
+// a *grpc.ClientConn, or a *connect.ClientConn for the Connect transport.
+type pooledConn interface {
+	grpc.ClientConnInterface
+	Close() error
+}
+
+// clientPool hands out Workshop clients keyed by endpoint and credential. A
+// connection is dialed the first time its key is requested and shared by every
+// later caller, so resources that target the same endpoint reuse one
+// connection. It is safe for concurrent use.
+type clientPool struct {
+	mu    sync.Mutex
+	conns map[clientKey]pooledConn
+	// dials are the dials in progress. Dialing can exchange credentials over
+	// the network, so it happens without mu held, and callers that want a
+	// key being dialed wait for that dial rather than starting another.
+	dials map[clientKey]*pendingDial
+
+	// dial connects to an endpoint; tests replace it.
+	dial func(ctx context.Context, endpoint, apiKey string, opts connOptions) (pooledConn, error)
+
+	// interceptors are installed on every connection the pool dials.
+	interceptors []grpc.UnaryClientInterceptor
+}
+
+// pendingDial is a dial in progress. conn and err are set before done is
+// closed.
+type pendingDial struct {
+	done chan struct{}
+	conn pooledConn
+	err  error
+}
+
+func newClientPool(interceptors ...grpc.UnaryClientInterceptor) *clientPool {
+	p := &clientPool{
+		conns:        map[clientKey]pooledConn{},
+		dials:        map[clientKey]*pendingDial{},
+		interceptors: interceptors,
+	}
+	p.dial = p.dialConn
+	return p
+}
+
+// Get returns a client for endpoint, authenticated with apiKey or, when apiKey
+// is empty, with the stored login token for endpoint. A failed dial isn't
+// kept, so the next Get for the key dials again.
+func (p *clientPool) Get(ctx context.Context, endpoint, apiKey string, opts connOptions) (svcpb.WorkshopServiceClient, error) {
+	key := newClientKey(endpoint, apiKey, opts)
+
+	p.mu.Lock()
+	if conn, ok := p.conns[key]; ok {
+		p.mu.Unlock()
+		return svcpb.NewWorkshopServiceClient(conn), nil
+	}
+	d, ok := p.dials[key]
+	if !ok {
+		d = &pendingDial{done: make(chan struct{})}
+		p.dials[key] = d
+	}
+	p.mu.Unlock()
+
+	if ok {
+		select {
+		case <-d.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	} else {
+		d.conn, d.err = p.dial(ctx, endpoint, apiKey, opts)
+		p.mu.Lock()
+		delete(p.dials, key)
+		if d.err == nil {
+			p.conns[key] = d.conn
+		}
+		p.mu.Unlock()
+		close(d.done)
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	return svcpb.NewWorkshopServiceClient(d.conn), nil
+}
+
+func (p *clientPool) dialConn(ctx context.Context, endpoint, apiKey string, opts connOptions) (pooledConn, error) {
+	tlsConfig := &tls.Config{}
+	if opts.caBundle != "" {
+		pool, err := caCertPool(opts.caBundle)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
+	}
+	httpClient := newHTTPClient(opts.proxyURL, tlsConfig.RootCAs)
+
+	// Get the necessary auth call option.
+	var (
+		rpcCreds credentials.PerRPCCredentials
+		err      error
+	)
+	switch {
+	case opts.serviceAccount != nil:
+		rpcCreds, err = auth.ServiceAccountCredentials(ctx, *opts.serviceAccount, endpoint, httpClient)
+	case opts.oidc != nil:
+		rpcCreds, err = auth.OIDCCredentials(ctx, *opts.oidc, endpoint, httpClient)
+	default:
+		rpcCreds, err = auth.APIKeyOrToken(ctx, apiKey, endpoint, httpClient)
+	}
+	if err != nil {
+		return nil, &clientAuthError{err: err}
+	}
+
+	scheme := "https"
+	if endpoint == "localhost:8080" {
+		scheme = "http"
+	}
+	if opts.transport == transportConnect {
+		return connect.NewClient(scheme+"://"+endpoint, httpClient, rpcCreds, p.interceptors...).WithUserAgent(opts.userAgent), nil
+	}
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithPerRPCCredentials(rpcCreds),
+		grpc.WithChainUnaryInterceptor(p.interceptors...),
+	}
+	if opts.userAgent != "" {
+		dialOpts = append(dialOpts, grpc.WithUserAgent(opts.userAgent))
+	}
+	dialOpts = append(dialOpts, opts.grpc.dialOptions()...)
+
+	// If the endpoint is localhost, allow an insecure connection.
+	// Otherwise ensure TLS is used.
+	if endpoint == "localhost:8080" {
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	} else {
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	}
+
+	// gRPC follows HTTPS_PROXY and NO_PROXY itself, but only without a custom
+	// dialer. An explicit proxy needs one, and the passthrough resolver so the
+	// dialer gets the host name for the proxy to resolve.
+	target := "dns:" + endpoint
+	if opts.proxyURL != "" {
+		dialOpts = append(dialOpts, grpc.WithContextDialer(proxyDialer(scheme, proxyFunc(opts.proxyURL))))
+		target = "passthrough:///" + endpoint
+	}
+
+	// grpc.NewClient doesn't connect until the first RPC, so an unreachable
+	// endpoint surfaces as an error from the first call rather than here.
+	conn, err := grpc.NewClient(target, dialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to endpoint: %w", err)
+	}
+	return conn, nil
+}
+
+// newHTTPClient returns the client for the provider's HTTP requests (the
+// Connect transport and logins) through proxyURL and trusting rootCAs, or nil
+// to use the default client, which already follows the proxy environment
+// variables and trusts the system's CAs.
+func newHTTPClient(proxyURL string, rootCAs *x509.CertPool) *http.Client {
+	if proxyURL == "" && rootCAs == nil {
+		return nil
+	}
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if proxyURL != "" {
+		proxy := proxyFunc(proxyURL)
+		t.Proxy = func(req *http.Request) (*url.URL, error) { return proxy(req.URL) }
+	}
+	if rootCAs != nil {
+		t.TLSClientConfig = &tls.Config{RootCAs: rootCAs}
+	}
+	return &http.Client{Transport: t}
+}
+
+// Close closes every pooled connection. The plugin framework has no provider
+// shutdown hook, so in normal operation connections live until the provider
+// process exits; Close exists for tests and embedders that own the pool. Dials
+// in progress aren't waited for; their connections are pooled once dialed.
+func (p *clientPool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var errs []error
+	for key, conn := range p.conns {
+		errs = append(errs, conn.Close())
+		delete(p.conns, key)
+	}
+	return errors.Join(errs...)
+}
+
+// clientAuthError marks a failure to obtain credentials, which Configure
+// reports separately from connection errors.
+type clientAuthError struct {
+	err error
+}
+
+func (e *clientAuthError) Error() string { return e.err.Error() }
+func (e *clientAuthError) Unwrap() error { return e.err }
+
+// logUnaryInterceptor logs every Workshop RPC with its duration. Failures are
+// logged at debug level too: many are expected (e.g. NotFound on a delete) and
+// the caller decides how to surface them.
+func logUnaryInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	fields := map[string]any{"method": method, "duration_ms": time.Since(start).Milliseconds()}
+	if err != nil {
+		fields["err"] = err.Error()
+	}
+	tflog.Debug(ctx, "Workshop RPC", fields)
+	return err
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestClientPoolReusesConnections(t *testing.T) {
+	t.Setenv("WORKSHOP_API_KEY", "")
+	ctx := context.Background()
+	p := newClientPool(logUnaryInterceptor)
+
+	for range 2 {
+		if _, err := p.Get(ctx, "localhost:8080", "key-a", connOptions{transport: transportGRPC}); err != nil {
+			t.Fatalf("Get() error: %v", err)
+		}
+	}
+	if len(p.conns) != 1 {
+		t.Fatalf("got %d connections after repeated Get, want 1", len(p.conns))
+	}
+
+	if _, err := p.Get(ctx, "localhost:8080", "key-b", connOptions{transport: transportGRPC}); err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+	if _, err := p.Get(ctx, "other.example:443", "key-a", connOptions{transport: transportGRPC}); err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+	if _, err := p.Get(ctx, "other.example:443", "key-a", connOptions{transport: transportConnect}); err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+	if _, err := p.Get(ctx, "other.example:443", "key-a", connOptions{transport: transportConnect, proxyURL: "http://proxy.example:3128"}); err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+	if _, err := p.Get(ctx, "other.example:443", "key-a", connOptions{transport: transportGRPC, grpc: grpcTuning{keepaliveTime: time.Minute}}); err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+	if len(p.conns) != 6 {
+		t.Fatalf("got %d connections, want one per endpoint, credential, transport, proxy, and tuning", len(p.conns))
+	}
+
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+	if len(p.conns) != 0 {
+		t.Errorf("Close() left %d connections", len(p.conns))
+	}
+}
+
+func TestClientPoolDialsWithoutBlockingOtherKeys(t *testing.T) {
+	ctx := context.Background()
+	p := newClientPool()
+	release := make(chan struct{})
+	var dials atomic.Int32
+	p.dial = func(ctx context.Context, endpoint, apiKey string, opts connOptions) (pooledConn, error) {
+		dials.Add(1)
+		if endpoint == "slow.example:443" {
+			<-release
+		}
+		return grpc.NewClient("passthrough:///"+endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+
+	// Two callers wait on the same slow dial, e.g. a device-token exchange.
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := p.Get(ctx, "slow.example:443", "key", connOptions{}); err != nil {
+				t.Errorf("Get(slow) error: %v", err)
+			}
+		}()
+	}
+
+	// Another endpoint is dialed meanwhile, and a caller giving up on the slow
+	// one isn't stuck behind it.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, err := p.Get(ctx, "fast.example:443", "key", connOptions{}); err != nil {
+			t.Errorf("Get(fast) error: %v", err)
+		}
+		for dials.Load() < 2 {
+			time.Sleep(time.Millisecond)
+		}
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		if _, err := p.Get(cancelled, "slow.example:443", "key", connOptions{}); err == nil {
+			t.Error("Get(slow) with a cancelled context succeeded while the dial was pending")
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Get for another endpoint blocked on a pending dial")
+	}
+
+	close(release)
+	wg.Wait()
+	if got := dials.Load(); got != 2 {
+		t.Errorf("dialed %d times, want once per endpoint", got)
+	}
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// connectionCheckTimeout bounds validate_connection so an unreachable endpoint
+// fails the plan promptly instead of waiting out gRPC's connection backoff.
+const connectionCheckTimeout = 30 * time.Second
+
+// checkConnection makes one cheap authenticated RPC so that a wrong endpoint
+// or bad credentials are reported at configure time. grpc.NewClient never
+// dials, so otherwise they only surface on the first resource RPC.
+func checkConnection(ctx context.Context, client svcpb.WorkshopServiceClient, endpoint string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	ctx, cancel := context.WithTimeout(ctx, connectionCheckTimeout)
+	defer cancel()
+	_, err := client.ListTags(ctx, apipb.ListTagsRequest_builder{PageSize: proto.Uint32(1)}.Build())
+
+	switch status.Code(err) {
+	case codes.OK, codes.PermissionDenied:
+		// A permission error still proves the endpoint and credentials work;
+		// the key just can't list tags.
+	case codes.Unauthenticated:
+		diags.AddError(
+			codeUnauthenticated.summary("NPS Provider Authentication error"),
+			fmt.Sprintf("Workshop at %s rejected the provider's credentials: %s\n\nCheck api_key (or WORKSHOP_API_KEY), or log in again if the provider uses a stored user token.", endpoint, status.Convert(err).Message()),
+		)
+	case codes.Unavailable, codes.DeadlineExceeded:
+		diags.AddError(
+			codeUnavailable.summary("NPS Provider connection error"),
+			fmt.Sprintf("Could not reach Workshop at %s: %s\n\nCheck endpoint (or WORKSHOP_ENDPOINT) and that the host is reachable from here.", endpoint, status.Convert(err).Message()),
+		)
+	default:
+		diags.AddError(
+			apiErrorCode(err).summary("NPS Provider connection error"),
+			fmt.Sprintf("Workshop at %s failed a connection check: %v\n\nCheck that endpoint (or WORKSHOP_ENDPOINT) is a Workshop API endpoint.", endpoint, err),
+		)
+	}
+	return diags
+}
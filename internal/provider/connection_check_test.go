@@ -0,0 +1,37 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCheckConnection(t *testing.T) {
+	for _, c := range []struct {
+		name        string
+		err         error
+		wantSummary string
+	}{
+		{name: "ok"},
+		{name: "permission denied", err: status.Error(codes.PermissionDenied, "missing read:tags")},
+		{name: "unauthenticated", err: status.Error(codes.Unauthenticated, "invalid API key"), wantSummary: "[NPS003 Unauthenticated] NPS Provider Authentication error"},
+		{name: "unreachable", err: status.Error(codes.Unavailable, "connection refused"), wantSummary: "[NPS007 Unavailable] NPS Provider connection error"},
+		{name: "not workshop", err: status.Error(codes.Unimplemented, "unknown service"), wantSummary: "[NPS008 APIError] NPS Provider connection error"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			diags := checkConnection(context.Background(), &fakeWorkshopClient{listTagsErr: c.err}, "workshop.example")
+			if c.wantSummary == "" {
+				if diags.HasError() {
+					t.Fatalf("checkConnection() = %v, want no error", diags)
+				}
+				return
+			}
+			if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != c.wantSummary {
+				t.Errorf("checkConnection() = %v, want one %q error", diags, c.wantSummary)
+			}
+		})
+	}
+}
@@ -0,0 +1,384 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// The tests in this file check that each rule resource's model survives a
+// round trip through its proto: building the proto a Create sends and
+// applying it back as Read does must reproduce the state, or every refresh
+// after an apply would show a diff.
+
+// assertSameState fails unless got and want encode to the same state under
+// r's schema.
+func assertSameState(t *testing.T, r resource.Resource, got, want any) {
+	t.Helper()
+	ctx := context.Background()
+	var sResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &sResp)
+
+	gotState := tfsdk.State{Schema: sResp.Schema}
+	wantState := tfsdk.State{Schema: sResp.Schema}
+	if d := gotState.Set(ctx, got); d.HasError() {
+		t.Fatalf("encoding got: %v", d)
+	}
+	if d := wantState.Set(ctx, want); d.HasError() {
+		t.Fatalf("encoding want: %v", d)
+	}
+	if !gotState.Raw.Equal(wantState.Raw) {
+		diffs, _ := gotState.Raw.Diff(wantState.Raw)
+		for _, d := range diffs {
+			t.Errorf("%s: got %s, want %s", d.Path, d.Value1, d.Value2)
+		}
+	}
+}
+
+func stringList(values ...string) types.List {
+	if len(values) == 0 {
+		return types.ListNull(types.StringType)
+	}
+	elems := make([]attr.Value, 0, len(values))
+	for _, v := range values {
+		elems = append(elems, types.StringValue(v))
+	}
+	return types.ListValueMust(types.StringType, elems)
+}
+
+// optionalString is the state value of an optional string attribute the
+// provider sends as-is: the API doesn't distinguish empty from unset.
+func optionalString(v string) types.String {
+	if v == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(v)
+}
+
+func ruleRoundTrip(t *testing.T, data RuleResourceModel) RuleResourceModel {
+	t.Helper()
+	rule := buildCreateRuleRequest(data).GetRule()
+	rule.SetRuleId(data.Id.ValueString())
+
+	var diags diag.Diagnostics
+	got := data
+	applyRuleProto(&got, rule, newReadDecoder(true, &diags))
+	if diags.HasError() {
+		t.Fatalf("applyRuleProto: %v", diags)
+	}
+	return got
+}
+
+func TestRuleConversionRoundTrip(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		data RuleResourceModel
+	}{
+		{
+			name: "minimal allowlist",
+			data: RuleResourceModel{
+				Identifier:  types.StringValue("EQHXZ8M8AV"),
+				RuleType:    types.StringValue("TEAMID"),
+				Policy:      types.StringValue("ALLOWLIST"),
+				Silent:      types.BoolValue(false),
+				BlockReason: types.StringNull(),
+				Tag:         types.StringValue("global"),
+				Id:          types.StringValue("rule-1"),
+			},
+		},
+		{
+			name: "blocklist with messages",
+			data: RuleResourceModel{
+				Identifier:  types.StringValue("platform:com.apple.curl"),
+				RuleType:    types.StringValue("SIGNINGID"),
+				Policy:      types.StringValue("SILENT_BLOCKLIST"),
+				Silent:      types.BoolValue(true),
+				BlockReason: types.StringValue("BLOCK_REASON_MALICIOUS"),
+				Tag:         types.StringValue("eng"),
+				Comment:     types.StringValue("no curl"),
+				CustomMsg:   types.StringValue("Blocked"),
+				CustomURL:   types.StringValue("https://example.com/{{.SHA256}}"),
+				Id:          types.StringValue("rule-2"),
+			},
+		},
+		{
+			name: "cel",
+			data: RuleResourceModel{
+				Identifier:  types.StringValue("platform:com.apple.ls"),
+				RuleType:    types.StringValue("SIGNINGID"),
+				Policy:      types.StringValue("CEL"),
+				Silent:      types.BoolValue(false),
+				BlockReason: types.StringNull(),
+				Tag:         types.StringValue("global"),
+				CELExpr:     types.StringValue("target.signing_time >= timestamp('2025-01-01T00:00:00Z')"),
+				Id:          types.StringValue("rule-3"),
+			},
+		},
+		{
+			// Sent as SILENT_BLOCKLIST, read back in the configured form.
+			name: "silenced blocklist",
+			data: RuleResourceModel{
+				Identifier:  types.StringValue("EQHXZ8M8AV"),
+				RuleType:    types.StringValue("TEAMID"),
+				Policy:      types.StringValue("BLOCKLIST"),
+				Silent:      types.BoolValue(true),
+				BlockReason: types.StringValue("BLOCK_REASON_POLICY"),
+				Tag:         types.StringValue("global"),
+				Id:          types.StringValue("rule-4"),
+			},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			assertSameState(t, &RuleResource{}, ruleRoundTrip(t, c.data), c.data)
+		})
+	}
+}
+
+func FuzzRuleConversionRoundTrip(f *testing.F) {
+	policies := enumValues(apipb.Policy(0).Descriptor())
+	ruleTypes := enumValues(apipb.RuleType(0).Descriptor())
+	f.Add("EQHXZ8M8AV", "global", "comment", "message", uint8(0), uint8(0))
+	f.Add("platform:com.apple.curl", "eng", "", "", uint8(3), uint8(4))
+	f.Fuzz(func(t *testing.T, identifier, tag, comment, customMsg string, policy, ruleType uint8) {
+		data := RuleResourceModel{
+			Identifier: types.StringValue(identifier),
+			RuleType:   types.StringValue(ruleTypes[int(ruleType)%len(ruleTypes)]),
+			Policy:     types.StringValue(policies[int(policy)%len(policies)]),
+			Tag:        types.StringValue(tag),
+			Comment:    optionalString(comment),
+			CustomMsg:  optionalString(customMsg),
+			Id:         types.StringValue("rule-1"),
+		}
+		data.BlockReason = resolveBlockReason(data.Policy.ValueString())
+		data.Silent = types.BoolValue(data.Policy.ValueString() == "SILENT_BLOCKLIST")
+
+		assertSameState(t, &RuleResource{}, ruleRoundTrip(t, data), data)
+	})
+}
+
+func fileAccessRuleRoundTrip(t *testing.T, data FileAccessRuleResourceModel) FileAccessRuleResourceModel {
+	t.Helper()
+	ctx := context.Background()
+	var diags diag.Diagnostics
+	rule := buildFileAccessRule(ctx, data, &diags)
+	rule.SetRuleId(data.Id.ValueInt64())
+
+	got := data
+	applyFileAccessRuleProto(ctx, &got, rule, newReadDecoder(true, &diags))
+	if diags.HasError() {
+		t.Fatalf("file access rule conversion: %v", diags)
+	}
+	return got
+}
+
+func TestFileAccessRuleConversionRoundTrip(t *testing.T) {
+	base := FileAccessRuleResourceModel{
+		Tag:                       types.StringValue("global"),
+		Name:                      types.StringValue("ssh"),
+		AllowReadAccess:           types.BoolValue(false),
+		BlockViolations:           types.BoolValue(true),
+		RuleType:                  types.StringValue("PathsWithAllowedProcesses"),
+		EnableSilentMode:          types.BoolValue(false),
+		EnableSilentTtyMode:       types.BoolValue(false),
+		BlockMessage:              types.StringNull(),
+		EventDetailUrl:            types.StringNull(),
+		EventDetailText:           types.StringNull(),
+		PathLiterals:              stringList(),
+		PathPrefixes:              stringList("/Users/*/.ssh/"),
+		ProcessBinaryPaths:        stringList("/usr/bin/ssh", "/usr/bin/scp"),
+		ProcessCdHashes:           stringList(),
+		ProcessSigningIds:         stringList(),
+		ProcessCertificateSha256s: stringList(),
+		ProcessTeamIds:            stringList(),
+		PreserveOrder:             types.BoolNull(),
+		Id:                        types.Int64Value(9),
+	}
+
+	withMessages := base
+	withMessages.RuleType = types.StringValue("ProcessesWithDeniedPaths")
+	withMessages.AllowReadAccess = types.BoolValue(true)
+	withMessages.EnableSilentTtyMode = types.BoolValue(true)
+	withMessages.BlockMessage = types.StringValue("Not here")
+	withMessages.EventDetailUrl = types.StringValue("https://example.com")
+	withMessages.EventDetailText = types.StringValue("Details")
+	withMessages.ProcessSigningIds = stringList("EQHXZ8M8AV:com.example.tool")
+	withMessages.ProcessTeamIds = stringList("EQHXZ8M8AV")
+	withMessages.PreserveOrder = types.BoolValue(true)
+
+	// The server stores hashes lower case; the configured case is kept.
+	upperHashes := base
+	upperHashes.ProcessCdHashes = stringList("A9F1C2D3E4B5A6978877665544332211FFEEDDCC")
+	upperHashes.ProcessCertificateSha256s = stringList("5A1B3C8D2E4F60718293A4B5C6D7E8F90A1B2C3D4E5F60718293A4B5C6D7E8F9")
+
+	for name, data := range map[string]FileAccessRuleResourceModel{
+		"paths with allowed processes": base,
+		"processes with denied paths":  withMessages,
+		"upper-case hashes":            upperHashes,
+	} {
+		t.Run(name, func(t *testing.T) {
+			assertSameState(t, &FileAccessRuleResource{}, fileAccessRuleRoundTrip(t, data), data)
+		})
+	}
+}
+
+func FuzzFileAccessRuleConversionRoundTrip(f *testing.F) {
+	f.Add("global", "ssh", "/Users/*/.ssh/", "/usr/bin/ssh", "", false)
+	f.Add("eng", "tmp", "", "", "blocked", true)
+	f.Fuzz(func(t *testing.T, tag, name, prefix, binaryPath, blockMessage string, silent bool) {
+		list := func(v string) types.List {
+			if v == "" {
+				return stringList()
+			}
+			return stringList(v)
+		}
+		data := FileAccessRuleResourceModel{
+			Tag:                       types.StringValue(tag),
+			Name:                      types.StringValue(name),
+			AllowReadAccess:           types.BoolValue(false),
+			BlockViolations:           types.BoolValue(true),
+			RuleType:                  types.StringValue("PathsWithAllowedProcesses"),
+			EnableSilentMode:          types.BoolValue(silent),
+			EnableSilentTtyMode:       types.BoolValue(false),
+			BlockMessage:              optionalString(blockMessage),
+			EventDetailUrl:            types.StringNull(),
+			EventDetailText:           types.StringNull(),
+			PathLiterals:              stringList(),
+			PathPrefixes:              list(prefix),
+			ProcessBinaryPaths:        list(binaryPath),
+			ProcessCdHashes:           stringList(),
+			ProcessSigningIds:         stringList(),
+			ProcessCertificateSha256s: stringList(),
+			ProcessTeamIds:            stringList(),
+			PreserveOrder:             types.BoolNull(),
+			Id:                        types.Int64Value(1),
+		}
+		assertSameState(t, &FileAccessRuleResource{}, fileAccessRuleRoundTrip(t, data), data)
+	})
+}
+
+func packageRuleRoundTrip(t *testing.T, data PackageRuleResourceModel) PackageRuleResourceModel {
+	t.Helper()
+	var diags diag.Diagnostics
+	rule := buildPackageRule(data, &diags)
+	rule.SetRuleId(data.Id.ValueInt64())
+
+	got := data
+	applyPackageRuleProto(&got, rule, newReadDecoder(true, &diags))
+	if diags.HasError() {
+		t.Fatalf("package rule conversion: %v", diags)
+	}
+	return got
+}
+
+func TestPackageRuleConversionRoundTrip(t *testing.T) {
+	base := PackageRuleResourceModel{
+		Tag:           types.StringValue("global"),
+		Source:        types.StringValue("PACKAGE_SOURCE_HOMEBREW"),
+		Name:          types.StringValue("wget"),
+		Policy:        types.StringValue("ALLOWLIST"),
+		RuleType:      types.StringValue("SIGNINGID"),
+		MinDate:       types.StringNull(),
+		MaxDate:       types.StringNull(),
+		VersionRegexp: types.StringNull(),
+		Versions:      stringList(),
+		Id:            types.Int64Value(5),
+
+		GeneratedIdentifiers: types.ListNull(types.StringType),
+	}
+	filtered := base
+	filtered.MinDate = types.StringValue("2024-01-01T00:00:00Z")
+	filtered.MaxDate = types.StringValue("2024-12-31T23:59:59Z")
+	filtered.VersionRegexp = types.StringValue(`^1\.`)
+	pinned := base
+	pinned.Versions = stringList("1.24.5", "1.25.0+1")
+
+	for name, data := range map[string]PackageRuleResourceModel{
+		"unfiltered": base,
+		"filtered":   filtered,
+		"pinned":     pinned,
+	} {
+		t.Run(name, func(t *testing.T) {
+			assertSameState(t, &PackageRuleResource{}, packageRuleRoundTrip(t, data), data)
+		})
+	}
+}
+
+func FuzzPackageRuleConversionRoundTrip(f *testing.F) {
+	f.Add("wget", `^1\.`, int64(1704067200))
+	f.Add("express", "", int64(0))
+	f.Fuzz(func(t *testing.T, name, versionRegexp string, minDate int64) {
+		data := PackageRuleResourceModel{
+			Tag:           types.StringValue("global"),
+			Source:        types.StringValue("PACKAGE_SOURCE_NPM"),
+			Name:          types.StringValue(name),
+			Policy:        types.StringValue("ALLOWLIST"),
+			RuleType:      types.StringValue("SIGNINGID"),
+			MinDate:       types.StringNull(),
+			MaxDate:       types.StringNull(),
+			VersionRegexp: optionalString(versionRegexp),
+			Versions:      stringList(),
+			Id:            types.Int64Value(5),
+
+			GeneratedIdentifiers: types.ListNull(types.StringType),
+		}
+		// Years outside 0001-9999 can't be written as RFC3339.
+		if minDate > 0 && minDate < 253402300800 {
+			data.MinDate = types.StringValue(time.Unix(minDate, 0).UTC().Format(time.RFC3339))
+		}
+
+		assertSameState(t, &PackageRuleResource{}, packageRuleRoundTrip(t, data), data)
+	})
+}
+
+func TestNetworkFlowRuleConversionRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	data := NetworkFlowRuleResourceModel{
+		Tag:               types.StringValue("global"),
+		Name:              types.StringValue("block-telnet"),
+		Action:            types.StringValue("NETWORK_FLOW_RULE_ACTION_DENY"),
+		Direction:         types.StringValue("NETWORK_FLOW_DIRECTION_OUTGOING"),
+		Priority:          types.BoolNull(),
+		Rank:              types.Int64Value(10),
+		ProcessCdHashes:   stringList(),
+		ProcessSigningIds: stringList(),
+		ProcessTeamIds:    stringList("EQHXZ8M8AV"),
+		RemoteHostnames:   stringList(),
+		RemoteDomains:     stringList("example.com"),
+		RemoteAddresses:   stringList(),
+		Protocols:         types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(6)}),
+		CustomMsg:         types.StringValue("No telnet"),
+		CustomUrl:         types.StringNull(),
+		Comment:           types.StringNull(),
+		Ports: []NetworkFlowRulePortRangeModel{
+			{Low: types.Int64Value(23), High: types.Int64Null()},
+			{Low: types.Int64Value(2000), High: types.Int64Value(2010)},
+		},
+		Id: types.Int64Value(3),
+	}
+
+	var diags diag.Diagnostics
+	rule := buildNetworkFlowRule(ctx, data, &diags)
+	rule.SetRuleId(data.Id.ValueInt64())
+
+	got := data
+	applyNetworkFlowRuleProto(ctx, &got, rule, &diags)
+	if diags.HasError() {
+		t.Fatalf("network flow rule conversion: %v", diags)
+	}
+	assertSameState(t, &NetworkFlowRuleResource{}, got, data)
+
+	// List applies onto an empty model, which must still encode.
+	var listed NetworkFlowRuleResourceModel
+	applyNetworkFlowRuleProto(ctx, &listed, rule, &diags)
+	listed.ProcessCdHashes = stringList()
+	assertSameState(t, &NetworkFlowRuleResource{}, listed, data)
+}
@@ -0,0 +1,273 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+
+	"github.com/northpolesec/terraform-provider-nps/internal/testserver"
+)
+
+// crudHarness calls a resource's Create, Read, Update, and Delete the way
+// Terraform does, with models of type M, against an in-memory Workshop. It
+// covers a resource's conversions and error handling end to end without
+// Terraform or a live instance; tests of a single RPC's payload use
+// fakeWorkshopClient instead.
+type crudHarness[M any] struct {
+	t      *testing.T
+	ctx    context.Context
+	r      resource.Resource
+	server *testserver.Server
+	client svcpb.WorkshopServiceClient
+
+	schema   resource.SchemaResponse
+	identity resource.IdentitySchemaResponse
+}
+
+// newCRUDHarness configures r with pd, or the default provider data if pd is
+// nil, and a client of a new in-memory Workshop.
+func newCRUDHarness[M any](t *testing.T, r resource.Resource, pd *NPSProviderResourceData) *crudHarness[M] {
+	t.Helper()
+	h := &crudHarness[M]{t: t, ctx: context.Background(), r: r, server: testserver.New()}
+	client, stop, err := h.server.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(stop)
+	h.client = client
+
+	if pd == nil {
+		pd = &NPSProviderResourceData{}
+	}
+	pd.Client = client
+	var cResp resource.ConfigureResponse
+	r.(resource.ResourceWithConfigure).Configure(h.ctx, resource.ConfigureRequest{ProviderData: pd}, &cResp)
+	if cResp.Diagnostics.HasError() {
+		t.Fatalf("Configure() = %v", cResp.Diagnostics)
+	}
+
+	r.Schema(h.ctx, resource.SchemaRequest{}, &h.schema)
+	if ri, ok := r.(resource.ResourceWithIdentity); ok {
+		ri.IdentitySchema(h.ctx, resource.IdentitySchemaRequest{}, &h.identity)
+	}
+	return h
+}
+
+func (h *crudHarness[M]) state(m *M) tfsdk.State {
+	h.t.Helper()
+	s := tfsdk.State{Schema: h.schema.Schema}
+	if m == nil {
+		s.RemoveResource(h.ctx)
+	} else if diags := s.Set(h.ctx, m); diags.HasError() {
+		h.t.Fatalf("setting state: %v", diags)
+	}
+	return s
+}
+
+func (h *crudHarness[M]) plan(m M) tfsdk.Plan {
+	s := h.state(&m)
+	return tfsdk.Plan{Schema: s.Schema, Raw: s.Raw}
+}
+
+func (h *crudHarness[M]) newIdentity() *tfsdk.ResourceIdentity {
+	if h.identity.IdentitySchema.Attributes == nil {
+		return nil
+	}
+	return &tfsdk.ResourceIdentity{Schema: h.identity.IdentitySchema}
+}
+
+// model returns the model in s, and false if s is removed.
+func (h *crudHarness[M]) model(s tfsdk.State) (M, bool) {
+	h.t.Helper()
+	var m M
+	if s.Raw.IsNull() {
+		return m, false
+	}
+	if diags := s.Get(h.ctx, &m); diags.HasError() {
+		h.t.Fatalf("reading state: %v", diags)
+	}
+	return m, true
+}
+
+func (h *crudHarness[M]) create(plan M) (M, diag.Diagnostics) {
+	h.t.Helper()
+	p := h.plan(plan)
+	resp := &resource.CreateResponse{State: h.state(nil), Identity: h.newIdentity()}
+	h.r.Create(h.ctx, resource.CreateRequest{Config: tfsdk.Config{Schema: p.Schema, Raw: p.Raw}, Plan: p}, resp)
+	m, _ := h.model(resp.State)
+	return m, resp.Diagnostics
+}
+
+// read returns the refreshed state, and false if Read removed it.
+func (h *crudHarness[M]) read(state M) (M, bool, diag.Diagnostics) {
+	h.t.Helper()
+	resp := &resource.ReadResponse{State: h.state(&state), Identity: h.newIdentity()}
+	h.r.Read(h.ctx, resource.ReadRequest{State: h.state(&state)}, resp)
+	m, ok := h.model(resp.State)
+	return m, ok, resp.Diagnostics
+}
+
+func (h *crudHarness[M]) update(state, plan M) (M, diag.Diagnostics) {
+	h.t.Helper()
+	p := h.plan(plan)
+	resp := &resource.UpdateResponse{State: h.state(&state), Identity: h.newIdentity()}
+	h.r.Update(h.ctx, resource.UpdateRequest{Config: tfsdk.Config{Schema: p.Schema, Raw: p.Raw}, Plan: p, State: h.state(&state)}, resp)
+	m, _ := h.model(resp.State)
+	return m, resp.Diagnostics
+}
+
+func (h *crudHarness[M]) delete(state M) diag.Diagnostics {
+	h.t.Helper()
+	resp := &resource.DeleteResponse{State: h.state(&state)}
+	h.r.Delete(h.ctx, resource.DeleteRequest{State: h.state(&state)}, resp)
+	return resp.Diagnostics
+}
+
+func TestRuleResourceCRUD(t *testing.T) {
+	h := newCRUDHarness[RuleResourceModel](t, NewRuleResource(), nil)
+
+	plan := RuleResourceModel{
+		Identifier:     types.StringValue("platform:com.apple.curl"),
+		RuleType:       types.StringValue("SIGNINGID"),
+		Policy:         types.StringValue("BLOCKLIST"),
+		Silent:         types.BoolValue(true),
+		BlockReason:    types.StringValue("BLOCK_REASON_POLICY"),
+		Tag:            types.StringValue("global"),
+		Comment:        types.StringNull(),
+		CustomMsg:      types.StringValue("Use the approved build"),
+		CustomURL:      types.StringNull(),
+		CELExpr:        types.StringNull(),
+		SeatbeltPolicy: types.StringNull(),
+		Id:             types.StringUnknown(),
+	}
+	state, diags := h.create(plan)
+	if diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	rules := h.server.Rules()
+	if len(rules) != 1 || rules[0].GetPolicy() != apipb.Policy_SILENT_BLOCKLIST || rules[0].GetRuleId() != state.Id.ValueString() {
+		t.Fatalf("server has %v, want one SILENT_BLOCKLIST rule with ID %s", rules, state.Id)
+	}
+
+	// A rule edited outside Terraform gets a new ID; Read finds it by its key
+	// and refreshes it, keeping the BLOCKLIST plus silent form.
+	if _, err := h.client.CreateRule(h.ctx, apipb.CreateRuleRequest_builder{Rule: apipb.Rule_builder{
+		Identifier: "platform:com.apple.curl",
+		RuleType:   apipb.RuleType_SIGNINGID,
+		Policy:     apipb.Policy_SILENT_BLOCKLIST,
+		Tag:        "global",
+		CustomMsg:  "Edited in the console",
+	}.Build()}.Build()); err != nil {
+		t.Fatal(err)
+	}
+	refreshed, ok, diags := h.read(state)
+	if diags.HasError() || !ok {
+		t.Fatalf("read: found %v, %v", ok, diags)
+	}
+	if refreshed.Id == state.Id || refreshed.CustomMsg.ValueString() != "Edited in the console" || refreshed.Policy.ValueString() != "BLOCKLIST" || !refreshed.Silent.ValueBool() {
+		t.Errorf("refreshed state = %+v, want the edited rule as BLOCKLIST with silent", refreshed)
+	}
+
+	// A failed update leaves the rule and the state as they were.
+	plan.Silent = types.BoolValue(false)
+	h.server.Fail("CreateRule", status.Error(codes.PermissionDenied, "read-only key"))
+	if _, diags := h.update(refreshed, plan); !diags.HasError() {
+		t.Error("update with CreateRule failing succeeded")
+	}
+	if got := h.server.Rules()[0]; got.GetPolicy() != apipb.Policy_SILENT_BLOCKLIST {
+		t.Errorf("failed update changed the rule to %v", got)
+	}
+	h.server.Fail("CreateRule", nil)
+
+	updated, diags := h.update(refreshed, plan)
+	if diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+	if got := h.server.Rules(); len(got) != 1 || got[0].GetPolicy() != apipb.Policy_BLOCKLIST || got[0].GetRuleId() != updated.Id.ValueString() {
+		t.Errorf("server has %v after update, want one BLOCKLIST rule with ID %s", got, updated.Id)
+	}
+
+	if diags := h.delete(updated); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	if got := h.server.Rules(); len(got) != 0 {
+		t.Errorf("server has %v after delete", got)
+	}
+	if _, ok, diags := h.read(updated); ok || diags.HasError() {
+		t.Errorf("read after delete: found %v, %v; want the resource removed", ok, diags)
+	}
+	// Deleting a rule that is already gone is not an error.
+	if diags := h.delete(updated); diags.HasError() {
+		t.Errorf("second delete: %v", diags)
+	}
+}
+
+func TestFileAccessRuleResourceCRUD(t *testing.T) {
+	h := newCRUDHarness[FileAccessRuleResourceModel](t, NewFileAccessRuleResource(), nil)
+
+	list := func(values ...string) types.List {
+		l, _ := types.ListValueFrom(h.ctx, types.StringType, values)
+		return l
+	}
+	plan := FileAccessRuleResourceModel{
+		Tag:                       types.StringValue("global"),
+		Name:                      types.StringValue("ssh"),
+		AllowReadAccess:           types.BoolValue(true),
+		BlockViolations:           types.BoolValue(true),
+		RuleType:                  types.StringValue("PathsWithAllowedProcesses"),
+		EnableSilentMode:          types.BoolValue(false),
+		EnableSilentTtyMode:       types.BoolValue(false),
+		BlockMessage:              types.StringNull(),
+		EventDetailUrl:            types.StringNull(),
+		EventDetailText:           types.StringNull(),
+		PathLiterals:              types.ListNull(types.StringType),
+		PathPrefixes:              list("/Users/*/.ssh/"),
+		ProcessBinaryPaths:        list("/usr/bin/ssh", "/usr/bin/scp"),
+		ProcessCdHashes:           types.ListNull(types.StringType),
+		ProcessSigningIds:         types.ListNull(types.StringType),
+		ProcessCertificateSha256s: types.ListNull(types.StringType),
+		ProcessTeamIds:            types.ListNull(types.StringType),
+		PreserveOrder:             types.BoolValue(false),
+		SkipUnchangedRefresh:      types.BoolValue(false),
+		Id:                        types.Int64Unknown(),
+	}
+	state, diags := h.create(plan)
+	if diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	rules := h.server.FileAccessRules()
+	if len(rules) != 1 || rules[0].GetRuleType() != apipb.FileAccessRuleType_FILE_ACCESS_RULE_TYPE_PATHS_WITH_ALLOWED_PROCESSES || len(rules[0].GetProcessBinaryPaths()) != 2 {
+		t.Fatalf("server has %v, want the ssh rule", rules)
+	}
+
+	refreshed, ok, diags := h.read(state)
+	if diags.HasError() || !ok {
+		t.Fatalf("read: found %v, %v", ok, diags)
+	}
+	if !refreshed.ProcessBinaryPaths.Equal(plan.ProcessBinaryPaths) || !refreshed.PathPrefixes.Equal(plan.PathPrefixes) || refreshed.RuleType != plan.RuleType {
+		t.Errorf("refreshed state = %+v, want the lists and rule type as configured", refreshed)
+	}
+
+	h.server.Fail("ListFileAccessRules", status.Error(codes.Unavailable, "down"))
+	if _, _, diags := h.read(state); !diags.HasError() {
+		t.Error("read with ListFileAccessRules failing succeeded")
+	}
+	h.server.Fail("ListFileAccessRules", nil)
+
+	if diags := h.delete(refreshed); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	if _, ok, _ := h.read(refreshed); ok {
+		t.Error("read after delete found the rule")
+	}
+}
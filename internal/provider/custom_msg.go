@@ -0,0 +1,56 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// customMsgTemplateRE matches template syntax that people reach for in block
+// messages: %placeholder% as in event URLs, and {{ variable }}.
+var customMsgTemplateRE = regexp.MustCompile(`%[a-z_]{3,}%|\{\{[^}]*\}\}`)
+
+// customMsgValidator rejects template variables in a block message. Neither
+// Workshop nor Santa substitutes anything in custom messages, so a variable
+// would reach users' screens as literal text; Santa only substitutes
+// placeholders in event detail URLs.
+type customMsgValidator struct{}
+
+func (v customMsgValidator) Description(ctx context.Context) string {
+	return "must not contain template variables, which are shown literally"
+}
+
+func (v customMsgValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v customMsgValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if vars := customMsgTemplateVars(req.ConfigValue.ValueString()); len(vars) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			codeInvalidConfig.summary("Unsupported message variable"),
+			fmt.Sprintf("Santa shows block messages exactly as written, so %s would be displayed literally. Per-event details such as the hostname, user, or binary can only be passed through placeholders in the event detail URL, e.g. %%hostname%%, %%username%%, or %%bundle_or_file%%.",
+				strings.Join(vars, ", ")),
+		)
+	}
+}
+
+// customMsgTemplateVars returns the template variables in msg, in order of
+// first appearance.
+func customMsgTemplateVars(msg string) []string {
+	var vars []string
+	for _, m := range customMsgTemplateRE.FindAllString(msg, -1) {
+		if !slices.Contains(vars, m) {
+			vars = append(vars, m)
+		}
+	}
+	return vars
+}
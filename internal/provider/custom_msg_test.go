@@ -0,0 +1,35 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCustomMsgValidator(t *testing.T) {
+	cases := []struct {
+		name    string
+		msg     types.String
+		wantErr bool
+	}{
+		{"plain text", types.StringValue("Blocked by IT. Contact <b>#help</b>."), false},
+		{"percent signs", types.StringValue("50% of 100% coverage"), false},
+		{"url placeholder", types.StringValue("%hostname% is not allowed to run this"), true},
+		{"braces", types.StringValue("Sorry {{ user }}, {{app}} is blocked"), true},
+		{"unknown value", types.StringUnknown(), false},
+		{"null value", types.StringNull(), false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			resp := &validator.StringResponse{}
+			customMsgValidator{}.ValidateString(context.Background(), validator.StringRequest{Path: path.Root("custom_msg"), ConfigValue: c.msg}, resp)
+			if resp.Diagnostics.HasError() != c.wantErr {
+				t.Errorf("HasError() = %v, want %v: %v", resp.Diagnostics.HasError(), c.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
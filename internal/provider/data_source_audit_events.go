@@ -0,0 +1,299 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/northpolesec/terraform-provider-nps/internal/filter"
+	"google.golang.org/protobuf/proto"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AuditEventsDataSource{}
+var _ datasource.DataSourceWithConfigure = &AuditEventsDataSource{}
+
+func NewAuditEventsDataSource() datasource.DataSource {
+	return &AuditEventsDataSource{}
+}
+
+// AuditEventsDataSource lists Workshop audit log entries.
+type AuditEventsDataSource struct {
+	client svcpb.WorkshopServiceClient
+}
+
+// AuditEventsDataSourceModel describes the data source data model.
+type AuditEventsDataSourceModel struct {
+	Filter        types.String      `tfsdk:"filter"`
+	Actors        []string          `tfsdk:"actors"`
+	ExcludeActors []string          `tfsdk:"exclude_actors"`
+	EventTypes    []string          `tfsdk:"event_types"`
+	StartTime     types.String      `tfsdk:"start_time"`
+	EndTime       types.String      `tfsdk:"end_time"`
+	Limit         types.Int64       `tfsdk:"limit"`
+	Truncated     types.Bool        `tfsdk:"truncated"`
+	PageToken     types.String      `tfsdk:"page_token"`
+	NextPageToken types.String      `tfsdk:"next_page_token"`
+	Events        []AuditEventModel `tfsdk:"events"`
+}
+
+// AuditEventModel describes a single audit log entry.
+type AuditEventModel struct {
+	ID            types.String `tfsdk:"id"`
+	TransactionID types.String `tfsdk:"transaction_id"`
+	Timestamp     types.String `tfsdk:"timestamp"`
+	Actor         types.String `tfsdk:"actor"`
+	Event         types.String `tfsdk:"event"`
+	Resource      types.String `tfsdk:"resource"`
+	Outcome       types.String `tfsdk:"outcome"`
+	Details       types.String `tfsdk:"details"`
+	PreviousValue types.String `tfsdk:"previous_value"`
+}
+
+func (d *AuditEventsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workshop_audit_events"
+}
+
+func (d *AuditEventsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The `nps_workshop_audit_events` data source lists entries of the Workshop audit log, newest first: who changed what, and when. Combined with a `check` block or a postcondition it lets a pipeline assert that no rule was changed outside Terraform, e.g. by listing rule events from every actor except the provider's own API key.\n\n" +
+			"Reading the audit log requires the `read:audit` permission.",
+
+		Attributes: map[string]schema.Attribute{
+			"filter": schema.StringAttribute{
+				MarkdownDescription: "A Workshop filter expression, e.g. `outcome = \"OUTCOME_DENIED\"`. Combined with the other arguments when those are set.",
+				Optional:            true,
+			},
+			"actors": schema.ListAttribute{
+				MarkdownDescription: "Only include events by one of these actors. Actors are written `user:<email>`, `apikey:<name>`, `host:<machine ID>`, or `system`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"exclude_actors": schema.ListAttribute{
+				MarkdownDescription: "Leave out events by these actors, e.g. the `apikey:<name>` of the key Terraform applies with.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"event_types": schema.ListAttribute{
+				MarkdownDescription: "Only include these kinds of event, e.g. `AUDIT_EVENT_RULE_UPSERT` and `AUDIT_EVENT_RULE_DELETE`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listValuesOneOf(enumValues(apipb.AuditEvent(0).Descriptor())...),
+				},
+			},
+			"start_time": schema.StringAttribute{
+				MarkdownDescription: "Only include events at or after this time. Format: RFC3339 (e.g., `2024-01-01T00:00:00Z`).",
+				Optional:            true,
+			},
+			"end_time": schema.StringAttribute{
+				MarkdownDescription: "Only include events before this time. Format: RFC3339 (e.g., `2024-01-02T00:00:00Z`).",
+				Optional:            true,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The maximum number of events to return. Defaults to `%d`; `truncated` is set when more events match.", defaultListLimit),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, maxListLimit),
+				},
+			},
+			"truncated": schema.BoolAttribute{
+				MarkdownDescription: "Whether more events matched than `limit` allowed. An empty `events` only proves nothing matched when this is false.",
+				Computed:            true,
+			},
+			"page_token": schema.StringAttribute{
+				MarkdownDescription: "The `next_page_token` of a previous read, to continue after the events it returned. Leave unset to start from the beginning. The token is only meaningful with the same filters. It is just the base64-encoded number of events already returned, not a cursor, so events added or removed before that position between reads shift the window: the next read can repeat or skip some.",
+				Optional:            true,
+			},
+			"next_page_token": schema.StringAttribute{
+				MarkdownDescription: "A token to pass as `page_token` to read the events after these. Null unless `truncated` is set.",
+				Computed:            true,
+			},
+			"events": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching events, newest first.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The event's ID.",
+							Computed:            true,
+						},
+						"transaction_id": schema.StringAttribute{
+							MarkdownDescription: "The ID shared by the events of one API call.",
+							Computed:            true,
+						},
+						"timestamp": schema.StringAttribute{
+							MarkdownDescription: "When the event happened, as an RFC3339 timestamp.",
+							Computed:            true,
+						},
+						"actor": schema.StringAttribute{
+							MarkdownDescription: "Who caused the event, e.g. `user:alice@example.com` or `apikey:terraform`.",
+							Computed:            true,
+						},
+						"event": schema.StringAttribute{
+							MarkdownDescription: "The kind of event, e.g. `AUDIT_EVENT_RULE_UPSERT`.",
+							Computed:            true,
+						},
+						"resource": schema.StringAttribute{
+							MarkdownDescription: "The object the event concerns, if any.",
+							Computed:            true,
+						},
+						"outcome": schema.StringAttribute{
+							MarkdownDescription: "Whether the action succeeded, e.g. `OUTCOME_SUCCESS` or `OUTCOME_DENIED`.",
+							Computed:            true,
+						},
+						"details": schema.StringAttribute{
+							MarkdownDescription: "What changed, as recorded by Workshop. Null if nothing was recorded.",
+							Computed:            true,
+						},
+						"previous_value": schema.StringAttribute{
+							MarkdownDescription: "The value before the change, as recorded by Workshop. Null if nothing was recorded.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *AuditEventsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(svcpb.WorkshopServiceClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			codeInternal.summary("Unexpected Data Source Configure Type"),
+			fmt.Sprintf("Expected WorkshopServiceClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *AuditEventsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AuditEventsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, diags := auditEventsFilter(data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := defaultListLimit
+	if !data.Limit.IsNull() {
+		limit = int(data.Limit.ValueInt64())
+	}
+	pageSize := min(limit+1, listPageSize)
+	offset, err := parseOffsetToken(data.PageToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("page_token"), codeInvalidConfig.summary("Invalid page_token"), err.Error())
+		return
+	}
+
+	pages := listPagesFrom(ctx, "audit events", offset, pageSize, func(page uint32) ([]*apipb.AuditEventEntry, bool, error) {
+		listReq := apipb.ListAuditEventsRequest_builder{
+			PageSize: proto.Uint32(uint32(pageSize)),
+			Page:     proto.Uint32(page),
+			OrderBy:  proto.String("timestamp desc"),
+		}
+		if query != "" {
+			listReq.Filter = proto.String(query)
+		}
+		ret, err := d.client.ListAuditEvents(ctx, listReq.Build())
+		return ret.GetEvents(), ret.GetMore(), err
+	})
+	events, truncated, err := collectPages(pages, limit)
+	if err != nil {
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to list audit events: %v", err))
+		return
+	}
+
+	data.Truncated = types.BoolValue(truncated)
+	data.NextPageToken = types.StringNull()
+	if truncated {
+		data.NextPageToken = types.StringValue(offsetToken(offset + len(events)))
+	}
+	data.Events = make([]AuditEventModel, 0, len(events))
+	for _, e := range events {
+		data.Events = append(data.Events, AuditEventModel{
+			ID:            types.StringValue(e.GetId()),
+			TransactionID: types.StringValue(e.GetTransactionId()),
+			Timestamp:     eventTime(e.GetTimestamp()),
+			Actor:         types.StringValue(e.GetActor()),
+			Event:         types.StringValue(e.GetEvent().String()),
+			Resource:      types.StringValue(e.GetResource()),
+			Outcome:       types.StringValue(e.GetOutcome().String()),
+			Details:       emptyStringToNull(e.GetDetails()),
+			PreviousValue: emptyStringToNull(e.GetPreviousValue()),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// auditEventsFilter combines the configured filter with the actor, event, and
+// time restrictions.
+func auditEventsFilter(data AuditEventsDataSourceModel) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	clauses := []filter.Expr{filter.Raw(data.Filter.ValueString())}
+
+	var actors []filter.Expr
+	for _, a := range data.Actors {
+		actors = append(actors, filter.Eq("actor", a))
+	}
+	clauses = append(clauses, filter.Or(actors...))
+	for _, a := range data.ExcludeActors {
+		clauses = append(clauses, filter.Ne("actor", a))
+	}
+	var events []filter.Expr
+	for _, e := range data.EventTypes {
+		events = append(events, filter.Eq("event", e))
+	}
+	clauses = append(clauses, filter.Or(events...))
+
+	for _, bound := range []struct {
+		attr  string
+		value types.String
+		op    string
+	}{
+		{"start_time", data.StartTime, ">="},
+		{"end_time", data.EndTime, "<"},
+	} {
+		if bound.value.ValueString() == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, bound.value.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root(bound.attr), codeInvalidConfig.summary("Invalid "+bound.attr), fmt.Sprintf("Failed to parse %s: %v", bound.attr, err))
+			continue
+		}
+		clauses = append(clauses, filter.Cmp("timestamp", bound.op, t.UTC().Format(time.RFC3339)))
+	}
+	return filter.And(clauses...).String(), diags
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestAuditEventsFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    AuditEventsDataSourceModel
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			data: AuditEventsDataSourceModel{},
+			want: "",
+		},
+		{
+			name: "one actor",
+			data: AuditEventsDataSourceModel{Actors: []string{"user:alice@example.com"}},
+			want: `actor = "user:alice@example.com"`,
+		},
+		{
+			name: "out-of-band rule changes",
+			data: AuditEventsDataSourceModel{
+				ExcludeActors: []string{"apikey:terraform", "system"},
+				EventTypes:    []string{"AUDIT_EVENT_RULE_UPSERT", "AUDIT_EVENT_RULE_DELETE"},
+				StartTime:     types.StringValue("2026-03-01T01:00:00+01:00"),
+			},
+			want: `actor != "apikey:terraform" AND actor != "system" AND (event = "AUDIT_EVENT_RULE_UPSERT" OR event = "AUDIT_EVENT_RULE_DELETE") AND timestamp >= "2026-03-01T00:00:00Z"`,
+		},
+		{
+			name: "filter and actors",
+			data: AuditEventsDataSourceModel{
+				Filter:  types.StringValue(`outcome = "OUTCOME_DENIED"`),
+				Actors:  []string{"user:alice@example.com", "user:bob@example.com"},
+				EndTime: types.StringValue("2026-03-02T00:00:00Z"),
+			},
+			want: `(outcome = "OUTCOME_DENIED") AND (actor = "user:alice@example.com" OR actor = "user:bob@example.com") AND timestamp < "2026-03-02T00:00:00Z"`,
+		},
+		{
+			name:    "invalid time",
+			data:    AuditEventsDataSourceModel{EndTime: types.StringValue("tomorrow")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := auditEventsFilter(tt.data)
+			if diags.HasError() != tt.wantErr {
+				t.Fatalf("auditEventsFilter() diags = %v, wantErr %v", diags, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("auditEventsFilter() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
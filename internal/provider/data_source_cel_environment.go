@@ -0,0 +1,172 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/northpolesec/terraform-provider-nps/internal/utils"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	celv2pb "buf.build/gen/go/northpolesec/protos/protocolbuffers/go/celv2"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CELEnvironmentDataSource{}
+
+func NewCELEnvironmentDataSource() datasource.DataSource {
+	return &CELEnvironmentDataSource{}
+}
+
+// CELEnvironmentDataSource describes the variables Santa provides to CEL
+// rules, derived from the CEL protos the provider is built against. It makes
+// no RPCs.
+type CELEnvironmentDataSource struct{}
+
+// CELEnvironmentDataSourceModel describes the data source data model.
+type CELEnvironmentDataSourceModel struct {
+	Variables    []CELVariableModel `tfsdk:"variables"`
+	ReturnValues []string           `tfsdk:"return_values"`
+}
+
+// CELVariableModel describes one variable or field.
+type CELVariableModel struct {
+	Name      types.String `tfsdk:"name"`
+	Type      types.String `tfsdk:"type"`
+	Cacheable types.Bool   `tfsdk:"cacheable"`
+}
+
+func (d *CELEnvironmentDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workshop_cel_environment"
+}
+
+func (d *CELEnvironmentDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The `nps_workshop_cel_environment` data source lists the variables Santa provides to the CEL expressions of `CEL` rules, and the values an expression can return. It is derived from the Santa CEL (v2) definitions this provider version was built with, so it makes no requests to Workshop.\n\n" +
+			"Santa's CEL extension functions are not listed.",
+
+		Attributes: map[string]schema.Attribute{
+			"variables": schema.ListNestedAttribute{
+				MarkdownDescription: "Every variable and field, e.g. `target.signing_id` or `args`. Fields of list elements are listed with `[]`, e.g. `ancestors[].team_id`.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The path to the variable.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The CEL type, e.g. `string`, `bool`, `int`, `uint`, `bytes`, `timestamp`, `list(string)`, or `map(string, string)`. Messages and enums are shown by name, e.g. `ExecutableFile`.",
+							Computed:            true,
+						},
+						"cacheable": schema.BoolAttribute{
+							MarkdownDescription: "Whether the variable depends only on the executed file. Santa caches the result of expressions that use only cacheable variables.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"return_values": schema.ListAttribute{
+				MarkdownDescription: "The values an expression can return, e.g. `ALLOWLIST` or `BLOCKLIST`. An expression can also return a boolean, where `true` means `ALLOWLIST` and `false` means `BLOCKLIST`.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *CELEnvironmentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	data := CELEnvironmentDataSourceModel{
+		Variables: celVariables((&celv2pb.ExecutionContext{}).ProtoReflect().Descriptor()),
+		// Skip UNSPECIFIED, which an expression can't usefully return.
+		ReturnValues: utils.ProtoEnumToList(celv2pb.ReturnValue(0).Descriptor())[1:],
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// celVariables lists the fields of an ExecutionContext message, depth first.
+// Only the target field is cacheable: Santa documents every other field as
+// preventing the result from being cached.
+func celVariables(md protoreflect.MessageDescriptor) []CELVariableModel {
+	var out []CELVariableModel
+	var walk func(md protoreflect.MessageDescriptor, prefix string, cacheable bool)
+	walk = func(md protoreflect.MessageDescriptor, prefix string, cacheable bool) {
+		fields := md.Fields()
+		for i := range fields.Len() {
+			fd := fields.Get(i)
+			name := prefix + string(fd.Name())
+			fieldCacheable := cacheable
+			if prefix == "" {
+				fieldCacheable = fd.Name() == "target"
+			}
+			out = append(out, CELVariableModel{
+				Name:      types.StringValue(name),
+				Type:      types.StringValue(celType(fd)),
+				Cacheable: types.BoolValue(fieldCacheable),
+			})
+
+			if fd.IsMap() || fd.Kind() != protoreflect.MessageKind || isWellKnownType(fd.Message()) {
+				continue
+			}
+			if fd.IsList() {
+				name += "[]"
+			}
+			walk(fd.Message(), name+".", fieldCacheable)
+		}
+	}
+	walk(md, "", false)
+	return out
+}
+
+// celType returns the CEL type of a field.
+func celType(fd protoreflect.FieldDescriptor) string {
+	switch {
+	case fd.IsMap():
+		return fmt.Sprintf("map(%s, %s)", celScalarType(fd.MapKey()), celScalarType(fd.MapValue()))
+	case fd.IsList():
+		return fmt.Sprintf("list(%s)", celScalarType(fd))
+	default:
+		return celScalarType(fd)
+	}
+}
+
+// celScalarType returns the CEL type of a single value of the field, ignoring
+// whether it is repeated.
+func celScalarType(fd protoreflect.FieldDescriptor) string {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return "bool"
+	case protoreflect.StringKind:
+		return "string"
+	case protoreflect.BytesKind:
+		return "bytes"
+	case protoreflect.DoubleKind, protoreflect.FloatKind:
+		return "double"
+	case protoreflect.Int32Kind, protoreflect.Int64Kind, protoreflect.Sint32Kind, protoreflect.Sint64Kind, protoreflect.Sfixed32Kind, protoreflect.Sfixed64Kind:
+		return "int"
+	case protoreflect.Uint32Kind, protoreflect.Uint64Kind, protoreflect.Fixed32Kind, protoreflect.Fixed64Kind:
+		return "uint"
+	case protoreflect.EnumKind:
+		return string(fd.Enum().Name())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		switch fd.Message().FullName() {
+		case "google.protobuf.Timestamp":
+			return "timestamp"
+		case "google.protobuf.Duration":
+			return "duration"
+		}
+		return string(fd.Message().Name())
+	default:
+		return fd.Kind().String()
+	}
+}
+
+// isWellKnownType reports whether md is a google.protobuf type, which CEL
+// treats as a value rather than a message with fields.
+func isWellKnownType(md protoreflect.MessageDescriptor) bool {
+	return md.ParentFile().Package() == "google.protobuf"
+}
@@ -0,0 +1,45 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"testing"
+
+	celv2pb "buf.build/gen/go/northpolesec/protos/protocolbuffers/go/celv2"
+)
+
+func TestCELVariables(t *testing.T) {
+	got := map[string]CELVariableModel{}
+	for _, v := range celVariables((&celv2pb.ExecutionContext{}).ProtoReflect().Descriptor()) {
+		got[v.Name.ValueString()] = v
+	}
+
+	for _, tc := range []struct {
+		name      string
+		typ       string
+		cacheable bool
+	}{
+		{"target", "ExecutableFile", true},
+		{"target.signing_id", "string", true},
+		{"target.signing_time", "timestamp", true},
+		{"target.is_platform_binary", "bool", true},
+		{"args", "list(string)", false},
+		{"envs", "map(string, string)", false},
+		{"euid", "int", false},
+		{"ancestors", "list(Ancestor)", false},
+		{"ancestors[].team_id", "string", false},
+	} {
+		v, ok := got[tc.name]
+		if !ok {
+			t.Errorf("variable %q missing", tc.name)
+			continue
+		}
+		if v.Type.ValueString() != tc.typ || v.Cacheable.ValueBool() != tc.cacheable {
+			t.Errorf("variable %q = (%s, %t), want (%s, %t)", tc.name, v.Type.ValueString(), v.Cacheable.ValueBool(), tc.typ, tc.cacheable)
+		}
+	}
+
+	// Well-known types are values, not messages to walk into.
+	if _, ok := got["target.signing_time.seconds"]; ok {
+		t.Error("timestamp fields should not be listed")
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/northpolesec/terraform-provider-nps/internal/filter"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+const defaultEventsPageSize = 100
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &EventsDataSource{}
+var _ datasource.DataSourceWithConfigure = &EventsDataSource{}
+
+func NewEventsDataSource() datasource.DataSource {
+	return &EventsDataSource{}
+}
+
+// EventsDataSource lists recent execution events.
+type EventsDataSource struct {
+	client svcpb.WorkshopServiceClient
+}
+
+// EventsDataSourceModel describes the data source data model.
+type EventsDataSourceModel struct {
+	Filter        types.String `tfsdk:"filter"`
+	Match         types.Map    `tfsdk:"match"`
+	StartTime     types.String `tfsdk:"start_time"`
+	EndTime       types.String `tfsdk:"end_time"`
+	PageSize      types.Int64  `tfsdk:"page_size"`
+	PageToken     types.String `tfsdk:"page_token"`
+	NextPageToken types.String `tfsdk:"next_page_token"`
+	Events        []EventModel `tfsdk:"events"`
+}
+
+// EventModel describes a single execution event.
+type EventModel struct {
+	UUID          types.String `tfsdk:"uuid"`
+	Sha256        types.String `tfsdk:"sha256"`
+	FilePath      types.String `tfsdk:"file_path"`
+	Decision      types.String `tfsdk:"decision"`
+	HostUUID      types.String `tfsdk:"host_uuid"`
+	Hostname      types.String `tfsdk:"hostname"`
+	ExecutingUser types.String `tfsdk:"executing_user"`
+	TeamID        types.String `tfsdk:"team_id"`
+	SigningID     types.String `tfsdk:"signing_id"`
+	ExecutionTime types.String `tfsdk:"execution_time"`
+}
+
+func (d *EventsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workshop_events"
+}
+
+func (d *EventsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The `nps_workshop_events` data source lists the most recent execution events reported by Santa, newest first.\n\nBy default Santa only uploads executions that were blocked, or would have been blocked in Lockdown mode, so allowed executions are only listed when `enable_all_event_upload` is set in `nps_workshop_sync_settings`.\n\nReading events requires the `read:events` permission.",
+
+		Attributes: map[string]schema.Attribute{
+			"filter": schema.StringAttribute{
+				MarkdownDescription: "A Workshop filter expression, e.g. `decision = \"BLOCK_BINARY\"`. Combined with `match`, `start_time`, and `end_time` when those are set.",
+				Optional:            true,
+			},
+			"match": schema.MapAttribute{
+				MarkdownDescription: "Fields that must equal the given values, e.g. `{ decision = \"BLOCK_BINARY\", team_id = \"EQHXZ8M8AV\" }`. Values are matched literally, so unlike `filter` they need no quoting or escaping.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"start_time": schema.StringAttribute{
+				MarkdownDescription: "Only include events executed at or after this time. Format: RFC3339 (e.g., `2024-01-01T00:00:00Z`).",
+				Optional:            true,
+			},
+			"end_time": schema.StringAttribute{
+				MarkdownDescription: "Only include events executed before this time. Format: RFC3339 (e.g., `2024-01-02T00:00:00Z`).",
+				Optional:            true,
+			},
+			"page_size": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The maximum number of events to return. Defaults to `%d`.", defaultEventsPageSize),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 1000),
+				},
+			},
+			"page_token": schema.StringAttribute{
+				MarkdownDescription: "The `next_page_token` of a previous read, to continue from where it stopped. Leave unset to start with the newest events. The token is only meaningful with the same `filter`, `match`, `start_time`, `end_time`, and `page_size`. It is just the base64-encoded page number, not a cursor, so events recorded between reads shift the window: the next read can repeat events already returned.",
+				Optional:            true,
+			},
+			"next_page_token": schema.StringAttribute{
+				MarkdownDescription: "A token to pass as `page_token` to read the next page of events. Null when there are no more events.",
+				Computed:            true,
+			},
+			"events": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching events, newest first.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"uuid": schema.StringAttribute{
+							MarkdownDescription: "The event's UUID.",
+							Computed:            true,
+						},
+						"sha256": schema.StringAttribute{
+							MarkdownDescription: "The SHA-256 of the executed binary.",
+							Computed:            true,
+						},
+						"file_path": schema.StringAttribute{
+							MarkdownDescription: "The path of the executed binary.",
+							Computed:            true,
+						},
+						"decision": schema.StringAttribute{
+							MarkdownDescription: "The decision Santa made, e.g. `BLOCK_BINARY`.",
+							Computed:            true,
+						},
+						"host_uuid": schema.StringAttribute{
+							MarkdownDescription: "The UUID of the host that reported the event.",
+							Computed:            true,
+						},
+						"hostname": schema.StringAttribute{
+							MarkdownDescription: "The hostname of the host that reported the event.",
+							Computed:            true,
+						},
+						"executing_user": schema.StringAttribute{
+							MarkdownDescription: "The user that ran the binary.",
+							Computed:            true,
+						},
+						"team_id": schema.StringAttribute{
+							MarkdownDescription: "The Team ID of the binary's signing certificate, if any.",
+							Computed:            true,
+						},
+						"signing_id": schema.StringAttribute{
+							MarkdownDescription: "The binary's signing ID, if any.",
+							Computed:            true,
+						},
+						"execution_time": schema.StringAttribute{
+							MarkdownDescription: "When the binary was executed, as an RFC3339 timestamp.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *EventsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(svcpb.WorkshopServiceClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			codeInternal.summary("Unexpected Data Source Configure Type"),
+			fmt.Sprintf("Expected WorkshopServiceClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *EventsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data EventsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, diags := eventsFilter(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	page, err := parsePageToken(data.PageToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("page_token"), codeInvalidConfig.summary("Invalid page_token"), err.Error())
+		return
+	}
+
+	pageSize := uint32(defaultEventsPageSize)
+	if !data.PageSize.IsNull() {
+		pageSize = uint32(data.PageSize.ValueInt64())
+	}
+
+	listReq := apipb.ListEventsRequest_builder{
+		PageSize: proto.Uint32(pageSize),
+		Page:     proto.Uint32(page),
+		OrderBy:  proto.String("execution_time desc"),
+	}
+	if query != "" {
+		listReq.Filter = proto.String(query)
+	}
+	ret, err := d.client.ListEvents(ctx, listReq.Build())
+	if err != nil {
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to list events: %v", err))
+		return
+	}
+
+	data.NextPageToken = types.StringNull()
+	if ret.GetMore() {
+		data.NextPageToken = types.StringValue(pageToken(page + 1))
+	}
+
+	data.Events = make([]EventModel, 0, len(ret.GetEvents()))
+	for _, e := range ret.GetEvents() {
+		data.Events = append(data.Events, EventModel{
+			UUID:          types.StringValue(e.GetUuid()),
+			Sha256:        types.StringValue(e.GetSha256()),
+			FilePath:      types.StringValue(e.GetFilePath()),
+			Decision:      types.StringValue(e.GetDecision().String()),
+			HostUUID:      types.StringValue(e.GetHost().GetUuid()),
+			Hostname:      types.StringValue(e.GetHost().GetHostname()),
+			ExecutingUser: types.StringValue(e.GetExecutingUser()),
+			TeamID:        types.StringValue(e.GetTeamId()),
+			SigningID:     types.StringValue(e.GetSigningId()),
+			ExecutionTime: eventTime(e.GetExecutionTime()),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// eventsFilter combines the configured filter with match and the time range.
+func eventsFilter(ctx context.Context, data EventsDataSourceModel) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	clauses := []filter.Expr{filter.Raw(data.Filter.ValueString())}
+
+	match := map[string]string{}
+	if !data.Match.IsNull() {
+		diags.Append(data.Match.ElementsAs(ctx, &match, false)...)
+	}
+	for _, field := range slices.Sorted(maps.Keys(match)) {
+		clauses = append(clauses, filter.Eq(field, match[field]))
+	}
+
+	for _, bound := range []struct {
+		attr  string
+		value types.String
+		op    string
+	}{
+		{"start_time", data.StartTime, ">="},
+		{"end_time", data.EndTime, "<"},
+	} {
+		if bound.value.ValueString() == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, bound.value.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root(bound.attr), codeInvalidConfig.summary("Invalid "+bound.attr), fmt.Sprintf("Failed to parse %s: %v", bound.attr, err))
+			continue
+		}
+		clauses = append(clauses, filter.Cmp("execution_time", bound.op, t.UTC().Format(time.RFC3339)))
+	}
+	return filter.And(clauses...).String(), diags
+}
+
+func eventTime(ts *timestamppb.Timestamp) types.String {
+	if ts == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(ts.AsTime().UTC().Format(time.RFC3339))
+}
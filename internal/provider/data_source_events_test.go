@@ -0,0 +1,66 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestEventsFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    EventsDataSourceModel
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			data: EventsDataSourceModel{},
+			want: "",
+		},
+		{
+			name: "filter only",
+			data: EventsDataSourceModel{Filter: types.StringValue(`decision = "BLOCK_BINARY"`)},
+			want: `(decision = "BLOCK_BINARY")`,
+		},
+		{
+			name: "time range",
+			data: EventsDataSourceModel{
+				Filter:    types.StringValue(`team_id = "EQHXZ8M8AV"`),
+				StartTime: types.StringValue("2024-01-01T01:00:00+01:00"),
+				EndTime:   types.StringValue("2024-01-02T00:00:00Z"),
+			},
+			want: `(team_id = "EQHXZ8M8AV") AND execution_time >= "2024-01-01T00:00:00Z" AND execution_time < "2024-01-02T00:00:00Z"`,
+		},
+		{
+			name: "match",
+			data: EventsDataSourceModel{
+				Filter: types.StringValue(`decision = "BLOCK_BINARY" OR decision = "BLOCK_CERTIFICATE"`),
+				Match: types.MapValueMust(types.StringType, map[string]attr.Value{
+					"team_id":  types.StringValue("EQHXZ8M8AV"),
+					"hostname": types.StringValue(`bob's "laptop"`),
+				}),
+			},
+			want: `(decision = "BLOCK_BINARY" OR decision = "BLOCK_CERTIFICATE") AND hostname = "bob's \"laptop\"" AND team_id = "EQHXZ8M8AV"`,
+		},
+		{
+			name:    "invalid time",
+			data:    EventsDataSourceModel{StartTime: types.StringValue("yesterday")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := eventsFilter(context.Background(), tt.data)
+			if diags.HasError() != tt.wantErr {
+				t.Fatalf("eventsFilter() diags = %v, wantErr %v", diags, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("eventsFilter() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
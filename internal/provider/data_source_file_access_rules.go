@@ -0,0 +1,294 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/protobuf/proto"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &FileAccessRulesDataSource{}
+var _ datasource.DataSourceWithConfigure = &FileAccessRulesDataSource{}
+
+func NewFileAccessRulesDataSource() datasource.DataSource {
+	return &FileAccessRulesDataSource{}
+}
+
+// FileAccessRulesDataSource lists the file access rules matching a filter.
+type FileAccessRulesDataSource struct {
+	client svcpb.WorkshopServiceClient
+}
+
+// FileAccessRulesDataSourceModel describes the data source data model.
+type FileAccessRulesDataSourceModel struct {
+	Filter        types.String                       `tfsdk:"filter"`
+	Limit         types.Int64                        `tfsdk:"limit"`
+	Truncated     types.Bool                         `tfsdk:"truncated"`
+	PageToken     types.String                       `tfsdk:"page_token"`
+	NextPageToken types.String                       `tfsdk:"next_page_token"`
+	Rules         []FileAccessRuleDataModel          `tfsdk:"rules"`
+	RulesByKey    map[string]FileAccessRuleDataModel `tfsdk:"rules_by_key"`
+}
+
+// FileAccessRuleDataModel describes a single file access rule.
+type FileAccessRuleDataModel struct {
+	Id                        types.Int64  `tfsdk:"id"`
+	Key                       types.String `tfsdk:"key"`
+	Tag                       types.String `tfsdk:"tag"`
+	Name                      types.String `tfsdk:"name"`
+	RuleType                  types.String `tfsdk:"rule_type"`
+	AllowReadAccess           types.Bool   `tfsdk:"allow_read_access"`
+	BlockViolations           types.Bool   `tfsdk:"block_violations"`
+	EnableSilentMode          types.Bool   `tfsdk:"enable_silent_mode"`
+	EnableSilentTtyMode       types.Bool   `tfsdk:"enable_silent_tty_mode"`
+	BlockMessage              types.String `tfsdk:"block_message"`
+	EventDetailUrl            types.String `tfsdk:"event_detail_url"`
+	EventDetailText           types.String `tfsdk:"event_detail_text"`
+	PathLiterals              types.List   `tfsdk:"path_literals"`
+	PathPrefixes              types.List   `tfsdk:"path_prefixes"`
+	ProcessBinaryPaths        types.List   `tfsdk:"process_binary_paths"`
+	ProcessCdHashes           types.List   `tfsdk:"process_cd_hashes"`
+	ProcessSigningIds         types.List   `tfsdk:"process_signing_ids"`
+	ProcessCertificateSha256s types.List   `tfsdk:"process_certificate_sha256s"`
+	ProcessTeamIds            types.List   `tfsdk:"process_team_ids"`
+	AddedBy                   types.String `tfsdk:"added_by"`
+}
+
+func (d *FileAccessRulesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workshop_file_access_rules"
+}
+
+// fileAccessRuleDataAttributes is the schema of a FileAccessRuleDataModel.
+func fileAccessRuleDataAttributes() map[string]schema.Attribute {
+	stringList := func(description string) schema.Attribute {
+		return schema.ListAttribute{
+			MarkdownDescription: description,
+			ElementType:         types.StringType,
+			Computed:            true,
+		}
+	}
+	return map[string]schema.Attribute{
+		"id": schema.Int64Attribute{
+			MarkdownDescription: "The rule's ID. This ID is reassigned whenever the rule is updated.",
+			Computed:            true,
+		},
+		"key": schema.StringAttribute{
+			MarkdownDescription: "The rule's natural key, `<tag>/<name>`, which is also accepted by `terraform import nps_workshop_file_access_rule`.",
+			Computed:            true,
+		},
+		"tag": schema.StringAttribute{
+			MarkdownDescription: "The tag the rule applies to.",
+			Computed:            true,
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "The rule's name, unique per tag.",
+			Computed:            true,
+		},
+		"rule_type": schema.StringAttribute{
+			MarkdownDescription: "The rule's type, as `nps_workshop_file_access_rule` writes it, e.g. `PathsWithAllowedProcesses`.",
+			Computed:            true,
+		},
+		"allow_read_access": schema.BoolAttribute{
+			MarkdownDescription: "Whether the rule allows read access to the files it matches.",
+			Computed:            true,
+		},
+		"block_violations": schema.BoolAttribute{
+			MarkdownDescription: "Whether violations are blocked, rather than only logged.",
+			Computed:            true,
+		},
+		"enable_silent_mode": schema.BoolAttribute{
+			MarkdownDescription: "Whether Santa's UI is suppressed for violations.",
+			Computed:            true,
+		},
+		"enable_silent_tty_mode": schema.BoolAttribute{
+			MarkdownDescription: "Whether TTY messages are suppressed for violations.",
+			Computed:            true,
+		},
+		"block_message": schema.StringAttribute{
+			MarkdownDescription: "The rule's custom block message, if any.",
+			Computed:            true,
+		},
+		"event_detail_url": schema.StringAttribute{
+			MarkdownDescription: "The rule's event detail URL, if any.",
+			Computed:            true,
+		},
+		"event_detail_text": schema.StringAttribute{
+			MarkdownDescription: "The rule's event detail button text, if any.",
+			Computed:            true,
+		},
+		"path_literals":               stringList("The exact paths the rule watches."),
+		"path_prefixes":               stringList("The path prefixes the rule watches."),
+		"process_binary_paths":        stringList("The processes the rule names, by binary path."),
+		"process_cd_hashes":           stringList("The processes the rule names, by CDHash."),
+		"process_signing_ids":         stringList("The processes the rule names, by signing ID."),
+		"process_certificate_sha256s": stringList("The processes the rule names, by certificate SHA-256."),
+		"process_team_ids":            stringList("The processes the rule names, by Team ID."),
+		"added_by": schema.StringAttribute{
+			MarkdownDescription: "The user or API key that created the rule, if known.",
+			Computed:            true,
+		},
+	}
+}
+
+func (d *FileAccessRulesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	ruleAttributes := schema.NestedAttributeObject{Attributes: fileAccessRuleDataAttributes()}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The `nps_workshop_file_access_rules` data source lists the file access rules matching a filter, walking every page of results up to `limit`, e.g. to audit which rules cover a sensitive path.\n\nReading file access rules requires the `read:rules` permission.",
+
+		Attributes: map[string]schema.Attribute{
+			"filter": schema.StringAttribute{
+				MarkdownDescription: "A Workshop filter expression, e.g. `tag = \"global\"`. Leave unset to list every file access rule.",
+				Optional:            true,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The maximum number of rules to return. Defaults to `%d`; `truncated` is set when more rules match.", defaultListLimit),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, maxListLimit),
+				},
+			},
+			"truncated": schema.BoolAttribute{
+				MarkdownDescription: "Whether more rules matched than `limit` allowed.",
+				Computed:            true,
+			},
+			"page_token": schema.StringAttribute{
+				MarkdownDescription: "The `next_page_token` of a previous read, to continue after the rules it returned. Leave unset to start from the beginning. The token is only meaningful with the same filters. It is just the base64-encoded number of rules already returned, not a cursor, so rules added or removed before that position between reads shift the window: the next read can repeat or skip some.",
+				Optional:            true,
+			},
+			"next_page_token": schema.StringAttribute{
+				MarkdownDescription: "A token to pass as `page_token` to read the rules after these. Null unless `truncated` is set.",
+				Computed:            true,
+			},
+			"rules": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching rules.",
+				Computed:            true,
+				NestedObject:        ruleAttributes,
+			},
+			"rules_by_key": schema.MapNestedAttribute{
+				MarkdownDescription: "The matching rules keyed by `key`, for looking a rule up by its tag and name.",
+				Computed:            true,
+				NestedObject:        ruleAttributes,
+			},
+		},
+	}
+}
+
+func (d *FileAccessRulesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(svcpb.WorkshopServiceClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			codeInternal.summary("Unexpected Data Source Configure Type"),
+			fmt.Sprintf("Expected WorkshopServiceClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *FileAccessRulesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data FileAccessRulesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := defaultListLimit
+	if !data.Limit.IsNull() {
+		limit = int(data.Limit.ValueInt64())
+	}
+	pageSize := min(limit+1, listPageSize)
+	offset, err := parseOffsetToken(data.PageToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("page_token"), codeInvalidConfig.summary("Invalid page_token"), err.Error())
+		return
+	}
+
+	pages := listPagesFrom(ctx, "file access rules", offset, pageSize, func(page uint32) ([]*apipb.FileAccessRule, bool, error) {
+		listReq := apipb.ListFileAccessRulesRequest_builder{
+			PageSize: proto.Uint32(uint32(pageSize)),
+			Page:     proto.Uint32(page),
+		}
+		if f := data.Filter.ValueString(); f != "" {
+			listReq.Filter = proto.String(f)
+		}
+		ret, err := d.client.ListFileAccessRules(ctx, listReq.Build())
+		return ret.GetRules(), ret.GetMore(), err
+	})
+	rules, truncated, err := collectPages(pages, limit)
+	if err != nil {
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to list file access rules: %v", err))
+		return
+	}
+
+	data.Truncated = types.BoolValue(truncated)
+	data.NextPageToken = types.StringNull()
+	if truncated {
+		data.NextPageToken = types.StringValue(offsetToken(offset + len(rules)))
+	}
+	data.Rules = make([]FileAccessRuleDataModel, 0, len(rules))
+	data.RulesByKey = make(map[string]FileAccessRuleDataModel, len(rules))
+	for _, rule := range rules {
+		m := fileAccessRuleDataModel(ctx, rule, &resp.Diagnostics)
+		data.Rules = append(data.Rules, m)
+		data.RulesByKey[m.Key.ValueString()] = m
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// fileAccessRuleDataModel converts a file access rule as returned by
+// ListFileAccessRules. Its key is the <tag>/<name> form ImportState accepts,
+// and unset lists are empty rather than null so they can be searched directly.
+func fileAccessRuleDataModel(ctx context.Context, rule *apipb.FileAccessRule, diags *diag.Diagnostics) FileAccessRuleDataModel {
+	list := func(values []string) types.List {
+		l, d := types.ListValueFrom(ctx, types.StringType, append([]string{}, values...))
+		diags.Append(d...)
+		return l
+	}
+	ruleType, ok := fileAccessRuleTypeToFriendly[rule.GetRuleType()]
+	if !ok {
+		ruleType = rule.GetRuleType().String()
+	}
+	return FileAccessRuleDataModel{
+		Id:                        types.Int64Value(rule.GetRuleId()),
+		Key:                       types.StringValue(rule.GetTag() + "/" + rule.GetName()),
+		Tag:                       types.StringValue(rule.GetTag()),
+		Name:                      types.StringValue(rule.GetName()),
+		RuleType:                  types.StringValue(ruleType),
+		AllowReadAccess:           types.BoolValue(rule.GetAllowReadAccess()),
+		BlockViolations:           types.BoolValue(rule.GetBlockViolations()),
+		EnableSilentMode:          types.BoolValue(rule.GetEnableSilentMode()),
+		EnableSilentTtyMode:       types.BoolValue(rule.GetEnableSilentTtyMode()),
+		BlockMessage:              emptyStringToNull(rule.GetBlockMessage()),
+		EventDetailUrl:            emptyStringToNull(rule.GetEventDetailUrl()),
+		EventDetailText:           emptyStringToNull(rule.GetEventDetailText()),
+		PathLiterals:              list(rule.GetPathLiterals()),
+		PathPrefixes:              list(rule.GetPathPrefixes()),
+		ProcessBinaryPaths:        list(rule.GetProcessBinaryPaths()),
+		ProcessCdHashes:           list(rule.GetProcessCdHashes()),
+		ProcessSigningIds:         list(rule.GetProcessSigningIds()),
+		ProcessCertificateSha256s: list(rule.GetProcessCertificateSha256S()),
+		ProcessTeamIds:            list(rule.GetProcessTeamIds()),
+		AddedBy:                   emptyStringToNull(rule.GetAddedBy()),
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+func TestFileAccessRulesDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeWorkshopClient{listFileAccessRules: []*apipb.FileAccessRule{
+		apipb.FileAccessRule_builder{
+			RuleId:             11,
+			Tag:                "global",
+			Name:               "protect-ssh-keys",
+			RuleType:           apipb.FileAccessRuleType_FILE_ACCESS_RULE_TYPE_PATHS_WITH_ALLOWED_PROCESSES,
+			BlockViolations:    true,
+			PathPrefixes:       []string{"/Users/*/.ssh/"},
+			ProcessBinaryPaths: []string{"/usr/bin/ssh"},
+			AddedBy:            "apikey:terraform",
+		}.Build(),
+		apipb.FileAccessRule_builder{RuleId: 12, Tag: "dev", Name: "audit-only"}.Build(),
+	}}
+	d := &FileAccessRulesDataSource{client: client}
+
+	var sResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &sResp)
+	config := tfsdk.State{Schema: sResp.Schema}
+	if diags := config.Set(ctx, FileAccessRulesDataSourceModel{Filter: types.StringValue(`block_violations = true`)}); diags.HasError() {
+		t.Fatalf("building config: %v", diags)
+	}
+
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: sResp.Schema}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: sResp.Schema, Raw: config.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("read failed: %v", resp.Diagnostics)
+	}
+
+	var got FileAccessRulesDataSourceModel
+	resp.State.Get(ctx, &got)
+	if client.listFARulesFilter != `block_violations = true` {
+		t.Errorf("ListFileAccessRules filter = %s", client.listFARulesFilter)
+	}
+	if len(got.Rules) != 2 || got.Truncated.ValueBool() {
+		t.Fatalf("got %d rules, truncated %v; want 2, false", len(got.Rules), got.Truncated)
+	}
+	ssh, ok := got.RulesByKey["global/protect-ssh-keys"]
+	if !ok {
+		t.Fatalf("rules_by_key = %v, want global/protect-ssh-keys", got.RulesByKey)
+	}
+	var prefixes []string
+	ssh.PathPrefixes.ElementsAs(ctx, &prefixes, false)
+	if ssh.RuleType.ValueString() != "PathsWithAllowedProcesses" || len(prefixes) != 1 || ssh.AddedBy.ValueString() != "apikey:terraform" {
+		t.Errorf("protect-ssh-keys = %+v", ssh)
+	}
+	// Unset lists are empty, not null, so configurations can search them.
+	if audit := got.RulesByKey["dev/audit-only"]; audit.PathLiterals.IsNull() || len(audit.PathLiterals.Elements()) != 0 || !audit.BlockMessage.IsNull() {
+		t.Errorf("audit-only = %+v", audit)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/northpolesec/terraform-provider-nps/internal/filter"
+	"google.golang.org/protobuf/proto"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &HostDataSource{}
+var _ datasource.DataSourceWithConfigure = &HostDataSource{}
+
+func NewHostDataSource() datasource.DataSource {
+	return &HostDataSource{}
+}
+
+// HostDataSource looks up a host by serial number. Its data model is
+// HostDataModel, with serial as the input.
+type HostDataSource struct {
+	client svcpb.WorkshopServiceClient
+}
+
+func (d *HostDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workshop_host"
+}
+
+func (d *HostDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attrs := hostDataAttributes()
+	attrs["serial"] = schema.StringAttribute{
+		MarkdownDescription: "The host's serial number. Matched case-insensitively.",
+		Required:            true,
+	}
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The `nps_workshop_host` data source looks up a host by serial number, e.g. to find the machine ID and tags of a CI build machine.\n\n" +
+			"A machine that has been re-enrolled can have more than one host record; the one that synced most recently is used. Requires the `read:hosts` permission.",
+		Attributes: attrs,
+	}
+}
+
+func (d *HostDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(svcpb.WorkshopServiceClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			codeInternal.summary("Unexpected Data Source Configure Type"),
+			fmt.Sprintf("Expected WorkshopServiceClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *HostDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data HostDataModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	host, err := findHostBySerial(ctx, d.client, data.Serial.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to read host: %v", err))
+		return
+	}
+	if host == nil {
+		resp.Diagnostics.AddError(codeNotFound.summary("Host not found"), fmt.Sprintf("No Workshop host has serial number %q.", data.Serial.ValueString()))
+		return
+	}
+
+	// Keep the serial as configured so a case difference doesn't change it.
+	serial := data.Serial
+	data = hostDataModel(ctx, host, &resp.Diagnostics)
+	data.Serial = serial
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// findHostBySerial returns the host with serial number serial, ignoring case,
+// that synced most recently, or nil if there is none.
+func findHostBySerial(ctx context.Context, client svcpb.WorkshopServiceClient, serial string) (*apipb.Host, error) {
+	ret, err := client.ListHosts(ctx, apipb.ListHostsRequest_builder{
+		Filter:   proto.String(filter.Eq("serial", serial).String()),
+		PageSize: proto.Uint32(10),
+	}.Build())
+	if err != nil {
+		return nil, err
+	}
+	var found *apipb.Host
+	for _, host := range ret.GetHosts() {
+		if !strings.EqualFold(host.GetSerial(), serial) {
+			continue
+		}
+		if found == nil || host.GetLastSync().AsTime().After(found.GetLastSync().AsTime()) {
+			found = host
+		}
+	}
+	return found, nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+func readHostDataSource(t *testing.T, client *fakeWorkshopClient, serial string) (HostDataModel, *datasource.ReadResponse) {
+	t.Helper()
+	ctx := context.Background()
+	d := &HostDataSource{client: client}
+
+	var sResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &sResp)
+	config := tfsdk.State{Schema: sResp.Schema}
+	model := HostDataModel{Serial: types.StringValue(serial), Tags: types.ListNull(types.StringType)}
+	if diags := config.Set(ctx, model); diags.HasError() {
+		t.Fatalf("building config: %v", diags)
+	}
+
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: sResp.Schema}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: sResp.Schema, Raw: config.Raw}}, resp)
+	var got HostDataModel
+	if !resp.Diagnostics.HasError() {
+		resp.State.Get(ctx, &got)
+	}
+	return got, resp
+}
+
+func TestHostDataSourceRead(t *testing.T) {
+	synced := func(day int) *timestamppb.Timestamp {
+		return timestamppb.New(time.Date(2026, 3, day, 0, 0, 0, 0, time.UTC))
+	}
+	client := &fakeWorkshopClient{listHosts: []*apipb.Host{
+		apipb.Host_builder{Uuid: "old", Serial: "C02XK0AAJG5H", LastSync: synced(1), Tags: []string{"global"}}.Build(),
+		apipb.Host_builder{Uuid: "new", Serial: "C02XK0AAJG5H", LastSync: synced(2), Tags: []string{"ci", "global"}}.Build(),
+	}}
+
+	got, resp := readHostDataSource(t, client, "c02xk0aajg5h")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("read failed: %v", resp.Diagnostics)
+	}
+	if client.listHostsFilter != `serial = "c02xk0aajg5h"` {
+		t.Errorf("ListHosts filter = %s", client.listHostsFilter)
+	}
+	if got.MachineID.ValueString() != "new" {
+		t.Errorf("machine_id = %s, want the most recently synced host", got.MachineID)
+	}
+	if got.Serial.ValueString() != "c02xk0aajg5h" {
+		t.Errorf("serial = %s, want the configured value", got.Serial)
+	}
+}
+
+func TestHostDataSourceNotFound(t *testing.T) {
+	_, resp := readHostDataSource(t, &fakeWorkshopClient{}, "C02XK0AAJG5H")
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("read succeeded for a missing host")
+	}
+	if got := resp.Diagnostics.Errors()[0].Summary(); got != "[NPS001 NotFound] Host not found" {
+		t.Errorf("summary = %q", got)
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/protobuf/proto"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &HostsDataSource{}
+var _ datasource.DataSourceWithConfigure = &HostsDataSource{}
+
+func NewHostsDataSource() datasource.DataSource {
+	return &HostsDataSource{}
+}
+
+// HostsDataSource lists the hosts matching a filter.
+type HostsDataSource struct {
+	client svcpb.WorkshopServiceClient
+}
+
+// HostsDataSourceModel describes the data source data model.
+type HostsDataSourceModel struct {
+	Filter        types.String             `tfsdk:"filter"`
+	Limit         types.Int64              `tfsdk:"limit"`
+	Truncated     types.Bool               `tfsdk:"truncated"`
+	PageToken     types.String             `tfsdk:"page_token"`
+	NextPageToken types.String             `tfsdk:"next_page_token"`
+	Hosts         []HostDataModel          `tfsdk:"hosts"`
+	HostsBySerial map[string]HostDataModel `tfsdk:"hosts_by_serial"`
+}
+
+// HostDataModel describes a single host.
+type HostDataModel struct {
+	MachineID            types.String `tfsdk:"machine_id"`
+	Serial               types.String `tfsdk:"serial"`
+	Hostname             types.String `tfsdk:"hostname"`
+	MachineModel         types.String `tfsdk:"machine_model"`
+	OSVersion            types.String `tfsdk:"os_version"`
+	PrimaryUser          types.String `tfsdk:"primary_user"`
+	SantaVersion         types.String `tfsdk:"santa_version"`
+	ClientMode           types.String `tfsdk:"client_mode"`
+	ConfiguredClientMode types.String `tfsdk:"configured_client_mode"`
+	LastSync             types.String `tfsdk:"last_sync"`
+	Tags                 types.List   `tfsdk:"tags"`
+}
+
+// hostDataAttributes is the schema of a HostDataModel.
+func hostDataAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"machine_id": schema.StringAttribute{
+			MarkdownDescription: "The machine ID the host reports to Workshop, usually its hardware UUID.",
+			Computed:            true,
+		},
+		"serial": schema.StringAttribute{
+			MarkdownDescription: "The host's serial number.",
+			Computed:            true,
+		},
+		"hostname": schema.StringAttribute{
+			MarkdownDescription: "The host's hostname.",
+			Computed:            true,
+		},
+		"machine_model": schema.StringAttribute{
+			MarkdownDescription: "The host's model, e.g. `Mac14,2`.",
+			Computed:            true,
+		},
+		"os_version": schema.StringAttribute{
+			MarkdownDescription: "The host's OS version.",
+			Computed:            true,
+		},
+		"primary_user": schema.StringAttribute{
+			MarkdownDescription: "The host's primary user, if known.",
+			Computed:            true,
+		},
+		"santa_version": schema.StringAttribute{
+			MarkdownDescription: "The version of Santa the host runs.",
+			Computed:            true,
+		},
+		"client_mode": schema.StringAttribute{
+			MarkdownDescription: "The client mode the host reported at its last sync, e.g. `LOCKDOWN`.",
+			Computed:            true,
+		},
+		"configured_client_mode": schema.StringAttribute{
+			MarkdownDescription: "The client mode Workshop has configured for the host.",
+			Computed:            true,
+		},
+		"last_sync": schema.StringAttribute{
+			MarkdownDescription: "When the host last synced, as an RFC3339 timestamp.",
+			Computed:            true,
+		},
+		"tags": schema.ListAttribute{
+			MarkdownDescription: "The host's tags, in precedence order.",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+	}
+}
+
+func (d *HostsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workshop_hosts"
+}
+
+func (d *HostsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The `nps_workshop_hosts` data source lists the hosts matching a filter, walking every page of results up to `limit`, e.g. to create a tag or rule for each host with some attribute.\n\nReading hosts requires the `read:hosts` permission.",
+
+		Attributes: map[string]schema.Attribute{
+			"filter": schema.StringAttribute{
+				MarkdownDescription: "A Workshop filter expression, e.g. `santa_version < \"2025.1\"`. Leave unset to list every host.",
+				Optional:            true,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The maximum number of hosts to return. Defaults to `%d`; `truncated` is set when more hosts match.", defaultListLimit),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, maxListLimit),
+				},
+			},
+			"truncated": schema.BoolAttribute{
+				MarkdownDescription: "Whether more hosts matched than `limit` allowed.",
+				Computed:            true,
+			},
+			"page_token": schema.StringAttribute{
+				MarkdownDescription: "The `next_page_token` of a previous read, to continue after the hosts it returned. Leave unset to start from the beginning. The token is only meaningful with the same filters. It is just the base64-encoded number of hosts already returned, not a cursor, so hosts added or removed before that position between reads shift the window: the next read can repeat or skip some.",
+				Optional:            true,
+			},
+			"next_page_token": schema.StringAttribute{
+				MarkdownDescription: "A token to pass as `page_token` to read the hosts after these. Null unless `truncated` is set.",
+				Computed:            true,
+			},
+			"hosts": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching hosts.",
+				Computed:            true,
+				NestedObject:        schema.NestedAttributeObject{Attributes: hostDataAttributes()},
+			},
+			"hosts_by_serial": schema.MapNestedAttribute{
+				MarkdownDescription: "The matching hosts keyed by serial number. A host that hasn't reported a serial number is only in `hosts`.",
+				Computed:            true,
+				NestedObject:        schema.NestedAttributeObject{Attributes: hostDataAttributes()},
+			},
+		},
+	}
+}
+
+func (d *HostsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(svcpb.WorkshopServiceClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			codeInternal.summary("Unexpected Data Source Configure Type"),
+			fmt.Sprintf("Expected WorkshopServiceClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *HostsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data HostsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := defaultListLimit
+	if !data.Limit.IsNull() {
+		limit = int(data.Limit.ValueInt64())
+	}
+	pageSize := min(limit+1, listPageSize)
+	offset, err := parseOffsetToken(data.PageToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("page_token"), codeInvalidConfig.summary("Invalid page_token"), err.Error())
+		return
+	}
+
+	pages := listPagesFrom(ctx, "hosts", offset, pageSize, func(page uint32) ([]*apipb.Host, bool, error) {
+		listReq := apipb.ListHostsRequest_builder{
+			PageSize: proto.Uint32(uint32(pageSize)),
+			Page:     proto.Uint32(page),
+		}
+		if f := data.Filter.ValueString(); f != "" {
+			listReq.Filter = proto.String(f)
+		}
+		ret, err := d.client.ListHosts(ctx, listReq.Build())
+		return ret.GetHosts(), ret.GetMore(), err
+	})
+	hosts, truncated, err := collectPages(pages, limit)
+	if err != nil {
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to list hosts: %v", err))
+		return
+	}
+
+	data.Truncated = types.BoolValue(truncated)
+	data.NextPageToken = types.StringNull()
+	if truncated {
+		data.NextPageToken = types.StringValue(offsetToken(offset + len(hosts)))
+	}
+	data.Hosts = make([]HostDataModel, 0, len(hosts))
+	data.HostsBySerial = make(map[string]HostDataModel, len(hosts))
+	for _, host := range hosts {
+		m := hostDataModel(ctx, host, &resp.Diagnostics)
+		data.Hosts = append(data.Hosts, m)
+		if serial := host.GetSerial(); serial != "" {
+			data.HostsBySerial[serial] = m
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// hostDataModel converts a host as returned by ListHosts or GetHost.
+func hostDataModel(ctx context.Context, host *apipb.Host, diags *diag.Diagnostics) HostDataModel {
+	tags, d := types.ListValueFrom(ctx, types.StringType, host.GetTags())
+	diags.Append(d...)
+	return HostDataModel{
+		MachineID:            types.StringValue(host.GetUuid()),
+		Serial:               emptyStringToNull(host.GetSerial()),
+		Hostname:             emptyStringToNull(host.GetHostname()),
+		MachineModel:         emptyStringToNull(host.GetMachineModel()),
+		OSVersion:            emptyStringToNull(host.GetOsVersion()),
+		PrimaryUser:          emptyStringToNull(host.GetPrimaryUser()),
+		SantaVersion:         emptyStringToNull(host.GetSantaVersion()),
+		ClientMode:           types.StringValue(host.GetLastSeenClientMode().String()),
+		ConfiguredClientMode: types.StringValue(host.GetConfiguredClientMode().String()),
+		LastSync:             eventTime(host.GetLastSync()),
+		Tags:                 tags,
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+func TestHostsDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeWorkshopClient{listHosts: []*apipb.Host{
+		apipb.Host_builder{
+			Uuid:               "4F2B8C1E-0000-0000-0000-000000000001",
+			Serial:             "C02XK0AAJG5H",
+			Hostname:           "ci-builder-1",
+			SantaVersion:       "2025.9",
+			LastSeenClientMode: apipb.ClientMode_LOCKDOWN,
+			LastSync:           timestamppb.New(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)),
+			Tags:               []string{"ci", "global"},
+		}.Build(),
+		apipb.Host_builder{Uuid: "4F2B8C1E-0000-0000-0000-000000000002"}.Build(),
+	}}
+	d := &HostsDataSource{client: client}
+
+	var sResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &sResp)
+	config := tfsdk.State{Schema: sResp.Schema}
+	if diags := config.Set(ctx, HostsDataSourceModel{Filter: types.StringValue(`hostname = "ci-*"`)}); diags.HasError() {
+		t.Fatalf("building config: %v", diags)
+	}
+
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: sResp.Schema}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: sResp.Schema, Raw: config.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("read failed: %v", resp.Diagnostics)
+	}
+
+	var got HostsDataSourceModel
+	resp.State.Get(ctx, &got)
+	if client.listHostsFilter != `hostname = "ci-*"` {
+		t.Errorf("ListHosts filter = %s", client.listHostsFilter)
+	}
+	if len(got.Hosts) != 2 || got.Truncated.ValueBool() {
+		t.Fatalf("got %d hosts, truncated %v; want 2, false", len(got.Hosts), got.Truncated)
+	}
+	if len(got.HostsBySerial) != 1 {
+		t.Errorf("hosts_by_serial = %v, want only the host with a serial", got.HostsBySerial)
+	}
+	ci := got.HostsBySerial["C02XK0AAJG5H"]
+	var tags []string
+	ci.Tags.ElementsAs(ctx, &tags, false)
+	if ci.ClientMode.ValueString() != "LOCKDOWN" || ci.LastSync.ValueString() != "2026-03-01T12:00:00Z" || len(tags) != 2 {
+		t.Errorf("ci host = %+v", ci)
+	}
+	if !got.Hosts[1].Hostname.IsNull() {
+		t.Errorf("hostname = %v, want null when unreported", got.Hosts[1].Hostname)
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &PackageRulePreviewDataSource{}
+var _ datasource.DataSourceWithConfigure = &PackageRulePreviewDataSource{}
+
+func NewPackageRulePreviewDataSource() datasource.DataSource {
+	return &PackageRulePreviewDataSource{}
+}
+
+// PackageRulePreviewDataSource asks GAL how many identifiers a package rule
+// would expand to, without creating the rule.
+type PackageRulePreviewDataSource struct {
+	client svcpb.WorkshopServiceClient
+}
+
+// PackageRulePreviewDataSourceModel describes the data source data model.
+type PackageRulePreviewDataSourceModel struct {
+	Source        types.String `tfsdk:"source"`
+	Name          types.String `tfsdk:"name"`
+	RuleType      types.String `tfsdk:"rule_type"`
+	MinDate       types.String `tfsdk:"min_date"`
+	MaxDate       types.String `tfsdk:"max_date"`
+	VersionRegexp types.String `tfsdk:"version_regexp"`
+
+	IdentifierCount types.Int64  `tfsdk:"identifier_count"`
+	Status          types.String `tfsdk:"status"`
+}
+
+func (d *PackageRulePreviewDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workshop_package_rule_preview"
+}
+
+func (d *PackageRulePreviewDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The `nps_workshop_package_rule_preview` data source asks GAL how many execution-rule identifiers an `nps_workshop_package_rule` with the same arguments would expand to, without creating it. Use it with a `precondition` to check that a version filter matches what you expect before rolling it out.\n\n" +
+			"Workshop doesn't expose the individual versions, release dates, or identifiers behind the count. Requires the `read:rules` permission.",
+
+		Attributes: map[string]schema.Attribute{
+			"source": schema.StringAttribute{
+				MarkdownDescription: "The package source (e.g., `PACKAGE_SOURCE_HOMEBREW`, `PACKAGE_SOURCE_NPM`).",
+				Required:            true,
+				Validators: []validator.String{
+					oneOf(enumValues(apipb.PackageSource(0).Descriptor())...),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The package name (e.g., `wget`, `express`).",
+				Required:            true,
+			},
+			"rule_type": schema.StringAttribute{
+				MarkdownDescription: "The preferred rule type, as in `nps_workshop_package_rule`. The same fallback to more specific types is applied when counting.",
+				Required:            true,
+				Validators: []validator.String{
+					oneOf(enumValues(apipb.RuleType(0).Descriptor())...),
+				},
+			},
+			"min_date": schema.StringAttribute{
+				MarkdownDescription: "Optional: Only include versions released after this date. Format: RFC3339 (e.g., `2024-01-01T00:00:00Z`).",
+				Optional:            true,
+			},
+			"max_date": schema.StringAttribute{
+				MarkdownDescription: "Optional: Only include versions released before this date. Format: RFC3339 (e.g., `2024-12-31T23:59:59Z`).",
+				Optional:            true,
+			},
+			"version_regexp": schema.StringAttribute{
+				MarkdownDescription: "Optional: Regex to filter version strings.",
+				Optional:            true,
+			},
+			"identifier_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of identifiers the package rule would expand to, before identical existing rules are skipped.",
+				Computed:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Whether GAL has ingested the package: `PACKAGE_STATUS_RULES_AVAILABLE`, `PACKAGE_STATUS_CHECK_BACK_LATER` when it hasn't yet and `identifier_count` is not meaningful, or `PACKAGE_STATUS_NO_MACOS_BINARIES`.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *PackageRulePreviewDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(svcpb.WorkshopServiceClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			codeInternal.summary("Unexpected Data Source Configure Type"),
+			fmt.Sprintf("Expected WorkshopServiceClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *PackageRulePreviewDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PackageRulePreviewDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	countReq := packageRulePreviewRequest(data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ret, err := d.client.CountPackageRuleIdentifiers(ctx, countReq)
+	if err != nil {
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to preview package rule: %v", err))
+		return
+	}
+
+	data.IdentifierCount = types.Int64Value(int64(ret.GetIdentifierCount()))
+	data.Status = types.StringValue(ret.GetStatus().String())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// packageRulePreviewRequest builds the CountPackageRuleIdentifiers request from
+// the model, parsing the dates as buildPackageRule does.
+func packageRulePreviewRequest(data PackageRulePreviewDataSourceModel, diags *diag.Diagnostics) *apipb.CountPackageRuleIdentifiersRequest {
+	builder := apipb.CountPackageRuleIdentifiersRequest_builder{
+		Source:   apipb.PackageSource(apipb.PackageSource_value[data.Source.ValueString()]).Enum(),
+		Name:     proto.String(data.Name.ValueString()),
+		RuleType: apipb.RuleType(apipb.RuleType_value[data.RuleType.ValueString()]).Enum(),
+	}
+	if v := data.VersionRegexp.ValueString(); v != "" {
+		builder.VersionRegexp = proto.String(v)
+	}
+
+	for _, bound := range []struct {
+		attr  string
+		value types.String
+		dst   **timestamppb.Timestamp
+	}{
+		{"min_date", data.MinDate, &builder.MinDate},
+		{"max_date", data.MaxDate, &builder.MaxDate},
+	} {
+		if bound.value.ValueString() == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, bound.value.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root(bound.attr), codeInvalidConfig.summary("Invalid "+bound.attr), fmt.Sprintf("Failed to parse %s: %v", bound.attr, err))
+			continue
+		}
+		*bound.dst = timestamppb.New(t)
+	}
+
+	return builder.Build()
+}
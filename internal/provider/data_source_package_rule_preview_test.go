@@ -0,0 +1,48 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+func TestPackageRulePreviewRequest(t *testing.T) {
+	data := PackageRulePreviewDataSourceModel{
+		Source:        types.StringValue("PACKAGE_SOURCE_HOMEBREW"),
+		Name:          types.StringValue("wget"),
+		RuleType:      types.StringValue("SIGNINGID"),
+		MinDate:       types.StringValue("2024-01-01T00:00:00Z"),
+		MaxDate:       types.StringNull(),
+		VersionRegexp: types.StringValue(`^1\.`),
+	}
+
+	var diags diag.Diagnostics
+	got := packageRulePreviewRequest(data, &diags)
+	if diags.HasError() {
+		t.Fatalf("packageRulePreviewRequest() diags = %v", diags)
+	}
+	if got.GetSource() != apipb.PackageSource_PACKAGE_SOURCE_HOMEBREW || got.GetName() != "wget" || got.GetRuleType() != apipb.RuleType_SIGNINGID {
+		t.Errorf("packageRulePreviewRequest() = %v", got)
+	}
+	if got.GetVersionRegexp() != `^1\.` {
+		t.Errorf("version_regexp = %q", got.GetVersionRegexp())
+	}
+	if want := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC); !got.GetMinDate().AsTime().Equal(want) {
+		t.Errorf("min_date = %v, want %v", got.GetMinDate().AsTime(), want)
+	}
+	if got.HasMaxDate() {
+		t.Errorf("max_date = %v, want unset", got.GetMaxDate())
+	}
+
+	data.MaxDate = types.StringValue("next year")
+	diags = nil
+	packageRulePreviewRequest(data, &diags)
+	if !diags.HasError() {
+		t.Error("expected an error for an invalid max_date")
+	}
+}
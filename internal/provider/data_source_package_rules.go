@@ -0,0 +1,285 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/northpolesec/terraform-provider-nps/internal/filter"
+	"google.golang.org/protobuf/proto"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &PackageRulesDataSource{}
+var _ datasource.DataSourceWithConfigure = &PackageRulesDataSource{}
+
+func NewPackageRulesDataSource() datasource.DataSource {
+	return &PackageRulesDataSource{}
+}
+
+// PackageRulesDataSource lists the package rules matching a filter.
+type PackageRulesDataSource struct {
+	client svcpb.WorkshopServiceClient
+}
+
+// PackageRulesDataSourceModel describes the data source data model.
+type PackageRulesDataSourceModel struct {
+	Filter        types.String                    `tfsdk:"filter"`
+	Source        types.String                    `tfsdk:"source"`
+	Tag           types.String                    `tfsdk:"tag"`
+	Limit         types.Int64                     `tfsdk:"limit"`
+	Truncated     types.Bool                      `tfsdk:"truncated"`
+	PageToken     types.String                    `tfsdk:"page_token"`
+	NextPageToken types.String                    `tfsdk:"next_page_token"`
+	Rules         []PackageRuleDataModel          `tfsdk:"rules"`
+	RulesByKey    map[string]PackageRuleDataModel `tfsdk:"rules_by_key"`
+}
+
+// PackageRuleDataModel describes a single package rule.
+type PackageRuleDataModel struct {
+	Id            types.Int64  `tfsdk:"id"`
+	Key           types.String `tfsdk:"key"`
+	Tag           types.String `tfsdk:"tag"`
+	Source        types.String `tfsdk:"source"`
+	Name          types.String `tfsdk:"name"`
+	Policy        types.String `tfsdk:"policy"`
+	RuleType      types.String `tfsdk:"rule_type"`
+	MinDate       types.String `tfsdk:"min_date"`
+	MaxDate       types.String `tfsdk:"max_date"`
+	VersionRegexp types.String `tfsdk:"version_regexp"`
+	SyncStatus    types.String `tfsdk:"sync_status"`
+	LastSyncedAt  types.String `tfsdk:"last_synced_at"`
+	AddedBy       types.String `tfsdk:"added_by"`
+}
+
+func (d *PackageRulesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workshop_package_rules"
+}
+
+// packageRuleDataAttributes is the schema of a PackageRuleDataModel.
+func packageRuleDataAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.Int64Attribute{
+			MarkdownDescription: "The package rule's ID. This ID is reassigned whenever the rule is updated.",
+			Computed:            true,
+		},
+		"key": schema.StringAttribute{
+			MarkdownDescription: "The package rule's natural key, `SOURCE:name@tag`, e.g. `PACKAGE_SOURCE_HOMEBREW:wget@global`.",
+			Computed:            true,
+		},
+		"tag": schema.StringAttribute{
+			MarkdownDescription: "The tag the package rule applies to.",
+			Computed:            true,
+		},
+		"source": schema.StringAttribute{
+			MarkdownDescription: "The package's source, e.g. `PACKAGE_SOURCE_HOMEBREW`.",
+			Computed:            true,
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "The package's name.",
+			Computed:            true,
+		},
+		"policy": schema.StringAttribute{
+			MarkdownDescription: "The package rule's policy, e.g. `ALLOWLIST`.",
+			Computed:            true,
+		},
+		"rule_type": schema.StringAttribute{
+			MarkdownDescription: "The type of the rules the package rule creates, e.g. `SIGNINGID`.",
+			Computed:            true,
+		},
+		"min_date": schema.StringAttribute{
+			MarkdownDescription: "The package rule's earliest release date, as an RFC3339 timestamp, if any.",
+			Computed:            true,
+		},
+		"max_date": schema.StringAttribute{
+			MarkdownDescription: "The package rule's latest release date, as an RFC3339 timestamp, if any.",
+			Computed:            true,
+		},
+		"version_regexp": schema.StringAttribute{
+			MarkdownDescription: "The package rule's version filter, if any.",
+			Computed:            true,
+		},
+		"sync_status": schema.StringAttribute{
+			MarkdownDescription: "The outcome of the package rule's last sync with GAL, e.g. `PACKAGE_RULE_SYNC_STATUS_SUCCESS`. Null until it first syncs.",
+			Computed:            true,
+		},
+		"last_synced_at": schema.StringAttribute{
+			MarkdownDescription: "When the package rule last synced with GAL, as an RFC3339 timestamp. Null until it first syncs.",
+			Computed:            true,
+		},
+		"added_by": schema.StringAttribute{
+			MarkdownDescription: "The user or API key that created the package rule, if known.",
+			Computed:            true,
+		},
+	}
+}
+
+func (d *PackageRulesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	ruleAttributes := schema.NestedAttributeObject{Attributes: packageRuleDataAttributes()}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The `nps_workshop_package_rules` data source lists the package rules matching a filter, walking every page of results up to `limit`, e.g. to check whether a package is already covered before adding an `nps_workshop_package_rule` for it.\n\nReading package rules requires the `read:rules` permission.",
+
+		Attributes: map[string]schema.Attribute{
+			"filter": schema.StringAttribute{
+				MarkdownDescription: "A Workshop filter expression, e.g. `policy = \"BLOCKLIST\"`. Combined with `source` and `tag` when those are set. Leave all three unset to list every package rule.",
+				Optional:            true,
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "Only include package rules for packages from this source, e.g. `PACKAGE_SOURCE_HOMEBREW`.",
+				Optional:            true,
+				Validators: []validator.String{
+					oneOf(enumValues(apipb.PackageSource(0).Descriptor())...),
+				},
+			},
+			"tag": schema.StringAttribute{
+				MarkdownDescription: "Only include package rules that apply to this tag.",
+				Optional:            true,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The maximum number of package rules to return. Defaults to `%d`; `truncated` is set when more package rules match.", defaultListLimit),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, maxListLimit),
+				},
+			},
+			"truncated": schema.BoolAttribute{
+				MarkdownDescription: "Whether more package rules matched than `limit` allowed.",
+				Computed:            true,
+			},
+			"page_token": schema.StringAttribute{
+				MarkdownDescription: "The `next_page_token` of a previous read, to continue after the package rules it returned. Leave unset to start from the beginning. The token is only meaningful with the same filters. It is just the base64-encoded number of package rules already returned, not a cursor, so package rules added or removed before that position between reads shift the window: the next read can repeat or skip some.",
+				Optional:            true,
+			},
+			"next_page_token": schema.StringAttribute{
+				MarkdownDescription: "A token to pass as `page_token` to read the package rules after these. Null unless `truncated` is set.",
+				Computed:            true,
+			},
+			"rules": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching package rules.",
+				Computed:            true,
+				NestedObject:        ruleAttributes,
+			},
+			"rules_by_key": schema.MapNestedAttribute{
+				MarkdownDescription: "The matching package rules keyed by `key`, for checking whether a package already has a rule on a tag.",
+				Computed:            true,
+				NestedObject:        ruleAttributes,
+			},
+		},
+	}
+}
+
+func (d *PackageRulesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(svcpb.WorkshopServiceClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			codeInternal.summary("Unexpected Data Source Configure Type"),
+			fmt.Sprintf("Expected WorkshopServiceClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *PackageRulesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PackageRulesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := defaultListLimit
+	if !data.Limit.IsNull() {
+		limit = int(data.Limit.ValueInt64())
+	}
+	pageSize := min(limit+1, listPageSize)
+	offset, err := parseOffsetToken(data.PageToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("page_token"), codeInvalidConfig.summary("Invalid page_token"), err.Error())
+		return
+	}
+	query := packageRulesFilter(data)
+
+	pages := listPagesFrom(ctx, "package rules", offset, pageSize, func(page uint32) ([]*apipb.PackageRule, bool, error) {
+		listReq := apipb.ListPackageRulesRequest_builder{
+			PageSize: proto.Uint32(uint32(pageSize)),
+			Page:     proto.Uint32(page),
+		}
+		if query != "" {
+			listReq.Filter = proto.String(query)
+		}
+		ret, err := d.client.ListPackageRules(ctx, listReq.Build())
+		return ret.GetRules(), ret.GetMore(), err
+	})
+	rules, truncated, err := collectPages(pages, limit)
+	if err != nil {
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to list package rules: %v", err))
+		return
+	}
+
+	data.Truncated = types.BoolValue(truncated)
+	data.NextPageToken = types.StringNull()
+	if truncated {
+		data.NextPageToken = types.StringValue(offsetToken(offset + len(rules)))
+	}
+	data.Rules = make([]PackageRuleDataModel, 0, len(rules))
+	data.RulesByKey = make(map[string]PackageRuleDataModel, len(rules))
+	for _, rule := range rules {
+		m := packageRuleDataModel(rule)
+		data.Rules = append(data.Rules, m)
+		data.RulesByKey[m.Key.ValueString()] = m
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// packageRulesFilter combines the configured filter with source and tag.
+func packageRulesFilter(data PackageRulesDataSourceModel) string {
+	clauses := []filter.Expr{filter.Raw(data.Filter.ValueString())}
+	if v := data.Source.ValueString(); v != "" {
+		clauses = append(clauses, filter.Eq("source", v))
+	}
+	if v := data.Tag.ValueString(); v != "" {
+		clauses = append(clauses, filter.Eq("tag", v))
+	}
+	return filter.And(clauses...).String()
+}
+
+// packageRuleDataModel converts a package rule as returned by ListPackageRules.
+func packageRuleDataModel(rule *apipb.PackageRule) PackageRuleDataModel {
+	syncStatus := types.StringNull()
+	if code := rule.GetSyncStatusCode(); code != apipb.PackageRuleSyncStatus_PACKAGE_RULE_SYNC_STATUS_UNSPECIFIED {
+		syncStatus = types.StringValue(code.String())
+	}
+	return PackageRuleDataModel{
+		Id:            types.Int64Value(rule.GetRuleId()),
+		Key:           types.StringValue(fmt.Sprintf("%s:%s@%s", rule.GetSource(), rule.GetName(), rule.GetTag())),
+		Tag:           types.StringValue(rule.GetTag()),
+		Source:        types.StringValue(rule.GetSource().String()),
+		Name:          types.StringValue(rule.GetName()),
+		Policy:        types.StringValue(rule.GetPolicy().String()),
+		RuleType:      types.StringValue(rule.GetRuleType().String()),
+		MinDate:       eventTime(rule.GetMinDate()),
+		MaxDate:       eventTime(rule.GetMaxDate()),
+		VersionRegexp: emptyStringToNull(rule.GetVersionRegexp()),
+		SyncStatus:    syncStatus,
+		LastSyncedAt:  eventTime(rule.GetLastSyncedAt()),
+		AddedBy:       emptyStringToNull(rule.GetAddedBy()),
+	}
+}
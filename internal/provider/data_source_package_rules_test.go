@@ -0,0 +1,60 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+func TestPackageRulesDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeWorkshopClient{listPackageRules: []*apipb.PackageRule{
+		apipb.PackageRule_builder{
+			RuleId:         3,
+			Tag:            "global",
+			Source:         apipb.PackageSource_PACKAGE_SOURCE_HOMEBREW,
+			Name:           "wget",
+			Policy:         apipb.Policy_ALLOWLIST,
+			RuleType:       apipb.RuleType_SIGNINGID,
+			VersionRegexp:  `^1\.`,
+			SyncStatusCode: apipb.PackageRuleSyncStatus_PACKAGE_RULE_SYNC_STATUS_SUCCESS,
+		}.Build(),
+	}}
+	d := &PackageRulesDataSource{client: client}
+
+	var sResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &sResp)
+	config := tfsdk.State{Schema: sResp.Schema}
+	if diags := config.Set(ctx, PackageRulesDataSourceModel{
+		Filter: types.StringValue(`policy = "ALLOWLIST" OR policy = "BLOCKLIST"`),
+		Source: types.StringValue("PACKAGE_SOURCE_HOMEBREW"),
+		Tag:    types.StringValue("global"),
+	}); diags.HasError() {
+		t.Fatalf("building config: %v", diags)
+	}
+
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: sResp.Schema}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: sResp.Schema, Raw: config.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("read failed: %v", resp.Diagnostics)
+	}
+
+	var got PackageRulesDataSourceModel
+	resp.State.Get(ctx, &got)
+	if want := `(policy = "ALLOWLIST" OR policy = "BLOCKLIST") AND source = "PACKAGE_SOURCE_HOMEBREW" AND tag = "global"`; client.listPkgRulesFilter != want {
+		t.Errorf("ListPackageRules filter = %s, want %s", client.listPkgRulesFilter, want)
+	}
+	wget, ok := got.RulesByKey["PACKAGE_SOURCE_HOMEBREW:wget@global"]
+	if len(got.Rules) != 1 || !ok {
+		t.Fatalf("rules_by_key = %v, want only wget", got.RulesByKey)
+	}
+	if wget.Id.ValueInt64() != 3 || wget.VersionRegexp.ValueString() != `^1\.` || !wget.MinDate.IsNull() || wget.SyncStatus.ValueString() != "PACKAGE_RULE_SYNC_STATUS_SUCCESS" {
+		t.Errorf("wget = %+v", wget)
+	}
+}
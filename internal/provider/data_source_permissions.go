@@ -0,0 +1,71 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// workshopPermissions is every permission scope the Workshop API version this
+// provider was built against requires on one of its RPCs, sorted.
+var workshopPermissions = func() []string {
+	var out []string
+	for m := range workshopMethods() {
+		perm := methodPermission(m)
+		for _, p := range []string{perm.GetPermission(), perm.GetSelfPermission()} {
+			if p != "" {
+				out = append(out, p)
+			}
+		}
+	}
+	slices.Sort(out)
+	return slices.Compact(out)
+}()
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &PermissionsDataSource{}
+
+func NewPermissionsDataSource() datasource.DataSource {
+	return &PermissionsDataSource{}
+}
+
+// PermissionsDataSource lists the permission scopes an API key can be granted.
+// The list is built into the provider, so it needs no client.
+type PermissionsDataSource struct{}
+
+// PermissionsDataSourceModel describes the data source data model.
+type PermissionsDataSourceModel struct {
+	Permissions types.List `tfsdk:"permissions"`
+}
+
+func (d *PermissionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workshop_permissions"
+}
+
+func (d *PermissionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The `nps_workshop_permissions` data source lists the permission scopes that can be granted to an `nps_workshop_apikey`, as known to this version of the provider.",
+
+		Attributes: map[string]schema.Attribute{
+			"permissions": schema.ListAttribute{
+				MarkdownDescription: "The permission scopes, sorted, e.g. `read:rules`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (d *PermissionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PermissionsDataSourceModel
+
+	permissions, diags := types.ListValueFrom(ctx, types.StringType, workshopPermissions)
+	resp.Diagnostics.Append(diags...)
+	data.Permissions = permissions
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,47 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestWorkshopPermissions(t *testing.T) {
+	for _, want := range []string{"read:rules", "write:rules", "read:hosts", "write:hosts", "read:events:self", "write:kill-process"} {
+		if !slices.Contains(workshopPermissions, want) {
+			t.Errorf("workshopPermissions is missing %q: %v", want, workshopPermissions)
+		}
+	}
+	if !slices.IsSorted(workshopPermissions) {
+		t.Errorf("workshopPermissions is not sorted: %v", workshopPermissions)
+	}
+	for _, p := range workshopPermissions {
+		if !strings.HasPrefix(p, "read:") && !strings.HasPrefix(p, "write:") {
+			t.Errorf("unexpected scope %q", p)
+		}
+	}
+}
+
+func TestAccWorkshopPermissionsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "nps" {
+  endpoint = "localhost:8080"
+}
+
+data "nps_workshop_permissions" "all" {}
+`,
+				Check: resource.TestCheckTypeSetElemAttr("data.nps_workshop_permissions.all", "permissions.*", "read:rules"),
+			},
+		},
+	})
+}
@@ -0,0 +1,271 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// ruleTypePrecedence is the order in which Santa consults rule types when
+// deciding an execution; the first type with a matching rule wins.
+var ruleTypePrecedence = []apipb.RuleType{
+	apipb.RuleType_CDHASH,
+	apipb.RuleType_BINARY,
+	apipb.RuleType_SIGNINGID,
+	apipb.RuleType_CERTIFICATE,
+	apipb.RuleType_TEAMID,
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RuleTestDataSource{}
+
+func NewRuleTestDataSource() datasource.DataSource {
+	return &RuleTestDataSource{}
+}
+
+// RuleTestDataSource evaluates proposed rules against sample binaries the way
+// Santa would. It runs entirely in the provider, so it needs no client and
+// never reads or changes anything in Workshop.
+type RuleTestDataSource struct{}
+
+// RuleTestDataSourceModel describes the data source data model.
+type RuleTestDataSourceModel struct {
+	ClientMode types.String          `tfsdk:"client_mode"`
+	Rules      []RuleTestRuleModel   `tfsdk:"rules"`
+	Samples    []RuleTestSampleModel `tfsdk:"samples"`
+	Results    []RuleTestResultModel `tfsdk:"results"`
+}
+
+// RuleTestRuleModel describes a proposed rule.
+type RuleTestRuleModel struct {
+	Identifier types.String `tfsdk:"identifier"`
+	RuleType   types.String `tfsdk:"rule_type"`
+	Policy     types.String `tfsdk:"policy"`
+}
+
+// RuleTestSampleModel describes a sample binary.
+type RuleTestSampleModel struct {
+	Name              types.String `tfsdk:"name"`
+	Sha256            types.String `tfsdk:"sha256"`
+	Cdhash            types.String `tfsdk:"cdhash"`
+	SigningID         types.String `tfsdk:"signing_id"`
+	CertificateSha256 types.String `tfsdk:"certificate_sha256"`
+	TeamID            types.String `tfsdk:"team_id"`
+}
+
+// RuleTestResultModel describes the decision for one sample.
+type RuleTestResultModel struct {
+	Name              types.String `tfsdk:"name"`
+	Decision          types.String `tfsdk:"decision"`
+	Policy            types.String `tfsdk:"policy"`
+	MatchedRuleType   types.String `tfsdk:"matched_rule_type"`
+	MatchedIdentifier types.String `tfsdk:"matched_identifier"`
+}
+
+func (d *RuleTestDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workshop_rule_test"
+}
+
+func (d *RuleTestDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The `nps_workshop_rule_test` data source evaluates a set of proposed rules against sample binaries and reports the decision Santa would make for each, so policy can be unit tested with `terraform test` before it reaches the fleet.\n\nEvaluation happens entirely within the provider: nothing is read from or written to Workshop. Rule types are consulted in Santa's precedence order (`CDHASH`, `BINARY`, `SIGNINGID`, `CERTIFICATE`, `TEAMID`) and the first match decides. `CEL` and `SEATBELT` rules are reported as such rather than evaluated, and `REMOVE` rules are ignored.",
+
+		Attributes: map[string]schema.Attribute{
+			"client_mode": schema.StringAttribute{
+				MarkdownDescription: "The client mode to assume for samples that match no rule: `MONITOR` allows them, `LOCKDOWN` blocks them. Defaults to `MONITOR`.",
+				Optional:            true,
+				Validators: []validator.String{
+					oneOf("MONITOR", "LOCKDOWN"),
+				},
+			},
+			"rules": schema.ListNestedAttribute{
+				MarkdownDescription: "The proposed rules. These usually mirror the `identifier`, `rule_type`, and `policy` of `nps_workshop_rule` resources for a single tag.",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"identifier": schema.StringAttribute{
+							MarkdownDescription: "The identifier for this rule. The format of this identifier depends on the rule type.",
+							Required:            true,
+						},
+						"rule_type": schema.StringAttribute{
+							MarkdownDescription: "The type of this rule. The possible values are: `BINARY`, `CERTIFICATE`, `TEAMID`, `SIGNINGID`, and `CDHASH`.",
+							Required:            true,
+							Validators: []validator.String{
+								oneOf(enumValues(apipb.RuleType(0).Descriptor())...),
+							},
+						},
+						"policy": schema.StringAttribute{
+							MarkdownDescription: "The policy for this rule, e.g. `ALLOWLIST` or `BLOCKLIST`.",
+							Required:            true,
+							Validators: []validator.String{
+								oneOf(rulePolicyNames()...),
+							},
+						},
+					},
+				},
+			},
+			"samples": schema.ListNestedAttribute{
+				MarkdownDescription: "The sample binaries to evaluate. Only the attributes that are set are matched against rules.",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "A name for this sample, copied to its result.",
+							Optional:            true,
+						},
+						"sha256": schema.StringAttribute{
+							MarkdownDescription: "The SHA-256 of the binary, matched by `BINARY` rules.",
+							Optional:            true,
+						},
+						"cdhash": schema.StringAttribute{
+							MarkdownDescription: "The binary's CDHash, matched by `CDHASH` rules.",
+							Optional:            true,
+						},
+						"signing_id": schema.StringAttribute{
+							MarkdownDescription: "The binary's signing ID prefixed with its Team ID or `platform`, e.g. `platform:com.apple.curl`, matched by `SIGNINGID` rules.",
+							Optional:            true,
+						},
+						"certificate_sha256": schema.StringAttribute{
+							MarkdownDescription: "The SHA-256 of the binary's leaf signing certificate, matched by `CERTIFICATE` rules.",
+							Optional:            true,
+						},
+						"team_id": schema.StringAttribute{
+							MarkdownDescription: "The binary's Team ID, matched by `TEAMID` rules.",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"results": schema.ListNestedAttribute{
+				MarkdownDescription: "One result per sample, in the same order as `samples`.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The sample's name.",
+							Computed:            true,
+						},
+						"decision": schema.StringAttribute{
+							MarkdownDescription: "The decision: `ALLOW`, `BLOCK`, or, when the matching rule's outcome is decided on the host, `CEL` or `SEATBELT`.",
+							Computed:            true,
+						},
+						"policy": schema.StringAttribute{
+							MarkdownDescription: "The policy of the matching rule. Null when no rule matched.",
+							Computed:            true,
+						},
+						"matched_rule_type": schema.StringAttribute{
+							MarkdownDescription: "The rule type of the matching rule. Null when no rule matched.",
+							Computed:            true,
+						},
+						"matched_identifier": schema.StringAttribute{
+							MarkdownDescription: "The identifier of the matching rule. Null when no rule matched.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RuleTestDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RuleTestDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	lockdown := data.ClientMode.ValueString() == "LOCKDOWN"
+	data.Results = make([]RuleTestResultModel, 0, len(data.Samples))
+	for _, sample := range data.Samples {
+		data.Results = append(data.Results, evaluateRuleTest(data.Rules, sample, lockdown))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// evaluateRuleTest returns the decision Santa would make for sample given
+// rules.
+func evaluateRuleTest(rules []RuleTestRuleModel, sample RuleTestSampleModel, lockdown bool) RuleTestResultModel {
+	result := RuleTestResultModel{
+		Name:              sample.Name,
+		Policy:            types.StringNull(),
+		MatchedRuleType:   types.StringNull(),
+		MatchedIdentifier: types.StringNull(),
+	}
+
+	for _, ruleType := range ruleTypePrecedence {
+		want := ruleTestSampleIdentifier(sample, ruleType)
+		if want == "" {
+			continue
+		}
+		for _, rule := range rules {
+			if rule.RuleType.ValueString() != ruleType.String() || rule.Policy.ValueString() == apipb.Policy_REMOVE.String() {
+				continue
+			}
+			if !ruleTestIdentifierMatches(ruleType, rule.Identifier.ValueString(), want) {
+				continue
+			}
+			result.Decision = types.StringValue(ruleTestDecision(rule.Policy.ValueString()))
+			result.Policy = rule.Policy
+			result.MatchedRuleType = rule.RuleType
+			result.MatchedIdentifier = rule.Identifier
+			return result
+		}
+	}
+
+	if lockdown {
+		result.Decision = types.StringValue("BLOCK")
+	} else {
+		result.Decision = types.StringValue("ALLOW")
+	}
+	return result
+}
+
+// ruleTestSampleIdentifier returns the sample's identifier for ruleType, or ""
+// if the sample doesn't set it.
+func ruleTestSampleIdentifier(sample RuleTestSampleModel, ruleType apipb.RuleType) string {
+	switch ruleType {
+	case apipb.RuleType_CDHASH:
+		return sample.Cdhash.ValueString()
+	case apipb.RuleType_BINARY:
+		return sample.Sha256.ValueString()
+	case apipb.RuleType_SIGNINGID:
+		return sample.SigningID.ValueString()
+	case apipb.RuleType_CERTIFICATE:
+		return sample.CertificateSha256.ValueString()
+	case apipb.RuleType_TEAMID:
+		return sample.TeamID.ValueString()
+	}
+	return ""
+}
+
+// ruleTestIdentifierMatches compares identifiers the way Santa stores them:
+// hashes are hex and compared case-insensitively, Team IDs and signing IDs
+// are compared exactly.
+func ruleTestIdentifierMatches(ruleType apipb.RuleType, a, b string) bool {
+	switch ruleType {
+	case apipb.RuleType_CDHASH, apipb.RuleType_BINARY, apipb.RuleType_CERTIFICATE:
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
+
+// ruleTestDecision maps a rule policy to the decision reported for a match.
+func ruleTestDecision(policy string) string {
+	switch {
+	case strings.HasPrefix(policy, "ALLOWLIST"):
+		return "ALLOW"
+	case isBlocklistPolicy(policy):
+		return "BLOCK"
+	}
+	return policy
+}
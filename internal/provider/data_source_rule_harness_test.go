@@ -0,0 +1,117 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestEvaluateRuleTest(t *testing.T) {
+	rule := func(identifier, ruleType, policy string) RuleTestRuleModel {
+		return RuleTestRuleModel{
+			Identifier: types.StringValue(identifier),
+			RuleType:   types.StringValue(ruleType),
+			Policy:     types.StringValue(policy),
+		}
+	}
+	rules := []RuleTestRuleModel{
+		rule("EQHXZ8M8AV", "TEAMID", "ALLOWLIST"),
+		rule("EQHXZ8M8AV:com.google.Chrome", "SIGNINGID", "BLOCKLIST"),
+		rule("ABCDEF", "BINARY", "ALLOWLIST"),
+		rule("platform:com.apple.curl", "SIGNINGID", "CEL"),
+		rule("platform:com.apple.ls", "SIGNINGID", "REMOVE"),
+	}
+
+	tests := []struct {
+		name     string
+		sample   RuleTestSampleModel
+		lockdown bool
+		decision string
+		matched  string
+	}{
+		{
+			name:     "team id",
+			sample:   RuleTestSampleModel{TeamID: types.StringValue("EQHXZ8M8AV"), SigningID: types.StringValue("EQHXZ8M8AV:com.google.Keystone")},
+			decision: "ALLOW",
+			matched:  "EQHXZ8M8AV",
+		},
+		{
+			name:     "signing id beats team id",
+			sample:   RuleTestSampleModel{TeamID: types.StringValue("EQHXZ8M8AV"), SigningID: types.StringValue("EQHXZ8M8AV:com.google.Chrome")},
+			decision: "BLOCK",
+			matched:  "EQHXZ8M8AV:com.google.Chrome",
+		},
+		{
+			name:     "binary beats signing id, case-insensitively",
+			sample:   RuleTestSampleModel{Sha256: types.StringValue("abcdef"), SigningID: types.StringValue("EQHXZ8M8AV:com.google.Chrome")},
+			decision: "ALLOW",
+			matched:  "ABCDEF",
+		},
+		{
+			name:     "cel",
+			sample:   RuleTestSampleModel{SigningID: types.StringValue("platform:com.apple.curl")},
+			decision: "CEL",
+			matched:  "platform:com.apple.curl",
+		},
+		{
+			name:     "remove is ignored",
+			sample:   RuleTestSampleModel{SigningID: types.StringValue("platform:com.apple.ls")},
+			decision: "ALLOW",
+		},
+		{
+			name:     "unmatched in lockdown",
+			sample:   RuleTestSampleModel{Sha256: types.StringValue("123456")},
+			lockdown: true,
+			decision: "BLOCK",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := evaluateRuleTest(rules, tt.sample, tt.lockdown)
+			if got.Decision.ValueString() != tt.decision {
+				t.Errorf("decision = %s, want %s", got.Decision, tt.decision)
+			}
+			if got.MatchedIdentifier.ValueString() != tt.matched {
+				t.Errorf("matched_identifier = %s, want %q", got.MatchedIdentifier, tt.matched)
+			}
+		})
+	}
+}
+
+func TestAccRuleTestDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "nps" {
+  endpoint = "localhost:8080"
+}
+
+data "nps_workshop_rule_test" "test" {
+  rules = [
+    { identifier = "EQHXZ8M8AV", rule_type = "TEAMID", policy = "BLOCKLIST" },
+  ]
+  samples = [
+    { name = "chrome", team_id = "EQHXZ8M8AV" },
+    { name = "curl", signing_id = "platform:com.apple.curl" },
+  ]
+  client_mode = "LOCKDOWN"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.nps_workshop_rule_test.test", "results.0.name", "chrome"),
+					resource.TestCheckResourceAttr("data.nps_workshop_rule_test.test", "results.0.decision", "BLOCK"),
+					resource.TestCheckResourceAttr("data.nps_workshop_rule_test.test", "results.0.matched_rule_type", "TEAMID"),
+					resource.TestCheckResourceAttr("data.nps_workshop_rule_test.test", "results.1.decision", "BLOCK"),
+					resource.TestCheckNoResourceAttr("data.nps_workshop_rule_test.test", "results.1.policy"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,253 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/protobuf/proto"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RulesDataSource{}
+var _ datasource.DataSourceWithConfigure = &RulesDataSource{}
+
+func NewRulesDataSource() datasource.DataSource {
+	return &RulesDataSource{}
+}
+
+// RulesDataSource lists the rules matching a filter.
+type RulesDataSource struct {
+	client svcpb.WorkshopServiceClient
+}
+
+// RulesDataSourceModel describes the data source data model.
+type RulesDataSourceModel struct {
+	Filter        types.String             `tfsdk:"filter"`
+	Limit         types.Int64              `tfsdk:"limit"`
+	Truncated     types.Bool               `tfsdk:"truncated"`
+	PageToken     types.String             `tfsdk:"page_token"`
+	NextPageToken types.String             `tfsdk:"next_page_token"`
+	Rules         []RuleDataModel          `tfsdk:"rules"`
+	RulesByKey    map[string]RuleDataModel `tfsdk:"rules_by_key"`
+}
+
+// RuleDataModel describes a single rule.
+type RuleDataModel struct {
+	Id             types.String `tfsdk:"id"`
+	Key            types.String `tfsdk:"key"`
+	Identifier     types.String `tfsdk:"identifier"`
+	RuleType       types.String `tfsdk:"rule_type"`
+	Policy         types.String `tfsdk:"policy"`
+	BlockReason    types.String `tfsdk:"block_reason"`
+	Tag            types.String `tfsdk:"tag"`
+	Comment        types.String `tfsdk:"comment"`
+	CustomMsg      types.String `tfsdk:"custom_msg"`
+	CustomURL      types.String `tfsdk:"custom_url"`
+	CELExpr        types.String `tfsdk:"cel_expr"`
+	SeatbeltPolicy types.String `tfsdk:"seatbelt_policy"`
+}
+
+func (d *RulesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workshop_rules"
+}
+
+// ruleDataAttributes is the schema of a RuleDataModel.
+func ruleDataAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "The rule's ID. This ID is reassigned whenever the rule is updated.",
+			Computed:            true,
+		},
+		"key": schema.StringAttribute{
+			MarkdownDescription: "The rule's natural key, `RULE_TYPE:identifier@tag`, which is also accepted by `terraform import nps_workshop_rule`.",
+			Computed:            true,
+		},
+		"identifier": schema.StringAttribute{
+			MarkdownDescription: "The rule's identifier.",
+			Computed:            true,
+		},
+		"rule_type": schema.StringAttribute{
+			MarkdownDescription: "The rule's type, e.g. `SIGNINGID`.",
+			Computed:            true,
+		},
+		"policy": schema.StringAttribute{
+			MarkdownDescription: "The rule's policy, e.g. `ALLOWLIST`.",
+			Computed:            true,
+		},
+		"block_reason": schema.StringAttribute{
+			MarkdownDescription: "The rule's block reason, if any.",
+			Computed:            true,
+		},
+		"tag": schema.StringAttribute{
+			MarkdownDescription: "The tag the rule applies to.",
+			Computed:            true,
+		},
+		"comment": schema.StringAttribute{
+			MarkdownDescription: "The rule's comment, if any.",
+			Computed:            true,
+		},
+		"custom_msg": schema.StringAttribute{
+			MarkdownDescription: "The rule's custom block message, if any.",
+			Computed:            true,
+		},
+		"custom_url": schema.StringAttribute{
+			MarkdownDescription: "The rule's custom URL, if any.",
+			Computed:            true,
+		},
+		"cel_expr": schema.StringAttribute{
+			MarkdownDescription: "The rule's CEL expression, if any.",
+			Computed:            true,
+		},
+		"seatbelt_policy": schema.StringAttribute{
+			MarkdownDescription: "The rule's seatbelt policy, if any.",
+			Computed:            true,
+		},
+	}
+}
+
+func (d *RulesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	ruleAttributes := schema.NestedAttributeObject{Attributes: ruleDataAttributes()}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The `nps_workshop_rules` data source lists the rules matching a filter, walking every page of results up to `limit`.\n\nReading rules requires the `read:rules` permission.",
+
+		Attributes: map[string]schema.Attribute{
+			"filter": schema.StringAttribute{
+				MarkdownDescription: "A Workshop filter expression, e.g. `tag = \"global\" AND policy = \"BLOCKLIST\"`. Leave unset to list every rule.",
+				Optional:            true,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The maximum number of rules to return. Defaults to `%d`; `truncated` is set when more rules match.", defaultListLimit),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, maxListLimit),
+				},
+			},
+			"truncated": schema.BoolAttribute{
+				MarkdownDescription: "Whether more rules matched than `limit` allowed.",
+				Computed:            true,
+			},
+			"page_token": schema.StringAttribute{
+				MarkdownDescription: "The `next_page_token` of a previous read, to continue after the rules it returned. Leave unset to start from the beginning. The token is only meaningful with the same filters. It is just the base64-encoded number of rules already returned, not a cursor, so rules added or removed before that position between reads shift the window: the next read can repeat or skip some.",
+				Optional:            true,
+			},
+			"next_page_token": schema.StringAttribute{
+				MarkdownDescription: "A token to pass as `page_token` to read the rules after these. Null unless `truncated` is set.",
+				Computed:            true,
+			},
+			"rules": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching rules.",
+				Computed:            true,
+				NestedObject:        ruleAttributes,
+			},
+			"rules_by_key": schema.MapNestedAttribute{
+				MarkdownDescription: "The matching rules keyed by `key`, for looking a rule up by its natural key.",
+				Computed:            true,
+				NestedObject:        ruleAttributes,
+			},
+		},
+	}
+}
+
+func (d *RulesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(svcpb.WorkshopServiceClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			codeInternal.summary("Unexpected Data Source Configure Type"),
+			fmt.Sprintf("Expected WorkshopServiceClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *RulesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RulesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := defaultListLimit
+	if !data.Limit.IsNull() {
+		limit = int(data.Limit.ValueInt64())
+	}
+	pageSize := min(limit+1, listPageSize)
+	offset, err := parseOffsetToken(data.PageToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("page_token"), codeInvalidConfig.summary("Invalid page_token"), err.Error())
+		return
+	}
+
+	pages := listPagesFrom(ctx, "rules", offset, pageSize, func(page uint32) ([]*apipb.Rule, bool, error) {
+		listReq := apipb.ListRulesRequest_builder{
+			PageSize: proto.Int32(int32(pageSize)),
+			Page:     proto.Int32(int32(page)),
+		}
+		if f := data.Filter.ValueString(); f != "" {
+			listReq.Filter = proto.String(f)
+		}
+		ret, err := d.client.ListRules(ctx, listReq.Build())
+		return ret.GetRules(), ret.GetMore(), err
+	})
+	rules, truncated, err := collectPages(pages, limit)
+	if err != nil {
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to list rules: %v", err))
+		return
+	}
+
+	data.Truncated = types.BoolValue(truncated)
+	data.NextPageToken = types.StringNull()
+	if truncated {
+		data.NextPageToken = types.StringValue(offsetToken(offset + len(rules)))
+	}
+	data.Rules = make([]RuleDataModel, 0, len(rules))
+	data.RulesByKey = make(map[string]RuleDataModel, len(rules))
+	for _, rule := range rules {
+		m := ruleDataModel(rule)
+		data.Rules = append(data.Rules, m)
+		data.RulesByKey[m.Key.ValueString()] = m
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// ruleDataModel converts a rule as returned by ListRules. Its key is the
+// RULE_TYPE:identifier@tag form parseRuleImportID accepts.
+func ruleDataModel(rule *apipb.Rule) RuleDataModel {
+	blockReason := types.StringNull()
+	if rule.GetBlockReason() != apipb.Rule_BLOCK_REASON_UNSPECIFIED {
+		blockReason = types.StringValue(rule.GetBlockReason().String())
+	}
+	return RuleDataModel{
+		Id:             types.StringValue(rule.GetRuleId()),
+		Key:            types.StringValue(fmt.Sprintf("%s:%s@%s", rule.GetRuleType(), rule.GetIdentifier(), rule.GetTag())),
+		Identifier:     types.StringValue(rule.GetIdentifier()),
+		RuleType:       types.StringValue(rule.GetRuleType().String()),
+		Policy:         types.StringValue(rule.GetPolicy().String()),
+		BlockReason:    blockReason,
+		Tag:            types.StringValue(rule.GetTag()),
+		Comment:        emptyStringToNull(rule.GetComment()),
+		CustomMsg:      emptyStringToNull(rule.GetCustomMsg()),
+		CustomURL:      emptyStringToNull(rule.GetCustomUrl()),
+		CELExpr:        emptyStringToNull(rule.GetCelExpr()),
+		SeatbeltPolicy: emptyStringToNull(rule.GetSeatbeltPolicy()),
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+
+	"github.com/northpolesec/terraform-provider-nps/internal/testserver"
+)
+
+func TestRulesDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeWorkshopClient{listRules: []*apipb.Rule{
+		apipb.Rule_builder{RuleId: "1", Identifier: "EQHXZ8M8AV", RuleType: apipb.RuleType_TEAMID, Policy: apipb.Policy_ALLOWLIST, Tag: "global"}.Build(),
+		apipb.Rule_builder{RuleId: "2", Identifier: "platform:com.apple.curl", RuleType: apipb.RuleType_SIGNINGID, Policy: apipb.Policy_BLOCKLIST, BlockReason: apipb.Rule_BLOCK_REASON_MALICIOUS, Tag: "global", Comment: "no curl"}.Build(),
+		apipb.Rule_builder{RuleId: "3", Identifier: "abc", RuleType: apipb.RuleType_BINARY, Policy: apipb.Policy_ALLOWLIST, Tag: "dev"}.Build(),
+	}}
+	d := &RulesDataSource{client: client}
+
+	var sResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &sResp)
+	config := tfsdk.State{Schema: sResp.Schema}
+	if diags := config.Set(ctx, RulesDataSourceModel{
+		Filter: types.StringValue(`tag = "global"`),
+		Limit:  types.Int64Value(2),
+	}); diags.HasError() {
+		t.Fatalf("building config: %v", diags)
+	}
+
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: sResp.Schema}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: sResp.Schema, Raw: config.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("read failed: %v", resp.Diagnostics)
+	}
+
+	var got RulesDataSourceModel
+	resp.State.Get(ctx, &got)
+	if client.listRulesFilter != `tag = "global"` {
+		t.Errorf("ListRules filter = %s", client.listRulesFilter)
+	}
+	if len(got.Rules) != 2 || !got.Truncated.ValueBool() {
+		t.Fatalf("got %d rules, truncated %v; want 2, true", len(got.Rules), got.Truncated)
+	}
+	curl, ok := got.RulesByKey["SIGNINGID:platform:com.apple.curl@global"]
+	if !ok {
+		t.Fatalf("rules_by_key = %v, missing the curl rule", got.RulesByKey)
+	}
+	if curl.BlockReason.ValueString() != "BLOCK_REASON_MALICIOUS" || curl.Comment.ValueString() != "no curl" || !curl.CustomMsg.IsNull() {
+		t.Errorf("curl rule = %+v", curl)
+	}
+	if !got.Rules[0].BlockReason.IsNull() {
+		t.Errorf("allowlist block_reason = %v, want null", got.Rules[0].BlockReason)
+	}
+}
+
+func TestRulesDataSourcePageToken(t *testing.T) {
+	ctx := context.Background()
+	server := testserver.New()
+	client, stop, err := server.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		if _, err := client.CreateRule(ctx, apipb.CreateRuleRequest_builder{Rule: apipb.Rule_builder{
+			Identifier: id, RuleType: apipb.RuleType_BINARY, Policy: apipb.Policy_ALLOWLIST, Tag: "global",
+		}.Build()}.Build()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	d := &RulesDataSource{client: client}
+	var sResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &sResp)
+
+	read := func(token types.String) RulesDataSourceModel {
+		t.Helper()
+		config := tfsdk.State{Schema: sResp.Schema}
+		if diags := config.Set(ctx, RulesDataSourceModel{Limit: types.Int64Value(2), PageToken: token}); diags.HasError() {
+			t.Fatalf("building config: %v", diags)
+		}
+		resp := &datasource.ReadResponse{State: tfsdk.State{Schema: sResp.Schema}}
+		d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: sResp.Schema, Raw: config.Raw}}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("read failed: %v", resp.Diagnostics)
+		}
+		var got RulesDataSourceModel
+		resp.State.Get(ctx, &got)
+		return got
+	}
+
+	var identifiers []string
+	token := types.StringNull()
+	for range 3 {
+		got := read(token)
+		for _, r := range got.Rules {
+			identifiers = append(identifiers, r.Identifier.ValueString())
+		}
+		token = got.NextPageToken
+		if token.IsNull() != !got.Truncated.ValueBool() {
+			t.Errorf("next_page_token = %v with truncated = %v", token, got.Truncated)
+		}
+	}
+	if !slices.Equal(identifiers, []string{"a", "b", "c", "d", "e"}) || !token.IsNull() {
+		t.Errorf("paged through %v, ending with token %v; want every rule once and no token", identifiers, token)
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/protobuf/proto"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &TagsDataSource{}
+var _ datasource.DataSourceWithConfigure = &TagsDataSource{}
+
+func NewTagsDataSource() datasource.DataSource {
+	return &TagsDataSource{}
+}
+
+// TagsDataSource lists the tags matching a filter.
+type TagsDataSource struct {
+	client svcpb.WorkshopServiceClient
+}
+
+// TagsDataSourceModel describes the data source data model.
+type TagsDataSourceModel struct {
+	Filter        types.String            `tfsdk:"filter"`
+	Limit         types.Int64             `tfsdk:"limit"`
+	Truncated     types.Bool              `tfsdk:"truncated"`
+	PageToken     types.String            `tfsdk:"page_token"`
+	NextPageToken types.String            `tfsdk:"next_page_token"`
+	Tags          []TagDataModel          `tfsdk:"tags"`
+	TagsByName    map[string]TagDataModel `tfsdk:"tags_by_name"`
+}
+
+// TagDataModel describes a single tag and what uses it.
+type TagDataModel struct {
+	Name                types.String `tfsdk:"name"`
+	RuleCount           types.Int64  `tfsdk:"rule_count"`
+	FileAccessRuleCount types.Int64  `tfsdk:"file_access_rule_count"`
+	HostCount           types.Int64  `tfsdk:"host_count"`
+	GroupCount          types.Int64  `tfsdk:"group_count"`
+	SyncSettingsCount   types.Int64  `tfsdk:"sync_settings_count"`
+	ExceptionCount      types.Int64  `tfsdk:"exception_count"`
+}
+
+func (d *TagsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workshop_tags"
+}
+
+func (d *TagsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	tagAttributes := map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			MarkdownDescription: "The tag's name.",
+			Computed:            true,
+		},
+		"rule_count": schema.Int64Attribute{
+			MarkdownDescription: "The number of rules with this tag.",
+			Computed:            true,
+		},
+		"file_access_rule_count": schema.Int64Attribute{
+			MarkdownDescription: "The number of file access rules with this tag.",
+			Computed:            true,
+		},
+		"host_count": schema.Int64Attribute{
+			MarkdownDescription: "The number of hosts with this tag.",
+			Computed:            true,
+		},
+		"group_count": schema.Int64Attribute{
+			MarkdownDescription: "The number of groups assigned this tag.",
+			Computed:            true,
+		},
+		"sync_settings_count": schema.Int64Attribute{
+			MarkdownDescription: "The number of sync settings with this tag.",
+			Computed:            true,
+		},
+		"exception_count": schema.Int64Attribute{
+			MarkdownDescription: "The number of exceptions with this tag.",
+			Computed:            true,
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The `nps_workshop_tags` data source lists the tags matching a filter, with counts of what uses each one, walking every page of results up to `limit`.\n\nReading tags requires the `read:tags` permission.",
+
+		Attributes: map[string]schema.Attribute{
+			"filter": schema.StringAttribute{
+				MarkdownDescription: "A Workshop filter expression, e.g. `tag = \"engineering\"`. Leave unset to list every tag.",
+				Optional:            true,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The maximum number of tags to return. Defaults to `%d`; `truncated` is set when more tags match.", defaultListLimit),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, maxListLimit),
+				},
+			},
+			"truncated": schema.BoolAttribute{
+				MarkdownDescription: "Whether more tags matched than `limit` allowed.",
+				Computed:            true,
+			},
+			"page_token": schema.StringAttribute{
+				MarkdownDescription: "The `next_page_token` of a previous read, to continue after the tags it returned. Leave unset to start from the beginning. The token is only meaningful with the same filters. It is just the base64-encoded number of tags already returned, not a cursor, so tags added or removed before that position between reads shift the window: the next read can repeat or skip some.",
+				Optional:            true,
+			},
+			"next_page_token": schema.StringAttribute{
+				MarkdownDescription: "A token to pass as `page_token` to read the tags after these. Null unless `truncated` is set.",
+				Computed:            true,
+			},
+			"tags": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching tags.",
+				Computed:            true,
+				NestedObject:        schema.NestedAttributeObject{Attributes: tagAttributes},
+			},
+			"tags_by_name": schema.MapNestedAttribute{
+				MarkdownDescription: "The matching tags keyed by `name`.",
+				Computed:            true,
+				NestedObject:        schema.NestedAttributeObject{Attributes: tagAttributes},
+			},
+		},
+	}
+}
+
+func (d *TagsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(svcpb.WorkshopServiceClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			codeInternal.summary("Unexpected Data Source Configure Type"),
+			fmt.Sprintf("Expected WorkshopServiceClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *TagsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TagsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := defaultListLimit
+	if !data.Limit.IsNull() {
+		limit = int(data.Limit.ValueInt64())
+	}
+	pageSize := min(limit+1, listPageSize)
+	offset, err := parseOffsetToken(data.PageToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("page_token"), codeInvalidConfig.summary("Invalid page_token"), err.Error())
+		return
+	}
+
+	pages := listPagesFrom(ctx, "tags", offset, pageSize, func(page uint32) ([]*apipb.TagStats, bool, error) {
+		listReq := apipb.ListTagsRequest_builder{
+			PageSize: proto.Uint32(uint32(pageSize)),
+			Page:     proto.Uint32(page),
+		}
+		if f := data.Filter.ValueString(); f != "" {
+			listReq.Filter = proto.String(f)
+		}
+		ret, err := d.client.ListTags(ctx, listReq.Build())
+		return ret.GetTags(), ret.GetMore(), err
+	})
+	tags, truncated, err := collectPages(pages, limit)
+	if err != nil {
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to list tags: %v", err))
+		return
+	}
+
+	data.Truncated = types.BoolValue(truncated)
+	data.NextPageToken = types.StringNull()
+	if truncated {
+		data.NextPageToken = types.StringValue(offsetToken(offset + len(tags)))
+	}
+	data.Tags = make([]TagDataModel, 0, len(tags))
+	data.TagsByName = make(map[string]TagDataModel, len(tags))
+	for _, tag := range tags {
+		m := TagDataModel{
+			Name:                types.StringValue(tag.GetTag()),
+			RuleCount:           types.Int64Value(int64(tag.GetRuleCount())),
+			FileAccessRuleCount: types.Int64Value(int64(tag.GetFileAccessRuleCount())),
+			HostCount:           types.Int64Value(int64(tag.GetHostCount())),
+			GroupCount:          types.Int64Value(int64(tag.GetGroupCount())),
+			SyncSettingsCount:   types.Int64Value(int64(tag.GetSyncSettingsCount())),
+			ExceptionCount:      types.Int64Value(int64(tag.GetExceptionCount())),
+		}
+		data.Tags = append(data.Tags, m)
+		data.TagsByName[tag.GetTag()] = m
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
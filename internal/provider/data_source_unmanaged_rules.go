@@ -0,0 +1,216 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/protobuf/proto"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &UnmanagedRulesDataSource{}
+var _ datasource.DataSourceWithConfigure = &UnmanagedRulesDataSource{}
+
+func NewUnmanagedRulesDataSource() datasource.DataSource {
+	return &UnmanagedRulesDataSource{}
+}
+
+// UnmanagedRulesDataSource lists the rules matching a filter that aren't in a
+// given set, i.e. the rules Terraform doesn't manage.
+type UnmanagedRulesDataSource struct {
+	client svcpb.WorkshopServiceClient
+}
+
+// UnmanagedRulesDataSourceModel describes the data source data model.
+type UnmanagedRulesDataSourceModel struct {
+	Filter        types.String             `tfsdk:"filter"`
+	ManagedIDs    []string                 `tfsdk:"managed_ids"`
+	ManagedKeys   []string                 `tfsdk:"managed_keys"`
+	Limit         types.Int64              `tfsdk:"limit"`
+	Truncated     types.Bool               `tfsdk:"truncated"`
+	PageToken     types.String             `tfsdk:"page_token"`
+	NextPageToken types.String             `tfsdk:"next_page_token"`
+	Rules         []RuleDataModel          `tfsdk:"rules"`
+	RulesByKey    map[string]RuleDataModel `tfsdk:"rules_by_key"`
+}
+
+func (d *UnmanagedRulesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workshop_unmanaged_rules"
+}
+
+func (d *UnmanagedRulesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	ruleAttributes := schema.NestedAttributeObject{Attributes: ruleDataAttributes()}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The `nps_workshop_unmanaged_rules` data source lists the rules matching a filter that are not in a given set of managed rules, to detect and alert on rules created outside Terraform, e.g. in the Workshop UI.\n\n" +
+			"Pass the rules Terraform manages in `managed_keys` or `managed_ids`; a rule in either is left out. Keys are the better choice, as a rule's ID is reassigned whenever the rule is updated.\n\n" +
+			"Reading rules requires the `read:rules` permission.",
+
+		Attributes: map[string]schema.Attribute{
+			"filter": schema.StringAttribute{
+				MarkdownDescription: "A Workshop filter expression limiting which rules are examined, e.g. `tag = \"global\"`. Leave unset to examine every rule.",
+				Optional:            true,
+			},
+			"managed_ids": schema.SetAttribute{
+				MarkdownDescription: "The IDs of the managed rules, e.g. `[for r in nps_workshop_rule.all : r.id]`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"managed_keys": schema.SetAttribute{
+				MarkdownDescription: "The natural keys of the managed rules, `RULE_TYPE:identifier@tag`, e.g. `[for r in nps_workshop_rule.all : \"${r.rule_type}:${r.identifier}@${r.tag}\"]`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The maximum number of matching rules to examine. Defaults to `%d`; `truncated` is set when more rules match.", defaultListLimit),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, maxListLimit),
+				},
+			},
+			"truncated": schema.BoolAttribute{
+				MarkdownDescription: "Whether more rules matched `filter` than `limit` allowed, in which case unmanaged rules may be missing from `rules` until the rest are read with `next_page_token`.",
+				Computed:            true,
+			},
+			"page_token": schema.StringAttribute{
+				MarkdownDescription: "The `next_page_token` of a previous read, to continue after the rules it examined. Leave unset to start from the beginning. The token is only meaningful with the same `filter`. It is just the base64-encoded number of rules already examined, not a cursor, so rules added or removed before that position between reads shift the window: the next read can repeat or skip some.",
+				Optional:            true,
+			},
+			"next_page_token": schema.StringAttribute{
+				MarkdownDescription: "A token to pass as `page_token` to examine the rules after these. Null unless `truncated` is set.",
+				Computed:            true,
+			},
+			"rules": schema.ListNestedAttribute{
+				MarkdownDescription: "The unmanaged rules.",
+				Computed:            true,
+				NestedObject:        ruleAttributes,
+			},
+			"rules_by_key": schema.MapNestedAttribute{
+				MarkdownDescription: "The unmanaged rules keyed by `key`, which `terraform import nps_workshop_rule` accepts to bring a rule under management.",
+				Computed:            true,
+				NestedObject:        ruleAttributes,
+			},
+		},
+	}
+}
+
+func (d *UnmanagedRulesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(svcpb.WorkshopServiceClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			codeInternal.summary("Unexpected Data Source Configure Type"),
+			fmt.Sprintf("Expected WorkshopServiceClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *UnmanagedRulesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data UnmanagedRulesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(checkManagedKeys(data.ManagedKeys)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := defaultListLimit
+	if !data.Limit.IsNull() {
+		limit = int(data.Limit.ValueInt64())
+	}
+	pageSize := min(limit+1, listPageSize)
+	offset, err := parseOffsetToken(data.PageToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("page_token"), codeInvalidConfig.summary("Invalid page_token"), err.Error())
+		return
+	}
+
+	pages := listPagesFrom(ctx, "rules", offset, pageSize, func(page uint32) ([]*apipb.Rule, bool, error) {
+		listReq := apipb.ListRulesRequest_builder{
+			PageSize: proto.Int32(int32(pageSize)),
+			Page:     proto.Int32(int32(page)),
+		}
+		if f := data.Filter.ValueString(); f != "" {
+			listReq.Filter = proto.String(f)
+		}
+		ret, err := d.client.ListRules(ctx, listReq.Build())
+		return ret.GetRules(), ret.GetMore(), err
+	})
+	rules, truncated, err := collectPages(pages, limit)
+	if err != nil {
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to list rules: %v", err))
+		return
+	}
+
+	data.Truncated = types.BoolValue(truncated)
+	data.NextPageToken = types.StringNull()
+	if truncated {
+		data.NextPageToken = types.StringValue(offsetToken(offset + len(rules)))
+	}
+	data.Rules = unmanagedRules(rules, data.ManagedIDs, data.ManagedKeys)
+	data.RulesByKey = make(map[string]RuleDataModel, len(data.Rules))
+	for _, m := range data.Rules {
+		data.RulesByKey[m.Key.ValueString()] = m
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// checkManagedKeys reports managed_keys that aren't RULE_TYPE:identifier@tag.
+// A malformed key would never match, silently reporting its rule unmanaged.
+func checkManagedKeys(keys []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, key := range keys {
+		if _, _, _, ok := parseRuleImportID(key); !ok {
+			diags.AddAttributeError(
+				path.Root("managed_keys"),
+				codeInvalidConfig.summary("Invalid managed rule key"),
+				fmt.Sprintf("%q is not a rule key; keys are written RULE_TYPE:identifier@tag, e.g. SIGNINGID:EQHXZ8M8AV:com.google.Chrome@global.", key),
+			)
+		}
+	}
+	return diags
+}
+
+// unmanagedRules converts the rules whose ID is not in ids and whose key is not
+// in keys.
+func unmanagedRules(rules []*apipb.Rule, ids, keys []string) []RuleDataModel {
+	managedIDs := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		managedIDs[id] = true
+	}
+	managedKeys := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		managedKeys[key] = true
+	}
+	out := []RuleDataModel{}
+	for _, rule := range rules {
+		m := ruleDataModel(rule)
+		if managedIDs[m.Id.ValueString()] || managedKeys[m.Key.ValueString()] {
+			continue
+		}
+		out = append(out, m)
+	}
+	return out
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/northpolesec/terraform-provider-nps/internal/testserver"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+func TestUnmanagedRulesDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeWorkshopClient{listRules: []*apipb.Rule{
+		apipb.Rule_builder{RuleId: "1", Identifier: "EQHXZ8M8AV", RuleType: apipb.RuleType_TEAMID, Policy: apipb.Policy_ALLOWLIST, Tag: "global"}.Build(),
+		apipb.Rule_builder{RuleId: "2", Identifier: "platform:com.apple.curl", RuleType: apipb.RuleType_SIGNINGID, Policy: apipb.Policy_BLOCKLIST, Tag: "global"}.Build(),
+		apipb.Rule_builder{RuleId: "3", Identifier: "abc", RuleType: apipb.RuleType_BINARY, Policy: apipb.Policy_ALLOWLIST, Tag: "global"}.Build(),
+	}}
+	d := &UnmanagedRulesDataSource{client: client}
+
+	var sResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &sResp)
+	config := tfsdk.State{Schema: sResp.Schema}
+	if diags := config.Set(ctx, UnmanagedRulesDataSourceModel{
+		Filter:      types.StringValue(`tag = "global"`),
+		ManagedIDs:  []string{"3"},
+		ManagedKeys: []string{"TEAMID:EQHXZ8M8AV@global"},
+	}); diags.HasError() {
+		t.Fatalf("building config: %v", diags)
+	}
+
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: sResp.Schema}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: sResp.Schema, Raw: config.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("read failed: %v", resp.Diagnostics)
+	}
+
+	var got UnmanagedRulesDataSourceModel
+	resp.State.Get(ctx, &got)
+	if client.listRulesFilter != `tag = "global"` {
+		t.Errorf("ListRules filter = %s", client.listRulesFilter)
+	}
+	if len(got.Rules) != 1 || got.Truncated.ValueBool() {
+		t.Fatalf("got %d rules, truncated %v; want 1, false", len(got.Rules), got.Truncated)
+	}
+	if _, ok := got.RulesByKey["SIGNINGID:platform:com.apple.curl@global"]; !ok {
+		t.Errorf("rules_by_key = %v, want only the curl rule", got.RulesByKey)
+	}
+}
+
+func TestUnmanagedRulesDataSourcePageToken(t *testing.T) {
+	ctx := context.Background()
+	server := testserver.New()
+	client, stop, err := server.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		if _, err := client.CreateRule(ctx, apipb.CreateRuleRequest_builder{Rule: apipb.Rule_builder{
+			Identifier: id, RuleType: apipb.RuleType_BINARY, Policy: apipb.Policy_ALLOWLIST, Tag: "global",
+		}.Build()}.Build()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	d := &UnmanagedRulesDataSource{client: client}
+	var sResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &sResp)
+
+	read := func(token types.String) UnmanagedRulesDataSourceModel {
+		t.Helper()
+		config := tfsdk.State{Schema: sResp.Schema}
+		if diags := config.Set(ctx, UnmanagedRulesDataSourceModel{
+			ManagedKeys: []string{"BINARY:b@global", "BINARY:d@global"},
+			Limit:       types.Int64Value(2),
+			PageToken:   token,
+		}); diags.HasError() {
+			t.Fatalf("building config: %v", diags)
+		}
+		resp := &datasource.ReadResponse{State: tfsdk.State{Schema: sResp.Schema}}
+		d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: sResp.Schema, Raw: config.Raw}}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("read failed: %v", resp.Diagnostics)
+		}
+		var got UnmanagedRulesDataSourceModel
+		resp.State.Get(ctx, &got)
+		return got
+	}
+
+	// Each read examines two rules, so the unmanaged rules past the first
+	// read's limit are only found by continuing from its token.
+	var identifiers []string
+	token := types.StringNull()
+	for range 3 {
+		got := read(token)
+		for _, r := range got.Rules {
+			identifiers = append(identifiers, r.Identifier.ValueString())
+		}
+		token = got.NextPageToken
+		if token.IsNull() != !got.Truncated.ValueBool() {
+			t.Errorf("next_page_token = %v with truncated = %v", token, got.Truncated)
+		}
+	}
+	if !slices.Equal(identifiers, []string{"a", "c", "e"}) || !token.IsNull() {
+		t.Errorf("paged through %v, ending with token %v; want each unmanaged rule once and no token", identifiers, token)
+	}
+}
+
+func TestCheckManagedKeys(t *testing.T) {
+	if diags := checkManagedKeys([]string{"SIGNINGID:EQHXZ8M8AV:com.google.Chrome@global", "BINARY:abc@dev"}); diags.HasError() {
+		t.Errorf("valid keys: %v", diags)
+	}
+	if diags := checkManagedKeys([]string{"TEAMID:EQHXZ8M8AV@global", "EQHXZ8M8AV", "1234"}); diags.ErrorsCount() != 2 {
+		t.Errorf("got %d errors, want one for each malformed key: %v", diags.ErrorsCount(), diags)
+	}
+}
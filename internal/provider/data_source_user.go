@@ -0,0 +1,165 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/northpolesec/terraform-provider-nps/internal/filter"
+	"google.golang.org/protobuf/proto"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &UserDataSource{}
+var _ datasource.DataSourceWithConfigure = &UserDataSource{}
+
+func NewUserDataSource() datasource.DataSource {
+	return &UserDataSource{}
+}
+
+// UserDataSource looks up a Workshop user by username, including users
+// provisioned by the directory integration, which UserResource can't manage.
+type UserDataSource struct {
+	client svcpb.WorkshopServiceClient
+}
+
+// UserDataSourceModel describes the data source data model.
+type UserDataSourceModel struct {
+	Username types.String `tfsdk:"username"`
+
+	ID             types.String `tfsdk:"id"`
+	Type           types.String `tfsdk:"type"`
+	ManagerEmail   types.String `tfsdk:"manager_email"`
+	Groups         types.List   `tfsdk:"groups"`
+	CostCenterName types.String `tfsdk:"cost_center_name"`
+	DepartmentName types.String `tfsdk:"department_name"`
+}
+
+func (d *UserDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workshop_user"
+}
+
+func (d *UserDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The `nps_workshop_user` data source looks up a Workshop user by username, e.g. to check that a user referenced by a rule or tag exists.\n\n" +
+			"It finds both users provisioned by the directory (SCIM) integration and local users; `nps_workshop_user` resources manage local users only. Requires the `read:scim` permission.",
+
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "The user's username, usually their email address. Matched case-insensitively.",
+				Required:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The directory ID of the user.",
+				Computed:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Where the user comes from: `DIRECTORY_TYPE_DSYNC` for the directory integration or `DIRECTORY_TYPE_LOCAL`.",
+				Computed:            true,
+			},
+			"manager_email": schema.StringAttribute{
+				MarkdownDescription: "The email address of the user's manager, if the directory provides one.",
+				Computed:            true,
+			},
+			"groups": schema.ListAttribute{
+				MarkdownDescription: "The directory groups the user belongs to.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"cost_center_name": schema.StringAttribute{
+				MarkdownDescription: "The user's cost center, if the directory provides one.",
+				Computed:            true,
+			},
+			"department_name": schema.StringAttribute{
+				MarkdownDescription: "The user's department, if the directory provides one.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *UserDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(svcpb.WorkshopServiceClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			codeInternal.summary("Unexpected Data Source Configure Type"),
+			fmt.Sprintf("Expected WorkshopServiceClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data UserDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := findUser(ctx, d.client, data.Username.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to read user: %v", err))
+		return
+	}
+	if user == nil {
+		resp.Diagnostics.AddError(codeNotFound.summary("User not found"), fmt.Sprintf("No Workshop user has username %q.", data.Username.ValueString()))
+		return
+	}
+
+	groups := user.GetGroups()
+	if int(user.GetGroupCount()) > len(groups) {
+		// ListUsers truncates the groups it returns.
+		ret, err := d.client.GetUserGroups(ctx, apipb.GetUserGroupsRequest_builder{
+			Username: proto.String(user.GetUsername()),
+		}.Build())
+		if err != nil {
+			resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to read user groups: %v", err))
+			return
+		}
+		groups = ret.GetGroups()
+	}
+
+	data.ID = types.StringValue(user.GetId())
+	data.Type = types.StringValue(user.GetType().String())
+	data.ManagerEmail = types.StringValue(user.GetManagerEmail())
+	data.CostCenterName = types.StringValue(user.GetCostCenterName())
+	data.DepartmentName = types.StringValue(user.GetDepartmentName())
+	groupList, diags := types.ListValueFrom(ctx, types.StringType, groups)
+	resp.Diagnostics.Append(diags...)
+	data.Groups = groupList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// findUser returns the user whose username equals username, ignoring case, or
+// nil if there is none.
+func findUser(ctx context.Context, client svcpb.WorkshopServiceClient, username string) (*apipb.User, error) {
+	ret, err := client.ListUsers(ctx, apipb.ListUsersRequest_builder{
+		Filter:   proto.String(filter.Eq("username", username).String()),
+		PageSize: proto.Int32(10),
+	}.Build())
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range ret.GetUsers() {
+		if strings.EqualFold(user.GetUsername(), username) {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
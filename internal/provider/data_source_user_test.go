@@ -0,0 +1,32 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"testing"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+func TestFindUser(t *testing.T) {
+	client := &fakeWorkshopClient{listUsers: []*apipb.User{
+		apipb.User_builder{Id: "1", Username: "alice.smith@example.com"}.Build(),
+		apipb.User_builder{Id: "2", Username: "alice@example.com"}.Build(),
+	}}
+
+	user, err := findUser(context.Background(), client, "Alice@Example.com")
+	if err != nil {
+		t.Fatalf("findUser() error = %v", err)
+	}
+	if user.GetId() != "2" {
+		t.Errorf("findUser() = %v, want user 2", user)
+	}
+	if want := `username = "Alice@Example.com"`; client.listUsersFilter != want {
+		t.Errorf("ListUsers filter = %s, want %s", client.listUsersFilter, want)
+	}
+
+	user, err = findUser(context.Background(), client, "bob@example.com")
+	if err != nil || user != nil {
+		t.Errorf("findUser() = %v, %v, want nil, nil", user, err)
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// applyDefaultTag plans the provider's default_tag for a resource whose
+// configuration omits tag. It runs from ModifyPlan rather than as a plan
+// modifier because the default is only known once the provider is configured.
+//
+// tag is Computed with UseStateForUnknown, so an omitted tag otherwise plans
+// as the prior value; a changed default replaces the resource just as a
+// changed tag would.
+func applyDefaultTag(ctx context.Context, defaultTag string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var configured types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("tag"), &configured)...)
+	if resp.Diagnostics.HasError() || !configured.IsNull() {
+		return
+	}
+	if defaultTag == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tag"),
+			codeInvalidConfig.summary("Missing tag"),
+			"tag must be set when the provider does not set default_tag.",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tag"), defaultTag)...)
+	if req.State.Raw.IsNull() {
+		return
+	}
+	var prior types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("tag"), &prior)...)
+	if prior.ValueString() != defaultTag {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("tag"))
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestApplyDefaultTag(t *testing.T) {
+	ctx := context.Background()
+	var sResp resource.SchemaResponse
+	(&PackageRuleResource{}).Schema(ctx, resource.SchemaRequest{}, &sResp)
+
+	model := func(tag types.String) PackageRuleResourceModel {
+		return PackageRuleResourceModel{
+			Tag:           tag,
+			Source:        types.StringValue("PACKAGE_SOURCE_HOMEBREW"),
+			Name:          types.StringValue("wget"),
+			Policy:        types.StringValue("ALLOWLIST"),
+			RuleType:      types.StringValue("SIGNINGID"),
+			MinDate:       types.StringNull(),
+			MaxDate:       types.StringNull(),
+			VersionRegexp: types.StringNull(),
+			Versions:      types.ListNull(types.StringType),
+			Id:            types.Int64Unknown(),
+
+			GeneratedIdentifiers: types.ListNull(types.StringType),
+		}
+	}
+
+	for _, c := range []struct {
+		name        string
+		defaultTag  string
+		configTag   types.String
+		priorTag    *string
+		wantTag     string
+		wantReplace bool
+		wantErr     bool
+	}{
+		{name: "configured tag wins", defaultTag: "eng", configTag: types.StringValue("global"), wantTag: "global"},
+		{name: "default on create", defaultTag: "eng", configTag: types.StringNull(), wantTag: "eng"},
+		{name: "unchanged default", defaultTag: "eng", configTag: types.StringNull(), priorTag: proto.String("eng"), wantTag: "eng"},
+		{name: "changed default replaces", defaultTag: "eng", configTag: types.StringNull(), priorTag: proto.String("global"), wantTag: "eng", wantReplace: true},
+		{name: "no tag and no default", configTag: types.StringNull(), wantErr: true},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			plan := tfsdk.Plan{Schema: sResp.Schema}
+			planTag := c.configTag
+			if planTag.IsNull() {
+				planTag = types.StringUnknown()
+				if c.priorTag != nil {
+					planTag = types.StringValue(*c.priorTag) // UseStateForUnknown
+				}
+			}
+			plan.Set(ctx, model(planTag))
+
+			config := tfsdk.Plan{Schema: sResp.Schema}
+			config.Set(ctx, model(c.configTag))
+
+			state := tfsdk.State{Schema: sResp.Schema, Raw: tftypes.NewValue(sResp.Schema.Type().TerraformType(ctx), nil)}
+			if c.priorTag != nil {
+				state.Set(ctx, model(types.StringValue(*c.priorTag)))
+			}
+
+			req := resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: sResp.Schema, Raw: config.Raw},
+				Plan:   plan,
+				State:  state,
+			}
+			resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+			applyDefaultTag(ctx, c.defaultTag, req, resp)
+
+			if got := resp.Diagnostics.HasError(); got != c.wantErr {
+				t.Fatalf("HasError() = %v, want %v: %v", got, c.wantErr, resp.Diagnostics)
+			}
+			if c.wantErr {
+				return
+			}
+			var tag types.String
+			resp.Plan.GetAttribute(ctx, path.Root("tag"), &tag)
+			if tag.ValueString() != c.wantTag {
+				t.Errorf("tag = %s, want %q", tag, c.wantTag)
+			}
+			if got := len(resp.RequiresReplace) > 0; got != c.wantReplace {
+				t.Errorf("RequiresReplace = %v, want replace %v", resp.RequiresReplace, c.wantReplace)
+			}
+		})
+	}
+}
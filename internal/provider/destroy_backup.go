@@ -0,0 +1,178 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/northpolesec/terraform-provider-nps/internal/filter"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// destroyBackup saves a tag's rules to a local file before the provider first
+// deletes one of them, so that an accidental destroy can be recovered from.
+// It is shared by the rule resources of a provider configuration, backs up
+// each tag once per run, and is safe for concurrent use. A nil destroyBackup
+// backs up nothing.
+type destroyBackup struct {
+	client svcpb.WorkshopServiceClient
+	dir    string
+	now    func() time.Time
+
+	mu   sync.Mutex
+	tags map[string]*tagBackup
+}
+
+// tagBackup is the backup of one tag, which every delete from the tag waits
+// for.
+type tagBackup struct {
+	once sync.Once
+	path string
+	err  error
+}
+
+// newDestroyBackup returns a destroyBackup writing to dir, or nil if dir is
+// empty.
+func newDestroyBackup(client svcpb.WorkshopServiceClient, dir string) *destroyBackup {
+	if dir == "" {
+		return nil
+	}
+	return &destroyBackup{client: client, dir: dir, now: time.Now, tags: map[string]*tagBackup{}}
+}
+
+// ensure backs up tag unless that was done already this run. It returns the
+// backup file's path if this call wrote it, and "" otherwise.
+func (b *destroyBackup) ensure(ctx context.Context, tag string) (string, error) {
+	if b == nil {
+		return "", nil
+	}
+	b.mu.Lock()
+	tb := b.tags[tag]
+	if tb == nil {
+		tb = &tagBackup{}
+		b.tags[tag] = tb
+	}
+	b.mu.Unlock()
+
+	wrote := false
+	tb.once.Do(func() {
+		tb.path, tb.err = b.write(ctx, tag)
+		wrote = true
+	})
+	if tb.err != nil {
+		return "", tb.err
+	}
+	if !wrote {
+		return "", nil
+	}
+	return tb.path, nil
+}
+
+// tagBackupFile is the format of a backup file. The rules are in the
+// Workshop API's JSON encoding.
+type tagBackupFile struct {
+	Tag             string            `json:"tag"`
+	CreatedAt       string            `json:"created_at"`
+	Rules           []json.RawMessage `json:"rules"`
+	FileAccessRules []json.RawMessage `json:"file_access_rules"`
+	PackageRules    []json.RawMessage `json:"package_rules"`
+}
+
+func (b *destroyBackup) write(ctx context.Context, tag string) (string, error) {
+	query := filter.Eq("tag", tag).String()
+	now := b.now().UTC()
+	backup := tagBackupFile{Tag: tag, CreatedAt: now.Format(time.RFC3339)}
+
+	var err error
+	backup.Rules, err = backupPages(listPages(ctx, "rules", func(page uint32) ([]*apipb.Rule, bool, error) {
+		ret, err := b.client.ListRules(ctx, apipb.ListRulesRequest_builder{
+			Filter:   proto.String(query),
+			PageSize: proto.Int32(listPageSize),
+			Page:     proto.Int32(int32(page)),
+		}.Build())
+		return ret.GetRules(), ret.GetMore(), err
+	}))
+	if err != nil {
+		return "", fmt.Errorf("failed to list rules: %w", err)
+	}
+	backup.FileAccessRules, err = backupPages(listPages(ctx, "file access rules", func(page uint32) ([]*apipb.FileAccessRule, bool, error) {
+		ret, err := b.client.ListFileAccessRules(ctx, apipb.ListFileAccessRulesRequest_builder{
+			Filter:   proto.String(query),
+			PageSize: proto.Uint32(listPageSize),
+			Page:     proto.Uint32(page),
+		}.Build())
+		return ret.GetRules(), ret.GetMore(), err
+	}))
+	if err != nil {
+		return "", fmt.Errorf("failed to list file access rules: %w", err)
+	}
+	backup.PackageRules, err = backupPages(listPages(ctx, "package rules", func(page uint32) ([]*apipb.PackageRule, bool, error) {
+		ret, err := b.client.ListPackageRules(ctx, apipb.ListPackageRulesRequest_builder{
+			Filter:   proto.String(query),
+			PageSize: proto.Uint32(listPageSize),
+			Page:     proto.Uint32(page),
+		}.Build())
+		return ret.GetRules(), ret.GetMore(), err
+	}))
+	if err != nil {
+		return "", fmt.Errorf("failed to list package rules: %w", err)
+	}
+
+	out, err := json.MarshalIndent(backup, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(b.dir, 0o700); err != nil {
+		return "", err
+	}
+	// Tags can't contain path separators, but a backup must never land
+	// outside dir.
+	name := strings.NewReplacer("/", "_", "\\", "_").Replace(tag)
+	path := filepath.Join(b.dir, fmt.Sprintf("%s-%s.json", name, now.Format("20060102T150405Z")))
+	if err := os.WriteFile(path, append(out, '\n'), 0o600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// backupPages encodes every item of a listPages walk.
+func backupPages[T proto.Message](pages iter.Seq2[T, error]) ([]json.RawMessage, error) {
+	items := []json.RawMessage{}
+	for item, err := range pages {
+		if err != nil {
+			return nil, err
+		}
+		b, err := protojson.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, b)
+	}
+	return items, nil
+}
+
+// backupBeforeDelete backs up tag through b before a delete from it. It adds
+// an error if the delete must not go ahead, and reports a new backup file in
+// a warning.
+func backupBeforeDelete(ctx context.Context, b *destroyBackup, tag string, diags *diag.Diagnostics) {
+	path, err := b.ensure(ctx, tag)
+	if err != nil {
+		diags.AddError(codeLocalIO.summary("Backup Error"), fmt.Sprintf("Failed to back up tag %q before deleting from it: %v", tag, err))
+		return
+	}
+	if path != "" {
+		diags.AddWarning(codeNotice.summary("Tag backed up"), fmt.Sprintf("The rules of tag %q were saved to %s before deleting from it.", tag, path))
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+func TestDestroyBackup(t *testing.T) {
+	fake := &fakeWorkshopClient{
+		listRules:           []*apipb.Rule{testBatchRule("r1", "aaa", "global")},
+		listFileAccessRules: []*apipb.FileAccessRule{apipb.FileAccessRule_builder{Name: "ssh", Tag: "global"}.Build()},
+	}
+	dir := filepath.Join(t.TempDir(), "backups")
+	b := newDestroyBackup(fake, dir)
+	b.now = func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC) }
+
+	path, err := b.ensure(context.Background(), "global")
+	if err != nil {
+		t.Fatalf("ensure() error: %v", err)
+	}
+	if want := filepath.Join(dir, "global-20260301T120000Z.json"); path != want {
+		t.Errorf("backup path = %q, want %q", path, want)
+	}
+	if fake.listRulesFilter != `tag = "global"` {
+		t.Errorf("ListRules filter = %s", fake.listRulesFilter)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got tagBackupFile
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatalf("backup isn't JSON: %v", err)
+	}
+	if got.Tag != "global" || len(got.Rules) != 1 || len(got.FileAccessRules) != 1 || len(got.PackageRules) != 0 {
+		t.Errorf("backup = %s", raw)
+	}
+
+	// Later deletes from the tag don't back it up again.
+	if path, err := b.ensure(context.Background(), "global"); err != nil || path != "" {
+		t.Errorf("second ensure() = %q, %v; want no new backup", path, err)
+	}
+}
+
+func TestDestroyBackupDisabled(t *testing.T) {
+	b := newDestroyBackup(&fakeWorkshopClient{}, "")
+	if b != nil {
+		t.Fatal("newDestroyBackup() without a path returned a backup")
+	}
+	if path, err := b.ensure(context.Background(), "global"); err != nil || path != "" {
+		t.Errorf("ensure() on a nil backup = %q, %v", path, err)
+	}
+}
+
+func TestDestroyBackupFailureBlocksDelete(t *testing.T) {
+	// A file where the directory should be makes every backup fail.
+	dir := filepath.Join(t.TempDir(), "backups")
+	if err := os.WriteFile(dir, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	fake := &fakeWorkshopClient{}
+	r := &RuleResource{client: fake, backup: newDestroyBackup(fake, dir)}
+
+	ctx := context.Background()
+	var sResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &sResp)
+	req := resource.DeleteRequest{State: tfsdk.State{Schema: sResp.Schema}}
+	if diags := req.State.Set(ctx, reconcileState()); diags.HasError() {
+		t.Fatalf("failed to build state: %v", diags)
+	}
+	resp := &resource.DeleteResponse{State: req.State}
+	r.Delete(ctx, req, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("delete went ahead though the backup failed")
+	}
+	if fake.deleteCalls != 0 {
+		t.Errorf("made %d DeleteRule calls, want none", fake.deleteCalls)
+	}
+}
+
+func TestDestroyBackupFailureBlocksCloneDelete(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "backups")
+	if err := os.WriteFile(dir, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	fake := &fakeWorkshopClient{}
+	r := &FileAccessRuleCloneResource{client: fake, backup: newDestroyBackup(fake, dir)}
+
+	ctx := context.Background()
+	var sResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &sResp)
+	req := resource.DeleteRequest{State: tfsdk.State{Schema: sResp.Schema}}
+	if diags := req.State.Set(ctx, FileAccessRuleCloneResourceModel{
+		SourceTag:   types.StringValue("pilot"),
+		SourceName:  types.StringValue("ssh-keys"),
+		TargetTag:   types.StringValue("global"),
+		TargetName:  types.StringValue("ssh-keys"),
+		Id:          types.Int64Value(7),
+		SourceId:    types.Int64Value(3),
+		ContentHash: types.StringValue("hash"),
+	}); diags.HasError() {
+		t.Fatalf("failed to build state: %v", diags)
+	}
+	resp := &resource.DeleteResponse{State: req.State}
+	r.Delete(ctx, req, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("delete went ahead though the backup failed")
+	}
+	if fake.deleteCalls != 0 {
+		t.Errorf("made %d DeleteFileAccessRule calls, want none", fake.deleteCalls)
+	}
+}
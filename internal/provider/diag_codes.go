@@ -0,0 +1,117 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// diagCode is a stable, machine-readable code for a class of diagnostic. Every
+// diagnostic the provider emits starts its summary with one in brackets, e.g.
+// "[NPS001 NotFound] Client Error", so that CI systems and wrapper tooling can
+// match failures without parsing the free-form text after it. Codes are
+// documented in docs/index.md; never renumber or reuse one.
+type diagCode string
+
+const (
+	// Workshop API errors, by gRPC status.
+	codeNotFound           diagCode = "NPS001 NotFound"
+	codePermissionDenied   diagCode = "NPS002 PermissionDenied"
+	codeUnauthenticated    diagCode = "NPS003 Unauthenticated"
+	codeInvalidArgument    diagCode = "NPS004 InvalidArgument"
+	codeFailedPrecondition diagCode = "NPS005 FailedPrecondition"
+	codeAlreadyExists      diagCode = "NPS006 AlreadyExists"
+	codeUnavailable        diagCode = "NPS007 Unavailable"
+	codeAPIError           diagCode = "NPS008 APIError"
+
+	// Errors the provider finds itself.
+	codeProviderConfig     diagCode = "NPS010 ProviderConfig"
+	codeInvalidConfig      diagCode = "NPS011 InvalidConfig"
+	codePolicyViolation    diagCode = "NPS012 PolicyViolation"
+	codeUnexpectedResponse diagCode = "NPS013 UnexpectedResponse"
+	codeServerVersion      diagCode = "NPS014 ServerVersion"
+	codeLocalIO            diagCode = "NPS015 LocalIO"
+	codeInternal           diagCode = "NPS016 Internal"
+	codeApprovalRequired   diagCode = "NPS017 ApprovalRequired"
+
+	// Warnings that report what the provider did rather than a problem.
+	codeNotice diagCode = "NPS020 Notice"
+)
+
+// summary returns s prefixed with c.
+func (c diagCode) summary(s string) string {
+	return "[" + string(c) + "] " + s
+}
+
+// clientError returns the summary of a diagnostic reporting err, an error
+// from a Workshop RPC.
+func clientError(err error) string {
+	return apiErrorCode(err).summary("Client Error")
+}
+
+// apiErrorCode returns the code for err, an error from a Workshop RPC. A
+// permission error names the permission the RPC requires when it's known, as
+// in "NPS002 PermissionDenied:write:rules".
+func apiErrorCode(err error) diagCode {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return codeNotFound
+	case codes.PermissionDenied:
+		var permErr *permissionError
+		if errors.As(err, &permErr) {
+			return codePermissionDenied + diagCode(":"+permErr.permission)
+		}
+		return codePermissionDenied
+	case codes.Unauthenticated:
+		return codeUnauthenticated
+	case codes.InvalidArgument, codes.OutOfRange:
+		return codeInvalidArgument
+	case codes.FailedPrecondition, codes.Aborted:
+		return codeFailedPrecondition
+	case codes.AlreadyExists:
+		return codeAlreadyExists
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return codeUnavailable
+	}
+	return codeAPIError
+}
+
+// methodPermissions maps each Workshop RPC's full method name to the
+// permission it requires.
+var methodPermissions = func() map[string]string {
+	out := map[string]string{}
+	for m := range workshopMethods() {
+		if p := methodPermission(m).GetPermission(); p != "" {
+			out[fullMethodName(m)] = p
+		}
+	}
+	return out
+}()
+
+// permissionError is a PermissionDenied error from an RPC requiring
+// permission. It keeps the RPC's status.
+type permissionError struct {
+	err        error
+	permission string
+}
+
+func (e *permissionError) Error() string              { return e.err.Error() }
+func (e *permissionError) Unwrap() error              { return e.err }
+func (e *permissionError) GRPCStatus() *status.Status { return status.Convert(e.err) }
+
+// permissionUnaryInterceptor records the permission an RPC requires on its
+// PermissionDenied errors, for apiErrorCode.
+func permissionUnaryInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	if status.Code(err) != codes.PermissionDenied {
+		return err
+	}
+	if p, ok := methodPermissions[method]; ok {
+		return &permissionError{err: err, permission: p}
+	}
+	return err
+}
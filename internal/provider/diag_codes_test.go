@@ -0,0 +1,51 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestClientError(t *testing.T) {
+	for _, c := range []struct {
+		err  error
+		want string
+	}{
+		{status.Error(codes.NotFound, "no such rule"), "[NPS001 NotFound] Client Error"},
+		{status.Error(codes.PermissionDenied, "denied"), "[NPS002 PermissionDenied] Client Error"},
+		{&permissionError{err: status.Error(codes.PermissionDenied, "denied"), permission: "write:rules"}, "[NPS002 PermissionDenied:write:rules] Client Error"},
+		{status.Error(codes.Unauthenticated, "bad key"), "[NPS003 Unauthenticated] Client Error"},
+		{status.Error(codes.DeadlineExceeded, "timeout"), "[NPS007 Unavailable] Client Error"},
+		{status.Error(codes.Internal, "boom"), "[NPS008 APIError] Client Error"},
+		{errors.New("not an RPC error"), "[NPS008 APIError] Client Error"},
+	} {
+		if got := clientError(c.err); got != c.want {
+			t.Errorf("clientError(%v) = %q, want %q", c.err, got, c.want)
+		}
+	}
+}
+
+func TestPermissionUnaryInterceptor(t *testing.T) {
+	denied := status.Error(codes.PermissionDenied, "missing permission")
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return denied
+	}
+
+	err := permissionUnaryInterceptor(context.Background(), "/workshop.v1.WorkshopService/CreateRule", nil, nil, nil, invoker)
+	if got, want := apiErrorCode(err), diagCode("NPS002 PermissionDenied:write:rules"); got != want {
+		t.Errorf("apiErrorCode() = %q, want %q", got, want)
+	}
+	if status.Code(err) != codes.PermissionDenied || err.Error() != denied.Error() {
+		t.Errorf("interceptor changed the error to %v", err)
+	}
+
+	// Methods without a known permission keep their error as is.
+	if err := permissionUnaryInterceptor(context.Background(), "/other.Service/Method", nil, nil, nil, invoker); err != denied {
+		t.Errorf("interceptor wrapped %v for an unknown method", err)
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"fmt"
+	"slices"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// pinnedEnums is the set of values this provider version accepts for each API
+// enum used in a schema. utils.ProtoEnumToList reflects whatever the generated
+// protos contain, so without the pins a dependency bump would silently change
+// which values configs may use. TestPinnedEnums fails when the protos and the
+// pins disagree; review the new values and update this table deliberately.
+var pinnedEnums = map[protoreflect.FullName][]string{
+	"workshop.v1.Policy": {
+		"POLICY_UNKNOWN",
+		"ALLOWLIST",
+		"ALLOWLIST_COMPILER",
+		"BLOCKLIST",
+		"SILENT_BLOCKLIST",
+		"REMOVE",
+		"CEL",
+		"SEATBELT",
+		"SILENT_GUI_BLOCKLIST",
+		"SILENT_TTY_BLOCKLIST",
+	},
+	"workshop.v1.RuleType": {
+		"RULETYPE_UNKNOWN",
+		"BINARY",
+		"CERTIFICATE",
+		"TEAMID",
+		"SIGNINGID",
+		"CDHASH",
+	},
+	"workshop.v1.NetworkFlowDirection": {
+		"NETWORK_FLOW_DIRECTION_UNSPECIFIED",
+		"NETWORK_FLOW_DIRECTION_ANY",
+		"NETWORK_FLOW_DIRECTION_OUTGOING",
+		"NETWORK_FLOW_DIRECTION_INCOMING",
+	},
+	"workshop.v1.NetworkFlowRuleAction": {
+		"NETWORK_FLOW_RULE_ACTION_UNSPECIFIED",
+		"NETWORK_FLOW_RULE_ACTION_ALLOW",
+		"NETWORK_FLOW_RULE_ACTION_DENY",
+		"NETWORK_FLOW_RULE_ACTION_SILENT_DENY",
+		"NETWORK_FLOW_RULE_ACTION_AUDIT",
+	},
+	"workshop.v1.PackageSource": {
+		"PACKAGE_SOURCE_UNSPECIFIED",
+		"PACKAGE_SOURCE_HOMEBREW",
+		"PACKAGE_SOURCE_HOMEBREW_CASK",
+		"PACKAGE_SOURCE_NPM",
+		"PACKAGE_SOURCE_GITHUB",
+		"PACKAGE_SOURCE_RUST",
+		"PACKAGE_SOURCE_VSCODE",
+		"PACKAGE_SOURCE_TERRAFORM_PLUGIN",
+		"PACKAGE_SOURCE_BAZEL",
+		"PACKAGE_SOURCE_URL",
+	},
+	"workshop.v1.AuditEvent": {
+		"AUDIT_EVENT_UNSPECIFIED",
+		"AUDIT_EVENT_APIKEY_CREATE",
+		"AUDIT_EVENT_APIKEY_DELETE",
+		"AUDIT_EVENT_RULE_UPSERT",
+		"AUDIT_EVENT_RULE_DELETE",
+		"AUDIT_EVENT_HOST_UPDATE",
+		"AUDIT_EVENT_HOST_DELETE",
+		"AUDIT_EVENT_HOST_SYNC",
+		"AUDIT_EVENT_HOST_CLEAN_SYNC",
+		"AUDIT_EVENT_HOST_MANUAL_PUSH",
+		"AUDIT_EVENT_SCIM_SYNC",
+		"AUDIT_EVENT_GROUP_UPDATE",
+		"AUDIT_EVENT_TAG_SET_ORDER",
+		"AUDIT_EVENT_SETTINGS_TRIGGER_UPDATE",
+		"AUDIT_EVENT_SETTINGS_UPDATE_SYNC_SETTINGS",
+		"AUDIT_EVENT_SETTINGS_UPDATE_RE_SETTINGS",
+		"AUDIT_EVENT_TAG_CREATE",
+		"AUDIT_EVENT_TAG_DELETE",
+		"AUDIT_EVENT_SETTINGS_DELETE_SYNC_SETTINGS",
+		"AUDIT_EVENT_APPROVAL_WORKFLOW_SETTINGS_UPDATE",
+		"AUDIT_EVENT_APPROVAL_WORKFLOW_SETTINGS_DELETE",
+		"AUDIT_EVENT_HOST_CREATE",
+		"AUDIT_EVENT_SELF_SERVICE_RULE_CREATION",
+		"AUDIT_EVENT_SOCIAL_VOTING_RULE_CREATION",
+		"AUDIT_EVENT_VOTE_CAST",
+		"AUDIT_EVENT_DESIGNATED_APPROVER_REQUEST",
+		"AUDIT_EVENT_DESIGNATED_APPROVER_REQUEST_APPROVE",
+		"AUDIT_EVENT_DESIGNATED_APPROVER_REQUEST_REJECT",
+		"AUDIT_EVENT_DESIGNATED_APPROVER_RULE_CREATION",
+		"AUDIT_EVENT_VOTE_RESET_COUNTS",
+		"AUDIT_EVENT_RISK_ENGINE_EXCEPTION_CREATE",
+		"AUDIT_EVENT_RISK_ENGINE_EXCEPTION_UPDATE",
+		"AUDIT_EVENT_RISK_ENGINE_EXCEPTION_DELETE",
+		"AUDIT_EVENT_SETTINGS_SLACKBOT_UPDATE",
+		"AUDIT_EVENT_SETTINGS_SLACKBOT_DELETE",
+		"AUDIT_EVENT_SETTINGS_SLACKBOT_INSTALL",
+		"AUDIT_EVENT_BLOCKABLE_FLAG_MALICIOUS",
+		"AUDIT_EVENT_BLOCKABLE_SET_MALICIOUS_STATE_BENIGN",
+		"AUDIT_EVENT_BLOCKABLE_SET_MALICIOUS_STATE_FLAGGED",
+		"AUDIT_EVENT_BLOCKABLE_SET_MALICIOUS_STATE_CONFIRMED_MALICIOUS",
+		"AUDIT_EVENT_HOST_RULES_HASH_MISMATCH",
+		"AUDIT_EVENT_SETTINGS_TELEMETRY_CONFIG_UPDATE",
+		"AUDIT_EVENT_SETTINGS_TELEMETRY_CONFIG_DELETE",
+		"AUDIT_EVENT_FILE_ACCESS_RULE_UPSERT",
+		"AUDIT_EVENT_FILE_ACCESS_RULE_DELETE",
+		"AUDIT_EVENT_SETTINGS_AUTO_UPDATE",
+		"AUDIT_EVENT_HOST_ON_DEMAND_MONITOR_MODE_ENTERED",
+		"AUDIT_EVENT_HOST_ON_DEMAND_MONITOR_MODE_EXITED",
+		"AUDIT_EVENT_HOST_ON_DEMAND_MONITOR_MODE_REFRESHED",
+		"AUDIT_EVENT_SETTINGS_DIRECTORY_UPDATE",
+		"AUDIT_EVENT_USER_CREATE",
+		"AUDIT_EVENT_USER_UPDATE",
+		"AUDIT_EVENT_USER_DELETE",
+		"AUDIT_EVENT_ADD_USER_TO_GROUP",
+		"AUDIT_EVENT_REMOVE_USER_FROM_GROUP",
+		"AUDIT_EVENT_GROUP_CREATE",
+		"AUDIT_EVENT_GROUP_DELETE",
+		"AUDIT_EVENT_SETTINGS_EXPORT_CONFIG",
+		"AUDIT_EVENT_PACKAGE_RULE_UPSERT",
+		"AUDIT_EVENT_PACKAGE_RULE_DELETE",
+		"AUDIT_EVENT_KILL_PROCESS_ON_HOST_COMMAND",
+		"AUDIT_EVENT_KILL_PROCESS_ON_HOST_COMMAND_RESPONSE",
+		"AUDIT_EVENT_DEPARTMENT_TAGS_UPDATE",
+		"AUDIT_EVENT_COST_CENTER_TAGS_UPDATE",
+		"AUDIT_EVENT_MPA_SETTINGS_UPDATE",
+		"AUDIT_EVENT_MPA_DISABLE_REQUESTED",
+		"AUDIT_EVENT_MULTIPARTY_APPROVAL_REQUESTED",
+		"AUDIT_EVENT_MULTIPARTY_APPROVAL_APPROVED",
+		"AUDIT_EVENT_MULTIPARTY_APPROVAL_REJECTED",
+		"AUDIT_EVENT_MULTIPARTY_APPROVAL_EXPIRED",
+		"AUDIT_EVENT_MULTIPARTY_APPROVAL_EXECUTED",
+		"AUDIT_EVENT_RULES_FROM_BUNDLE_HASH_CREATE",
+		"AUDIT_EVENT_HOST_ID_CHANGE",
+		"AUDIT_EVENT_APIKEY_UPDATE",
+		"AUDIT_EVENT_TAG_RENAME",
+		"AUDIT_EVENT_EVENT_DROPPED_OUT_OF_RANGE",
+		"AUDIT_EVENT_MPA_PROTECTED_METHOD_ADD",
+		"AUDIT_EVENT_MPA_PROTECTED_METHOD_REMOVE",
+		"AUDIT_EVENT_SETTINGS_API_KEY_CIDR_UPDATE",
+		"AUDIT_EVENT_SANTA_COMMAND_QUEUED",
+		"AUDIT_EVENT_SANTA_COMMAND_FINALIZED",
+		"AUDIT_EVENT_PASSKEY_REGISTER",
+		"AUDIT_EVENT_PASSKEY_DELETE",
+		"AUDIT_EVENT_PASSKEY_LOGIN",
+		"AUDIT_EVENT_PASSKEY_SETTINGS_UPDATE",
+		"AUDIT_EVENT_EVENT_UPLOAD_ON_HOST_COMMAND",
+		"AUDIT_EVENT_EVENT_UPLOAD_ON_HOST_COMMAND_RESPONSE",
+		"AUDIT_EVENT_SETTINGS_BINARY_UPLOAD_CONFIG",
+		"AUDIT_EVENT_BINARY_UPLOAD_COMMAND",
+		"AUDIT_EVENT_TELEMETRY_QUERY_CREATE",
+		"AUDIT_EVENT_TELEMETRY_QUERY_UPDATE",
+		"AUDIT_EVENT_TELEMETRY_QUERY_DELETE",
+		"AUDIT_EVENT_TELEMETRY_QUERY_RESTORE",
+		"AUDIT_EVENT_SETTINGS_WEBHOOK_UPDATE",
+		"AUDIT_EVENT_SETTINGS_MCP_UPDATE",
+		"AUDIT_EVENT_SETTINGS_AI_CHAT_UPDATE",
+		"AUDIT_EVENT_HOST_ON_DEMAND_ADMIN_MODE_ENTERED",
+		"AUDIT_EVENT_HOST_ON_DEMAND_ADMIN_MODE_EXITED",
+		"AUDIT_EVENT_HOST_ON_DEMAND_ADMIN_MODE_REFRESHED",
+		"AUDIT_EVENT_HOST_ON_DEMAND_ADMIN_MODE_DENIED",
+		"AUDIT_EVENT_NETWORK_FLOW_RULE_UPSERT",
+		"AUDIT_EVENT_NETWORK_FLOW_RULE_DELETE",
+		"AUDIT_EVENT_SETTINGS_SIGNAL_UPSERT",
+		"AUDIT_EVENT_SETTINGS_SIGNAL_DELETE",
+		"AUDIT_EVENT_SIGNAL_REPORT_UPDATE",
+		"AUDIT_EVENT_SETTINGS_FEATURE_GATED_DISABLE",
+		"AUDIT_EVENT_SANTA_COMMAND_DELETED",
+	},
+	"workshop.v1.SignalReportState": {
+		"SIGNAL_REPORT_STATE_UNSPECIFIED",
+		"SIGNAL_REPORT_STATE_NEW",
+		"SIGNAL_REPORT_STATE_ACKNOWLEDGED",
+		"SIGNAL_REPORT_STATE_INVESTIGATING",
+		"SIGNAL_REPORT_STATE_REMEDIATED",
+		"SIGNAL_REPORT_STATE_DISMISSED",
+	},
+	"santa.common.v1.Severity": {
+		"SEVERITY_UNKNOWN",
+		"SEVERITY_INFO",
+		"SEVERITY_LOW",
+		"SEVERITY_MEDIUM",
+		"SEVERITY_HIGH",
+		"SEVERITY_CRITICAL",
+	},
+}
+
+// enumValues returns the pinned values of enum, for use in schema validators.
+// It panics if enum has no pins, so a schema can't start using an enum
+// without it being added to pinnedEnums.
+func enumValues(enum protoreflect.EnumDescriptor) []string {
+	values, ok := pinnedEnums[enum.FullName()]
+	if !ok {
+		panic(fmt.Sprintf("enum %s is not pinned; add it to pinnedEnums", enum.FullName()))
+	}
+	return slices.Clone(values)
+}
+
+// isPinnedEnumValue reports whether name is a pinned value of enum. Enums
+// without pins aren't restricted.
+func isPinnedEnumValue(enum protoreflect.EnumDescriptor, name string) bool {
+	values, ok := pinnedEnums[enum.FullName()]
+	return !ok || slices.Contains(values, name)
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/northpolesec/terraform-provider-nps/internal/utils"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// TestPinnedEnums fails when a dependency bump changes the values of a pinned
+// enum. Review what the new values mean for the provider before updating
+// pinnedEnums to match.
+func TestPinnedEnums(t *testing.T) {
+	for name, pinned := range pinnedEnums {
+		desc, err := protoregistry.GlobalTypes.FindEnumByName(name)
+		if err != nil {
+			t.Errorf("pinned enum %s not found: %v", name, err)
+			continue
+		}
+		current := utils.ProtoEnumToList(desc.Descriptor())
+		var added, removed []string
+		for _, v := range current {
+			if !slices.Contains(pinned, v) {
+				added = append(added, v)
+			}
+		}
+		for _, v := range pinned {
+			if !slices.Contains(current, v) {
+				removed = append(removed, v)
+			}
+		}
+		if len(added) > 0 || len(removed) > 0 {
+			t.Errorf("enum %s changed: added %v, removed %v; review the change and update pinnedEnums", name, added, removed)
+		}
+	}
+}
+
+func TestEnumValuesPanicsWhenUnpinned(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("enumValues of an unpinned enum did not panic")
+		}
+	}()
+	enumValues(apipb.Rule_BlockReason(0).Descriptor())
+}
+
+func TestReadDecoderUnpinnedEnum(t *testing.T) {
+	// Temporarily unpin a value to stand in for one added by a dependency bump.
+	orig := pinnedEnums["workshop.v1.Policy"]
+	pinnedEnums["workshop.v1.Policy"] = slices.DeleteFunc(slices.Clone(orig), func(v string) bool { return v == "CEL" })
+	t.Cleanup(func() { pinnedEnums["workshop.v1.Policy"] = orig })
+
+	prior := types.StringValue("BLOCKLIST")
+	var v protoreflect.Enum = apipb.Policy_CEL
+
+	var diags diag.Diagnostics
+	if got := newReadDecoder(false, &diags).enum(path.Root("policy"), v, prior); !got.Equal(prior) || diags.WarningsCount() != 1 {
+		t.Errorf("enum() = %v with %v, want prior and one warning", got, diags)
+	}
+
+	diags = nil
+	if got := newReadDecoder(true, &diags).withNewEnumValues(true).enum(path.Root("policy"), v, prior); got.ValueString() != "CEL" || len(diags) != 0 {
+		t.Errorf("enum() accepting new values = %v with %v, want CEL and no diagnostics", got, diags)
+	}
+}
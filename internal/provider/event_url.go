@@ -0,0 +1,109 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Placeholders Santa substitutes into an execution event's detail URL (the
+// EventDetailURL config key, or a rule's custom URL).
+var executionEventURLPlaceholders = []string{
+	"bundle_or_file",
+	"bundle_or_file_identifier",
+	"bundle_or_file_sha",
+	"cdhash",
+	"file_identifier",
+	"file_sha",
+	"hostname",
+	"machine_id",
+	"serial",
+	"signing_id",
+	"team_id",
+	"username",
+	"uuid",
+}
+
+// Placeholders Santa substitutes into a file access event's detail URL (the
+// FileAccessEventDetailURL config key, or a file access rule's
+// event_detail_url).
+var fileAccessEventURLPlaceholders = []string{
+	"accessed_path",
+	"file_identifier",
+	"hostname",
+	"machine_id",
+	"rule_name",
+	"rule_version",
+	"serial",
+	"username",
+	"uuid",
+}
+
+// eventURLPlaceholderRE matches a %placeholder% in an event detail URL. The
+// minimum length keeps it from matching percent-encoded bytes such as %2F.
+var eventURLPlaceholderRE = regexp.MustCompile(`%([a-z_]{3,})%`)
+
+// eventURLTemplateValidator checks that every %placeholder% in a URL is one
+// Santa substitutes. Santa leaves unrecognized placeholders in the URL as-is,
+// so a typo otherwise only shows up as a broken link on a user's machine.
+type eventURLTemplateValidator struct {
+	kind         string
+	placeholders []string
+}
+
+func (v eventURLTemplateValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("placeholders must be supported in %s event URLs: %s", v.kind, strings.Join(v.placeholders, ", "))
+}
+
+func (v eventURLTemplateValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v eventURLTemplateValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if unknown := unknownEventURLPlaceholders(req.ConfigValue.ValueString(), v.placeholders); len(unknown) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			codeInvalidConfig.summary("Unsupported URL placeholder"),
+			fmt.Sprintf("Santa does not substitute %s in %s event URLs. Supported placeholders: %s.",
+				strings.Join(unknown, ", "), v.kind, formatPlaceholders(v.placeholders)),
+		)
+	}
+}
+
+// executionEventURLValidator validates URLs opened for execution events.
+func executionEventURLValidator() validator.String {
+	return eventURLTemplateValidator{kind: "execution", placeholders: executionEventURLPlaceholders}
+}
+
+// fileAccessEventURLValidator validates URLs opened for file access events.
+func fileAccessEventURLValidator() validator.String {
+	return eventURLTemplateValidator{kind: "file access", placeholders: fileAccessEventURLPlaceholders}
+}
+
+// unknownEventURLPlaceholders returns the %placeholder%s in url that are not
+// in supported, in order of first appearance.
+func unknownEventURLPlaceholders(url string, supported []string) []string {
+	var unknown []string
+	for _, m := range eventURLPlaceholderRE.FindAllStringSubmatch(url, -1) {
+		if !slices.Contains(supported, m[1]) && !slices.Contains(unknown, m[0]) {
+			unknown = append(unknown, m[0])
+		}
+	}
+	return unknown
+}
+
+func formatPlaceholders(names []string) string {
+	out := make([]string, len(names))
+	for i, n := range names {
+		out[i] = "%" + n + "%"
+	}
+	return strings.Join(out, ", ")
+}
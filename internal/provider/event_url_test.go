@@ -0,0 +1,71 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestEventURLTemplateValidator(t *testing.T) {
+	cases := []struct {
+		name    string
+		v       validator.String
+		url     types.String
+		wantErr bool
+	}{
+		{"execution placeholders", executionEventURLValidator(), types.StringValue("https://x/?sha=%file_sha%&m=%machine_id%"), false},
+		{"percent-encoding is not a placeholder", executionEventURLValidator(), types.StringValue("https://x/a%2Fb%2Fc"), false},
+		{"typo", executionEventURLValidator(), types.StringValue("https://x/?sha=%filesha%"), true},
+		{"file access placeholder on execution url", executionEventURLValidator(), types.StringValue("https://x/%accessed_path%"), true},
+		{"file access placeholders", fileAccessEventURLValidator(), types.StringValue("https://x/%rule_name%/%accessed_path%"), false},
+		{"unknown value", executionEventURLValidator(), types.StringUnknown(), false},
+		{"null value", executionEventURLValidator(), types.StringNull(), false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			resp := &validator.StringResponse{}
+			c.v.ValidateString(context.Background(), validator.StringRequest{Path: path.Root("custom_url"), ConfigValue: c.url}, resp)
+			if resp.Diagnostics.HasError() != c.wantErr {
+				t.Errorf("HasError() = %v, want %v: %v", resp.Diagnostics.HasError(), c.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestEventURLFunction(t *testing.T) {
+	run := func(base string, placeholders ...string) (string, *function.FuncError) {
+		elemTypes := make([]attr.Type, len(placeholders))
+		elems := make([]attr.Value, len(placeholders))
+		for i, p := range placeholders {
+			elemTypes[i] = types.StringType
+			elems[i] = types.StringValue(p)
+		}
+		variadic := types.TupleValueMust(elemTypes, elems)
+
+		resp := &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+		(&EventURLFunction{}).Run(context.Background(), function.RunRequest{
+			Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(base), variadic}),
+		}, resp)
+		got, _ := resp.Result.Value().(types.String)
+		return got.ValueString(), resp.Error
+	}
+
+	if got, err := run("https://x/e", "file_sha", "%machine_id%"); err != nil || got != "https://x/e?file_sha=%file_sha%&machine_id=%machine_id%" {
+		t.Errorf("got %q, %v", got, err)
+	}
+	if got, err := run("https://x/e?src=santa", "rule_name"); err != nil || got != "https://x/e?src=santa&rule_name=%rule_name%" {
+		t.Errorf("got %q, %v", got, err)
+	}
+	if got, err := run("https://x/e"); err != nil || got != "https://x/e" {
+		t.Errorf("got %q, %v", got, err)
+	}
+	if _, err := run("https://x/e", "file_hash"); err == nil {
+		t.Error("expected an error for an unsupported placeholder")
+	}
+}
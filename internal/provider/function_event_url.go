@@ -0,0 +1,82 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &EventURLFunction{}
+
+func NewEventURLFunction() function.Function {
+	return &EventURLFunction{}
+}
+
+// EventURLFunction builds an event detail URL whose query string carries Santa
+// placeholders, e.g. event_url("https://x", "file_sha", "machine_id") returns
+// "https://x?file_sha=%file_sha%&machine_id=%machine_id%". Building the query
+// with url.Values would percent-encode the placeholders, which Santa would
+// then no longer substitute.
+type EventURLFunction struct{}
+
+func (f *EventURLFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "event_url"
+}
+
+func (f *EventURLFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Build an event detail URL with Santa placeholders",
+		MarkdownDescription: fmt.Sprintf("Appends each placeholder to `base` as a query parameter of the same name, e.g. `provider::nps::event_url(\"https://example.com/event\", \"file_sha\")` returns `https://example.com/event?file_sha=%%file_sha%%`. Supported placeholders: %s.", strings.Join(eventURLFunctionPlaceholders(), ", ")),
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "base",
+				MarkdownDescription: "The URL to append placeholders to. Any existing query string is kept.",
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:                "placeholders",
+			MarkdownDescription: "Placeholder names, without the surrounding `%`.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *EventURLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var base string
+	var placeholders []string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &base, &placeholders))
+	if resp.Error != nil {
+		return
+	}
+
+	supported := eventURLFunctionPlaceholders()
+	var b strings.Builder
+	b.WriteString(base)
+	sep := "?"
+	if strings.Contains(base, "?") {
+		sep = "&"
+	}
+	for _, p := range placeholders {
+		p = strings.Trim(p, "%")
+		if !slices.Contains(supported, p) {
+			resp.Error = function.NewArgumentFuncError(1, codeInvalidConfig.summary(fmt.Sprintf("unsupported placeholder %q; supported placeholders: %s", p, strings.Join(supported, ", "))))
+			return
+		}
+		fmt.Fprintf(&b, "%s%s=%%%s%%", sep, p, p)
+		sep = "&"
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, b.String()))
+}
+
+// eventURLFunctionPlaceholders is every placeholder Santa substitutes in either
+// kind of event URL; the function doesn't know which kind of URL it builds.
+func eventURLFunctionPlaceholders() []string {
+	out := slices.Concat(executionEventURLPlaceholders, fileAccessEventURLPlaceholders)
+	slices.Sort(out)
+	return slices.Compact(out)
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &NormalizeSHA256Function{}
+
+func NewNormalizeSHA256Function() function.Function {
+	return &NormalizeSHA256Function{}
+}
+
+// sha256Regex matches a SHA-256 hash in the form the server stores it.
+var sha256Regex = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// NormalizeSHA256Function returns a SHA-256 hash in the form the server
+// stores it: 64 lower-case hex digits. It accepts the upper-case and
+// colon-separated forms tools such as codesign and openssl print.
+type NormalizeSHA256Function struct{}
+
+func (f *NormalizeSHA256Function) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_sha256"
+}
+
+func (f *NormalizeSHA256Function) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Normalize a SHA-256 hash",
+		MarkdownDescription: "Returns a SHA-256 hash as 64 lower-case hex digits, the form Workshop stores binary and certificate hashes in. Upper-case and colon-separated hashes, as printed by `openssl x509 -fingerprint -sha256`, are accepted; anything else that isn't a SHA-256 hash is an error.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "value",
+				MarkdownDescription: "The hash to normalize.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *NormalizeSHA256Function) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	hash := normalizeHash(strings.ReplaceAll(strings.TrimSpace(value), ":", ""))
+	if !sha256Regex.MatchString(hash) {
+		resp.Error = function.NewArgumentFuncError(0, codeInvalidConfig.summary(fmt.Sprintf("%q is not a SHA-256 hash; expected 64 hex digits, optionally separated by colons", value)))
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, hash))
+}
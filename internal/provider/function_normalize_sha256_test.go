@@ -0,0 +1,39 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNormalizeSHA256Function(t *testing.T) {
+	run := func(value string) (string, *function.FuncError) {
+		resp := &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+		(&NormalizeSHA256Function{}).Run(context.Background(), function.RunRequest{
+			Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(value)}),
+		}, resp)
+		got, _ := resp.Result.Value().(types.String)
+		return got.ValueString(), resp.Error
+	}
+
+	const want = "5a1b3c8d2e4f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f9"
+	colons := make([]string, 0, 32)
+	for i := 0; i < len(want); i += 2 {
+		colons = append(colons, strings.ToUpper(want[i:i+2]))
+	}
+	for _, in := range []string{want, strings.ToUpper(want), strings.Join(colons, ":"), " " + want + "\n"} {
+		if got, err := run(in); err != nil || got != want {
+			t.Errorf("normalize_sha256(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", want[:63], want + "00", strings.Replace(want, "5", "g", 1)} {
+		if got, err := run(in); err == nil {
+			t.Errorf("normalize_sha256(%q) = %q, want an error", in, got)
+		}
+	}
+}
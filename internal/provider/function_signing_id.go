@@ -0,0 +1,66 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &SigningIDFunction{}
+
+func NewSigningIDFunction() function.Function {
+	return &SigningIDFunction{}
+}
+
+// SigningIDFunction builds a SIGNINGID rule identifier from a Team ID and a
+// signing ID, e.g. signing_id("EQHXZ8M8AV", "com.google.Chrome") returns
+// "EQHXZ8M8AV:com.google.Chrome". Apple platform binaries have no Team ID;
+// their identifiers use the platform prefix instead.
+type SigningIDFunction struct{}
+
+func (f *SigningIDFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "signing_id"
+}
+
+func (f *SigningIDFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Build a SIGNINGID rule identifier",
+		MarkdownDescription: "Joins a Team ID and a signing ID into the identifier of a `SIGNINGID` rule, e.g. `provider::nps::signing_id(\"EQHXZ8M8AV\", \"com.google.Chrome\")` returns `EQHXZ8M8AV:com.google.Chrome`. For an Apple platform binary, pass `platform` or an empty string as the Team ID: `provider::nps::signing_id(\"platform\", \"com.apple.curl\")` returns `platform:com.apple.curl`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "team_id",
+				MarkdownDescription: "The 10-character Team ID of the binary's developer, in either case, or `platform` or an empty string for an Apple platform binary.",
+			},
+			function.StringParameter{
+				Name:                "signing_id",
+				MarkdownDescription: "The binary's signing ID, usually its bundle ID, e.g. `com.google.Chrome`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *SigningIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var teamID, signingID string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &teamID, &signingID))
+	if resp.Error != nil {
+		return
+	}
+
+	if teamID == "" {
+		teamID = platformSigningIDPrefix
+	}
+	if !strings.EqualFold(teamID, platformSigningIDPrefix) && !teamIDRegex.MatchString(strings.ToUpper(teamID)) {
+		resp.Error = function.NewArgumentFuncError(0, codeInvalidConfig.summary(fmt.Sprintf("%q is not a Team ID; Team IDs are 10 letters and digits, e.g. EQHXZ8M8AV", teamID)))
+		return
+	}
+	if signingID == "" || strings.ContainsAny(signingID, ": \t\n") {
+		resp.Error = function.NewArgumentFuncError(1, codeInvalidConfig.summary(fmt.Sprintf("%q is not a signing ID", signingID)))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, normalizeIdentifier("SIGNINGID", teamID+":"+signingID)))
+}
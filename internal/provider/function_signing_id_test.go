@@ -0,0 +1,42 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSigningIDFunction(t *testing.T) {
+	run := func(teamID, signingID string) (string, *function.FuncError) {
+		resp := &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+		(&SigningIDFunction{}).Run(context.Background(), function.RunRequest{
+			Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(teamID), types.StringValue(signingID)}),
+		}, resp)
+		got, _ := resp.Result.Value().(types.String)
+		return got.ValueString(), resp.Error
+	}
+
+	for _, c := range []struct{ teamID, signingID, want string }{
+		{"EQHXZ8M8AV", "com.google.Chrome", "EQHXZ8M8AV:com.google.Chrome"},
+		{"eqhxz8m8av", "com.google.Chrome", "EQHXZ8M8AV:com.google.Chrome"},
+		{"Platform", "com.apple.curl", "platform:com.apple.curl"},
+		{"", "com.apple.curl", "platform:com.apple.curl"},
+	} {
+		if got, err := run(c.teamID, c.signingID); err != nil || got != c.want {
+			t.Errorf("signing_id(%q, %q) = %q, %v; want %q", c.teamID, c.signingID, got, err, c.want)
+		}
+	}
+	for _, c := range []struct{ teamID, signingID string }{
+		{"EQHXZ8M8", "com.google.Chrome"},
+		{"EQHXZ8M8AV", ""},
+		{"EQHXZ8M8AV", "EQHXZ8M8AV:com.google.Chrome"},
+	} {
+		if got, err := run(c.teamID, c.signingID); err == nil {
+			t.Errorf("signing_id(%q, %q) = %q, want an error", c.teamID, c.signingID, got)
+		}
+	}
+}
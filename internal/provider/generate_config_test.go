@@ -0,0 +1,277 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/terraform-exec/tfexec"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	tfresource "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// TestGeneratedConfigIsValid imports each resource against a fake client and
+// validates the configuration terraform plan -generate-config-out would write
+// for it, so Read never populates state that can't round-trip through HCL.
+func TestGeneratedConfigIsValid(t *testing.T) {
+	client := &fakeWorkshopClient{
+		listRules: []*apipb.Rule{apipb.Rule_builder{
+			RuleId:     "rule-1",
+			Identifier: "platform:com.apple.curl",
+			RuleType:   apipb.RuleType_SIGNINGID,
+			Policy:     apipb.Policy_ALLOWLIST,
+			Tag:        "global",
+			// The server may report a block reason on any policy, but it
+			// can only be configured on blocklist policies.
+			BlockReason: apipb.Rule_BLOCK_REASON_POLICY,
+		}.Build()},
+		listFileAccessRules: []*apipb.FileAccessRule{apipb.FileAccessRule_builder{
+			RuleId:             9,
+			Tag:                "global",
+			Name:               "ssh",
+			RuleType:           apipb.FileAccessRuleType_FILE_ACCESS_RULE_TYPE_PATHS_WITH_ALLOWED_PROCESSES,
+			PathPrefixes:       []string{"/Users/*/.ssh/"},
+			ProcessBinaryPaths: []string{"/usr/bin/ssh"},
+		}.Build()},
+		listPackageRules: []*apipb.PackageRule{apipb.PackageRule_builder{
+			RuleId:   5,
+			Tag:      "global",
+			Source:   apipb.PackageSource_PACKAGE_SOURCE_HOMEBREW,
+			Name:     "wget",
+			Policy:   apipb.Policy_ALLOWLIST,
+			RuleType: apipb.RuleType_SIGNINGID,
+		}.Build()},
+		listTags: []*apipb.TagStats{apipb.TagStats_builder{Tag: "dev"}.Build()},
+		listAPIKeys: []*apipb.APIKey{apipb.APIKey_builder{
+			Name:        "ci",
+			Permissions: []string{"read:rules", "write:rules"},
+			Expires:     timestamppb.New(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)),
+		}.Build()},
+	}
+
+	for _, c := range []struct {
+		typeName string
+		resource resource.Resource
+		id       string
+	}{
+		{"nps_workshop_rule", &RuleResource{client: client}, "SIGNINGID:platform:com.apple.curl@global"},
+		{"nps_workshop_file_access_rule", &FileAccessRuleResource{client: client}, "global/ssh"},
+		{"nps_workshop_package_rule", &PackageRuleResource{client: client}, "5"},
+		{"nps_workshop_tag", &TagResource{client: client}, "dev"},
+		{"nps_workshop_apikey", &APIKeyResource{client: client}, "ci"},
+	} {
+		t.Run(c.typeName, func(t *testing.T) {
+			ctx := context.Background()
+			state := importedState(t, c.resource, c.id)
+			config, err := generatedConfig(ctx, state)
+			if err != nil {
+				t.Fatalf("generating config: %v", err)
+			}
+
+			dv, err := tfprotov6.NewDynamicValue(config.Type(), config)
+			if err != nil {
+				t.Fatalf("encoding config: %v", err)
+			}
+			server := providerserver.NewProtocol6(New("test")())()
+			resp, err := server.ValidateResourceConfig(ctx, &tfprotov6.ValidateResourceConfigRequest{
+				TypeName: c.typeName,
+				Config:   &dv,
+			})
+			if err != nil {
+				t.Fatalf("validating config: %v", err)
+			}
+			for _, d := range resp.Diagnostics {
+				if d.Severity == tfprotov6.DiagnosticSeverityError {
+					t.Errorf("generated config is invalid: %s: %s", d.Summary, d.Detail)
+				}
+			}
+		})
+	}
+}
+
+// importedState runs ImportState and then Read, as Terraform does before
+// generating configuration for an import block.
+func importedState(t *testing.T, r resource.Resource, id string) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+
+	var sResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &sResp)
+	var iResp resource.IdentitySchemaResponse
+	r.(resource.ResourceWithIdentity).IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &iResp)
+
+	state := tfsdk.State{
+		Schema: sResp.Schema,
+		Raw:    tftypes.NewValue(sResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	identity := &tfsdk.ResourceIdentity{
+		Schema: iResp.IdentitySchema,
+		Raw:    tftypes.NewValue(iResp.IdentitySchema.Type().TerraformType(ctx), nil),
+	}
+
+	importResp := &resource.ImportStateResponse{State: state, Identity: identity}
+	r.(resource.ResourceWithImportState).ImportState(ctx, resource.ImportStateRequest{ID: id}, importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("import failed: %v", importResp.Diagnostics)
+	}
+
+	readResp := &resource.ReadResponse{State: importResp.State, Identity: identity}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State, Identity: identity}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read failed: %v", readResp.Diagnostics)
+	}
+	if readResp.State.Raw.IsNull() {
+		t.Fatal("read removed the resource")
+	}
+	return readResp.State
+}
+
+// testAccCheckGeneratedConfig imports the resource at address into an empty
+// configuration with terraform plan -generate-config-out, using the value of
+// idAttr as the import ID. It fails if Terraform can't generate valid
+// configuration or if the generated configuration doesn't plan cleanly.
+func testAccCheckGeneratedConfig(address, idAttr string) tfresource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[address]
+		if !ok {
+			return fmt.Errorf("%s not found in state", address)
+		}
+		return testAccGenerateConfig(address, rs.Primary.Attributes[idAttr])
+	}
+}
+
+func testAccGenerateConfig(address, id string) error {
+	ctx := context.Background()
+
+	tfPath := os.Getenv("TF_ACC_TERRAFORM_PATH")
+	if tfPath == "" {
+		var err error
+		if tfPath, err = exec.LookPath("terraform"); err != nil {
+			return fmt.Errorf("finding terraform: %w", err)
+		}
+	}
+
+	dir, err := os.MkdirTemp("", "nps-generate-config")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	resourceType, _, _ := strings.Cut(address, ".")
+	target := resourceType + ".generated"
+	config := fmt.Sprintf(`
+provider "nps" {
+  endpoint = "localhost:8080"
+}
+
+import {
+  to = %s
+  id = %q
+}
+`, target, id)
+	if err := os.WriteFile(filepath.Join(dir, "main.tf"), []byte(config), 0o600); err != nil {
+		return err
+	}
+
+	reattach, stop, err := testAccServeProvider(ctx)
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	tf, err := tfexec.NewTerraform(dir, tfPath)
+	if err != nil {
+		return err
+	}
+	if err := tf.Init(ctx, tfexec.Reattach(reattach)); err != nil {
+		return fmt.Errorf("terraform init: %w", err)
+	}
+	if _, err := tf.Plan(ctx, tfexec.Reattach(reattach), tfexec.GenerateConfigOut("generated.tf"), tfexec.Out("tfplan")); err != nil {
+		return fmt.Errorf("terraform plan -generate-config-out: %w", err)
+	}
+	generated, err := os.ReadFile(filepath.Join(dir, "generated.tf"))
+	if err != nil {
+		return err
+	}
+
+	plan, err := tf.ShowPlanFile(ctx, "tfplan", tfexec.Reattach(reattach))
+	if err != nil {
+		return fmt.Errorf("terraform show: %w", err)
+	}
+	for _, rc := range plan.ResourceChanges {
+		if rc.Address != target {
+			continue
+		}
+		if rc.Change.Importing == nil || !rc.Change.Actions.NoOp() {
+			return fmt.Errorf("expected a no-op import of %s, got %v with generated config:\n%s", target, rc.Change.Actions, generated)
+		}
+		return nil
+	}
+	return fmt.Errorf("plan has no change for %s; generated config:\n%s", target, generated)
+}
+
+// testAccServeProvider runs the provider in-process in debug mode and returns
+// the reattach configuration Terraform needs to use it.
+func testAccServeProvider(ctx context.Context) (tfexec.ReattachInfo, func(), error) {
+	ctx, cancel := context.WithCancel(ctx)
+	configCh := make(chan *plugin.ReattachConfig)
+	closeCh := make(chan struct{})
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- tf6server.Serve(
+			"registry.terraform.io/northpolesec/nps",
+			providerserver.NewProtocol6(New("test")()),
+			tf6server.WithDebug(ctx, configCh, closeCh),
+			tf6server.WithoutLogStderrOverride(),
+		)
+	}()
+
+	var config *plugin.ReattachConfig
+	select {
+	case config = <-configCh:
+	case err := <-errCh:
+		cancel()
+		return nil, nil, fmt.Errorf("serving provider: %w", err)
+	case <-time.After(30 * time.Second):
+		cancel()
+		return nil, nil, errors.New("timed out waiting for the provider to start")
+	}
+
+	reattach := tfexec.ReattachConfig{
+		Protocol:        string(config.Protocol),
+		ProtocolVersion: config.ProtocolVersion,
+		Pid:             config.Pid,
+		Test:            config.Test,
+		Addr: tfexec.ReattachConfigAddr{
+			Network: config.Addr.Network(),
+			String:  config.Addr.String(),
+		},
+	}
+	// The configuration has no required_providers block, so Terraform looks
+	// the provider up under the legacy hashicorp namespace.
+	info := tfexec.ReattachInfo{
+		"registry.terraform.io/hashicorp/nps":    reattach,
+		"registry.terraform.io/northpolesec/nps": reattach,
+	}
+	return info, func() {
+		cancel()
+		<-closeCh
+	}, nil
+}
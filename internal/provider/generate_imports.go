@@ -0,0 +1,279 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"math/big"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+)
+
+// importableResources are the resources -generate-imports lists, in the
+// order it writes them. API keys are left out: their secrets can't be
+// imported, and the key running the import is usually one of them.
+var importableResources = []func() list.ListResource{
+	NewTagListResource,
+	NewRuleListResource,
+	NewFileAccessRuleListResource,
+	NewPackageRuleListResource,
+	NewNetworkFlowRuleListResource,
+	NewSignalListResource,
+}
+
+// GenerateImports writes an import block and a resource block for every tag,
+// rule, file access rule, package rule, network flow rule, and signal on
+// endpoint, for the -generate-imports flag. It authenticates as the provider
+// does without an api_key: with WORKSHOP_API_KEY or the token stored by
+// -login.
+func GenerateImports(ctx context.Context, w io.Writer, endpoint string) error {
+	clients := newClientPool()
+	defer clients.Close()
+	client, err := clients.Get(ctx, endpoint, "", connOptions{transport: transportGRPC})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "# Generated by terraform-provider-nps -generate-imports %s.\n# Each resource block holds the resource's current configuration.\n", endpoint)
+	return writeImports(ctx, w, client)
+}
+
+// writeImports lists every importable resource through its list resource,
+// so the resource blocks hold exactly what an import followed by a Read
+// would put in state.
+func writeImports(ctx context.Context, w io.Writer, client svcpb.WorkshopServiceClient) error {
+	used := map[string]bool{}
+	for _, newListResource := range importableResources {
+		lr := newListResource()
+		r := lr.(resource.ResourceWithIdentity)
+
+		var mResp resource.MetadataResponse
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "nps"}, &mResp)
+		var cResp resource.ConfigureResponse
+		r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{ProviderData: &NPSProviderResourceData{Client: client}}, &cResp)
+		if cResp.Diagnostics.HasError() {
+			return fmt.Errorf("%s: %v", mResp.TypeName, cResp.Diagnostics)
+		}
+		var sResp resource.SchemaResponse
+		r.Schema(ctx, resource.SchemaRequest{}, &sResp)
+		var iResp resource.IdentitySchemaResponse
+		r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &iResp)
+
+		var stream list.ListResultsStream
+		lr.List(ctx, list.ListRequest{
+			IncludeResource:        true,
+			ResourceSchema:         sResp.Schema,
+			ResourceIdentitySchema: iResp.IdentitySchema,
+		}, &stream)
+		if stream.Results == nil {
+			continue
+		}
+		for result := range stream.Results {
+			if result.Diagnostics.HasError() {
+				return fmt.Errorf("%s: %v", mResp.TypeName, result.Diagnostics)
+			}
+			if err := writeImport(ctx, w, mResp.TypeName, result, used); err != nil {
+				return fmt.Errorf("%s %s: %w", mResp.TypeName, result.DisplayName, err)
+			}
+		}
+	}
+	return nil
+}
+
+// writeImport writes the import and resource blocks for one list result.
+// The import block names the resource by its natural key rather than its ID
+// wherever the identity has one, since rule IDs change on every update.
+func writeImport(ctx context.Context, w io.Writer, typeName string, result list.ListResult, used map[string]bool) error {
+	var identity map[string]tftypes.Value
+	if err := result.Identity.Raw.As(&identity); err != nil {
+		return err
+	}
+	var key []string
+	for _, k := range slices.Sorted(maps.Keys(identity)) {
+		var s string
+		if k == "id" || identity[k].IsNull() || identity[k].As(&s) != nil {
+			continue
+		}
+		key = append(key, s)
+	}
+	if len(key) > 0 {
+		delete(identity, "id")
+	} else {
+		key = []string{result.DisplayName}
+	}
+	name := importResourceName(key, used)
+
+	config, err := generatedConfig(ctx, tfsdk.State{Schema: result.Resource.Schema, Raw: result.Resource.Raw})
+	if err != nil {
+		return err
+	}
+	var attrs map[string]tftypes.Value
+	if err := config.As(&attrs); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\nimport {\n  to       = %s.%s\n  identity = %s\n}\n", typeName, name, hclObject(identity, false, "  "))
+	fmt.Fprintf(w, "\nresource %q %q %s\n", typeName, name, hclObject(attrs, false, ""))
+	return nil
+}
+
+var nonIdentifierChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// importResourceName returns a Terraform resource name for the natural key
+// parts, e.g. global_protect_ssh_keys, that isn't yet in used.
+func importResourceName(parts []string, used map[string]bool) string {
+	base := strings.Trim(nonIdentifierChars.ReplaceAllString(strings.ToLower(strings.Join(parts, "_")), "_"), "_")
+	if base == "" || base[0] >= '0' && base[0] <= '9' {
+		base = "r_" + base
+	}
+	name := base
+	for i := 2; used[name]; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	used[name] = true
+	return name
+}
+
+// generatedConfig returns the configuration terraform plan
+// -generate-config-out writes for state: every attribute a practitioner can
+// set keeps its value and computed-only attributes are left out.
+func generatedConfig(ctx context.Context, state tfsdk.State) (tftypes.Value, error) {
+	return tftypes.Transform(state.Raw, func(p *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if len(p.Steps()) == 0 {
+			return v, nil
+		}
+		attr, err := state.Schema.AttributeAtTerraformPath(ctx, p)
+		if err != nil {
+			// Blocks and collection elements aren't attributes.
+			return v, nil
+		}
+		if attr.IsComputed() && !attr.IsOptional() {
+			return tftypes.NewValue(v.Type(), nil), nil
+		}
+		return v, nil
+	})
+}
+
+// hclObject renders attrs as an HCL object body at indent, aligning the
+// equals signs of adjacent single-line attributes as terraform fmt does.
+// Null attributes are left out; map keys are quoted.
+func hclObject(attrs map[string]tftypes.Value, quoteKeys bool, indent string) string {
+	type line struct{ key, value string }
+	var lines []line
+	for _, k := range slices.Sorted(maps.Keys(attrs)) {
+		if attrs[k].IsNull() {
+			continue
+		}
+		if quoteKeys {
+			lines = append(lines, line{hclString(k), hclExpr(attrs[k], indent+"  ")})
+		} else {
+			lines = append(lines, line{k, hclExpr(attrs[k], indent+"  ")})
+		}
+	}
+	if len(lines) == 0 {
+		return "{}"
+	}
+
+	var b strings.Builder
+	b.WriteString("{\n")
+	for start := 0; start < len(lines); {
+		// A multi-line value ends a run of aligned attributes.
+		end, width := start, 0
+		for end < len(lines) {
+			width = max(width, len(lines[end].key))
+			end++
+			if strings.Contains(lines[end-1].value, "\n") {
+				break
+			}
+		}
+		for _, l := range lines[start:end] {
+			fmt.Fprintf(&b, "%s  %-*s = %s\n", indent, width, l.key, l.value)
+		}
+		start = end
+	}
+	b.WriteString(indent + "}")
+	return b.String()
+}
+
+// hclExpr renders v as an HCL expression whose continuation lines are at
+// indent.
+func hclExpr(v tftypes.Value, indent string) string {
+	if v.IsNull() {
+		return "null"
+	}
+	typ := v.Type()
+	switch {
+	case typ.Is(tftypes.String):
+		var s string
+		v.As(&s)
+		return hclString(s)
+	case typ.Is(tftypes.Number):
+		var f big.Float
+		v.As(&f)
+		return f.Text('f', -1)
+	case typ.Is(tftypes.Bool):
+		var b bool
+		v.As(&b)
+		return fmt.Sprint(b)
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}), typ.Is(tftypes.Tuple{}):
+		var elems []tftypes.Value
+		v.As(&elems)
+		values := make([]string, len(elems))
+		multiline := false
+		for i, e := range elems {
+			values[i] = hclExpr(e, indent+"  ")
+			multiline = multiline || strings.Contains(values[i], "\n")
+		}
+		if !multiline {
+			return "[" + strings.Join(values, ", ") + "]"
+		}
+		return "[\n" + indent + "  " + strings.Join(values, ",\n"+indent+"  ") + ",\n" + indent + "]"
+	case typ.Is(tftypes.Map{}):
+		var m map[string]tftypes.Value
+		v.As(&m)
+		return hclObject(m, true, indent)
+	case typ.Is(tftypes.Object{}):
+		var m map[string]tftypes.Value
+		v.As(&m)
+		return hclObject(m, false, indent)
+	}
+	return "null"
+}
+
+// hclString quotes s as an HCL string literal, escaping template sequences
+// so the value is taken literally.
+func hclString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case (r == '$' || r == '%') && strings.HasPrefix(s[i+1:], "{"):
+			b.WriteRune(r)
+			b.WriteRune(r)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&b, `\u%04X`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,67 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+func TestWriteImports(t *testing.T) {
+	client := &fakeWorkshopClient{
+		listTags: []*apipb.TagStats{apipb.TagStats_builder{Tag: "dev"}.Build()},
+		listRules: []*apipb.Rule{
+			apipb.Rule_builder{RuleId: "rule-1", Identifier: "platform:com.apple.curl", RuleType: apipb.RuleType_SIGNINGID, Policy: apipb.Policy_ALLOWLIST, Tag: "global"}.Build(),
+			apipb.Rule_builder{RuleId: "rule-2", Identifier: "platform:com.apple.curl", RuleType: apipb.RuleType_SIGNINGID, Policy: apipb.Policy_BLOCKLIST, Tag: "global-", CustomMsg: "Use ${tool} instead"}.Build(),
+		},
+		listFileAccessRules: []*apipb.FileAccessRule{apipb.FileAccessRule_builder{
+			RuleId:             9,
+			Tag:                "global",
+			Name:               "ssh",
+			RuleType:           apipb.FileAccessRuleType_FILE_ACCESS_RULE_TYPE_PATHS_WITH_ALLOWED_PROCESSES,
+			PathPrefixes:       []string{"/Users/*/.ssh/"},
+			ProcessBinaryPaths: []string{"/usr/bin/ssh"},
+		}.Build()},
+	}
+
+	var b strings.Builder
+	if err := writeImports(context.Background(), &b, client); err != nil {
+		t.Fatalf("writeImports() = %v", err)
+	}
+	got := b.String()
+
+	for _, want := range []string{
+		// Rules are imported by natural key, not by their changing IDs.
+		"import {\n  to       = nps_workshop_rule.platform_com_apple_curl_signingid_global\n  identity = {\n    identifier = \"platform:com.apple.curl\"\n    rule_type  = \"SIGNINGID\"\n    tag        = \"global\"\n  }\n}\n",
+		// Names that collide after sanitizing are numbered.
+		"resource \"nps_workshop_rule\" \"platform_com_apple_curl_signingid_global_2\" {",
+		// Template sequences in values are escaped.
+		`custom_msg   = "Use $${tool} instead"`,
+		"resource \"nps_workshop_tag\" \"dev\" {\n  name = \"dev\"\n",
+		`path_prefixes          = ["/Users/*/.ssh/"]`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output is missing %q", want)
+		}
+	}
+	if strings.Contains(got, "rule-1") {
+		t.Error("output contains a rule ID")
+	}
+}
+
+func TestHCLString(t *testing.T) {
+	for in, want := range map[string]string{
+		"plain":            `"plain"`,
+		`say "hi"\n`:       `"say \"hi\"\\n"`,
+		"line\nbreak\tand": `"line\nbreak\tand"`,
+		"${var} %{if}":     `"$${var} %%{if}"`,
+		"cost $5 or 50%":   `"cost $5 or 50%"`,
+		"bell\a":           `"bell\u0007"`,
+	} {
+		if got := hclString(in); got != want {
+			t.Errorf("hclString(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+// minKeepaliveTime is the shortest keepalive interval gRPC allows; it silently
+// raises shorter ones.
+const minKeepaliveTime = 10 * time.Second
+
+// GRPCOptionsModel describes the provider's grpc attribute.
+type GRPCOptionsModel struct {
+	KeepaliveTime    types.String `tfsdk:"keepalive_time"`
+	KeepaliveTimeout types.String `tfsdk:"keepalive_timeout"`
+	MaxRecvMsgSize   types.Int64  `tfsdk:"max_recv_msg_size"`
+	MaxSendMsgSize   types.Int64  `tfsdk:"max_send_msg_size"`
+}
+
+// grpcTuning is the gRPC transport's connection tuning. The zero value keeps
+// gRPC's defaults. It is comparable so that it can be part of a clientKey.
+type grpcTuning struct {
+	keepaliveTime    time.Duration
+	keepaliveTimeout time.Duration
+	maxRecvMsgSize   int
+	maxSendMsgSize   int
+}
+
+// newGRPCTuning converts the provider's grpc attribute, which may be nil.
+func newGRPCTuning(m *GRPCOptionsModel, diags *diag.Diagnostics) grpcTuning {
+	var t grpcTuning
+	if m == nil {
+		return t
+	}
+	parse := func(name string, v types.String, minimum time.Duration) time.Duration {
+		if v.ValueString() == "" {
+			return 0
+		}
+		d, err := time.ParseDuration(v.ValueString())
+		if err == nil && d < minimum {
+			err = fmt.Errorf("must be at least %s", minimum)
+		}
+		if err != nil {
+			diags.AddAttributeError(path.Root("grpc").AtName(name), codeProviderConfig.summary("NPS Provider configuration error"), fmt.Sprintf("grpc.%s is invalid: %v", name, err))
+		}
+		return d
+	}
+	t.keepaliveTime = parse("keepalive_time", m.KeepaliveTime, minKeepaliveTime)
+	t.keepaliveTimeout = parse("keepalive_timeout", m.KeepaliveTimeout, time.Second)
+	t.maxRecvMsgSize = int(m.MaxRecvMsgSize.ValueInt64())
+	t.maxSendMsgSize = int(m.MaxSendMsgSize.ValueInt64())
+	return t
+}
+
+// dialOptions returns the dial options that apply t.
+func (t grpcTuning) dialOptions() []grpc.DialOption {
+	var opts []grpc.DialOption
+	if t.keepaliveTime > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:    t.keepaliveTime,
+			Timeout: t.keepaliveTimeout,
+			// Pings matter most between calls, e.g. while Terraform waits on
+			// another provider during a long apply.
+			PermitWithoutStream: true,
+		}))
+	}
+	var callOpts []grpc.CallOption
+	if t.maxRecvMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallRecvMsgSize(t.maxRecvMsgSize))
+	}
+	if t.maxSendMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallSendMsgSize(t.maxSendMsgSize))
+	}
+	if len(callOpts) > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(callOpts...))
+	}
+	return opts
+}
@@ -0,0 +1,42 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNewGRPCTuning(t *testing.T) {
+	var diags diag.Diagnostics
+	if got := newGRPCTuning(nil, &diags); got != (grpcTuning{}) || len(got.dialOptions()) != 0 {
+		t.Errorf("newGRPCTuning(nil) = %+v, want gRPC's defaults", got)
+	}
+
+	got := newGRPCTuning(&GRPCOptionsModel{
+		KeepaliveTime:    types.StringValue("30s"),
+		KeepaliveTimeout: types.StringNull(),
+		MaxRecvMsgSize:   types.Int64Value(64 << 20),
+		MaxSendMsgSize:   types.Int64Null(),
+	}, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diags: %v", diags)
+	}
+	want := grpcTuning{keepaliveTime: 30 * time.Second, maxRecvMsgSize: 64 << 20}
+	if got != want {
+		t.Errorf("newGRPCTuning() = %+v, want %+v", got, want)
+	}
+	if n := len(got.dialOptions()); n != 2 {
+		t.Errorf("got %d dial options, want keepalive and call options", n)
+	}
+
+	for _, keepalive := range []string{"5s", "soon"} {
+		diags = nil
+		newGRPCTuning(&GRPCOptionsModel{KeepaliveTime: types.StringValue(keepalive)}, &diags)
+		if !diags.HasError() {
+			t.Errorf("keepalive_time %q was accepted", keepalive)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ignoreServerChangesAttribute returns the schema for a resource's
+// ignore_server_changes attribute, which may name any of names. Only
+// attributes that are safe to leave stale belong in names: never a natural key
+// or a computed attribute.
+func ignoreServerChangesAttribute(names ...string) schema.SetAttribute {
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = "`" + n + "`"
+	}
+	return schema.SetAttribute{
+		Description:         fmt.Sprintf("Attributes whose changes made outside Terraform are ignored: refresh keeps the value in state instead of the server's, so edits made in the Workshop UI don't show as drift. The server's value is still overwritten the next time Terraform updates the resource for another reason; add the attribute to lifecycle.ignore_changes as well to keep it. The possible values are: %s.", strings.Join(names, ", ")),
+		MarkdownDescription: fmt.Sprintf("Attributes whose changes made outside Terraform are ignored: refresh keeps the value in state instead of the server's, so edits made in the Workshop UI don't show as drift. The server's value is still overwritten the next time Terraform updates the resource for another reason; add the attribute to `lifecycle.ignore_changes` as well to keep it. The possible values are: %s.", strings.Join(quoted, ", ")),
+		Optional:            true,
+		ElementType:         types.StringType,
+		Validators: []validator.Set{
+			setvalidator.SizeAtLeast(1),
+			setValuesOneOf(names...),
+		},
+	}
+}
+
+// keepIgnoredServerChanges copies the attributes named in prior's
+// ignore_server_changes from prior to state, undoing what Read learned from
+// the server about them. Call it after Read has set state.
+func keepIgnoredServerChanges(ctx context.Context, prior tfsdk.State, state *tfsdk.State, diags *diag.Diagnostics) {
+	var ignored types.Set
+	diags.Append(prior.GetAttribute(ctx, path.Root("ignore_server_changes"), &ignored)...)
+	if diags.HasError() || ignored.IsNull() || ignored.IsUnknown() {
+		return
+	}
+
+	for _, e := range ignored.Elements() {
+		name, ok := e.(types.String)
+		if !ok || name.IsNull() || name.IsUnknown() {
+			continue
+		}
+		p := path.Root(name.ValueString())
+		var v attr.Value
+		diags.Append(prior.GetAttribute(ctx, p, &v)...)
+		if diags.HasError() {
+			return
+		}
+		diags.Append(state.SetAttribute(ctx, p, v)...)
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+func TestRuleReadIgnoreServerChanges(t *testing.T) {
+	rule := apipb.Rule_builder{
+		RuleId:     "rule-1",
+		Identifier: "abc",
+		RuleType:   apipb.RuleType_BINARY,
+		Policy:     apipb.Policy_BLOCKLIST,
+		Tag:        "global",
+		Comment:    "edited by on-call",
+		CustomMsg:  "edited message",
+	}.Build()
+	r := &RuleResource{client: &fakeWorkshopClient{listRules: []*apipb.Rule{rule}}}
+
+	prior := testRulePriorState()
+	prior.Comment = types.StringValue("managed by terraform")
+	prior.CustomMsg = types.StringValue("managed message")
+	prior.IgnoreServerChanges = []string{"comment"}
+
+	resp := callRuleRead(t, r, prior)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("read failed: %v", resp.Diagnostics)
+	}
+	var got RuleResourceModel
+	resp.State.Get(context.Background(), &got)
+	if got.Comment.ValueString() != "managed by terraform" {
+		t.Errorf("comment = %v, want the ignored prior value", got.Comment)
+	}
+	if got.CustomMsg.ValueString() != "edited message" {
+		t.Errorf("custom_msg = %v, want the server's value", got.CustomMsg)
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"iter"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// listPageSize is the page size used when walking a full listing.
+	listPageSize = 500
+	// listProgressInterval is how many items are yielded between progress logs.
+	listProgressInterval = 10000
+
+	// defaultListLimit and maxListLimit bound how many items a plural data
+	// source collects, so a broad filter can't pull a whole instance into
+	// state.
+	defaultListLimit = 1000
+	maxListLimit     = 100000
+)
+
+// listPages walks a paginated Workshop list RPC one page at a time. The
+// Workshop API has no server-streaming list endpoints, so this is the closest
+// equivalent: only one page is held in memory, and the next page is not
+// fetched until the consumer has taken every item of the current one, so a
+// consumer that stops early (e.g. a list result stream whose push returns
+// false) stops the walk.
+//
+// fetch is called with a one-based page number (matching the API's own
+// examples, which request page 1 first) and returns that page's items and
+// whether the server has more. An error from fetch is yielded once and
+// ends the walk.
+func listPages[T any](ctx context.Context, kind string, fetch func(page uint32) ([]T, bool, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var total int
+		for page := uint32(1); ; page++ {
+			items, more, err := fetch(page)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+				total++
+				if total%listProgressInterval == 0 {
+					tflog.Info(ctx, "Listing Workshop objects", map[string]any{"kind": kind, "count": total})
+				}
+			}
+			if !more || len(items) == 0 {
+				tflog.Debug(ctx, "Finished listing Workshop objects", map[string]any{"kind": kind, "count": total})
+				return
+			}
+		}
+	}
+}
+
+// listPagesFrom is listPages resuming offset items into the listing, e.g. from
+// a page token made by offsetToken. fetch must return pages of pageSize items;
+// the walk starts at the page holding item offset and skips the items before
+// it.
+func listPagesFrom[T any](ctx context.Context, kind string, offset, pageSize int, fetch func(page uint32) ([]T, bool, error)) iter.Seq2[T, error] {
+	first, skip := uint32(offset/pageSize), offset%pageSize
+	return listPages(ctx, kind, func(page uint32) ([]T, bool, error) {
+		items, more, err := fetch(first + page)
+		if page == 1 {
+			items = items[min(skip, len(items)):]
+		}
+		return items, more, err
+	})
+}
+
+// collectPages collects at most limit items from a listPages walk. truncated
+// reports whether there were more; the walk stops as soon as that is known, so
+// at most one item past the limit is fetched.
+func collectPages[T any](pages iter.Seq2[T, error], limit int) (items []T, truncated bool, err error) {
+	for item, err := range pages {
+		if err != nil {
+			return nil, false, err
+		}
+		if len(items) == limit {
+			return items, true, nil
+		}
+		items = append(items, item)
+	}
+	return items, false, nil
+}
+
+// pageToken returns the page token plural data sources expose for page. The
+// Workshop API pages by number rather than by cursor; encoding the number
+// keeps the token opaque so callers don't come to depend on its format. It is
+// still a position, not a cursor: rows inserted or deleted before it between
+// reads shift what the next read returns.
+func pageToken(page uint32) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(uint64(page), 10)))
+}
+
+// parsePageToken returns the page a token from pageToken refers to. An empty
+// token refers to the first page.
+func parsePageToken(token string) (uint32, error) {
+	if token == "" {
+		return 1, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, errors.New("not a page token returned by this provider")
+	}
+	page, err := strconv.ParseUint(string(b), 10, 32)
+	if err != nil || page == 0 {
+		return 0, errors.New("not a page token returned by this provider")
+	}
+	return uint32(page), nil
+}
+
+// offsetToken returns the page token of a plural data source that collects up
+// to a limit of items, for resuming after the first offset matching items.
+// Like pageToken it is an encoded position, not a cursor.
+func offsetToken(offset int) string {
+	return pageToken(uint32(offset))
+}
+
+// parseOffsetToken returns the offset a token from offsetToken refers to. An
+// empty token refers to the start of the listing.
+func parseOffsetToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+	offset, err := parsePageToken(token)
+	return int(offset), err
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestCollectPages(t *testing.T) {
+	ctx := context.Background()
+	data := [][]int{{1, 2}, {3, 4}, {5}}
+
+	var fetched []uint32
+	fetch := func(page uint32) ([]int, bool, error) {
+		fetched = append(fetched, page)
+		return data[page-1], int(page) < len(data), nil
+	}
+
+	for _, c := range []struct {
+		limit         int
+		want          []int
+		wantTruncated bool
+		wantFetched   []uint32
+	}{
+		{10, []int{1, 2, 3, 4, 5}, false, []uint32{1, 2, 3}},
+		{5, []int{1, 2, 3, 4, 5}, false, []uint32{1, 2, 3}},
+		{3, []int{1, 2, 3}, true, []uint32{1, 2}},
+		{2, []int{1, 2}, true, []uint32{1, 2}},
+	} {
+		fetched = nil
+		got, truncated, err := collectPages(listPages(ctx, "test", fetch), c.limit)
+		if err != nil || !slices.Equal(got, c.want) || truncated != c.wantTruncated {
+			t.Errorf("collectPages(limit=%d) = %v, %t, %v; want %v, %t", c.limit, got, truncated, err, c.want, c.wantTruncated)
+		}
+		if !slices.Equal(fetched, c.wantFetched) {
+			t.Errorf("collectPages(limit=%d) fetched pages %v, want %v", c.limit, fetched, c.wantFetched)
+		}
+	}
+
+	_, _, err := collectPages(listPages(ctx, "test", func(uint32) ([]int, bool, error) {
+		return nil, false, errors.New("boom")
+	}), 10)
+	if err == nil {
+		t.Error("collectPages() did not return the fetch error")
+	}
+}
+
+func TestListPages(t *testing.T) {
+	ctx := context.Background()
+	data := [][]int{{1, 2}, {3, 4}, {5}}
+
+	var fetched []uint32
+	fetch := func(page uint32) ([]int, bool, error) {
+		fetched = append(fetched, page)
+		return data[page-1], int(page) < len(data), nil
+	}
+
+	var got []int
+	for v, err := range listPages(ctx, "test", fetch) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("got %v", got)
+	}
+	if !slices.Equal(fetched, []uint32{1, 2, 3}) {
+		t.Errorf("fetched pages %v", fetched)
+	}
+
+	// Stopping early must not fetch further pages.
+	fetched = nil
+	for v := range listPages(ctx, "test", fetch) {
+		if v == 2 {
+			break
+		}
+	}
+	if !slices.Equal(fetched, []uint32{1}) {
+		t.Errorf("fetched pages after early stop %v, want [1]", fetched)
+	}
+
+	// An error is yielded once and ends the walk.
+	var errs int
+	for _, err := range listPages(ctx, "test", func(uint32) ([]int, bool, error) {
+		return nil, true, errors.New("boom")
+	}) {
+		if err != nil {
+			errs++
+		}
+	}
+	if errs != 1 {
+		t.Errorf("got %d errors, want 1", errs)
+	}
+}
+
+func TestPageToken(t *testing.T) {
+	for _, page := range []uint32{1, 2, 500, 4294967295} {
+		got, err := parsePageToken(pageToken(page))
+		if err != nil || got != page {
+			t.Errorf("parsePageToken(pageToken(%d)) = %d, %v", page, got, err)
+		}
+	}
+	if got, err := parsePageToken(""); err != nil || got != 1 {
+		t.Errorf("parsePageToken(\"\") = %d, %v; want 1", got, err)
+	}
+	for _, token := range []string{"2", "!!", pageToken(0)} {
+		if _, err := parsePageToken(token); err == nil {
+			t.Errorf("parsePageToken(%q) succeeded, want error", token)
+		}
+	}
+}
+
+func TestListPagesFrom(t *testing.T) {
+	ctx := context.Background()
+	data := []int{0, 1, 2, 3, 4, 5, 6}
+	const pageSize = 3
+	var fetched []uint32
+	fetch := func(page uint32) ([]int, bool, error) {
+		fetched = append(fetched, page)
+		start := min(int(page-1)*pageSize, len(data))
+		end := min(start+pageSize, len(data))
+		return data[start:end], end < len(data), nil
+	}
+
+	for offset, want := range map[int][]int{0: data, 2: data[2:], 3: data[3:], 7: nil} {
+		fetched = nil
+		got, _, err := collectPages(listPagesFrom(ctx, "ints", offset, pageSize, fetch), 100)
+		if err != nil || !slices.Equal(got, want) {
+			t.Errorf("offset %d: got %v, %v; want %v", offset, got, err, want)
+		}
+		if first := uint32(offset/pageSize) + 1; len(fetched) == 0 || fetched[0] != first {
+			t.Errorf("offset %d: fetched pages %v, want the walk to start at page %d", offset, fetched, first)
+		}
+	}
+
+	if got, err := parseOffsetToken(""); err != nil || got != 0 {
+		t.Errorf("parseOffsetToken(\"\") = %d, %v; want 0", got, err)
+	}
+	if got, err := parseOffsetToken(offsetToken(1000)); err != nil || got != 1000 {
+		t.Errorf("parseOffsetToken(offsetToken(1000)) = %d, %v", got, err)
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"iter"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// workshopMethods yields every RPC of the Workshop API version this provider
+// was built against, for reading the options Workshop annotates them with.
+func workshopMethods() iter.Seq[protoreflect.MethodDescriptor] {
+	return func(yield func(protoreflect.MethodDescriptor) bool) {
+		for _, f := range []protoreflect.FileDescriptor{
+			apipb.File_workshop_v1_api_proto,
+			apipb.File_workshop_v1_risk_engine_proto,
+			apipb.File_workshop_v1_santa_command_proto,
+		} {
+			services := f.Services()
+			for i := range services.Len() {
+				methods := services.Get(i).Methods()
+				for j := range methods.Len() {
+					if !yield(methods.Get(j)) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// methodPermission returns the permission annotation of m, or nil if it has
+// none. Its permission is the scope m requires; self_permission, if set, is a
+// narrower scope that allows m on the caller's own resources only.
+func methodPermission(m protoreflect.MethodDescriptor) *apipb.MethodPermission {
+	perm, _ := proto.GetExtension(m.Options(), apipb.E_Permission).(*apipb.MethodPermission)
+	return perm
+}
+
+// fullMethodName returns m's name as gRPC interceptors see it, e.g.
+// "/workshop.v1.WorkshopService/ListRules".
+func fullMethodName(m protoreflect.MethodDescriptor) string {
+	return "/" + string(m.Parent().FullName()) + "/" + string(m.Name())
+}
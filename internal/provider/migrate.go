@@ -0,0 +1,259 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// The formats -migrate-from reads.
+const (
+	migrateMoroz   = "moroz"
+	migrateZentral = "zentral"
+)
+
+// migratedRule is a rule read from another Santa sync server.
+type migratedRule struct {
+	RuleType   string
+	Identifier string
+	Policy     string
+	CustomMsg  string
+	CustomURL  string
+	CELExpr    string
+	Comment    string
+
+	// Notes on what couldn't be carried over, written above the rule.
+	Notes []string
+}
+
+// legacyPolicies maps the policy names older Santa sync servers use to the
+// current ones.
+var legacyPolicies = map[string]string{
+	"WHITELIST":          "ALLOWLIST",
+	"WHITELIST_COMPILER": "ALLOWLIST_COMPILER",
+	"BLACKLIST":          "BLOCKLIST",
+	"SILENT_BLACKLIST":   "SILENT_BLOCKLIST",
+}
+
+// MigrateRules reads the rules in the moroz or Zentral export at path and
+// writes an nps_workshop_rule resource on tag for each to w, for the
+// -migrate-from flag. Nothing is changed on a Workshop instance: the
+// configuration is applied, after review, with Terraform.
+func MigrateRules(w io.Writer, format, path, tag string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var rules []migratedRule
+	switch format {
+	case migrateMoroz:
+		rules, err = parseMorozRules(b)
+	case migrateZentral:
+		rules, err = parseZentralRules(b)
+	default:
+		return fmt.Errorf("unknown format %q, expected %s or %s", format, migrateMoroz, migrateZentral)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	fmt.Fprintf(w, "# Generated by terraform-provider-nps -migrate-from %s %s.\n", format, path)
+	return writeMigratedRules(w, rules, tag)
+}
+
+func writeMigratedRules(w io.Writer, rules []migratedRule, tag string) error {
+	used := map[string]bool{}
+	for i, r := range rules {
+		if p, ok := legacyPolicies[r.Policy]; ok {
+			r.Policy = p
+		}
+		if _, ok := rulePolicies[r.Policy]; !ok {
+			return fmt.Errorf("rule %d (%s): unknown policy %q", i+1, r.Identifier, r.Policy)
+		}
+		if v, ok := apipb.RuleType_value[r.RuleType]; !ok || v == int32(apipb.RuleType_RULETYPE_UNKNOWN) {
+			return fmt.Errorf("rule %d (%s): unknown rule type %q", i+1, r.Identifier, r.RuleType)
+		}
+		if r.Identifier == "" {
+			return fmt.Errorf("rule %d: missing identifier", i+1)
+		}
+
+		attrs := map[string]tftypes.Value{}
+		for k, v := range map[string]string{
+			"identifier": r.Identifier,
+			"rule_type":  r.RuleType,
+			"policy":     r.Policy,
+			"tag":        tag,
+			"custom_msg": r.CustomMsg,
+			"custom_url": r.CustomURL,
+			"cel_expr":   r.CELExpr,
+			"comment":    r.Comment,
+		} {
+			if v != "" {
+				attrs[k] = tftypes.NewValue(tftypes.String, v)
+			}
+		}
+
+		fmt.Fprintln(w)
+		for _, note := range r.Notes {
+			fmt.Fprintf(w, "# %s\n", note)
+		}
+		name := importResourceName([]string{r.RuleType, r.Identifier}, used)
+		if _, err := fmt.Fprintf(w, "resource \"nps_workshop_rule\" %q %s\n", name, hclObject(attrs, false, "")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseMorozRules reads the [[rules]] of a moroz configuration. moroz
+// configurations are TOML, but only use top-level keys and arrays of tables
+// of strings, numbers, and booleans, so that is all this reads. Older
+// configurations name the identifier sha256.
+func parseMorozRules(b []byte) ([]migratedRule, error) {
+	var rules []migratedRule
+	var current *migratedRule
+	for n, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			current = nil
+			if table := strings.TrimSpace(stripTOMLComment(line)); table == "[[rules]]" {
+				rules = append(rules, migratedRule{})
+				current = &rules[len(rules)-1]
+			}
+			continue
+		}
+
+		key, raw, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value", n+1)
+		}
+		if current == nil {
+			// Server settings such as client_mode have no rule to go on.
+			continue
+		}
+		value, err := parseTOMLValue(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+1, err)
+		}
+		switch strings.Trim(strings.TrimSpace(key), `"`) {
+		case "rule_type":
+			current.RuleType = strings.ToUpper(value)
+		case "policy":
+			current.Policy = strings.ToUpper(value)
+		case "identifier", "sha256":
+			current.Identifier = value
+		case "custom_msg":
+			current.CustomMsg = value
+		case "custom_url":
+			current.CustomURL = value
+		case "cel_expr":
+			current.CELExpr = value
+		}
+	}
+	return rules, nil
+}
+
+// parseTOMLValue parses a TOML string, number, or boolean, returning its text.
+func parseTOMLValue(raw string) (string, error) {
+	switch {
+	case strings.HasPrefix(raw, `"""`), strings.HasPrefix(raw, `'''`):
+		return "", fmt.Errorf("multi-line strings are not supported")
+	case strings.HasPrefix(raw, `"`):
+		for i := 1; i < len(raw); i++ {
+			switch raw[i] {
+			case '\\':
+				i++
+			case '"':
+				return strconv.Unquote(raw[:i+1])
+			}
+		}
+		return "", fmt.Errorf("unterminated string %s", raw)
+	case strings.HasPrefix(raw, "'"):
+		s, _, ok := strings.Cut(raw[1:], "'")
+		if !ok {
+			return "", fmt.Errorf("unterminated string %s", raw)
+		}
+		return s, nil
+	}
+	value := strings.TrimSpace(stripTOMLComment(raw))
+	if strings.HasPrefix(value, "[") || strings.HasPrefix(value, "{") {
+		return "", fmt.Errorf("arrays and inline tables are not supported")
+	}
+	return value, nil
+}
+
+func stripTOMLComment(s string) string {
+	s, _, _ = strings.Cut(s, "#")
+	return s
+}
+
+// zentralRule is a rule in a Zentral rule set, as exported by its API.
+type zentralRule struct {
+	RuleType      string   `json:"rule_type"`
+	Identifier    string   `json:"identifier"`
+	SHA256        string   `json:"sha256"`
+	Policy        string   `json:"policy"`
+	CustomMsg     string   `json:"custom_msg"`
+	CELExpr       string   `json:"cel_expr"`
+	Description   string   `json:"description"`
+	Tags          []string `json:"tags"`
+	ExcludedTags  []string `json:"excluded_tags"`
+	SerialNumbers []string `json:"serial_numbers"`
+	PrimaryUsers  []string `json:"primary_users"`
+}
+
+// parseZentralRules reads a Zentral rule set: an object with a rules array,
+// or the array alone. Zentral scopes rules to its own tags, serial numbers,
+// and users, which have no Workshop equivalent on a rule, so each scoped
+// rule is noted instead.
+func parseZentralRules(b []byte) ([]migratedRule, error) {
+	var set struct {
+		Rules []zentralRule `json:"rules"`
+	}
+	if err := json.Unmarshal(b, &set); err != nil {
+		if err := json.Unmarshal(b, &set.Rules); err != nil {
+			return nil, fmt.Errorf("expected a Zentral rule set: %w", err)
+		}
+	}
+
+	rules := make([]migratedRule, 0, len(set.Rules))
+	for _, z := range set.Rules {
+		r := migratedRule{
+			RuleType:   strings.ToUpper(z.RuleType),
+			Identifier: z.Identifier,
+			Policy:     strings.ToUpper(z.Policy),
+			CustomMsg:  z.CustomMsg,
+			CELExpr:    z.CELExpr,
+			Comment:    z.Description,
+		}
+		if r.Identifier == "" {
+			r.Identifier = z.SHA256
+		}
+		for _, scope := range []struct {
+			name   string
+			values []string
+		}{
+			{"tags", z.Tags},
+			{"excluded_tags", z.ExcludedTags},
+			{"serial_numbers", z.SerialNumbers},
+			{"primary_users", z.PrimaryUsers},
+		} {
+			if len(scope.values) > 0 {
+				r.Notes = append(r.Notes, fmt.Sprintf("Zentral %s not migrated: %s", scope.name, strings.Join(slices.Sorted(slices.Values(scope.values)), ", ")))
+			}
+		}
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseMorozRules(t *testing.T) {
+	rules, err := parseMorozRules([]byte(`
+client_mode = "MONITOR" # server settings are skipped
+batch_size = 100
+
+[[rules]]
+rule_type = "BINARY"
+policy = "BLACKLIST"
+sha256 = "2dc104631939b4bdf5d6bccab76e166e37fe5e1605340cf68dab919df58b8eda"
+custom_msg = "blocklist \"firefox\"" # trailing comment
+
+[[rules]]
+rule_type = 'TEAMID'
+policy = "ALLOWLIST"
+identifier = "EQHXZ8M8AV"
+`))
+	if err != nil {
+		t.Fatalf("parseMorozRules() = %v", err)
+	}
+	if len(rules) != 2 {
+		t.Fatalf("got %d rules, want 2: %+v", len(rules), rules)
+	}
+	if r := rules[0]; r.RuleType != "BINARY" || r.Policy != "BLACKLIST" || !strings.HasPrefix(r.Identifier, "2dc1") || r.CustomMsg != `blocklist "firefox"` {
+		t.Errorf("rules[0] = %+v", r)
+	}
+	if r := rules[1]; r.RuleType != "TEAMID" || r.Identifier != "EQHXZ8M8AV" {
+		t.Errorf("rules[1] = %+v", r)
+	}
+
+	if _, err := parseMorozRules([]byte("[[rules]]\npolicy = [\"ALLOWLIST\"]\n")); err == nil {
+		t.Error("parseMorozRules() accepted an array")
+	}
+}
+
+func TestParseZentralRules(t *testing.T) {
+	for _, in := range []string{
+		`{"name": "default", "rules": [{"rule_type": "SIGNINGID", "identifier": "platform:com.apple.curl", "policy": "BLOCKLIST", "description": "no curl", "serial_numbers": ["C02B", "A01X"]}]}`,
+		`[{"rule_type": "SIGNINGID", "identifier": "platform:com.apple.curl", "policy": "BLOCKLIST", "description": "no curl", "serial_numbers": ["C02B", "A01X"]}]`,
+	} {
+		rules, err := parseZentralRules([]byte(in))
+		if err != nil {
+			t.Fatalf("parseZentralRules() = %v", err)
+		}
+		if len(rules) != 1 || rules[0].Comment != "no curl" || len(rules[0].Notes) != 1 || rules[0].Notes[0] != "Zentral serial_numbers not migrated: A01X, C02B" {
+			t.Errorf("rules = %+v", rules)
+		}
+	}
+}
+
+func TestWriteMigratedRules(t *testing.T) {
+	var b strings.Builder
+	err := writeMigratedRules(&b, []migratedRule{{
+		RuleType:   "BINARY",
+		Identifier: "2dc104631939b4bdf5d6bccab76e166e37fe5e1605340cf68dab919df58b8eda",
+		Policy:     "SILENT_BLACKLIST",
+		Notes:      []string{"Zentral tags not migrated: dev"},
+	}}, "global")
+	if err != nil {
+		t.Fatalf("writeMigratedRules() = %v", err)
+	}
+	want := `
+# Zentral tags not migrated: dev
+resource "nps_workshop_rule" "binary_2dc104631939b4bdf5d6bccab76e166e37fe5e1605340cf68dab919df58b8eda" {
+  identifier = "2dc104631939b4bdf5d6bccab76e166e37fe5e1605340cf68dab919df58b8eda"
+  policy     = "SILENT_BLOCKLIST"
+  rule_type  = "BINARY"
+  tag        = "global"
+}
+`
+	if b.String() != want {
+		t.Errorf("writeMigratedRules() =\n%s\nwant\n%s", b.String(), want)
+	}
+
+	for _, bad := range []migratedRule{
+		{RuleType: "BINARY", Identifier: "abc", Policy: "GRAYLIST"},
+		{RuleType: "PATH", Identifier: "abc", Policy: "ALLOWLIST"},
+		{RuleType: "BINARY", Policy: "ALLOWLIST"},
+	} {
+		if err := writeMigratedRules(&b, []migratedRule{bad}, "global"); err == nil {
+			t.Errorf("writeMigratedRules(%+v) succeeded", bad)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// enumValidator is implemented by validators that restrict an attribute, or
+// its elements, to a fixed set of values, so the schema export can list them
+// as enum_values; the validators library doesn't expose them.
+type enumValidator interface {
+	allowedValues() []string
+}
+
+// oneOfValidator is stringvalidator.OneOf with its values kept.
+type oneOfValidator struct {
+	validator.String
+	values []string
+}
+
+func (v oneOfValidator) allowedValues() []string { return v.values }
+
+// oneOf returns a validator that a string is one of values. Use it rather than
+// stringvalidator.OneOf so -schema-json exports the values.
+func oneOf(values ...string) validator.String {
+	return oneOfValidator{String: stringvalidator.OneOf(values...), values: values}
+}
+
+// listOneOfValidator is listvalidator.ValueStringsAre(oneOf(...)) with its
+// values kept.
+type listOneOfValidator struct {
+	validator.List
+	values []string
+}
+
+func (v listOneOfValidator) allowedValues() []string { return v.values }
+
+// listValuesOneOf returns a validator that every element of a list of strings
+// is one of values. Use it rather than wrapping oneOf in
+// listvalidator.ValueStringsAre so -schema-json exports the values.
+func listValuesOneOf(values ...string) validator.List {
+	return listOneOfValidator{List: listvalidator.ValueStringsAre(oneOf(values...)), values: values}
+}
+
+// setOneOfValidator is setvalidator.ValueStringsAre(oneOf(...)) with its
+// values kept.
+type setOneOfValidator struct {
+	validator.Set
+	values []string
+}
+
+func (v setOneOfValidator) allowedValues() []string { return v.values }
+
+// setValuesOneOf returns a validator that every element of a set of strings is
+// one of values. Use it rather than wrapping oneOf in
+// setvalidator.ValueStringsAre so -schema-json exports the values.
+func setValuesOneOf(values ...string) validator.Set {
+	return setOneOfValidator{Set: setvalidator.ValueStringsAre(oneOf(values...)), values: values}
+}
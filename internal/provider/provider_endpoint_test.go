@@ -1,7 +1,11 @@
 // Copyright 2026 North Pole Security, Inc.
 package provider
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
 
 func TestResolveEndpointPrecedence(t *testing.T) {
 	t.Setenv("WORKSHOP_ENDPOINT", "workshop.example")
@@ -37,3 +41,60 @@ func TestResolveEndpointMissing(t *testing.T) {
 		t.Fatalf("resolveEndpoint(missing) = %q, %v", endpoint, deprecated)
 	}
 }
+
+func TestApplyEnvDefaults(t *testing.T) {
+	t.Setenv("WORKSHOP_TRANSPORT", "connect")
+	t.Setenv("WORKSHOP_STRICT_READ", "true")
+	t.Setenv("WORKSHOP_VALIDATE_CONNECTION", "1")
+	t.Setenv("WORKSHOP_TAG_ORDER_MAX_SIZE", "50")
+	t.Setenv("WORKSHOP_DEFAULT_TAG", "env-tag")
+	t.Setenv("WORKSHOP_MINIMUM_SERVER_VERSION", "")
+
+	data := NPSProviderModel{
+		DefaultTag:           types.StringValue("configured-tag"),
+		MinimumServerVersion: types.StringNull(),
+		Transport:            types.StringNull(),
+		StrictRead:           types.BoolNull(),
+		AcceptNewEnumValues:  types.BoolNull(),
+		ValidateConnection:   types.BoolNull(),
+		TagOrderMaxSize:      types.Int64Null(),
+	}
+	if diags := applyEnvDefaults(&data); diags.HasError() {
+		t.Fatalf("applyEnvDefaults() = %v", diags)
+	}
+
+	// Configuration wins over the environment.
+	if got := data.DefaultTag.ValueString(); got != "configured-tag" {
+		t.Errorf("default_tag = %q, want configured-tag", got)
+	}
+	if got := data.Transport.ValueString(); got != "connect" {
+		t.Errorf("transport = %q, want connect", got)
+	}
+	if !data.StrictRead.ValueBool() || !data.ValidateConnection.ValueBool() {
+		t.Errorf("strict_read = %v, validate_connection = %v, want true", data.StrictRead, data.ValidateConnection)
+	}
+	if got := data.TagOrderMaxSize.ValueInt64(); got != 50 {
+		t.Errorf("tag_order_max_size = %d, want 50", got)
+	}
+	// Empty or missing variables leave attributes unset.
+	if !data.MinimumServerVersion.IsNull() || !data.AcceptNewEnumValues.IsNull() {
+		t.Errorf("minimum_server_version = %v, accept_new_enum_values = %v, want null", data.MinimumServerVersion, data.AcceptNewEnumValues)
+	}
+}
+
+func TestApplyEnvDefaultsInvalid(t *testing.T) {
+	for name, value := range map[string]string{
+		"WORKSHOP_TRANSPORT":          "http",
+		"WORKSHOP_STRICT_READ":        "maybe",
+		"WORKSHOP_TAG_ORDER_MAX_SIZE": "0",
+		"WORKSHOP_AUTH":               "oidc",
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(name, value)
+			data := NPSProviderModel{}
+			if diags := applyEnvDefaults(&data); !diags.HasError() {
+				t.Errorf("%s=%s was accepted", name, value)
+			}
+		})
+	}
+}
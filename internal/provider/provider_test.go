@@ -2,10 +2,17 @@
 package provider
 
 import (
+	"fmt"
+	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	tfresource "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/northpolesec/terraform-provider-nps/internal/testserver"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -16,8 +23,50 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 	"nps": providerserver.NewProtocol6WithError(New("test")()),
 }
 
+// testAccServer is the in-memory Workshop the running acceptance test is
+// using, or nil if it is using a live Workshop.
+var testAccServer *testserver.Server
+
 func testAccPreCheck(t *testing.T) {
-	// You can add code here to run prior to any test case execution, for example assertions
-	// about the appropriate environment variables being set are common to see in a pre-check
-	// function.
+	// With NPS_ACC_TESTSERVER set, acceptance tests run against an
+	// in-memory Workshop on the endpoint they configure, localhost:8080,
+	// instead of a live instance. Each test gets an empty server.
+	if os.Getenv("NPS_ACC_TESTSERVER") == "" {
+		return
+	}
+	testAccServer = testserver.New()
+	stop, err := testAccServer.Start("localhost:8080")
+	if err != nil {
+		t.Fatalf("starting the test server: %v", err)
+	}
+	t.Cleanup(func() {
+		stop()
+		testAccServer = nil
+	})
+	// The server accepts any credentials, but the provider needs some.
+	t.Setenv("WORKSHOP_API_KEY", "test")
+}
+
+// testAccCheckServer runs check against the in-memory Workshop, to assert
+// what a configuration left on the server. Against a live Workshop it does
+// nothing.
+func testAccCheckServer(check func(s *testserver.Server) error) tfresource.TestCheckFunc {
+	return func(*terraform.State) error {
+		if testAccServer == nil {
+			return nil
+		}
+		return check(testAccServer)
+	}
+}
+
+// testAccCheckServerTag checks that the tag exists on the in-memory Workshop.
+func testAccCheckServerTag(tag string) tfresource.TestCheckFunc {
+	return testAccCheckServer(func(s *testserver.Server) error {
+		for _, t := range s.Tags() {
+			if t == tag {
+				return nil
+			}
+		}
+		return fmt.Errorf("tag %q not found on the server, which has %v", tag, s.Tags())
+	})
 }
@@ -0,0 +1,130 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// parseProxyURL validates the provider's proxy_url. Only HTTP proxies, reached
+// over plain HTTP or TLS, are supported: gRPC tunnels through them with
+// CONNECT.
+func parseProxyURL(s string) (*url.URL, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("scheme must be http or https, got %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("%q has no host", s)
+	}
+	return u, nil
+}
+
+// proxyFunc chooses the proxy, if any, for a request to target. With proxyURL
+// empty it follows HTTPS_PROXY, HTTP_PROXY, and NO_PROXY like
+// http.ProxyFromEnvironment; otherwise every host NO_PROXY doesn't exclude
+// goes through proxyURL.
+func proxyFunc(proxyURL string) func(target *url.URL) (*url.URL, error) {
+	if proxyURL == "" {
+		return httpproxy.FromEnvironment().ProxyFunc()
+	}
+	noProxy := os.Getenv("NO_PROXY")
+	if noProxy == "" {
+		noProxy = os.Getenv("no_proxy")
+	}
+	return (&httpproxy.Config{
+		HTTPProxy:  proxyURL,
+		HTTPSProxy: proxyURL,
+		NoProxy:    noProxy,
+	}).ProxyFunc()
+}
+
+// proxyDialer returns a gRPC dialer that reaches addr through the proxy that
+// proxy chooses for scheme://addr, tunnelling with an HTTP CONNECT request,
+// or directly if it chooses none. addr must be a host name rather than a
+// resolved address so that NO_PROXY matches and the proxy resolves it.
+func proxyDialer(scheme string, proxy func(*url.URL) (*url.URL, error)) func(ctx context.Context, addr string) (net.Conn, error) {
+	return func(ctx context.Context, addr string) (net.Conn, error) {
+		var d net.Dialer
+		p, err := proxy(&url.URL{Scheme: scheme, Host: addr})
+		if err != nil {
+			return nil, err
+		}
+		if p == nil {
+			return d.DialContext(ctx, "tcp", addr)
+		}
+
+		proxyAddr := p.Host
+		if p.Port() == "" {
+			port := "80"
+			if p.Scheme == "https" {
+				port = "443"
+			}
+			proxyAddr = net.JoinHostPort(p.Hostname(), port)
+		}
+		conn, err := d.DialContext(ctx, "tcp", proxyAddr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to proxy %s: %w", p.Redacted(), err)
+		}
+		if p.Scheme == "https" {
+			conn = tls.Client(conn, &tls.Config{ServerName: p.Hostname()})
+		}
+		if err := proxyConnect(ctx, conn, p, addr); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("proxy %s: %w", p.Redacted(), err)
+		}
+		return conn, nil
+	}
+}
+
+// proxyConnect asks the proxy at the other end of conn to tunnel to addr.
+func proxyConnect(ctx context.Context, conn net.Conn, p *url.URL, addr string) error {
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+		defer conn.SetDeadline(time.Time{})
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: http.Header{},
+	}
+	if u := p.User; u != nil {
+		password, _ := u.Password()
+		req.Header.Set("Proxy-Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(u.Username()+":"+password)))
+	}
+	if err := req.Write(conn); err != nil {
+		return err
+	}
+
+	br := bufio.NewReader(conn)
+	// The tunnel follows the response, so its body is left unread, as
+	// net/http's transport does.
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("CONNECT %s: %s", addr, resp.Status)
+	}
+	// Neither TLS nor HTTP/2 servers speak first, so anything buffered here is
+	// unexpected and would be lost.
+	if br.Buffered() > 0 {
+		return fmt.Errorf("CONNECT %s: unexpected data after the response", addr)
+	}
+	return nil
+}
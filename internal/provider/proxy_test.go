@@ -0,0 +1,155 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// connectProxy is an HTTP proxy that tunnels every CONNECT to backend,
+// whatever host it names, and records the requests it saw.
+func connectProxy(t *testing.T, backend string, status int) (*httptest.Server, *[]*http.Request) {
+	t.Helper()
+	var reqs []*http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqs = append(reqs, r)
+		if r.Method != http.MethodConnect || status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		upstream, err := net.Dial("tcp", backend)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Errorf("Hijack() error: %v", err)
+			return
+		}
+		go func() {
+			io.Copy(upstream, conn)
+			upstream.Close()
+		}()
+		io.Copy(conn, upstream)
+		conn.Close()
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &reqs
+}
+
+// echoServer accepts one connection and echoes what it reads.
+func echoServer(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		io.Copy(conn, conn)
+		conn.Close()
+	}()
+	return l.Addr().String()
+}
+
+func TestProxyDialer(t *testing.T) {
+	t.Setenv("NO_PROXY", "")
+	t.Setenv("no_proxy", "")
+	proxy, reqs := connectProxy(t, echoServer(t), http.StatusOK)
+	proxyURL := strings.Replace(proxy.URL, "http://", "http://alice:s3cret@", 1)
+
+	dial := proxyDialer("https", proxyFunc(proxyURL))
+	conn, err := dial(context.Background(), "workshop.example.com:443")
+	if err != nil {
+		t.Fatalf("dial error: %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := io.WriteString(conn, "ping"); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 4)
+	if _, err := io.ReadFull(conn, buf); err != nil || string(buf) != "ping" {
+		t.Fatalf("read %q, %v through the tunnel, want ping", buf, err)
+	}
+
+	if len(*reqs) != 1 {
+		t.Fatalf("proxy saw %d requests, want 1", len(*reqs))
+	}
+	r := (*reqs)[0]
+	if r.Method != http.MethodConnect || r.Host != "workshop.example.com:443" {
+		t.Errorf("proxy saw %s %s, want CONNECT workshop.example.com:443", r.Method, r.Host)
+	}
+	// alice:s3cret
+	if got := r.Header.Get("Proxy-Authorization"); got != "Basic YWxpY2U6czNjcmV0" {
+		t.Errorf("Proxy-Authorization = %q", got)
+	}
+}
+
+func TestProxyDialerRefused(t *testing.T) {
+	t.Setenv("NO_PROXY", "")
+	t.Setenv("no_proxy", "")
+	proxy, _ := connectProxy(t, "", http.StatusProxyAuthRequired)
+
+	_, err := proxyDialer("https", proxyFunc(proxy.URL))(context.Background(), "workshop.example.com:443")
+	if err == nil || !strings.Contains(err.Error(), "407") {
+		t.Errorf("dial error = %v, want the proxy's 407", err)
+	}
+}
+
+func TestProxyFunc(t *testing.T) {
+	t.Setenv("HTTPS_PROXY", "http://env-proxy:3128")
+	t.Setenv("NO_PROXY", "internal.example.com")
+	t.Setenv("https_proxy", "")
+	t.Setenv("no_proxy", "")
+
+	tests := []struct {
+		name, proxyURL, host, want string
+	}{
+		{"environment", "", "workshop.example.com:443", "http://env-proxy:3128"},
+		{"environment NO_PROXY", "", "internal.example.com:443", ""},
+		{"explicit", "http://explicit:8080", "workshop.example.com:443", "http://explicit:8080"},
+		{"explicit honors NO_PROXY", "http://explicit:8080", "internal.example.com:443", ""},
+		{"loopback is never proxied", "http://explicit:8080", "localhost:8080", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := proxyFunc(tt.proxyURL)(&url.URL{Scheme: "https", Host: tt.host})
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotURL := ""
+			if got != nil {
+				gotURL = got.String()
+			}
+			if gotURL != tt.want {
+				t.Errorf("proxy = %q, want %q", gotURL, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseProxyURL(t *testing.T) {
+	for _, s := range []string{"http://proxy:3128", "https://user:pw@proxy.example.com"} {
+		if _, err := parseProxyURL(s); err != nil {
+			t.Errorf("parseProxyURL(%q) error: %v", s, err)
+		}
+	}
+	for _, s := range []string{"proxy:3128", "socks5://proxy:1080", "http://", "://bad"} {
+		if _, err := parseProxyURL(s); err == nil {
+			t.Errorf("parseProxyURL(%q) accepted an invalid proxy", s)
+		}
+	}
+}
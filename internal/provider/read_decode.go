@@ -0,0 +1,89 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// readDecoder maps API values onto Terraform values during Read and List.
+//
+// A newer Workshop server can return values this build of the provider does
+// not know about (e.g. an enum number added after the provider's protos were
+// generated), and a misbehaving server can return malformed timestamps.
+// Rather than panicking or storing the raw enum number in state, the decoder
+// reports the value: in strict mode as an error, otherwise as a warning while
+// keeping the prior state value so that refresh does not fail outright.
+type readDecoder struct {
+	strict bool
+	diags  *diag.Diagnostics
+
+	// acceptNewEnums keeps enum values the generated protos know but
+	// pinnedEnums doesn't, rather than reporting them.
+	acceptNewEnums bool
+}
+
+func newReadDecoder(strict bool, diags *diag.Diagnostics) readDecoder {
+	return readDecoder{strict: strict, diags: diags}
+}
+
+// withNewEnumValues returns a copy of d that accepts enum values missing from
+// pinnedEnums when accept is true.
+func (d readDecoder) withNewEnumValues(accept bool) readDecoder {
+	d.acceptNewEnums = accept
+	return d
+}
+
+// enum decodes v into its enum value name. An unrecognized enum number is
+// reported and prior is returned instead.
+func (d readDecoder) enum(attr path.Path, v protoreflect.Enum, prior types.String) types.String {
+	desc := v.Descriptor().Values().ByNumber(v.Number())
+	if desc == nil {
+		d.report(attr, fmt.Sprintf("The Workshop API returned an unrecognized %s value (%d).", v.Descriptor().Name(), v.Number()))
+		return prior
+	}
+	if !d.acceptNewEnums && !isPinnedEnumValue(v.Descriptor(), string(desc.Name())) {
+		d.report(attr, fmt.Sprintf("The Workshop API returned a %s value (%s) that this provider version does not support. Set accept_new_enum_values to store it anyway.", v.Descriptor().Name(), desc.Name()))
+		return prior
+	}
+	return types.StringValue(string(desc.Name()))
+}
+
+// mapped reports v when it has no Terraform representation, for attributes
+// whose values do not match the enum value names. name is the looked-up
+// Terraform value, empty when v is missing from the attribute's mapping
+// (including the UNSPECIFIED value); prior is returned in that case.
+func (d readDecoder) mapped(attr path.Path, v protoreflect.Enum, name string, prior types.String) types.String {
+	if name != "" {
+		return types.StringValue(name)
+	}
+	d.report(attr, fmt.Sprintf("The Workshop API returned a %s value (%d) that cannot be represented by this provider.", v.Descriptor().Name(), v.Number()))
+	return prior
+}
+
+// timestamp decodes ts as an RFC3339 string. A nil timestamp is null; an
+// out-of-range timestamp is reported and prior is returned instead.
+func (d readDecoder) timestamp(attr path.Path, ts *timestamppb.Timestamp, prior types.String) types.String {
+	if ts == nil {
+		return types.StringNull()
+	}
+	if err := ts.CheckValid(); err != nil {
+		d.report(attr, fmt.Sprintf("The Workshop API returned a malformed timestamp: %v.", err))
+		return prior
+	}
+	return types.StringValue(ts.AsTime().Format(time.RFC3339))
+}
+
+func (d readDecoder) report(attr path.Path, detail string) {
+	if d.strict {
+		d.diags.AddAttributeError(attr, codeUnexpectedResponse.summary("Unexpected API response"), detail+" Upgrade the provider, or unset strict_read to keep the prior value.")
+		return
+	}
+	d.diags.AddAttributeWarning(attr, codeUnexpectedResponse.summary("Unexpected API response"), detail+" The prior value has been kept; upgrade the provider to resolve this warning.")
+}
@@ -0,0 +1,424 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/northpolesec/terraform-provider-nps/internal/utils"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+func TestReadDecoderEnum(t *testing.T) {
+	prior := types.StringValue("BLOCKLIST")
+	cases := []struct {
+		name        string
+		strict      bool
+		v           apipb.Policy
+		want        types.String
+		wantError   bool
+		wantWarning bool
+	}{
+		{"known", false, apipb.Policy_ALLOWLIST, types.StringValue("ALLOWLIST"), false, false},
+		{"known strict", true, apipb.Policy_ALLOWLIST, types.StringValue("ALLOWLIST"), false, false},
+		{"unknown lenient keeps prior", false, apipb.Policy(9999), prior, false, true},
+		{"unknown strict errors", true, apipb.Policy(9999), prior, true, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			got := newReadDecoder(c.strict, &diags).enum(path.Root("policy"), c.v, prior)
+			if !got.Equal(c.want) {
+				t.Errorf("enum() = %v, want %v", got, c.want)
+			}
+			if diags.HasError() != c.wantError {
+				t.Errorf("HasError() = %v, want %v (%v)", diags.HasError(), c.wantError, diags)
+			}
+			if (diags.WarningsCount() > 0) != c.wantWarning {
+				t.Errorf("WarningsCount() = %d, wantWarning %v", diags.WarningsCount(), c.wantWarning)
+			}
+		})
+	}
+}
+
+func TestReadDecoderMapped(t *testing.T) {
+	prior := types.StringValue("PathsWithAllowedProcesses")
+
+	var diags diag.Diagnostics
+	dec := newReadDecoder(true, &diags)
+	rt := apipb.FileAccessRuleType_FILE_ACCESS_RULE_TYPE_PROCESSES_WITH_DENIED_PATHS
+	if got := dec.mapped(path.Root("rule_type"), rt, fileAccessRuleTypeToFriendly[rt], prior); got.ValueString() != "ProcessesWithDeniedPaths" {
+		t.Errorf("mapped() = %v, want ProcessesWithDeniedPaths", got)
+	}
+	if diags.HasError() {
+		t.Fatalf("unexpected diags: %v", diags)
+	}
+
+	rt = apipb.FileAccessRuleType_FILE_ACCESS_RULE_TYPE_UNSPECIFIED
+	if got := dec.mapped(path.Root("rule_type"), rt, fileAccessRuleTypeToFriendly[rt], prior); !got.Equal(prior) {
+		t.Errorf("mapped(UNSPECIFIED) = %v, want prior %v", got, prior)
+	}
+	if !diags.HasError() {
+		t.Error("expected an error for an unmapped value in strict mode")
+	}
+}
+
+func TestReadDecoderTimestamp(t *testing.T) {
+	prior := types.StringValue("2024-01-01T00:00:00Z")
+
+	var diags diag.Diagnostics
+	dec := newReadDecoder(false, &diags)
+
+	if got := dec.timestamp(path.Root("min_date"), nil, prior); !got.IsNull() {
+		t.Errorf("timestamp(nil) = %v, want null", got)
+	}
+	if got := dec.timestamp(path.Root("min_date"), &timestamppb.Timestamp{Seconds: 1735689600}, prior); got.ValueString() != "2025-01-01T00:00:00Z" {
+		t.Errorf("timestamp(valid) = %v", got)
+	}
+	if diags.WarningsCount() != 0 {
+		t.Fatalf("unexpected diags: %v", diags)
+	}
+
+	// Nanos out of range and seconds past year 9999 are both invalid.
+	for _, ts := range []*timestamppb.Timestamp{{Nanos: -1}, {Seconds: 1 << 40}} {
+		if got := dec.timestamp(path.Root("min_date"), ts, prior); !got.Equal(prior) {
+			t.Errorf("timestamp(%v) = %v, want prior", ts, got)
+		}
+	}
+	if diags.WarningsCount() != 2 || diags.HasError() {
+		t.Errorf("expected two warnings in lenient mode, got %v", diags)
+	}
+}
+
+// callRuleRead drives RuleResource.Read from the given prior state, wiring the
+// State/Identity the framework would normally pre-populate.
+func callRuleRead(t *testing.T, r *RuleResource, prior RuleResourceModel) *resource.ReadResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	var sResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &sResp)
+	var iResp resource.IdentitySchemaResponse
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &iResp)
+
+	req := resource.ReadRequest{State: tfsdk.State{Schema: sResp.Schema}}
+	if diags := req.State.Set(ctx, prior); diags.HasError() {
+		t.Fatalf("failed to build state: %v", diags)
+	}
+	resp := &resource.ReadResponse{
+		State:    req.State,
+		Identity: &tfsdk.ResourceIdentity{Schema: iResp.IdentitySchema},
+	}
+	r.Read(ctx, req, resp)
+	return resp
+}
+
+func testRulePriorState() RuleResourceModel {
+	return RuleResourceModel{
+		Id:          types.StringValue("rule-1"),
+		Identifier:  types.StringValue("abc"),
+		RuleType:    types.StringValue("BINARY"),
+		Policy:      types.StringValue("BLOCKLIST"),
+		BlockReason: types.StringValue("BLOCK_REASON_POLICY"),
+		Tag:         types.StringValue("global"),
+	}
+}
+
+func TestRuleReadUnknownPolicy(t *testing.T) {
+	rule := apipb.Rule_builder{
+		RuleId:     "rule-1",
+		Identifier: "abc",
+		RuleType:   apipb.RuleType_BINARY,
+		Policy:     apipb.Policy(9999),
+		Tag:        "global",
+	}.Build()
+
+	t.Run("lenient", func(t *testing.T) {
+		r := &RuleResource{client: &fakeWorkshopClient{listRules: []*apipb.Rule{rule}}}
+		resp := callRuleRead(t, r, testRulePriorState())
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("lenient read should not error: %v", resp.Diagnostics)
+		}
+		if resp.Diagnostics.WarningsCount() != 1 {
+			t.Errorf("expected one warning, got %v", resp.Diagnostics)
+		}
+		var got RuleResourceModel
+		resp.State.Get(context.Background(), &got)
+		if got.Policy.ValueString() != "BLOCKLIST" {
+			t.Errorf("policy = %v, want prior BLOCKLIST", got.Policy)
+		}
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		r := &RuleResource{client: &fakeWorkshopClient{listRules: []*apipb.Rule{rule}}, strictRead: true}
+		resp := callRuleRead(t, r, testRulePriorState())
+		if !resp.Diagnostics.HasError() {
+			t.Fatal("strict read should error on an unknown policy")
+		}
+	})
+}
+
+func TestRuleReadUnspecifiedBlockReason(t *testing.T) {
+	for _, c := range []struct {
+		policy apipb.Policy
+		want   types.String
+	}{
+		{apipb.Policy_BLOCKLIST, types.StringValue("BLOCK_REASON_POLICY")},
+		{apipb.Policy_SILENT_GUI_BLOCKLIST, types.StringValue("BLOCK_REASON_POLICY")},
+		{apipb.Policy_ALLOWLIST, types.StringNull()},
+	} {
+		t.Run(c.policy.String(), func(t *testing.T) {
+			rule := apipb.Rule_builder{
+				RuleId:     "rule-1",
+				Identifier: "abc",
+				RuleType:   apipb.RuleType_BINARY,
+				Policy:     c.policy,
+				Tag:        "global",
+			}.Build()
+			r := &RuleResource{client: &fakeWorkshopClient{listRules: []*apipb.Rule{rule}}}
+			resp := callRuleRead(t, r, testRulePriorState())
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("read failed: %v", resp.Diagnostics)
+			}
+			var got RuleResourceModel
+			resp.State.Get(context.Background(), &got)
+			if !got.BlockReason.Equal(c.want) {
+				t.Errorf("block_reason = %v, want %v", got.BlockReason, c.want)
+			}
+		})
+	}
+}
+
+// FuzzRuleRead feeds arbitrary enum numbers through RuleResource.Read and
+// checks it never panics and never stores a value the schema would reject.
+func FuzzRuleRead(f *testing.F) {
+	f.Add(int32(apipb.RuleType_BINARY), int32(apipb.Policy_BLOCKLIST), int32(apipb.Rule_BLOCK_REASON_MALICIOUS), false)
+	f.Add(int32(-1), int32(9999), int32(42), false)
+	f.Add(int32(0), int32(0), int32(0), true)
+
+	ruleTypes := utils.ProtoEnumToList(apipb.RuleType(0).Descriptor())
+	policies := utils.ProtoEnumToList(apipb.Policy(0).Descriptor())
+	blockReasons := utils.ProtoEnumToList(apipb.Rule_BlockReason(0).Descriptor())
+
+	f.Fuzz(func(t *testing.T, ruleType, policy, blockReason int32, strict bool) {
+		rule := apipb.Rule_builder{
+			RuleId:      "rule-1",
+			Identifier:  "abc",
+			RuleType:    apipb.RuleType(ruleType),
+			Policy:      apipb.Policy(policy),
+			BlockReason: apipb.Rule_BlockReason(blockReason),
+			Tag:         "global",
+		}.Build()
+		r := &RuleResource{client: &fakeWorkshopClient{listRules: []*apipb.Rule{rule}}, strictRead: strict}
+
+		resp := callRuleRead(t, r, testRulePriorState())
+		if resp.Diagnostics.HasError() {
+			if !strict {
+				t.Fatalf("lenient read errored: %v", resp.Diagnostics)
+			}
+			return
+		}
+
+		var got RuleResourceModel
+		if diags := resp.State.Get(context.Background(), &got); diags.HasError() {
+			t.Fatalf("failed to decode state: %v", diags)
+		}
+		if !slices.Contains(ruleTypes, got.RuleType.ValueString()) {
+			t.Errorf("rule_type = %q is not a known value", got.RuleType.ValueString())
+		}
+		if !slices.Contains(policies, got.Policy.ValueString()) {
+			t.Errorf("policy = %q is not a known value", got.Policy.ValueString())
+		}
+		if !got.BlockReason.IsNull() && !slices.Contains(blockReasons, got.BlockReason.ValueString()) {
+			t.Errorf("block_reason = %q is not a known value", got.BlockReason.ValueString())
+		}
+	})
+}
+
+func TestPackageRuleReadMalformedTimestamp(t *testing.T) {
+	ctx := context.Background()
+	rule := apipb.PackageRule_builder{
+		RuleId:   7,
+		Tag:      "global",
+		Source:   apipb.PackageSource_PACKAGE_SOURCE_HOMEBREW,
+		Name:     "wget",
+		Policy:   apipb.Policy_ALLOWLIST,
+		RuleType: apipb.RuleType_BINARY,
+		MinDate:  &timestamppb.Timestamp{Seconds: 1 << 40},
+	}.Build()
+	r := &PackageRuleResource{client: &fakeWorkshopClient{listPackageRules: []*apipb.PackageRule{rule}}}
+
+	var sResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &sResp)
+	var iResp resource.IdentitySchemaResponse
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &iResp)
+
+	req := resource.ReadRequest{State: tfsdk.State{Schema: sResp.Schema}}
+	req.State.Set(ctx, PackageRuleResourceModel{
+		Id:       types.Int64Value(7),
+		Tag:      types.StringValue("global"),
+		Source:   types.StringValue("PACKAGE_SOURCE_HOMEBREW"),
+		Name:     types.StringValue("wget"),
+		Policy:   types.StringValue("ALLOWLIST"),
+		RuleType: types.StringValue("BINARY"),
+		MinDate:  types.StringValue("2024-01-01T00:00:00Z"),
+		Versions: types.ListNull(types.StringType),
+
+		GeneratedIdentifiers: types.ListNull(types.StringType),
+	})
+	resp := &resource.ReadResponse{
+		State:    req.State,
+		Identity: &tfsdk.ResourceIdentity{Schema: iResp.IdentitySchema},
+	}
+	r.Read(ctx, req, resp)
+
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected a single warning, got %v", resp.Diagnostics)
+	}
+	var got PackageRuleResourceModel
+	resp.State.Get(ctx, &got)
+	if got.MinDate.ValueString() != "2024-01-01T00:00:00Z" {
+		t.Errorf("min_date = %v, want prior value", got.MinDate)
+	}
+}
+
+func TestFileAccessRuleReadLists(t *testing.T) {
+	ctx := context.Background()
+	rule := apipb.FileAccessRule_builder{
+		RuleId:       9,
+		Tag:          "global",
+		Name:         "ssh",
+		RuleType:     apipb.FileAccessRuleType_FILE_ACCESS_RULE_TYPE_PATHS_WITH_ALLOWED_PROCESSES,
+		PathLiterals: []string{"/a", "/b"},
+	}.Build()
+
+	for _, c := range []struct {
+		preserveOrder bool
+		want          []string
+	}{
+		{false, []string{"/a", "/b"}},
+		{true, []string{"/b", "/a"}},
+	} {
+		t.Run(fmt.Sprintf("preserve_order=%v", c.preserveOrder), func(t *testing.T) {
+			r := &FileAccessRuleResource{client: &fakeWorkshopClient{listFileAccessRules: []*apipb.FileAccessRule{rule}}}
+
+			var sResp resource.SchemaResponse
+			r.Schema(ctx, resource.SchemaRequest{}, &sResp)
+			var iResp resource.IdentitySchemaResponse
+			r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &iResp)
+
+			req := resource.ReadRequest{State: tfsdk.State{Schema: sResp.Schema}}
+			req.State.Set(ctx, FileAccessRuleResourceModel{
+				Id:                        types.Int64Value(9),
+				Tag:                       types.StringValue("global"),
+				Name:                      types.StringValue("ssh"),
+				RuleType:                  types.StringValue("PathsWithAllowedProcesses"),
+				PathLiterals:              types.ListValueMust(types.StringType, []attr.Value{types.StringValue("/b"), types.StringValue("/a")}),
+				PathPrefixes:              types.ListNull(types.StringType),
+				ProcessBinaryPaths:        types.ListNull(types.StringType),
+				ProcessCdHashes:           types.ListNull(types.StringType),
+				ProcessSigningIds:         types.ListNull(types.StringType),
+				ProcessCertificateSha256s: types.ListNull(types.StringType),
+				ProcessTeamIds:            types.ListValueMust(types.StringType, []attr.Value{types.StringValue("EQHXZ8M8AV")}),
+				PreserveOrder:             types.BoolValue(c.preserveOrder),
+			})
+			resp := &resource.ReadResponse{
+				State:    req.State,
+				Identity: &tfsdk.ResourceIdentity{Schema: iResp.IdentitySchema},
+			}
+			r.Read(ctx, req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("read failed: %v", resp.Diagnostics)
+			}
+
+			var got FileAccessRuleResourceModel
+			resp.State.Get(ctx, &got)
+			var paths []string
+			got.PathLiterals.ElementsAs(ctx, &paths, false)
+			if !slices.Equal(paths, c.want) {
+				t.Errorf("path_literals = %v, want %v", paths, c.want)
+			}
+			if !got.ProcessTeamIds.IsNull() {
+				t.Errorf("process_team_ids = %v, want null after the server dropped them", got.ProcessTeamIds)
+			}
+		})
+	}
+}
+
+func TestFileAccessRuleSkipUnchangedRefresh(t *testing.T) {
+	ctx := context.Background()
+	rule := apipb.FileAccessRule_builder{
+		RuleId:       10,
+		Tag:          "global",
+		Name:         "ssh",
+		RuleType:     apipb.FileAccessRuleType_FILE_ACCESS_RULE_TYPE_PATHS_WITH_ALLOWED_PROCESSES,
+		PathLiterals: []string{"/a", "/b"},
+	}.Build()
+
+	for _, c := range []struct {
+		name      string
+		count     int64
+		wantCalls int
+		want      []string
+	}{
+		// The ID in state still exists, so the rule hasn't changed.
+		{"unchanged", 1, 0, []string{"/a"}},
+		// The rule was updated and has a new ID, so it is fetched.
+		{"changed", 0, 1, []string{"/a", "/b"}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			client := &fakeWorkshopClient{listFileAccessRules: []*apipb.FileAccessRule{rule}, listFARulesCount: c.count}
+			r := &FileAccessRuleResource{client: client}
+
+			var sResp resource.SchemaResponse
+			r.Schema(ctx, resource.SchemaRequest{}, &sResp)
+			var iResp resource.IdentitySchemaResponse
+			r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &iResp)
+
+			req := resource.ReadRequest{State: tfsdk.State{Schema: sResp.Schema}}
+			req.State.Set(ctx, FileAccessRuleResourceModel{
+				Id:                        types.Int64Value(9),
+				Tag:                       types.StringValue("global"),
+				Name:                      types.StringValue("ssh"),
+				RuleType:                  types.StringValue("PathsWithAllowedProcesses"),
+				PathLiterals:              types.ListValueMust(types.StringType, []attr.Value{types.StringValue("/a")}),
+				PathPrefixes:              types.ListNull(types.StringType),
+				ProcessBinaryPaths:        types.ListNull(types.StringType),
+				ProcessCdHashes:           types.ListNull(types.StringType),
+				ProcessSigningIds:         types.ListNull(types.StringType),
+				ProcessCertificateSha256s: types.ListNull(types.StringType),
+				ProcessTeamIds:            types.ListNull(types.StringType),
+				SkipUnchangedRefresh:      types.BoolValue(true),
+			})
+			resp := &resource.ReadResponse{
+				State:    req.State,
+				Identity: &tfsdk.ResourceIdentity{Schema: iResp.IdentitySchema},
+			}
+			r.Read(ctx, req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("read failed: %v", resp.Diagnostics)
+			}
+			if client.listFARulesCalls != c.wantCalls {
+				t.Errorf("full ListFileAccessRules calls = %d, want %d", client.listFARulesCalls, c.wantCalls)
+			}
+
+			var got FileAccessRuleResourceModel
+			resp.State.Get(ctx, &got)
+			var paths []string
+			got.PathLiterals.ElementsAs(ctx, &paths, false)
+			if !slices.Equal(paths, c.want) {
+				t.Errorf("path_literals = %v, want %v", paths, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/northpolesec/terraform-provider-nps/internal/filter"
+	"google.golang.org/protobuf/proto"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ApplyReportResource{}
+var _ resource.ResourceWithConfigure = &ApplyReportResource{}
+
+func NewApplyReportResource() resource.Resource {
+	return &ApplyReportResource{}
+}
+
+// ApplyReportResource lists the rules on a set of tags whenever it is created
+// or updated and reports the rules added and removed since the previous
+// report. It reads from Workshop but never changes it.
+//
+// The comparison is with the previous report rather than with the rules as
+// they were before this apply: Terraform plans the resource again just before
+// applying it, after the rules it depends on, so a snapshot taken in
+// ModifyPlan would already include the apply's changes.
+type ApplyReportResource struct {
+	client svcpb.WorkshopServiceClient
+}
+
+// ApplyReportResourceModel describes the resource data model.
+type ApplyReportResourceModel struct {
+	Tags       types.Set    `tfsdk:"tags"`
+	Triggers   types.Map    `tfsdk:"triggers"`
+	OutputFile types.String `tfsdk:"output_file"`
+
+	RuleCounts types.Map    `tfsdk:"rule_counts"`
+	Summary    types.String `tfsdk:"summary"`
+}
+
+func (r *ApplyReportResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workshop_apply_report"
+}
+
+func (r *ApplyReportResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The `nps_workshop_apply_report` resource lists the rules on a set of tags each time it is applied and reports the rules added and removed since the last report, e.g. `tag eng: +12 rules, -3 (120 -> 129)`, as a quick check that an apply did roughly what was expected.\n\n" +
+			"The report is logged, shown as a warning, stored in `summary`, and optionally written to `output_file`. It only runs when the resource changes, so set `triggers` to a value that changes on every apply, such as `timestamp()`, and add the managed rules to `depends_on` so they are applied first.\n\n" +
+			"The report compares with the last report, not with the rules as they were just before this apply, so rules added or removed outside Terraform since the last report are counted too. Reports cover every rule on a tag, including rules managed outside this configuration. Requires the `read:rules` permission.",
+
+		Attributes: map[string]schema.Attribute{
+			"tags": schema.SetAttribute{
+				MarkdownDescription: "The tags to count rules on.",
+				Required:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that, when changed, run the report again.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"output_file": schema.StringAttribute{
+				MarkdownDescription: "A path to write the report to, replacing any existing file.",
+				Optional:            true,
+			},
+			"rule_counts": schema.MapAttribute{
+				MarkdownDescription: "The number of rules on each tag when the report last ran.",
+				Computed:            true,
+				ElementType:         types.Int64Type,
+			},
+			"summary": schema.StringAttribute{
+				MarkdownDescription: "The report, one line per tag.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *ApplyReportResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(*NPSProviderResourceData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			codeInternal.summary("Unexpected Resource Configure Type"),
+			fmt.Sprintf("Expected NPSProviderResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = pd.Client
+}
+
+func (r *ApplyReportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ApplyReportResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys, diags := r.report(ctx, &data, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(setApplyReportRuleKeys(ctx, resp.Private, keys)...)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ApplyReportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// The report describes the last apply; there is nothing to refresh.
+}
+
+func (r *ApplyReportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ApplyReportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The rule keys of the last report. State written before they were kept
+	// has none, and is reported as if for the first time.
+	var before map[string][]string
+	b, diags := req.Private.GetKey(ctx, applyReportRuleKeysKey)
+	resp.Diagnostics.Append(diags...)
+	if len(b) > 0 {
+		if err := json.Unmarshal(b, &before); err != nil {
+			resp.Diagnostics.AddError(codeInternal.summary("Invalid private state"), fmt.Sprintf("Failed to read the last report's rules: %v", err))
+			return
+		}
+	}
+
+	keys, diags := r.report(ctx, &data, before)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(setApplyReportRuleKeys(ctx, resp.Private, keys)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ApplyReportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Nothing to delete in Workshop; output_file is left in place.
+}
+
+// applyReportRuleKeysKey is the private state key holding the rules on each
+// tag at the last report, as a JSON object of sorted applyReportRuleKey lists.
+const applyReportRuleKeysKey = "rule_keys"
+
+// applyReportPrivate is the part of the framework's private state that
+// setApplyReportRuleKeys uses.
+type applyReportPrivate interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+func setApplyReportRuleKeys(ctx context.Context, private applyReportPrivate, keys map[string][]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	b, err := json.Marshal(keys)
+	if err != nil {
+		diags.AddError(codeInternal.summary("Invalid private state"), fmt.Sprintf("Failed to save the report's rules: %v", err))
+		return diags
+	}
+	return private.SetKey(ctx, applyReportRuleKeysKey, b)
+}
+
+// report lists the rules on data's tags, compares them with before, the rule
+// keys of the last report, and sets the computed attributes. It returns the
+// rule keys on each tag for the next report to compare with. A tag missing
+// from before is reported without a change.
+func (r *ApplyReportResource) report(ctx context.Context, data *ApplyReportResourceModel, before map[string][]string) (map[string][]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var tags []string
+	diags.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	slices.Sort(tags)
+
+	after := make(map[string][]string, len(tags))
+	counts := make(map[string]int64, len(tags))
+	for _, tag := range tags {
+		query := filter.Eq("tag", tag).String()
+		keys := []string{}
+		for rule, err := range listPages(ctx, "rules", func(page uint32) ([]*apipb.Rule, bool, error) {
+			ret, err := r.client.ListRules(ctx, apipb.ListRulesRequest_builder{
+				Filter:   proto.String(query),
+				PageSize: proto.Int32(listPageSize),
+				Page:     proto.Int32(int32(page)),
+			}.Build())
+			return ret.GetRules(), ret.GetMore(), err
+		}) {
+			if err != nil {
+				diags.AddError(clientError(err), fmt.Sprintf("Failed to list rules on tag %q: %v", tag, err))
+				return nil, diags
+			}
+			keys = append(keys, applyReportRuleKey(rule))
+		}
+		slices.Sort(keys)
+		after[tag] = keys
+		counts[tag] = int64(len(keys))
+	}
+
+	summary := applyReportSummary(tags, before, after)
+	tflog.Info(ctx, "Rule change report", map[string]any{"summary": summary})
+	diags.AddWarning(codeNotice.summary("Rule change report"), summary)
+
+	if path := data.OutputFile.ValueString(); path != "" {
+		if err := os.WriteFile(path, []byte(summary+"\n"), 0o644); err != nil {
+			diags.AddError(codeLocalIO.summary("Failed to write report"), fmt.Sprintf("Failed to write %s: %v", path, err))
+			return nil, diags
+		}
+	}
+
+	ruleCounts, d := types.MapValueFrom(ctx, types.Int64Type, counts)
+	diags.Append(d...)
+	data.RuleCounts = ruleCounts
+	data.Summary = types.StringValue(summary)
+	return after, diags
+}
+
+// applyReportRuleKey identifies rule within its tag. Workshop gives a rule a
+// new ID whenever it is updated, so rules are compared by their natural key
+// instead: an update in place is then no change.
+func applyReportRuleKey(rule *apipb.Rule) string {
+	ruleType := rule.GetRuleType().String()
+	return ruleType + ":" + normalizeIdentifier(ruleType, rule.GetIdentifier())
+}
+
+// applyReportSummary formats one line per tag, in the order of tags. before
+// and after hold each tag's sorted rule keys.
+func applyReportSummary(tags []string, before, after map[string][]string) string {
+	lines := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		keys := after[tag]
+		prev, ok := before[tag]
+		if !ok {
+			lines = append(lines, fmt.Sprintf("tag %s: %d rules", tag, len(keys)))
+			continue
+		}
+		added, removed := sortedDifference(keys, prev), sortedDifference(prev, keys)
+		if added == 0 && removed == 0 {
+			lines = append(lines, fmt.Sprintf("tag %s: no change (%d rules)", tag, len(keys)))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("tag %s: +%d rules, -%d (%d -> %d)", tag, added, removed, len(prev), len(keys)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// sortedDifference counts the elements of a, a sorted slice, that aren't in b,
+// also sorted.
+func sortedDifference(a, b []string) int {
+	n := 0
+	for _, s := range a {
+		if _, found := slices.BinarySearch(b, s); !found {
+			n++
+		}
+	}
+	return n
+}
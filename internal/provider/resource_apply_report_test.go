@@ -0,0 +1,100 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+func TestApplyReportSummary(t *testing.T) {
+	got := applyReportSummary(
+		[]string{"eng", "global", "new", "ops"},
+		map[string][]string{"eng": {"a", "b", "c"}, "global": {"g"}, "ops": {"x", "y"}},
+		map[string][]string{"eng": {"b", "c", "d", "e"}, "global": {"g"}, "new": {"n"}, "ops": {"x", "z"}},
+	)
+	want := "tag eng: +2 rules, -1 (3 -> 4)\n" +
+		"tag global: no change (1 rules)\n" +
+		"tag new: 1 rules\n" +
+		"tag ops: +1 rules, -1 (2 -> 2)"
+	if got != want {
+		t.Errorf("applyReportSummary() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestApplyReportReport(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeWorkshopClient{listRules: []*apipb.Rule{
+		apipb.Rule_builder{RuleId: "2", Identifier: "ubf8t346g9", RuleType: apipb.RuleType_TEAMID, Tag: "eng"}.Build(),
+		apipb.Rule_builder{RuleId: "3", Identifier: "ZMCG7MLDV9", RuleType: apipb.RuleType_TEAMID, Tag: "eng"}.Build(),
+		apipb.Rule_builder{RuleId: "4", Identifier: "platform:com.apple.curl", RuleType: apipb.RuleType_SIGNINGID, Tag: "eng"}.Build(),
+	}}
+	r := &ApplyReportResource{client: fake}
+
+	out := filepath.Join(t.TempDir(), "report.txt")
+	data := ApplyReportResourceModel{
+		Tags:       types.SetValueMust(types.StringType, []attr.Value{types.StringValue("eng")}),
+		OutputFile: types.StringValue(out),
+	}
+	keys, diags := r.report(ctx, &data, map[string][]string{"eng": {"TEAMID:EQHXZ8M8AV", "TEAMID:UBF8T346G9"}})
+	if diags.HasError() {
+		t.Fatalf("report failed: %v", diags)
+	}
+	if diags.WarningsCount() != 1 {
+		t.Errorf("got %d warnings, want the report as one warning", diags.WarningsCount())
+	}
+	if fake.listRulesFilter != `tag = "eng"` {
+		t.Errorf("ListRules filter = %q", fake.listRulesFilter)
+	}
+	if want := []string{"SIGNINGID:platform:com.apple.curl", "TEAMID:UBF8T346G9", "TEAMID:ZMCG7MLDV9"}; !slices.Equal(keys["eng"], want) {
+		t.Errorf("rule keys = %v, want %v", keys["eng"], want)
+	}
+
+	const want = "tag eng: +2 rules, -1 (2 -> 3)"
+	if data.Summary.ValueString() != want {
+		t.Errorf("summary = %q, want %q", data.Summary.ValueString(), want)
+	}
+	if got := data.RuleCounts.Elements()["eng"]; !got.Equal(types.Int64Value(3)) {
+		t.Errorf("rule_counts[eng] = %v, want 3", got)
+	}
+	written, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(written) != want+"\n" {
+		t.Errorf("output_file = %q, want %q", written, want+"\n")
+	}
+}
+
+func TestApplyReportUpdatedRuleIsNoChange(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeWorkshopClient{listRules: []*apipb.Rule{
+		apipb.Rule_builder{RuleId: "1", Identifier: "EQHXZ8M8AV", RuleType: apipb.RuleType_TEAMID, Policy: apipb.Policy_ALLOWLIST, Comment: "before", Tag: "eng"}.Build(),
+	}}
+	r := &ApplyReportResource{client: fake}
+	data := ApplyReportResourceModel{
+		Tags: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("eng")}),
+	}
+	before, diags := r.report(ctx, &data, nil)
+	if diags.HasError() {
+		t.Fatalf("first report failed: %v", diags)
+	}
+
+	// Updating a rule gives it a new ID, but it is still the same rule.
+	fake.listRules = []*apipb.Rule{
+		apipb.Rule_builder{RuleId: "2", Identifier: "EQHXZ8M8AV", RuleType: apipb.RuleType_TEAMID, Policy: apipb.Policy_BLOCKLIST, Comment: "after", Tag: "eng"}.Build(),
+	}
+	if _, diags := r.report(ctx, &data, before); diags.HasError() {
+		t.Fatalf("second report failed: %v", diags)
+	}
+	if want := "tag eng: no change (1 rules)"; data.Summary.ValueString() != want {
+		t.Errorf("summary = %q, want %q", data.Summary.ValueString(), want)
+	}
+}
@@ -0,0 +1,532 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/northpolesec/terraform-provider-nps/internal/filter"
+	"github.com/northpolesec/terraform-provider-nps/internal/utils"
+	"google.golang.org/protobuf/proto"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ApprovalWorkflowResource{}
+var _ resource.ResourceWithConfigure = &ApprovalWorkflowResource{}
+var _ resource.ResourceWithConfigValidators = &ApprovalWorkflowResource{}
+var _ resource.ResourceWithImportState = &ApprovalWorkflowResource{}
+var _ resource.ResourceWithIdentity = &ApprovalWorkflowResource{}
+
+func NewApprovalWorkflowResource() resource.Resource {
+	return &ApprovalWorkflowResource{}
+}
+
+// ApprovalWorkflowResource manages how users on a tag get approval to run
+// blocked software.
+type ApprovalWorkflowResource struct {
+	client svcpb.WorkshopServiceClient
+}
+
+// ApprovalWorkflowIdentityModel describes the identity data model.
+type ApprovalWorkflowIdentityModel struct {
+	Tag types.String `tfsdk:"tag"`
+}
+
+// ApprovalWorkflowResourceModel describes the resource data model. At most
+// one of the workflow attributes is set; none means approvals are disabled.
+type ApprovalWorkflowResourceModel struct {
+	Tag                types.String `tfsdk:"tag"`
+	ApprovalRuleType   types.String `tfsdk:"approval_rule_type"`
+	NotificationMethod types.String `tfsdk:"notification_method"`
+
+	SelfService        *ApprovalWorkflowSelfServiceModel        `tfsdk:"self_service"`
+	DesignatedApprover *ApprovalWorkflowDesignatedApproverModel `tfsdk:"designated_approver"`
+	SocialVoting       *ApprovalWorkflowSocialVotingModel       `tfsdk:"social_voting"`
+}
+
+type ApprovalWorkflowSelfServiceModel struct {
+	RequireJustification types.Bool `tfsdk:"require_justification"`
+}
+
+type ApprovalWorkflowDesignatedApproverModel struct {
+	ApproverTag          types.String `tfsdk:"approver_tag"`
+	ApproverEmails       types.Set    `tfsdk:"approver_emails"`
+	Manager              types.Bool   `tfsdk:"manager"`
+	Threshold            types.Int64  `tfsdk:"threshold"`
+	ChatChannel          types.String `tfsdk:"chat_channel"`
+	RequireJustification types.Bool   `tfsdk:"require_justification"`
+}
+
+type ApprovalWorkflowSocialVotingModel struct {
+	LocalThreshold          types.Int64 `tfsdk:"local_threshold"`
+	TagThreshold            types.Int64 `tfsdk:"tag_threshold"`
+	TagsToConsiderVotesFrom types.Set   `tfsdk:"tags_to_consider_votes_from"`
+	RequireJustification    types.Bool  `tfsdk:"require_justification"`
+}
+
+var (
+	// approvalWorkflowRuleTypeValues are the rule types the server accepts for
+	// approved software.
+	approvalWorkflowRuleTypeValues = []string{"BINARY", "SIGNINGID", "CDHASH"}
+
+	approvalWorkflowNotificationMethodValues = []string{
+		"NOTIFICATION_METHOD_NONE",
+		"NOTIFICATION_METHOD_SLACK",
+	}
+)
+
+func (r *ApprovalWorkflowResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workshop_approval_workflow"
+}
+
+func (r *ApprovalWorkflowResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	workflows := []string{"self_service", "designated_approver", "social_voting"}
+	conflictsWithOthers := func(name string) []validator.Object {
+		var others []path.Expression
+		for _, w := range workflows {
+			if w != name {
+				others = append(others, path.MatchRoot(w))
+			}
+		}
+		return []validator.Object{objectvalidator.ConflictsWith(others...)}
+	}
+	requireJustification := schema.BoolAttribute{
+		MarkdownDescription: "Whether the requester must give a justification. Defaults to `false`.",
+		Optional:            true,
+		Computed:            true,
+		Default:             booldefault.StaticBool(false),
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The `nps_workshop_approval_workflow` resource manages how users on a tag request approval to run software Santa blocked: by approving it themselves, by asking designated approvers, or by a vote of their peers. Set at most one of `self_service`, `designated_approver`, and `social_voting`; setting none disables approval requests for the tag.\n\n" +
+			"Destroying the resource deletes the tag's approval workflow.\n\n" +
+			"Requires the `read:settings` and `write:settings` permissions.",
+
+		Attributes: map[string]schema.Attribute{
+			"tag": schema.StringAttribute{
+				MarkdownDescription: "The tag whose approval workflow this resource manages. Changing the tag forces replacement.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 42),
+					stringvalidator.RegexMatches(
+						syncSettingsTagRegex,
+						"must contain only letters, digits, periods, colons, hyphens, and underscores",
+					),
+				},
+			},
+			"approval_rule_type": schema.StringAttribute{
+				MarkdownDescription: "The type of rule created for approved software. One of: `BINARY`, `SIGNINGID`, `CDHASH`.",
+				Required:            true,
+				Validators: []validator.String{
+					oneOf(approvalWorkflowRuleTypeValues...),
+				},
+			},
+			"notification_method": schema.StringAttribute{
+				MarkdownDescription: "How approvers are notified. One of: `NOTIFICATION_METHOD_NONE` (requests are handled in the Workshop web interface, which Santa opens), `NOTIFICATION_METHOD_SLACK`.",
+				Optional:            true,
+				Validators: []validator.String{
+					oneOf(approvalWorkflowNotificationMethodValues...),
+				},
+			},
+			"self_service": schema.SingleNestedAttribute{
+				MarkdownDescription: "Users approve software for themselves.",
+				Optional:            true,
+				Validators:          conflictsWithOthers("self_service"),
+				Attributes: map[string]schema.Attribute{
+					"require_justification": requireJustification,
+				},
+			},
+			"designated_approver": schema.SingleNestedAttribute{
+				MarkdownDescription: "Designated approvers approve software for the requester. Set exactly one of `approver_tag`, `approver_emails`, and `manager`.",
+				Optional:            true,
+				Validators:          conflictsWithOthers("designated_approver"),
+				Attributes: map[string]schema.Attribute{
+					"approver_tag": schema.StringAttribute{
+						MarkdownDescription: "A tag whose users are the approvers.",
+						Optional:            true,
+					},
+					"approver_emails": schema.SetAttribute{
+						MarkdownDescription: "The email addresses of the approvers.",
+						Optional:            true,
+						ElementType:         types.StringType,
+						Validators: []validator.Set{
+							setvalidator.SizeAtLeast(1),
+						},
+					},
+					"manager": schema.BoolAttribute{
+						MarkdownDescription: "Whether the requester's manager, from the directory integration, is the approver. Must be `true` if set.",
+						Optional:            true,
+					},
+					"threshold": schema.Int64Attribute{
+						MarkdownDescription: "The number of approvals required, between 1 and the number of approvers. Required with `approver_tag` or `approver_emails`.",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.Between(1, 1<<32-1),
+						},
+					},
+					"chat_channel": schema.StringAttribute{
+						MarkdownDescription: "The chat channel approval requests are sent to. If unset, the chatbot asks the requester to pick an approver.",
+						Optional:            true,
+					},
+					"require_justification": requireJustification,
+				},
+			},
+			"social_voting": schema.SingleNestedAttribute{
+				MarkdownDescription: "Users vote on software. Enough votes approve it for the requester, and more approve it for every user on the tag.",
+				Optional:            true,
+				Validators:          conflictsWithOthers("social_voting"),
+				Attributes: map[string]schema.Attribute{
+					"local_threshold": schema.Int64Attribute{
+						MarkdownDescription: "The number of votes that approve the software for the requester.",
+						Required:            true,
+						Validators: []validator.Int64{
+							int64validator.Between(1, 1<<32-1),
+						},
+					},
+					"tag_threshold": schema.Int64Attribute{
+						MarkdownDescription: "The number of votes that approve the software for every user on the tag. If unset, votes never approve software tag-wide.",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.Between(1, 1<<32-1),
+						},
+					},
+					"tags_to_consider_votes_from": schema.SetAttribute{
+						MarkdownDescription: "The tags whose users' votes count toward the thresholds.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"require_justification": requireJustification,
+				},
+			},
+		},
+	}
+}
+
+func (r *ApprovalWorkflowResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		utils.ConfigValidatorFunc("Validate designated_approver", func(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+			var data ApprovalWorkflowResourceModel
+			resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			validateDesignatedApprover(data.DesignatedApprover, &resp.Diagnostics)
+		}),
+	}
+}
+
+// validateDesignatedApprover checks that exactly one kind of approver is set,
+// with a threshold unless it is the manager. Unknown values are skipped.
+func validateDesignatedApprover(m *ApprovalWorkflowDesignatedApproverModel, diags *diag.Diagnostics) {
+	if m == nil {
+		return
+	}
+	if m.ApproverTag.IsUnknown() || m.ApproverEmails.IsUnknown() || m.Manager.IsUnknown() {
+		return
+	}
+	p := path.Root("designated_approver")
+
+	if !m.Manager.IsNull() && !m.Manager.ValueBool() {
+		diags.AddAttributeError(p.AtName("manager"), codeInvalidConfig.summary("Invalid manager"), "manager must be true if set; remove it to use another approver")
+		return
+	}
+	set := 0
+	for _, isSet := range []bool{!m.ApproverTag.IsNull(), !m.ApproverEmails.IsNull(), m.Manager.ValueBool()} {
+		if isSet {
+			set++
+		}
+	}
+	if set != 1 {
+		diags.AddAttributeError(p, codeInvalidConfig.summary("Invalid designated approver"), "exactly one of approver_tag, approver_emails, and manager must be set")
+		return
+	}
+
+	if m.Manager.ValueBool() {
+		if !m.Threshold.IsNull() {
+			diags.AddAttributeError(p.AtName("threshold"), codeInvalidConfig.summary("threshold is not supported"), "threshold can't be set when the approver is the manager")
+		}
+	} else if m.Threshold.IsNull() {
+		diags.AddAttributeError(p.AtName("threshold"), codeInvalidConfig.summary("threshold is required"), "threshold must be set when approver_tag or approver_emails is set")
+	}
+}
+
+func (r *ApprovalWorkflowResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(*NPSProviderResourceData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			codeInternal.summary("Unexpected Resource Configure Type"),
+			fmt.Sprintf("Expected NPSProviderResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = pd.Client
+}
+
+func (r *ApprovalWorkflowResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ApprovalWorkflowResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.updateApprovalWorkflow(ctx, data, &resp.Diagnostics) {
+		return
+	}
+
+	tflog.Info(ctx, "Created approval workflow", map[string]any{"tag": data.Tag.ValueString()})
+
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ApprovalWorkflowIdentityModel{Tag: data.Tag})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ApprovalWorkflowResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ApprovalWorkflowResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings, found, err := fetchApprovalWorkflow(ctx, r.client, data.Tag.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to list approval workflows: %v", err))
+		return
+	}
+	if !found {
+		tflog.Info(ctx, fmt.Sprintf("Approval workflow for tag %q not found", data.Tag.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	newData := approvalWorkflowProtoToModel(ctx, settings, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ApprovalWorkflowIdentityModel{Tag: newData.Tag})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newData)...)
+}
+
+func (r *ApprovalWorkflowResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ApprovalWorkflowResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.updateApprovalWorkflow(ctx, data, &resp.Diagnostics) {
+		return
+	}
+
+	tflog.Info(ctx, "Updated approval workflow", map[string]any{"tag": data.Tag.ValueString()})
+
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ApprovalWorkflowIdentityModel{Tag: data.Tag})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// updateApprovalWorkflow replaces the tag's approval workflow with data's,
+// reporting whether it succeeded.
+func (r *ApprovalWorkflowResource) updateApprovalWorkflow(ctx context.Context, data ApprovalWorkflowResourceModel, diags *diag.Diagnostics) bool {
+	settings := approvalWorkflowModelToProto(ctx, data, diags)
+	if diags.HasError() {
+		return false
+	}
+
+	_, err := r.client.UpdateApprovalWorkflowSettings(ctx, apipb.UpdateApprovalWorkflowSettingsRequest_builder{
+		Tag:                      proto.String(data.Tag.ValueString()),
+		ApprovalWorkflowSettings: settings,
+	}.Build())
+	if err != nil {
+		diags.AddError(clientError(err), fmt.Sprintf("Failed to update approval workflow for tag %q: %v", data.Tag.ValueString(), err))
+		return false
+	}
+	return true
+}
+
+func (r *ApprovalWorkflowResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ApprovalWorkflowResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.DeleteApprovalWorkflowSettings(ctx, apipb.DeleteApprovalWorkflowSettingsRequest_builder{Tag: proto.String(data.Tag.ValueString())}.Build())
+	if err != nil && !isDeleteNoOp(err) {
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to delete approval workflow for tag %q: %v", data.Tag.ValueString(), err))
+		return
+	}
+
+	tflog.Info(ctx, "Deleted approval workflow", map[string]any{"tag": data.Tag.ValueString()})
+}
+
+func (r *ApprovalWorkflowResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tag := req.ID
+	var identity ApprovalWorkflowIdentityModel
+	if importIdentity(ctx, req, resp, &identity) {
+		tag = identity.Tag.ValueString()
+	}
+	if resp.Diagnostics.HasError() || !validateTagImportID(tag, &resp.Diagnostics) {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tag"), tag)...)
+}
+
+func (r *ApprovalWorkflowResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"tag": identityschema.StringAttribute{
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// fetchApprovalWorkflow returns the approval workflow for tag, if it has one.
+func fetchApprovalWorkflow(ctx context.Context, client svcpb.WorkshopServiceClient, tag string) (*apipb.ApprovalWorkflowSettings, bool, error) {
+	ret, err := client.ListApprovalWorkflowSettings(ctx, apipb.ListApprovalWorkflowSettingsRequest_builder{
+		Filter:   proto.String(filter.Eq("tag", tag).String()),
+		PageSize: proto.Uint32(1),
+	}.Build())
+	if err != nil {
+		return nil, false, err
+	}
+	for _, s := range ret.GetApprovalWorkflowSettings() {
+		if s.GetTag() == tag {
+			return s, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+func approvalWorkflowModelToProto(ctx context.Context, m ApprovalWorkflowResourceModel, diags *diag.Diagnostics) *apipb.ApprovalWorkflowSettings {
+	b := apipb.ApprovalWorkflowSettings_builder{
+		Tag:              m.Tag.ValueString(),
+		ApprovalRuleType: apipb.RuleType(apipb.RuleType_value[m.ApprovalRuleType.ValueString()]),
+	}
+	if !m.NotificationMethod.IsNull() {
+		b.NotificationMethod = apipb.ApprovalWorkflowSettings_NotificationMethod(apipb.ApprovalWorkflowSettings_NotificationMethod_value[m.NotificationMethod.ValueString()])
+	}
+
+	switch {
+	case m.SelfService != nil:
+		b.SelfService = apipb.SelfApprovalWorkflowSettings_builder{
+			RequireJustification: m.SelfService.RequireJustification.ValueBool(),
+		}.Build()
+
+	case m.DesignatedApprover != nil:
+		da := m.DesignatedApprover
+		db := apipb.DesignatedApproverWorkflowSettings_builder{
+			ChatChannel:          da.ChatChannel.ValueString(),
+			RequireJustification: da.RequireJustification.ValueBool(),
+		}
+		threshold := uint32(da.Threshold.ValueInt64())
+		switch {
+		case !da.ApproverTag.IsNull():
+			db.Tag = apipb.DesignatedApproverTag_builder{Tag: da.ApproverTag.ValueString(), Threshold: threshold}.Build()
+		case !da.ApproverEmails.IsNull():
+			var emails []string
+			diags.Append(da.ApproverEmails.ElementsAs(ctx, &emails, false)...)
+			db.Emails = apipb.DesignatedApproverEmails_builder{ApproverEmails: emails, Threshold: threshold}.Build()
+		default:
+			db.Manager = apipb.DesignatedApproverManager_builder{}.Build()
+		}
+		b.DesignatedApprover = db.Build()
+
+	case m.SocialVoting != nil:
+		sv := m.SocialVoting
+		var tags []string
+		if !sv.TagsToConsiderVotesFrom.IsNull() {
+			diags.Append(sv.TagsToConsiderVotesFrom.ElementsAs(ctx, &tags, false)...)
+		}
+		b.SocialVoting = apipb.SocialVotingWorkflowSettings_builder{
+			LocalThreshold:          uint32(sv.LocalThreshold.ValueInt64()),
+			TagThreshold:            uint32(sv.TagThreshold.ValueInt64()),
+			TagsToConsiderVotesFrom: tags,
+			RequireJustification:    sv.RequireJustification.ValueBool(),
+		}.Build()
+
+	default:
+		b.None = apipb.NoneApprovalWorkflowSettings_builder{}.Build()
+	}
+
+	return b.Build()
+}
+
+func approvalWorkflowProtoToModel(ctx context.Context, s *apipb.ApprovalWorkflowSettings, diags *diag.Diagnostics) ApprovalWorkflowResourceModel {
+	m := ApprovalWorkflowResourceModel{
+		Tag:                types.StringValue(s.GetTag()),
+		ApprovalRuleType:   types.StringValue(s.GetApprovalRuleType().String()),
+		NotificationMethod: types.StringNull(),
+	}
+	if nm := s.GetNotificationMethod(); nm != apipb.ApprovalWorkflowSettings_NOTIFICATION_METHOD_UNSPECIFIED {
+		m.NotificationMethod = types.StringValue(nm.String())
+	}
+
+	switch {
+	case s.HasSelfService():
+		m.SelfService = &ApprovalWorkflowSelfServiceModel{
+			RequireJustification: types.BoolValue(s.GetSelfService().GetRequireJustification()),
+		}
+
+	case s.HasDesignatedApprover():
+		da := s.GetDesignatedApprover()
+		dm := &ApprovalWorkflowDesignatedApproverModel{
+			ApproverTag:          types.StringNull(),
+			ApproverEmails:       types.SetNull(types.StringType),
+			Manager:              types.BoolNull(),
+			Threshold:            types.Int64Null(),
+			ChatChannel:          emptyStringToNull(da.GetChatChannel()),
+			RequireJustification: types.BoolValue(da.GetRequireJustification()),
+		}
+		switch {
+		case da.HasTag():
+			dm.ApproverTag = types.StringValue(da.GetTag().GetTag())
+			dm.Threshold = types.Int64Value(int64(da.GetTag().GetThreshold()))
+		case da.HasEmails():
+			emails, d := types.SetValueFrom(ctx, types.StringType, da.GetEmails().GetApproverEmails())
+			diags.Append(d...)
+			dm.ApproverEmails = emails
+			dm.Threshold = types.Int64Value(int64(da.GetEmails().GetThreshold()))
+		case da.HasManager():
+			dm.Manager = types.BoolValue(true)
+		}
+		m.DesignatedApprover = dm
+
+	case s.HasSocialVoting():
+		sv := s.GetSocialVoting()
+		m.SocialVoting = &ApprovalWorkflowSocialVotingModel{
+			LocalThreshold:          types.Int64Value(int64(sv.GetLocalThreshold())),
+			TagThreshold:            zeroUint32ToNullInt64(sv.GetTagThreshold()),
+			TagsToConsiderVotesFrom: stringSetOrNull(ctx, sv.GetTagsToConsiderVotesFrom(), diags),
+			RequireJustification:    types.BoolValue(sv.GetRequireJustification()),
+		}
+	}
+
+	return m
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func stringSet(values ...string) types.Set {
+	elems := make([]attr.Value, 0, len(values))
+	for _, v := range values {
+		elems = append(elems, types.StringValue(v))
+	}
+	return types.SetValueMust(types.StringType, elems)
+}
+
+func TestApprovalWorkflowConversionRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	base := ApprovalWorkflowResourceModel{
+		Tag:                types.StringValue("engineering"),
+		ApprovalRuleType:   types.StringValue("SIGNINGID"),
+		NotificationMethod: types.StringNull(),
+	}
+
+	for name, update := range map[string]func(m *ApprovalWorkflowResourceModel){
+		"none": func(m *ApprovalWorkflowResourceModel) {},
+		"self service": func(m *ApprovalWorkflowResourceModel) {
+			m.NotificationMethod = types.StringValue("NOTIFICATION_METHOD_SLACK")
+			m.SelfService = &ApprovalWorkflowSelfServiceModel{RequireJustification: types.BoolValue(true)}
+		},
+		"designated approver tag": func(m *ApprovalWorkflowResourceModel) {
+			m.DesignatedApprover = &ApprovalWorkflowDesignatedApproverModel{
+				ApproverTag:          types.StringValue("security"),
+				ApproverEmails:       types.SetNull(types.StringType),
+				Manager:              types.BoolNull(),
+				Threshold:            types.Int64Value(2),
+				ChatChannel:          types.StringValue("#approvals"),
+				RequireJustification: types.BoolValue(false),
+			}
+		},
+		"designated approver emails": func(m *ApprovalWorkflowResourceModel) {
+			m.DesignatedApprover = &ApprovalWorkflowDesignatedApproverModel{
+				ApproverTag:          types.StringNull(),
+				ApproverEmails:       stringSet("alice@example.com", "bob@example.com"),
+				Manager:              types.BoolNull(),
+				Threshold:            types.Int64Value(1),
+				ChatChannel:          types.StringNull(),
+				RequireJustification: types.BoolValue(true),
+			}
+		},
+		"designated approver manager": func(m *ApprovalWorkflowResourceModel) {
+			m.DesignatedApprover = &ApprovalWorkflowDesignatedApproverModel{
+				ApproverTag:          types.StringNull(),
+				ApproverEmails:       types.SetNull(types.StringType),
+				Manager:              types.BoolValue(true),
+				Threshold:            types.Int64Null(),
+				ChatChannel:          types.StringNull(),
+				RequireJustification: types.BoolValue(false),
+			}
+		},
+		"social voting": func(m *ApprovalWorkflowResourceModel) {
+			m.SocialVoting = &ApprovalWorkflowSocialVotingModel{
+				LocalThreshold:          types.Int64Value(3),
+				TagThreshold:            types.Int64Value(10),
+				TagsToConsiderVotesFrom: stringSet("engineering", "security"),
+				RequireJustification:    types.BoolValue(true),
+			}
+		},
+		"social voting local only": func(m *ApprovalWorkflowResourceModel) {
+			m.SocialVoting = &ApprovalWorkflowSocialVotingModel{
+				LocalThreshold:          types.Int64Value(3),
+				TagThreshold:            types.Int64Null(),
+				TagsToConsiderVotesFrom: types.SetNull(types.StringType),
+				RequireJustification:    types.BoolValue(false),
+			}
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			data := base
+			update(&data)
+
+			var diags diag.Diagnostics
+			got := approvalWorkflowProtoToModel(ctx, approvalWorkflowModelToProto(ctx, data, &diags), &diags)
+			if diags.HasError() {
+				t.Fatalf("approval workflow conversion: %v", diags)
+			}
+			assertSameState(t, &ApprovalWorkflowResource{}, got, data)
+		})
+	}
+}
+
+func TestValidateDesignatedApprover(t *testing.T) {
+	approver := func(tag string, emails types.Set, manager types.Bool, threshold types.Int64) *ApprovalWorkflowDesignatedApproverModel {
+		return &ApprovalWorkflowDesignatedApproverModel{
+			ApproverTag:    optionalString(tag),
+			ApproverEmails: emails,
+			Manager:        manager,
+			Threshold:      threshold,
+		}
+	}
+	noEmails := types.SetNull(types.StringType)
+
+	for _, c := range []struct {
+		name    string
+		m       *ApprovalWorkflowDesignatedApproverModel
+		wantErr bool
+	}{
+		{"unset", nil, false},
+		{"tag", approver("security", noEmails, types.BoolNull(), types.Int64Value(1)), false},
+		{"emails", approver("", stringSet("a@example.com"), types.BoolNull(), types.Int64Value(1)), false},
+		{"manager", approver("", noEmails, types.BoolValue(true), types.Int64Null()), false},
+		{"no approver", approver("", noEmails, types.BoolNull(), types.Int64Value(1)), true},
+		{"two approvers", approver("security", stringSet("a@example.com"), types.BoolNull(), types.Int64Value(1)), true},
+		{"manager false", approver("security", noEmails, types.BoolValue(false), types.Int64Value(1)), true},
+		{"tag without threshold", approver("security", noEmails, types.BoolNull(), types.Int64Null()), true},
+		{"manager with threshold", approver("", noEmails, types.BoolValue(true), types.Int64Value(1)), true},
+		{"unknown", approver("", types.SetUnknown(types.StringType), types.BoolNull(), types.Int64Null()), false},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			validateDesignatedApprover(c.m, &diags)
+			if diags.HasError() != c.wantErr {
+				t.Errorf("validateDesignatedApprover() = %v, want error %t", diags, c.wantErr)
+			}
+		})
+	}
+}
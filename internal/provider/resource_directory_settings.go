@@ -5,7 +5,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -75,7 +74,7 @@ func (r *DirectorySettingsResource) Schema(ctx context.Context, req resource.Sch
 				MarkdownDescription: "The directory type. Must be one of: `DIRECTORY_TYPE_DSYNC`, `DIRECTORY_TYPE_LOCAL`.",
 				Required:            true,
 				Validators: []validator.String{
-					stringvalidator.OneOf(
+					oneOf(
 						"DIRECTORY_TYPE_DSYNC",
 						"DIRECTORY_TYPE_LOCAL",
 					),
@@ -118,7 +117,7 @@ func (r *DirectorySettingsResource) ConfigValidators(ctx context.Context) []reso
 
 			if data.DirectoryType.ValueString() == "DIRECTORY_TYPE_LOCAL" && len(data.DirectorySyncGroupFilter.Elements()) > 0 {
 				resp.Diagnostics.AddError(
-					"Invalid configuration",
+					codeInvalidConfig.summary("Invalid configuration"),
 					"directory_sync_group_filter can only be set when directory_type is DIRECTORY_TYPE_DSYNC",
 				)
 			}
@@ -134,7 +133,7 @@ func (r *DirectorySettingsResource) Configure(ctx context.Context, req resource.
 	pd, ok := req.ProviderData.(*NPSProviderResourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
+			codeInternal.summary("Unexpected Resource Configure Type"),
 			fmt.Sprintf("Expected NPSProviderResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
@@ -164,7 +163,7 @@ func (r *DirectorySettingsResource) Create(ctx context.Context, req resource.Cre
 
 	_, err := r.client.UpdateDirectorySettings(ctx, updateReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to update directory settings: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to update directory settings: %v", err))
 		return
 	}
 
@@ -184,7 +183,7 @@ func (r *DirectorySettingsResource) Read(ctx context.Context, req resource.ReadR
 
 	ret, err := r.client.GetDirectorySettings(ctx, apipb.GetDirectorySettingsRequest_builder{}.Build())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to get directory settings: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to get directory settings: %v", err))
 		return
 	}
 
@@ -216,7 +215,7 @@ func (r *DirectorySettingsResource) Update(ctx context.Context, req resource.Upd
 
 	_, err := r.client.UpdateDirectorySettings(ctx, updateReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to update directory settings: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to update directory settings: %v", err))
 		return
 	}
 
@@ -270,7 +269,7 @@ func groupsModelToProto(ctx context.Context, list types.List, diags *diag.Diagno
 		var tags []string
 		diags.Append(g.Tags.ElementsAs(ctx, &tags, false)...)
 		if diags.HasError() {
-			diags.AddError("Invalid group filter", fmt.Sprintf("Failed to extract tags for group %q", g.Id.ValueString()))
+			diags.AddError(codeInvalidConfig.summary("Invalid group filter"), fmt.Sprintf("Failed to extract tags for group %q", g.Id.ValueString()))
 			return nil
 		}
 
@@ -0,0 +1,292 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"google.golang.org/protobuf/proto"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// emergencyLockdownConfirmation is the value confirm must be set to.
+const emergencyLockdownConfirmation = "I understand this locks down every host in these tags"
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &EmergencyLockdownResource{}
+var _ resource.ResourceWithConfigure = &EmergencyLockdownResource{}
+
+func NewEmergencyLockdownResource() resource.Resource {
+	return &EmergencyLockdownResource{}
+}
+
+// EmergencyLockdownResource switches tags to LOCKDOWN and creates a prepared
+// blocklist in a single apply. It behaves like an action: creating it does the
+// work, every attribute forces replacement, and destroying it only removes it
+// from state.
+type EmergencyLockdownResource struct {
+	client svcpb.WorkshopServiceClient
+}
+
+// EmergencyLockdownResourceModel describes the resource data model.
+type EmergencyLockdownResourceModel struct {
+	Tags      types.Set                     `tfsdk:"tags"`
+	Confirm   types.String                  `tfsdk:"confirm"`
+	Reason    types.String                  `tfsdk:"reason"`
+	Blocklist []EmergencyLockdownBlockModel `tfsdk:"blocklist"`
+
+	PreviousClientModes types.Map    `tfsdk:"previous_client_modes"`
+	ActivatedAt         types.String `tfsdk:"activated_at"`
+	Id                  types.String `tfsdk:"id"`
+}
+
+// EmergencyLockdownBlockModel describes one blocklist entry.
+type EmergencyLockdownBlockModel struct {
+	Identifier types.String `tfsdk:"identifier"`
+	RuleType   types.String `tfsdk:"rule_type"`
+	CustomMsg  types.String `tfsdk:"custom_msg"`
+}
+
+func (r *EmergencyLockdownResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workshop_emergency_lockdown"
+}
+
+func (r *EmergencyLockdownResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The `nps_workshop_emergency_lockdown` resource is an incident response action: creating it switches the sync settings of every listed tag to `LOCKDOWN` and creates a `BLOCKLIST` rule on each tag for every `blocklist` entry, in a single apply.\n\n" +
+			"Every attribute forces replacement, so changing the configuration runs the action again. Destroying the resource only removes it from state: it does not lift the lockdown or delete the rules, which must be undone deliberately, for example through `nps_workshop_sync_settings` or the Workshop UI. `previous_client_modes` records each tag's mode before the lockdown to help with that. If the apply fails after a tag was locked down, the resource is still saved, as tainted, with `previous_client_modes` set; the replacement's destroy warning repeats them before the next lockdown records `LOCKDOWN` as the tags' mode.\n\n" +
+			"If a tag's sync settings are managed by `nps_workshop_sync_settings`, that resource will revert the client mode on its next apply.\n\n" +
+			"Requires the `read:settings`, `write:settings`, and `write:rules` permissions.",
+
+		Attributes: map[string]schema.Attribute{
+			"tags": schema.SetAttribute{
+				MarkdownDescription: "The tags to lock down.",
+				Required:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"confirm": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Must be set to `%s`. Guards against applying the resource by accident.", emergencyLockdownConfirmation),
+				Required:            true,
+				Validators: []validator.String{
+					oneOf(emergencyLockdownConfirmation),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"reason": schema.StringAttribute{
+				MarkdownDescription: "Why the lockdown was triggered, e.g. an incident ticket. Used as the comment on the blocklist rules.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"blocklist": schema.ListNestedAttribute{
+				MarkdownDescription: "Binaries to block on every tag. The rules are created with the `BLOCKLIST` policy and `BLOCK_REASON_MALICIOUS`.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"identifier": schema.StringAttribute{
+							MarkdownDescription: "The identifier for this rule. The format of this identifier depends on the rule type.",
+							Required:            true,
+						},
+						"rule_type": schema.StringAttribute{
+							MarkdownDescription: "The type of this rule. The possible values are: `BINARY`, `CERTIFICATE`, `TEAMID`, `SIGNINGID`, and `CDHASH`.",
+							Required:            true,
+							Validators: []validator.String{
+								oneOf(enumValues(apipb.RuleType(0).Descriptor())...),
+							},
+						},
+						"custom_msg": schema.StringAttribute{
+							MarkdownDescription: "A custom message to display to the user when this rule causes Santa to block the execution.",
+							Optional:            true,
+							Validators: []validator.String{
+								customMsgValidator{},
+							},
+						},
+					},
+				},
+			},
+			"previous_client_modes": schema.MapAttribute{
+				MarkdownDescription: "Each tag's client mode before the lockdown. Tags that didn't set a client mode of their own are omitted.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"activated_at": schema.StringAttribute{
+				MarkdownDescription: "When the lockdown was applied, as an RFC3339 timestamp.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "An identifier for this lockdown, the same as `activated_at`.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *EmergencyLockdownResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(*NPSProviderResourceData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			codeInternal.summary("Unexpected Resource Configure Type"),
+			fmt.Sprintf("Expected NPSProviderResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = pd.Client
+}
+
+func (r *EmergencyLockdownResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data EmergencyLockdownResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var tags []string
+	resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	slices.Sort(tags)
+
+	// If a later step fails, the state is still saved, with the modes the
+	// tags had before: Terraform keeps it as tainted, so the undo information
+	// isn't lost when a re-apply finds the tags already in LOCKDOWN.
+	previous := map[string]string{}
+	data.ActivatedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	data.Id = data.ActivatedAt
+	saveState := func() {
+		prev, diags := types.MapValueFrom(ctx, types.StringType, previous)
+		resp.Diagnostics.Append(diags...)
+		data.PreviousClientModes = prev
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	}
+
+	// Lock the tags down first: it takes effect for every binary that isn't
+	// already allowlisted, while the blocklist only covers known bad ones.
+	for i, tag := range tags {
+		mode, diags := lockDownTag(ctx, r.client, tag)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			if i > 0 {
+				saveState()
+			}
+			return
+		}
+		if mode != "" {
+			previous[tag] = mode
+		}
+	}
+
+	for _, tag := range tags {
+		for _, entry := range data.Blocklist {
+			_, err := r.client.CreateRule(ctx, buildCreateRuleRequest(RuleResourceModel{
+				Identifier:  entry.Identifier,
+				RuleType:    entry.RuleType,
+				Policy:      types.StringValue(apipb.Policy_BLOCKLIST.String()),
+				BlockReason: types.StringValue(apipb.Rule_BLOCK_REASON_MALICIOUS.String()),
+				Tag:         types.StringValue(tag),
+				Comment:     data.Reason,
+				CustomMsg:   entry.CustomMsg,
+			}))
+			if err != nil {
+				resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to create blocklist rule for %s %q on tag %q: %v. The tags have already been locked down; their previous client modes are recorded in previous_client_modes.", entry.RuleType.ValueString(), entry.Identifier.ValueString(), tag, err))
+				saveState()
+				return
+			}
+		}
+	}
+
+	tflog.Warn(ctx, "Emergency lockdown applied", map[string]any{"tags": tags, "blocklist": len(data.Blocklist)})
+
+	// Save data into Terraform state
+	saveState()
+}
+
+// lockDownTag sets tag's client mode to LOCKDOWN, keeping the rest of its sync
+// settings, and returns the mode it replaced ("" if the tag had no settings).
+func lockDownTag(ctx context.Context, client svcpb.WorkshopServiceClient, tag string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	ss, found, err := fetchSyncSettings(ctx, client, tag)
+	if err != nil {
+		diags.AddError(clientError(err), fmt.Sprintf("Failed to read sync settings for tag %q: %v", tag, err))
+		return "", diags
+	}
+
+	var previous string
+	if found {
+		ss = proto.CloneOf(ss)
+		if mode := ss.GetClientMode(); mode != apipb.ClientMode_UNKNOWN_CLIENT_MODE {
+			previous = mode.String()
+		}
+	} else {
+		ss = apipb.SyncSettings_builder{Tag: tag}.Build()
+	}
+	ss.SetClientMode(apipb.ClientMode_LOCKDOWN)
+
+	if _, err := client.UpdateSyncSettings(ctx, apipb.UpdateSyncSettingsRequest_builder{SyncSettings: ss}.Build()); err != nil {
+		diags.AddError(clientError(err), fmt.Sprintf("Failed to lock down tag %q: %v", tag, err))
+		return "", diags
+	}
+	return previous, diags
+}
+
+// Read keeps the recorded state: the resource records an action that was
+// taken rather than an object that can drift.
+func (r *EmergencyLockdownResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// Update is never called with changes, since every configurable attribute
+// forces replacement.
+func (r *EmergencyLockdownResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data EmergencyLockdownResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *EmergencyLockdownResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data EmergencyLockdownResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.AddWarning(
+		codeNotice.summary("Lockdown not lifted"),
+		fmt.Sprintf("Destroying nps_workshop_emergency_lockdown only removes it from state. The tags remain in LOCKDOWN and the blocklist rules remain in place; the previous client modes were %s.", data.PreviousClientModes),
+	)
+}
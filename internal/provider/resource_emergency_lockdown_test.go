@@ -0,0 +1,112 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// createEmergencyLockdown runs Create for a lockdown of the "dev" tag with one
+// blocklist entry.
+func createEmergencyLockdown(t *testing.T, r *EmergencyLockdownResource) *resource.CreateResponse {
+	t.Helper()
+	ctx := context.Background()
+	var sResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &sResp)
+
+	plan := tfsdk.Plan{Schema: sResp.Schema}
+	diags := plan.Set(ctx, EmergencyLockdownResourceModel{
+		Tags:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue("dev")}),
+		Confirm: types.StringValue(emergencyLockdownConfirmation),
+		Reason:  types.StringValue("INC-1"),
+		Blocklist: []EmergencyLockdownBlockModel{
+			{Identifier: types.StringValue("EQHXZ8M8AV"), RuleType: types.StringValue("TEAMID"), CustomMsg: types.StringNull()},
+		},
+		PreviousClientModes: types.MapUnknown(types.StringType),
+		ActivatedAt:         types.StringUnknown(),
+		Id:                  types.StringUnknown(),
+	})
+	if diags.HasError() {
+		t.Fatalf("failed to build plan: %v", diags)
+	}
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: sResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+	return resp
+}
+
+func TestEmergencyLockdownCreate(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeWorkshopClient{
+		listSync: []*apipb.SyncSettings{
+			apipb.SyncSettings_builder{Tag: "dev", ClientMode: apipb.ClientMode_MONITOR, BatchSize: proto.Uint32(50)}.Build(),
+		},
+	}
+	r := &EmergencyLockdownResource{client: fake}
+
+	resp := createEmergencyLockdown(t, r)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("create failed: %v", resp.Diagnostics)
+	}
+
+	if len(fake.syncUpdates) != 1 {
+		t.Fatalf("UpdateSyncSettings called %d times, want 1", len(fake.syncUpdates))
+	}
+	if got := fake.syncUpdates[0]; got.GetClientMode() != apipb.ClientMode_LOCKDOWN || got.GetBatchSize() != 50 {
+		t.Errorf("sync settings = %v, want LOCKDOWN with the existing batch size kept", got)
+	}
+	if fake.listSync[0].GetClientMode() != apipb.ClientMode_MONITOR {
+		t.Error("the fetched sync settings were modified in place")
+	}
+
+	if len(fake.createRuleReqs) != 1 {
+		t.Fatalf("CreateRule called %d times, want 1", len(fake.createRuleReqs))
+	}
+	rule := fake.createRuleReqs[0].GetRule()
+	if rule.GetPolicy() != apipb.Policy_BLOCKLIST || rule.GetTag() != "dev" || rule.GetComment() != "INC-1" {
+		t.Errorf("rule = %v", rule)
+	}
+
+	var got EmergencyLockdownResourceModel
+	resp.State.Get(ctx, &got)
+	if mode := got.PreviousClientModes.Elements()["dev"]; mode == nil || mode.(types.String).ValueString() != "MONITOR" {
+		t.Errorf("previous_client_modes = %v, want dev = MONITOR", got.PreviousClientModes)
+	}
+}
+
+func TestEmergencyLockdownCreateKeepsPreviousModesOnFailure(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeWorkshopClient{
+		listSync: []*apipb.SyncSettings{
+			apipb.SyncSettings_builder{Tag: "dev", ClientMode: apipb.ClientMode_MONITOR}.Build(),
+		},
+		createErr: status.Error(codes.Unavailable, "unavailable"),
+	}
+	r := &EmergencyLockdownResource{client: fake}
+
+	resp := createEmergencyLockdown(t, r)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("create succeeded, want the CreateRule error")
+	}
+	if resp.State.Raw.IsNull() {
+		t.Fatal("no state saved after the tags were locked down")
+	}
+	var got EmergencyLockdownResourceModel
+	resp.State.Get(ctx, &got)
+	if mode := got.PreviousClientModes.Elements()["dev"]; mode == nil || mode.(types.String).ValueString() != "MONITOR" {
+		t.Errorf("previous_client_modes = %v, want dev = MONITOR", got.PreviousClientModes)
+	}
+	if got.Id.IsUnknown() || got.Id.IsNull() {
+		t.Errorf("id = %v, want it set", got.Id)
+	}
+}
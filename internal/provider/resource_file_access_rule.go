@@ -4,10 +4,11 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
-	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/list"
 	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
@@ -21,6 +22,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/northpolesec/terraform-provider-nps/internal/filter"
+	"github.com/northpolesec/terraform-provider-nps/internal/utils"
 	"google.golang.org/protobuf/proto"
 
 	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
@@ -32,6 +35,9 @@ var _ resource.Resource = &FileAccessRuleResource{}
 var _ resource.ResourceWithConfigure = &FileAccessRuleResource{}
 var _ resource.ResourceWithImportState = &FileAccessRuleResource{}
 var _ resource.ResourceWithIdentity = &FileAccessRuleResource{}
+var _ resource.ResourceWithUpgradeIdentity = &FileAccessRuleResource{}
+var _ resource.ResourceWithModifyPlan = &FileAccessRuleResource{}
+var _ resource.ResourceWithConfigValidators = &FileAccessRuleResource{}
 var _ list.ListResource = &FileAccessRuleResource{}
 var _ list.ListResourceWithConfigure = &FileAccessRuleResource{}
 
@@ -42,25 +48,29 @@ var fileAccessRuleTypeToFriendly = map[apipb.FileAccessRuleType]string{
 	apipb.FileAccessRuleType_FILE_ACCESS_RULE_TYPE_PROCESSES_WITH_DENIED_PATHS:  "ProcessesWithDeniedPaths",
 }
 
-func fileAccessRuleTypeFriendlyName(rt apipb.FileAccessRuleType) string {
-	if name, ok := fileAccessRuleTypeToFriendly[rt]; ok {
-		return name
-	}
-	return rt.String()
-}
-
 func NewFileAccessRuleResource() resource.Resource {
 	return &FileAccessRuleResource{}
 }
 
 // FileAccessRuleResource defines the resource implementation.
 type FileAccessRuleResource struct {
-	client svcpb.WorkshopServiceClient
+	client              svcpb.WorkshopServiceClient
+	strictRead          bool
+	acceptNewEnumValues bool
+	defaultTag          string
+	backup              *destroyBackup
 }
 
 // FileAccessRuleIdentityModel describes the identity data model.
 type FileAccessRuleIdentityModel struct {
-	Id types.Int64 `tfsdk:"id"`
+	Id   types.Int64  `tfsdk:"id"`
+	Tag  types.String `tfsdk:"tag"`
+	Name types.String `tfsdk:"name"`
+}
+
+// fileAccessRuleIdentity is the identity of the file access rule in data.
+func fileAccessRuleIdentity(data FileAccessRuleResourceModel) FileAccessRuleIdentityModel {
+	return FileAccessRuleIdentityModel{Id: data.Id, Tag: data.Tag, Name: data.Name}
 }
 
 // FileAccessRuleResourceModel describes the resource data model.
@@ -82,6 +92,9 @@ type FileAccessRuleResourceModel struct {
 	ProcessSigningIds         types.List   `tfsdk:"process_signing_ids"`
 	ProcessCertificateSha256s types.List   `tfsdk:"process_certificate_sha256s"`
 	ProcessTeamIds            types.List   `tfsdk:"process_team_ids"`
+	PreserveOrder             types.Bool   `tfsdk:"preserve_order"`
+	SkipUnchangedRefresh      types.Bool   `tfsdk:"skip_unchanged_refresh"`
+	IgnoreServerChanges       []string     `tfsdk:"ignore_server_changes"`
 
 	Id types.Int64 `tfsdk:"id"`
 }
@@ -112,12 +125,15 @@ func (r *FileAccessRuleResource) Schema(ctx context.Context, req resource.Schema
 				},
 			},
 			"tag": schema.StringAttribute{
-				Description:         "The tag for this file access rule. The tag determines which hosts this rule will apply to. The tag must already exist in Workshop.",
-				MarkdownDescription: "The tag for this file access rule. The tag determines which hosts this rule will apply to. The tag must already exist in Workshop.",
-				Required:            true,
+				Description:         "The tag for this file access rule. The tag determines which hosts this rule will apply to. The tag must already exist in Workshop. Defaults to the provider's default_tag; one of the two must be set.",
+				MarkdownDescription: "The tag for this file access rule. The tag determines which hosts this rule will apply to. The tag must already exist in Workshop. Defaults to the provider's `default_tag`; one of the two must be set.",
+				Optional:            true,
+				Computed:            true,
 				// TODO(rah): Add validator
 				// Part of the natural key; see name.
+				// An omitted tag takes the provider's default_tag; see applyDefaultTag.
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -136,11 +152,11 @@ func (r *FileAccessRuleResource) Schema(ctx context.Context, req resource.Schema
 				Default:             booldefault.StaticBool(false),
 			},
 			"rule_type": schema.StringAttribute{
-				Description:         "The type of this file access rule. The possible values are: PathsWithAllowedProcesses, PathsWithDeniedProcesses, ProcessesWithAllowedPaths, ProcessesWithDeniedPaths.",
-				MarkdownDescription: "The type of this file access rule. The possible values are: `PathsWithAllowedProcesses`, `PathsWithDeniedProcesses`, `ProcessesWithAllowedPaths`, `ProcessesWithDeniedPaths`.",
+				Description:         "The type of this file access rule. The possible values are: PathsWithAllowedProcesses, PathsWithDeniedProcesses, ProcessesWithAllowedPaths, ProcessesWithDeniedPaths. Every rule needs path_literals or path_prefixes; all types except PathsWithAllowedProcesses also need at least one process_* attribute.",
+				MarkdownDescription: "The type of this file access rule. The possible values are: `PathsWithAllowedProcesses`, `PathsWithDeniedProcesses`, `ProcessesWithAllowedPaths`, `ProcessesWithDeniedPaths`. Every rule needs `path_literals` or `path_prefixes`; all types except `PathsWithAllowedProcesses` also need at least one `process_*` attribute.",
 				Required:            true,
 				Validators: []validator.String{
-					stringvalidator.OneOf(
+					oneOf(
 						"PathsWithAllowedProcesses",
 						"PathsWithDeniedProcesses",
 						"ProcessesWithAllowedPaths",
@@ -166,11 +182,17 @@ func (r *FileAccessRuleResource) Schema(ctx context.Context, req resource.Schema
 				Description:         "A custom message to display to the user when this rule blocks file access.",
 				MarkdownDescription: "A custom message to display to the user when this rule blocks file access.",
 				Optional:            true,
+				Validators: []validator.String{
+					customMsgValidator{},
+				},
 			},
 			"event_detail_url": schema.StringAttribute{
 				Description:         "A custom URL to redirect the user to when viewing details about a file access event. Setting a custom URL will override the EventDetailURL used by the Open button.",
 				MarkdownDescription: "A custom URL to redirect the user to when viewing details about a file access event. Setting a custom URL will override the `EventDetailURL` used by the Open button.",
 				Optional:            true,
+				Validators: []validator.String{
+					fileAccessEventURLValidator(),
+				},
 			},
 			"event_detail_text": schema.StringAttribute{
 				Description:         "Custom text to display for the event detail link.",
@@ -183,7 +205,10 @@ func (r *FileAccessRuleResource) Schema(ctx context.Context, req resource.Schema
 				Optional:            true,
 				ElementType:         types.StringType,
 				Validators: []validator.List{
-					listvalidator.SizeAtLeast(0),
+					// Omit a list rather than setting it empty: the API doesn't
+					// distinguish the two, so Read stores both as null. The same
+					// applies to every list attribute below.
+					listvalidator.SizeAtLeast(1),
 					listvalidator.AtLeastOneOf(path.MatchRoot("path_prefixes")),
 				},
 			},
@@ -193,7 +218,7 @@ func (r *FileAccessRuleResource) Schema(ctx context.Context, req resource.Schema
 				Optional:            true,
 				ElementType:         types.StringType,
 				Validators: []validator.List{
-					listvalidator.SizeAtLeast(0),
+					listvalidator.SizeAtLeast(1),
 				},
 			},
 			"process_binary_paths": schema.ListAttribute{
@@ -202,16 +227,16 @@ func (r *FileAccessRuleResource) Schema(ctx context.Context, req resource.Schema
 				Optional:            true,
 				ElementType:         types.StringType,
 				Validators: []validator.List{
-					listvalidator.SizeAtLeast(0),
+					listvalidator.SizeAtLeast(1),
 				},
 			},
 			"process_cd_hashes": schema.ListAttribute{
-				Description:         "Process CDHashes that this rule applies to.",
-				MarkdownDescription: "Process CDHashes that this rule applies to.",
+				Description:         "Process CDHashes that this rule applies to. Hashes are case-insensitive.",
+				MarkdownDescription: "Process CDHashes that this rule applies to. Hashes are case-insensitive.",
 				Optional:            true,
 				ElementType:         types.StringType,
 				Validators: []validator.List{
-					listvalidator.SizeAtLeast(0),
+					listvalidator.SizeAtLeast(1),
 					// TODO(rah): Add validator.
 				},
 			},
@@ -221,17 +246,17 @@ func (r *FileAccessRuleResource) Schema(ctx context.Context, req resource.Schema
 				Optional:            true,
 				ElementType:         types.StringType,
 				Validators: []validator.List{
-					listvalidator.SizeAtLeast(0),
+					listvalidator.SizeAtLeast(1),
 					// TODO(rah): Add validator.
 				},
 			},
 			"process_certificate_sha256s": schema.ListAttribute{
-				Description:         "Process certificate SHA256 hashes that this rule applies to.",
-				MarkdownDescription: "Process certificate SHA256 hashes that this rule applies to.",
+				Description:         "Process certificate SHA256 hashes that this rule applies to. Hashes are case-insensitive.",
+				MarkdownDescription: "Process certificate SHA256 hashes that this rule applies to. Hashes are case-insensitive.",
 				Optional:            true,
 				ElementType:         types.StringType,
 				Validators: []validator.List{
-					listvalidator.SizeAtLeast(0),
+					listvalidator.SizeAtLeast(1),
 					// TODO(rah): Add validator.
 				},
 			},
@@ -241,10 +266,21 @@ func (r *FileAccessRuleResource) Schema(ctx context.Context, req resource.Schema
 				Optional:            true,
 				ElementType:         types.StringType,
 				Validators: []validator.List{
-					listvalidator.SizeAtLeast(0),
+					listvalidator.SizeAtLeast(1),
 					// TODO(rah): Add validator.
 				},
 			},
+			"preserve_order": schema.BoolAttribute{
+				Description:         "Whether refresh keeps the configured order of the path and process lists when the server returns the same entries in a different order. Defaults to false, in which case the server's order is stored and a reordering shows as a diff.",
+				MarkdownDescription: "Whether refresh keeps the configured order of the path and process lists when the server returns the same entries in a different order. Defaults to `false`, in which case the server's order is stored and a reordering shows as a diff.",
+				Optional:            true,
+			},
+			"ignore_server_changes": ignoreServerChangesAttribute("block_message", "event_detail_url", "event_detail_text"),
+			"skip_unchanged_refresh": schema.BoolAttribute{
+				Description:         "Whether refresh skips fetching the rule when it hasn't changed since the last refresh. The server reassigns a rule's ID whenever the rule is updated, so refresh first checks whether a rule with the ID in state still exists and only fetches the full rule if it doesn't. Useful for rules with thousands of paths. Defaults to false.",
+				MarkdownDescription: "Whether refresh skips fetching the rule when it hasn't changed since the last refresh. The server reassigns a rule's ID whenever the rule is updated, so refresh first checks whether a rule with the ID in state still exists and only fetches the full rule if it doesn't. Useful for rules with thousands of paths. Defaults to `false`.",
+				Optional:            true,
+			},
 
 			// Computed value, returned from Create. The ID changes on every
 			// upsert (including in-place updates), so it is intentionally left
@@ -258,6 +294,47 @@ func (r *FileAccessRuleResource) Schema(ctx context.Context, req resource.Schema
 	}
 }
 
+func (r *FileAccessRuleResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		utils.ConfigValidatorFunc("Validate process matchers for rule_type", func(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+			var data FileAccessRuleResourceModel
+			resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			validateFileAccessRuleProcesses(data, &resp.Diagnostics)
+		}),
+	}
+}
+
+// validateFileAccessRuleProcesses checks that a rule whose type can't work
+// without a process matcher has one. Process-oriented rules match processes
+// and constrain paths, so the processes are the subject; a
+// PathsWithDeniedProcesses rule with no processes would never deny anything.
+// Only PathsWithAllowedProcesses may omit them, meaning no process is allowed.
+// Paths are required for every type; see path_literals.
+func validateFileAccessRuleProcesses(data FileAccessRuleResourceModel, diags *diag.Diagnostics) {
+	if data.RuleType.IsUnknown() || data.RuleType.IsNull() || data.RuleType.ValueString() == "PathsWithAllowedProcesses" {
+		return
+	}
+	for _, l := range []types.List{
+		data.ProcessBinaryPaths,
+		data.ProcessCdHashes,
+		data.ProcessSigningIds,
+		data.ProcessCertificateSha256s,
+		data.ProcessTeamIds,
+	} {
+		if l.IsUnknown() || len(l.Elements()) > 0 {
+			return
+		}
+	}
+	diags.AddAttributeError(
+		path.Root("rule_type"),
+		codeInvalidConfig.summary("Invalid configuration"),
+		fmt.Sprintf("A %s rule needs at least one of process_binary_paths, process_cd_hashes, process_signing_ids, process_certificate_sha256s or process_team_ids.", data.RuleType.ValueString()),
+	)
+}
+
 func (r *FileAccessRuleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -267,12 +344,25 @@ func (r *FileAccessRuleResource) Configure(ctx context.Context, req resource.Con
 	pd, ok := req.ProviderData.(*NPSProviderResourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
+			codeInternal.summary("Unexpected Resource Configure Type"),
 			fmt.Sprintf("Expected NPSProviderResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 	r.client = pd.Client
+	r.strictRead = pd.StrictRead
+	r.acceptNewEnumValues = pd.AcceptNewEnumValues
+	r.defaultTag = pd.DefaultTag
+	r.backup = pd.DestroyBackup
+}
+
+// ModifyPlan applies the provider's default_tag, which is only available once
+// the provider is configured.
+func (r *FileAccessRuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+	applyDefaultTag(ctx, r.defaultTag, req, resp)
 }
 
 func (r *FileAccessRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -294,7 +384,7 @@ func (r *FileAccessRuleResource) Create(ctx context.Context, req resource.Create
 		Rule: rule,
 	}.Build())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to create file access rule: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to create file access rule: %v", err))
 		return
 	}
 
@@ -302,7 +392,7 @@ func (r *FileAccessRuleResource) Create(ctx context.Context, req resource.Create
 	tflog.Info(ctx, fmt.Sprintf("Created file access rule: %d", data.Id.ValueInt64()))
 
 	// Set the identity
-	resp.Diagnostics.Append(resp.Identity.Set(ctx, FileAccessRuleIdentityModel{Id: data.Id})...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, fileAccessRuleIdentity(data))...)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -318,16 +408,32 @@ func (r *FileAccessRuleResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
+	if data.SkipUnchangedRefresh.ValueBool() {
+		unchanged, err := r.fileAccessRuleUnchanged(ctx, data)
+		if err != nil {
+			resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to count file access rules: %v", err))
+			return
+		}
+		if unchanged {
+			tflog.Debug(ctx, fmt.Sprintf("File access rule %d unchanged, keeping state", data.Id.ValueInt64()))
+			resp.Diagnostics.Append(resp.Identity.Set(ctx, fileAccessRuleIdentity(data))...)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+	}
+
 	// Query for the rule by ID, name, and tag
-	filter := fmt.Sprintf(`rule_id = %d OR (name = "%s" AND tag = "%s")`,
-		data.Id.ValueInt64(), data.Name.ValueString(), data.Tag.ValueString())
+	query := filter.Or(
+		filter.Eq("rule_id", data.Id.ValueInt64()),
+		filter.And(filter.Eq("name", data.Name.ValueString()), filter.Eq("tag", data.Tag.ValueString())),
+	)
 
 	ret, err := r.client.ListFileAccessRules(ctx, apipb.ListFileAccessRulesRequest_builder{
-		Filter:   proto.String(filter),
+		Filter:   proto.String(query.String()),
 		PageSize: proto.Uint32(1),
 	}.Build())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to list file access rules: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to list file access rules: %v", err))
 		return
 	}
 	if len(ret.GetRules()) == 0 {
@@ -340,13 +446,54 @@ func (r *FileAccessRuleResource) Read(ctx context.Context, req resource.ReadRequ
 
 	// Now that we've found the rule, overwrite the state data with the actual
 	// values retrieved via the API.
-	rule := ret.GetRules()[0]
+	dec := newReadDecoder(r.strictRead, &resp.Diagnostics).withNewEnumValues(r.acceptNewEnumValues)
+	applyFileAccessRuleProto(ctx, &data, ret.GetRules()[0], dec)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set the identity
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, fileAccessRuleIdentity(data))...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	keepIgnoredServerChanges(ctx, req.State, &resp.State, &resp.Diagnostics)
+}
+
+// fileAccessRuleUnchanged reports whether the rule in data is unchanged on the
+// server. The API has no field masks or etags, but every upsert reassigns the
+// rule ID, so a rule that still has the ID in state hasn't changed; checking
+// that with a count avoids transferring the rule's lists.
+func (r *FileAccessRuleResource) fileAccessRuleUnchanged(ctx context.Context, data FileAccessRuleResourceModel) (bool, error) {
+	if data.Id.IsNull() || data.Id.IsUnknown() {
+		return false, nil
+	}
+	query := filter.And(
+		filter.Eq("rule_id", data.Id.ValueInt64()),
+		filter.Eq("name", data.Name.ValueString()),
+		filter.Eq("tag", data.Tag.ValueString()),
+	)
+	ret, err := r.client.ListFileAccessRules(ctx, apipb.ListFileAccessRulesRequest_builder{
+		Filter:    proto.String(query.String()),
+		CountOnly: proto.Bool(true),
+	}.Build())
+	if err != nil {
+		return false, err
+	}
+	return ret.GetCount() == 1, nil
+}
+
+// applyFileAccessRuleProto overwrites data with the values of rule, as
+// returned by ListFileAccessRules. Optional strings the server reports as
+// empty keep their value in data, so Read passes the prior state and List an
+// empty model.
+func applyFileAccessRuleProto(ctx context.Context, data *FileAccessRuleResourceModel, rule *apipb.FileAccessRule, dec readDecoder) {
 	data.Id = types.Int64Value(rule.GetRuleId())
 	data.Tag = types.StringValue(rule.GetTag())
 	data.Name = types.StringValue(rule.GetName())
 	data.AllowReadAccess = types.BoolValue(rule.GetAllowReadAccess())
 	data.BlockViolations = types.BoolValue(rule.GetBlockViolations())
-	data.RuleType = types.StringValue(fileAccessRuleTypeFriendlyName(rule.GetRuleType()))
+	data.RuleType = dec.mapped(path.Root("rule_type"), rule.GetRuleType(), fileAccessRuleTypeToFriendly[rule.GetRuleType()], data.RuleType)
 	data.EnableSilentMode = types.BoolValue(rule.GetEnableSilentMode())
 	data.EnableSilentTtyMode = types.BoolValue(rule.GetEnableSilentTtyMode())
 
@@ -360,34 +507,67 @@ func (r *FileAccessRuleResource) Read(ctx context.Context, req resource.ReadRequ
 		data.EventDetailText = types.StringValue(rule.GetEventDetailText())
 	}
 
-	// Convert slices to list types
-	if len(rule.GetPathLiterals()) > 0 {
-		data.PathLiterals, _ = types.ListValueFrom(ctx, types.StringType, rule.GetPathLiterals())
-	}
-	if len(rule.GetPathPrefixes()) > 0 {
-		data.PathPrefixes, _ = types.ListValueFrom(ctx, types.StringType, rule.GetPathPrefixes())
-	}
-	if len(rule.GetProcessBinaryPaths()) > 0 {
-		data.ProcessBinaryPaths, _ = types.ListValueFrom(ctx, types.StringType, rule.GetProcessBinaryPaths())
-	}
-	if len(rule.GetProcessCdHashes()) > 0 {
-		data.ProcessCdHashes, _ = types.ListValueFrom(ctx, types.StringType, rule.GetProcessCdHashes())
-	}
-	if len(rule.GetProcessSigningIds()) > 0 {
-		data.ProcessSigningIds, _ = types.ListValueFrom(ctx, types.StringType, rule.GetProcessSigningIds())
-	}
-	if len(rule.GetProcessCertificateSha256S()) > 0 {
-		data.ProcessCertificateSha256s, _ = types.ListValueFrom(ctx, types.StringType, rule.GetProcessCertificateSha256S())
-	}
-	if len(rule.GetProcessTeamIds()) > 0 {
-		data.ProcessTeamIds, _ = types.ListValueFrom(ctx, types.StringType, rule.GetProcessTeamIds())
+	// Convert slices to list types. Every list is overwritten, so entries
+	// removed on the server don't linger in state.
+	preserveOrder := data.PreserveOrder.ValueBool()
+	data.PathLiterals = fileAccessRuleList(ctx, rule.GetPathLiterals(), data.PathLiterals, preserveOrder, dec.diags)
+	data.PathPrefixes = fileAccessRuleList(ctx, rule.GetPathPrefixes(), data.PathPrefixes, preserveOrder, dec.diags)
+	data.ProcessBinaryPaths = fileAccessRuleList(ctx, rule.GetProcessBinaryPaths(), data.ProcessBinaryPaths, preserveOrder, dec.diags)
+	data.ProcessCdHashes = fileAccessRuleHashList(ctx, rule.GetProcessCdHashes(), data.ProcessCdHashes, preserveOrder, dec.diags)
+	data.ProcessSigningIds = fileAccessRuleList(ctx, rule.GetProcessSigningIds(), data.ProcessSigningIds, preserveOrder, dec.diags)
+	data.ProcessCertificateSha256s = fileAccessRuleHashList(ctx, rule.GetProcessCertificateSha256S(), data.ProcessCertificateSha256s, preserveOrder, dec.diags)
+	data.ProcessTeamIds = fileAccessRuleList(ctx, rule.GetProcessTeamIds(), data.ProcessTeamIds, preserveOrder, dec.diags)
+}
+
+// fileAccessRuleList converts a list from the API into its Terraform value.
+// The API doesn't distinguish an empty list from an unset one, so an empty
+// list is null. When preserveOrder is set and prior holds the same entries in
+// a different order, prior is returned so a server-side reordering doesn't
+// show as a diff.
+func fileAccessRuleList(ctx context.Context, values []string, prior types.List, preserveOrder bool, diags *diag.Diagnostics) types.List {
+	if len(values) == 0 {
+		return types.ListNull(types.StringType)
+	}
+	if preserveOrder && !prior.IsNull() && !prior.IsUnknown() {
+		var priorValues []string
+		if d := prior.ElementsAs(ctx, &priorValues, false); !d.HasError() && sameElements(priorValues, values) {
+			return prior
+		}
 	}
+	l, d := types.ListValueFrom(ctx, types.StringType, values)
+	diags.Append(d...)
+	return l
+}
 
-	// Set the identity
-	resp.Diagnostics.Append(resp.Identity.Set(ctx, FileAccessRuleIdentityModel{Id: data.Id})...)
+// fileAccessRuleHashList is fileAccessRuleList for a list of hashes, which the
+// server stores lower case. prior is kept if it holds the same hashes written
+// in another case, so pasting upper-case hashes doesn't show as a diff.
+func fileAccessRuleHashList(ctx context.Context, values []string, prior types.List, preserveOrder bool, diags *diag.Diagnostics) types.List {
+	if len(values) > 0 && !prior.IsNull() && !prior.IsUnknown() {
+		var priorValues []string
+		if d := prior.ElementsAs(ctx, &priorValues, false); !d.HasError() {
+			normalized := make([]string, len(priorValues))
+			for i, v := range priorValues {
+				normalized[i] = normalizeHash(v)
+			}
+			if slices.Equal(normalized, values) || preserveOrder && sameElements(normalized, values) {
+				return prior
+			}
+		}
+	}
+	return fileAccessRuleList(ctx, values, prior, preserveOrder, diags)
+}
 
-	// Save updated data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+// sameElements reports whether a and b hold the same strings, with the same
+// multiplicity, in any order.
+func sameElements(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
 }
 
 // buildFileAccessRule builds the (upsert) FileAccessRule from the model.
@@ -430,6 +610,11 @@ func buildFileAccessRule(ctx context.Context, data FileAccessRuleResourceModel,
 	convertListHelper(data.ProcessSigningIds, &builder.ProcessSigningIds)
 	convertListHelper(data.ProcessCertificateSha256s, &builder.ProcessCertificateSha256S)
 	convertListHelper(data.ProcessTeamIds, &builder.ProcessTeamIds)
+	for _, hashes := range [][]string{builder.ProcessCdHashes, builder.ProcessCertificateSha256S} {
+		for i, h := range hashes {
+			hashes[i] = normalizeHash(h)
+		}
+	}
 
 	return builder.Build()
 }
@@ -449,7 +634,7 @@ func (r *FileAccessRuleResource) Update(ctx context.Context, req resource.Update
 	plan.Id = newID
 	tflog.Info(ctx, fmt.Sprintf("Updated file access rule: %d", plan.Id.ValueInt64()))
 
-	resp.Diagnostics.Append(resp.Identity.Set(ctx, FileAccessRuleIdentityModel{Id: plan.Id})...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, fileAccessRuleIdentity(plan))...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -472,7 +657,7 @@ func (r *FileAccessRuleResource) upsertFileAccessRule(ctx context.Context, plan
 		Rule: rule,
 	}.Build())
 	if err != nil {
-		diags.AddError("Client Error", fmt.Sprintf("Failed to update file access rule: %v", err))
+		diags.AddError(clientError(err), fmt.Sprintf("Failed to update file access rule: %v", err))
 		return types.Int64Null(), diags
 	}
 	return types.Int64Value(crResp.GetRuleId()), diags
@@ -489,12 +674,17 @@ func (r *FileAccessRuleResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
+	backupBeforeDelete(ctx, r.backup, data.Tag.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	ruleId := data.Id.ValueInt64()
 	_, err := r.client.DeleteFileAccessRule(ctx, apipb.DeleteFileAccessRuleRequest_builder{
 		RuleId: proto.Int64(ruleId),
 	}.Build())
 	if err != nil && !isRuleDeleteNoOp(err) {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete file access rule: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to delete file access rule: %v", err))
 		return
 	}
 
@@ -502,25 +692,68 @@ func (r *FileAccessRuleResource) Delete(ctx context.Context, req resource.Delete
 }
 
 func (r *FileAccessRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var identity FileAccessRuleIdentityModel
+	if importIdentity(ctx, req, resp, &identity) {
+		switch {
+		case resp.Diagnostics.HasError():
+		case !identity.Id.IsNull():
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.Id)...)
+		case identity.Tag.ValueString() != "" && identity.Name.ValueString() != "":
+			// Read resolves the tag and name to the rule's current ID.
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), int64(0))...)
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tag"), identity.Tag)...)
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), identity.Name)...)
+		default:
+			resp.Diagnostics.AddError(codeInvalidConfig.summary("Invalid import identity"), "Expected an identity with id, or with tag and name.")
+		}
+		return
+	}
+
 	// Import a file access rule by ID, which will trigger a Read.
 	id, err := strconv.ParseInt(req.ID, 10, 64)
-	if err != nil {
-		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Failed to parse ID %q as integer: %v", req.ID, err))
+	if err == nil {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+		return
+	}
+
+	// Rule names are unique per tag, so a rule can also be imported as
+	// <tag>/<name>. Read resolves the tag and name to the rule's current ID.
+	tag, name, ok := strings.Cut(req.ID, "/")
+	if !ok || tag == "" || name == "" {
+		resp.Diagnostics.AddError(codeInvalidConfig.summary("Invalid ID"), fmt.Sprintf("Expected a numeric rule ID or <tag>/<name>, got %q", req.ID))
 		return
 	}
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), int64(0))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tag"), tag)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
 }
 
 func (r *FileAccessRuleResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
 	resp.IdentitySchema = identityschema.Schema{
+		Version: 1,
 		Attributes: map[string]identityschema.Attribute{
 			"id": identityschema.Int64Attribute{
-				RequiredForImport: true,
+				Description:       "The rule's ID. Set either `id`, or `tag` and `name`.",
+				OptionalForImport: true,
+			},
+			"tag": identityschema.StringAttribute{
+				Description:       "The tag the rule applies to.",
+				OptionalForImport: true,
+			},
+			"name": identityschema.StringAttribute{
+				Description:       "The rule's name, which is unique per tag.",
+				OptionalForImport: true,
 			},
 		},
 	}
 }
 
+func (r *FileAccessRuleResource) UpgradeIdentity(ctx context.Context) map[int64]resource.IdentityUpgrader {
+	return idIdentityUpgraders(identityschema.Int64Attribute{RequiredForImport: true}, func(id types.Int64) any {
+		return FileAccessRuleIdentityModel{Id: id}
+	})
+}
+
 func NewFileAccessRuleListResource() list.ListResource {
 	return &FileAccessRuleResource{}
 }
@@ -534,58 +767,33 @@ func (r *FileAccessRuleResource) ListResourceConfigSchema(ctx context.Context, r
 
 func (r *FileAccessRuleResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
 	stream.Results = func(push func(list.ListResult) bool) {
-		ret, err := r.client.ListFileAccessRules(ctx, apipb.ListFileAccessRulesRequest_builder{}.Build())
-		if err != nil {
-			result := req.NewListResult(ctx)
-			result.Diagnostics.AddError("Client Error", "Failed to list file access rules: "+err.Error())
-			push(result)
-			return
-		}
+		pages := listPages(ctx, "file access rules", func(page uint32) ([]*apipb.FileAccessRule, bool, error) {
+			ret, err := r.client.ListFileAccessRules(ctx, apipb.ListFileAccessRulesRequest_builder{
+				PageSize: proto.Uint32(listPageSize),
+				Page:     proto.Uint32(page),
+			}.Build())
+			return ret.GetRules(), ret.GetMore(), err
+		})
+		for rule, err := range pages {
+			if err != nil {
+				result := req.NewListResult(ctx)
+				result.Diagnostics.AddError(clientError(err), "Failed to list file access rules: "+err.Error())
+				push(result)
+				return
+			}
 
-		for _, rule := range ret.GetRules() {
 			result := req.NewListResult(ctx)
 			result.DisplayName = rule.GetName()
 
 			result.Diagnostics.Append(result.Identity.Set(ctx, FileAccessRuleIdentityModel{
-				Id: types.Int64Value(rule.GetRuleId()),
+				Id:   types.Int64Value(rule.GetRuleId()),
+				Tag:  types.StringValue(rule.GetTag()),
+				Name: types.StringValue(rule.GetName()),
 			})...)
 
 			if req.IncludeResource {
-				toListOrNull := func(slice []string) types.List {
-					if len(slice) > 0 {
-						l, _ := types.ListValueFrom(ctx, types.StringType, slice)
-						return l
-					}
-					return types.ListNull(types.StringType)
-				}
-
-				model := FileAccessRuleResourceModel{
-					Id:                        types.Int64Value(rule.GetRuleId()),
-					Tag:                       types.StringValue(rule.GetTag()),
-					Name:                      types.StringValue(rule.GetName()),
-					AllowReadAccess:           types.BoolValue(rule.GetAllowReadAccess()),
-					BlockViolations:           types.BoolValue(rule.GetBlockViolations()),
-					RuleType:                  types.StringValue(fileAccessRuleTypeFriendlyName(rule.GetRuleType())),
-					EnableSilentMode:          types.BoolValue(rule.GetEnableSilentMode()),
-					EnableSilentTtyMode:       types.BoolValue(rule.GetEnableSilentTtyMode()),
-					PathLiterals:              toListOrNull(rule.GetPathLiterals()),
-					PathPrefixes:              toListOrNull(rule.GetPathPrefixes()),
-					ProcessBinaryPaths:        toListOrNull(rule.GetProcessBinaryPaths()),
-					ProcessCdHashes:           toListOrNull(rule.GetProcessCdHashes()),
-					ProcessSigningIds:         toListOrNull(rule.GetProcessSigningIds()),
-					ProcessCertificateSha256s: toListOrNull(rule.GetProcessCertificateSha256S()),
-					ProcessTeamIds:            toListOrNull(rule.GetProcessTeamIds()),
-				}
-
-				if rule.GetBlockMessage() != "" {
-					model.BlockMessage = types.StringValue(rule.GetBlockMessage())
-				}
-				if rule.GetEventDetailUrl() != "" {
-					model.EventDetailUrl = types.StringValue(rule.GetEventDetailUrl())
-				}
-				if rule.GetEventDetailText() != "" {
-					model.EventDetailText = types.StringValue(rule.GetEventDetailText())
-				}
+				var model FileAccessRuleResourceModel
+				applyFileAccessRuleProto(ctx, &model, rule, newReadDecoder(r.strictRead, &result.Diagnostics).withNewEnumValues(r.acceptNewEnumValues))
 
 				result.Diagnostics.Append(result.Resource.Set(ctx, model)...)
 			}
@@ -0,0 +1,347 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/northpolesec/terraform-provider-nps/internal/filter"
+	"google.golang.org/protobuf/proto"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &FileAccessRuleCloneResource{}
+var _ resource.ResourceWithConfigure = &FileAccessRuleCloneResource{}
+var _ resource.ResourceWithModifyPlan = &FileAccessRuleCloneResource{}
+
+func NewFileAccessRuleCloneResource() resource.Resource {
+	return &FileAccessRuleCloneResource{}
+}
+
+// FileAccessRuleCloneResource copies a file access rule from one tag to
+// another and keeps the copy in step with its source.
+type FileAccessRuleCloneResource struct {
+	client svcpb.WorkshopServiceClient
+	backup *destroyBackup
+}
+
+// FileAccessRuleCloneResourceModel describes the resource data model.
+type FileAccessRuleCloneResourceModel struct {
+	SourceTag  types.String `tfsdk:"source_tag"`
+	SourceName types.String `tfsdk:"source_name"`
+	TargetTag  types.String `tfsdk:"target_tag"`
+	TargetName types.String `tfsdk:"target_name"`
+
+	Id          types.Int64  `tfsdk:"id"`
+	SourceId    types.Int64  `tfsdk:"source_id"`
+	ContentHash types.String `tfsdk:"content_hash"`
+}
+
+func (r *FileAccessRuleCloneResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workshop_file_access_rule_clone"
+}
+
+func (r *FileAccessRuleCloneResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The `nps_workshop_file_access_rule_clone` resource copies an existing file access rule to another tag, e.g. to promote a rule from a pilot tag to production. The source rule can be managed by `nps_workshop_file_access_rule` or in the Workshop UI.\n\n" +
+			"Every plan compares the copy with the current source rule, and plans an update that copies the source again when either has changed. Destroying the resource deletes the copy; the source rule is unaffected.",
+
+		Attributes: map[string]schema.Attribute{
+			"source_tag": schema.StringAttribute{
+				MarkdownDescription: "The tag of the rule to copy.",
+				Required:            true,
+			},
+			"source_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the rule to copy.",
+				Required:            true,
+			},
+			"target_tag": schema.StringAttribute{
+				MarkdownDescription: "The tag to copy the rule to. Changing it forces replacement.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"target_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the copy. Defaults to `source_name`. Changing it forces replacement. A rule with this name on `target_tag` is replaced.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"id": schema.Int64Attribute{
+				MarkdownDescription: "The ID of the copy. It changes each time the rule is copied again.",
+				Computed:            true,
+			},
+			"source_id": schema.Int64Attribute{
+				MarkdownDescription: "The ID of the source rule when it was last copied.",
+				Computed:            true,
+			},
+			"content_hash": schema.StringAttribute{
+				MarkdownDescription: "A hash of the rule's settings, excluding its tag and name. A plan shows it changing when the source differs from the copy.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *FileAccessRuleCloneResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(*NPSProviderResourceData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			codeInternal.summary("Unexpected Resource Configure Type"),
+			fmt.Sprintf("Expected NPSProviderResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = pd.Client
+	r.backup = pd.DestroyBackup
+}
+
+// ModifyPlan looks up the source rule so that the plan shows an update
+// whenever the source's content differs from the copy's, as recorded by Read.
+func (r *FileAccessRuleCloneResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// No plan on destroy, and the client is unset during validate.
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan FileAccessRuleCloneResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An unset target_name follows source_name. Renaming the copy would leave
+	// the old one behind, so a change replaces it, as when target_name is
+	// configured.
+	if plan.TargetName.IsUnknown() {
+		plan.TargetName = plan.SourceName
+		if !req.State.Raw.IsNull() {
+			var state FileAccessRuleCloneResourceModel
+			resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+			if !plan.TargetName.Equal(state.TargetName) {
+				resp.RequiresReplace = append(resp.RequiresReplace, path.Root("target_name"))
+			}
+		}
+	}
+	if plan.SourceTag.IsUnknown() || plan.SourceName.IsUnknown() {
+		plan.ContentHash = types.StringUnknown()
+		plan.SourceId = types.Int64Unknown()
+		plan.Id = types.Int64Unknown()
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return
+	}
+
+	source, err := findFileAccessRule(ctx, r.client, plan.SourceTag.ValueString(), plan.SourceName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to list file access rules: %v", err))
+		return
+	}
+	if source == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("source_name"),
+			codeNotFound.summary("Source file access rule not found"),
+			fmt.Sprintf("Tag %q has no file access rule named %q.", plan.SourceTag.ValueString(), plan.SourceName.ValueString()),
+		)
+		return
+	}
+
+	hash := fileAccessRuleContentHash(source)
+	if hash != plan.ContentHash.ValueString() || source.GetRuleId() != plan.SourceId.ValueInt64() {
+		plan.ContentHash = types.StringValue(hash)
+		plan.SourceId = types.Int64Value(source.GetRuleId())
+		plan.Id = types.Int64Unknown()
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *FileAccessRuleCloneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data FileAccessRuleCloneResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.cloneRule(ctx, &data, &resp.Diagnostics) {
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("Cloned file access rule %d to %d", data.SourceId.ValueInt64(), data.Id.ValueInt64()))
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read checks the copy still exists and records the hash of its content, so
+// ModifyPlan can compare it with the source.
+func (r *FileAccessRuleCloneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data FileAccessRuleCloneResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	target, err := findFileAccessRule(ctx, r.client, data.TargetTag.ValueString(), data.TargetName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to list file access rules: %v", err))
+		return
+	}
+	if target == nil {
+		tflog.Info(ctx, fmt.Sprintf("File access rule %q not found on tag %q", data.TargetName.ValueString(), data.TargetTag.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Id = types.Int64Value(target.GetRuleId())
+	data.ContentHash = types.StringValue(fileAccessRuleContentHash(target))
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FileAccessRuleCloneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data FileAccessRuleCloneResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The copy's tag and name force replacement, so the upsert supersedes the
+	// existing copy in place.
+	if !r.cloneRule(ctx, &data, &resp.Diagnostics) {
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("Re-cloned file access rule %d to %d", data.SourceId.ValueInt64(), data.Id.ValueInt64()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// cloneRule copies the current source rule to the target tag and name,
+// filling in the computed attributes of data. It reports whether it
+// succeeded.
+func (r *FileAccessRuleCloneResource) cloneRule(ctx context.Context, data *FileAccessRuleCloneResourceModel, diags *diag.Diagnostics) bool {
+	if data.TargetName.IsUnknown() || data.TargetName.IsNull() {
+		data.TargetName = data.SourceName
+	}
+
+	source, err := findFileAccessRule(ctx, r.client, data.SourceTag.ValueString(), data.SourceName.ValueString())
+	if err != nil {
+		diags.AddError(clientError(err), fmt.Sprintf("Failed to list file access rules: %v", err))
+		return false
+	}
+	if source == nil {
+		diags.AddAttributeError(
+			path.Root("source_name"),
+			codeNotFound.summary("Source file access rule not found"),
+			fmt.Sprintf("Tag %q has no file access rule named %q.", data.SourceTag.ValueString(), data.SourceName.ValueString()),
+		)
+		return false
+	}
+
+	rule := cloneFileAccessRule(source, data.TargetTag.ValueString(), data.TargetName.ValueString())
+	crResp, err := r.client.CreateFileAccessRule(ctx, apipb.CreateFileAccessRuleRequest_builder{Rule: rule}.Build())
+	if err != nil {
+		diags.AddError(clientError(err), fmt.Sprintf("Failed to clone file access rule: %v", err))
+		return false
+	}
+
+	data.Id = types.Int64Value(crResp.GetRuleId())
+	data.SourceId = types.Int64Value(source.GetRuleId())
+	data.ContentHash = types.StringValue(fileAccessRuleContentHash(source))
+	return true
+}
+
+func (r *FileAccessRuleCloneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data FileAccessRuleCloneResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	backupBeforeDelete(ctx, r.backup, data.TargetTag.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ruleId := data.Id.ValueInt64()
+	_, err := r.client.DeleteFileAccessRule(ctx, apipb.DeleteFileAccessRuleRequest_builder{
+		RuleId: proto.Int64(ruleId),
+	}.Build())
+	if err != nil && !isRuleDeleteNoOp(err) {
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to delete file access rule: %v", err))
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Deleted cloned file access rule: %d", ruleId))
+}
+
+// findFileAccessRule returns the file access rule with name on tag, or nil if
+// there isn't one.
+func findFileAccessRule(ctx context.Context, client svcpb.WorkshopServiceClient, tag, name string) (*apipb.FileAccessRule, error) {
+	ret, err := client.ListFileAccessRules(ctx, apipb.ListFileAccessRulesRequest_builder{
+		Filter:   proto.String(filter.And(filter.Eq("name", name), filter.Eq("tag", tag)).String()),
+		PageSize: proto.Uint32(1),
+	}.Build())
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range ret.GetRules() {
+		if rule.GetTag() == tag && rule.GetName() == name {
+			return rule, nil
+		}
+	}
+	return nil, nil
+}
+
+// cloneFileAccessRule returns a copy of rule for creating on tag with name,
+// without the fields the server sets.
+func cloneFileAccessRule(rule *apipb.FileAccessRule, tag, name string) *apipb.FileAccessRule {
+	c := proto.CloneOf(rule)
+	c.SetTag(tag)
+	c.SetName(name)
+	c.SetRuleId(0)
+	c.ClearCreatedAt()
+	c.ClearUpdatedAt()
+	c.SetAddedBy("")
+	c.ClearRulePackSubscriptionId()
+	return c
+}
+
+// fileAccessRuleContentHash hashes the settings of rule that a copy shares
+// with its source, so a source and its up-to-date copy hash the same.
+func fileAccessRuleContentHash(rule *apipb.FileAccessRule) string {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(cloneFileAccessRule(rule, "", ""))
+	if err != nil {
+		// Marshaling a valid message can't fail.
+		panic(err)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
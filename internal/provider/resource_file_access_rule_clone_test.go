@@ -0,0 +1,70 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+func TestFileAccessRuleClone(t *testing.T) {
+	ctx := context.Background()
+	source := apipb.FileAccessRule_builder{
+		RuleId:          7,
+		Tag:             "pilot",
+		Name:            "protect-ssh-keys",
+		BlockViolations: true,
+		RuleType:        apipb.FileAccessRuleType_FILE_ACCESS_RULE_TYPE_PATHS_WITH_ALLOWED_PROCESSES,
+		PathPrefixes:    []string{"/Users/*/.ssh/"},
+		ProcessTeamIds:  []string{"EQHXZ8M8AV"},
+		CreatedAt:       timestamppb.Now(),
+		AddedBy:         "alice@example.com",
+	}.Build()
+	client := &fakeWorkshopClient{listFileAccessRules: []*apipb.FileAccessRule{source}, newID: 42}
+	r := &FileAccessRuleCloneResource{client: client}
+
+	data := FileAccessRuleCloneResourceModel{
+		SourceTag:  types.StringValue("pilot"),
+		SourceName: types.StringValue("protect-ssh-keys"),
+		TargetTag:  types.StringValue("production"),
+		TargetName: types.StringUnknown(),
+	}
+	var diags diag.Diagnostics
+	if !r.cloneRule(ctx, &data, &diags) {
+		t.Fatalf("cloneRule() failed: %v", diags)
+	}
+
+	if data.Id.ValueInt64() != 42 || data.SourceId.ValueInt64() != 7 || data.TargetName.ValueString() != "protect-ssh-keys" {
+		t.Errorf("cloneRule() = %+v", data)
+	}
+	if len(client.createFARules) != 1 {
+		t.Fatalf("CreateFileAccessRule called %d times, want 1", len(client.createFARules))
+	}
+	created := client.createFARules[0]
+	if created.GetTag() != "production" || created.GetName() != "protect-ssh-keys" || created.GetRuleId() != 0 || created.HasCreatedAt() || created.GetAddedBy() != "" {
+		t.Errorf("created rule = %v", created)
+	}
+
+	// The copy hashes like its source, so an up-to-date copy plans no change.
+	if got, want := fileAccessRuleContentHash(created), data.ContentHash.ValueString(); got != want {
+		t.Errorf("copy hash = %s, want source hash %s", got, want)
+	}
+	changed := proto.CloneOf(source)
+	changed.SetPathPrefixes([]string{"/Users/*/.ssh/", "/Users/*/.gnupg/"})
+	if fileAccessRuleContentHash(changed) == data.ContentHash.ValueString() {
+		t.Error("changing the source didn't change its hash")
+	}
+
+	// A missing source fails without creating anything.
+	data.SourceName = types.StringValue("missing")
+	diags = nil
+	if r.cloneRule(ctx, &data, &diags) || !diags.HasError() || len(client.createFARules) != 1 {
+		t.Errorf("cloneRule(missing source) = %v, %d creates", diags, len(client.createFARules))
+	}
+}
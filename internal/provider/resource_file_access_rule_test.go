@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 )
 
 func TestAccFileAccessRule(t *testing.T) {
@@ -24,6 +27,7 @@ func TestAccFileAccessRule(t *testing.T) {
 					resource.TestCheckResourceAttr("nps_workshop_file_access_rule.test", "rule_type", "PathsWithAllowedProcesses"),
 					resource.TestCheckResourceAttr("nps_workshop_file_access_rule.test", "allow_read_access", "true"),
 					resource.TestCheckResourceAttr("nps_workshop_file_access_rule.test", "block_violations", "false"),
+					testAccCheckGeneratedConfig("nps_workshop_file_access_rule.test", "id"),
 				),
 			},
 			// ImportState testing
@@ -32,6 +36,27 @@ func TestAccFileAccessRule(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			// Import by tag and name rather than by ID.
+			{
+				ResourceName:      "nps_workshop_file_access_rule.test",
+				ImportState:       true,
+				ImportStateId:     "global/TestRule1",
+				ImportStateVerify: true,
+			},
+			// Changing non-key fields updates the rule in place.
+			{
+				Config: testAccFileAccessRuleResourceUpdatedConfig("TestRule1", "global"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("nps_workshop_file_access_rule.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("nps_workshop_file_access_rule.test", "block_message", "Access to this path is restricted"),
+					resource.TestCheckResourceAttr("nps_workshop_file_access_rule.test", "path_prefixes.#", "2"),
+					resource.TestCheckResourceAttr("nps_workshop_file_access_rule.test", "path_prefixes.1", "/var/tmp/"),
+				),
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
@@ -60,3 +85,64 @@ resource "nps_workshop_file_access_rule" "test" {
 }
 `, name, tag)
 }
+
+func testAccFileAccessRuleResourceUpdatedConfig(name string, tag string) string {
+	return fmt.Sprintf(`
+provider "nps" {
+  endpoint = "localhost:8080"
+}
+
+resource "nps_workshop_file_access_rule" "test" {
+  name              = %[1]q
+  tag               = %[2]q
+  rule_type         = "PathsWithAllowedProcesses"
+  allow_read_access = true
+  block_violations  = false
+  block_message     = "Access to this path is restricted"
+
+  path_prefixes = [
+    "/tmp/",
+    "/var/tmp/",
+  ]
+
+  process_binary_paths = [
+    "/usr/bin/test",
+  ]
+}
+`, name, tag)
+}
+
+func TestValidateFileAccessRuleProcesses(t *testing.T) {
+	noProcesses := FileAccessRuleResourceModel{
+		ProcessBinaryPaths:        types.ListNull(types.StringType),
+		ProcessCdHashes:           types.ListNull(types.StringType),
+		ProcessSigningIds:         types.ListNull(types.StringType),
+		ProcessCertificateSha256s: types.ListNull(types.StringType),
+		ProcessTeamIds:            types.ListNull(types.StringType),
+	}
+	withTeamID := noProcesses
+	withTeamID.ProcessTeamIds = stringList("EQHXZ8M8AV")
+	unknown := noProcesses
+	unknown.ProcessSigningIds = types.ListUnknown(types.StringType)
+
+	for _, c := range []struct {
+		ruleType string
+		data     FileAccessRuleResourceModel
+		wantErr  bool
+	}{
+		{"PathsWithAllowedProcesses", noProcesses, false},
+		{"PathsWithDeniedProcesses", noProcesses, true},
+		{"ProcessesWithAllowedPaths", noProcesses, true},
+		{"ProcessesWithDeniedPaths", noProcesses, true},
+		{"ProcessesWithAllowedPaths", withTeamID, false},
+		{"PathsWithDeniedProcesses", withTeamID, false},
+		{"ProcessesWithDeniedPaths", unknown, false},
+	} {
+		c.data.RuleType = types.StringValue(c.ruleType)
+		var diags diag.Diagnostics
+		validateFileAccessRuleProcesses(c.data, &diags)
+		if diags.HasError() != c.wantErr {
+			t.Errorf("validateFileAccessRuleProcesses(%s, %v) = %v, want error %t", c.ruleType, c.data.ProcessTeamIds, diags, c.wantErr)
+		}
+	}
+}
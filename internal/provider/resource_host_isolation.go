@@ -0,0 +1,237 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"google.golang.org/protobuf/proto"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &HostIsolationResource{}
+var _ resource.ResourceWithConfigure = &HostIsolationResource{}
+
+func NewHostIsolationResource() resource.Resource {
+	return &HostIsolationResource{}
+}
+
+// HostIsolationResource moves a single host onto a dedicated isolation tag in
+// LOCKDOWN and puts its previous tags back when destroyed.
+type HostIsolationResource struct {
+	client svcpb.WorkshopServiceClient
+}
+
+// HostIsolationResourceModel describes the resource data model.
+type HostIsolationResourceModel struct {
+	MachineID    types.String `tfsdk:"machine_id"`
+	IsolationTag types.String `tfsdk:"isolation_tag"`
+	Reason       types.String `tfsdk:"reason"`
+
+	PreviousTags types.List   `tfsdk:"previous_tags"`
+	Hostname     types.String `tfsdk:"hostname"`
+	Id           types.String `tfsdk:"id"`
+}
+
+func (r *HostIsolationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workshop_host_isolation"
+}
+
+func (r *HostIsolationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The `nps_workshop_host_isolation` resource contains a single host during an incident: creating it switches `isolation_tag` to the `LOCKDOWN` client mode and replaces the host's tags with `isolation_tag` alone, so only the rules on that tag apply. Destroying it restores the host's previous tags. Both changes request a clean rule sync, so the host drops rules it received from its other tags.\n\n" +
+			"Use a tag dedicated to isolation that holds only baseline rules, such as those for the OS and the tools incident responders need; the tag stays in `LOCKDOWN` after the host is released. If the host's tags are changed outside Terraform while it is isolated, the resource is removed from state and the next apply isolates the host again.\n\n" +
+			"Requires the `read:hosts`, `write:hosts`, `read:settings`, and `write:settings` permissions.",
+
+		Attributes: map[string]schema.Attribute{
+			"machine_id": schema.StringAttribute{
+				MarkdownDescription: "The machine ID the host reports to Workshop, usually its hardware UUID.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"isolation_tag": schema.StringAttribute{
+				MarkdownDescription: "The tag the host is moved to. It is switched to `LOCKDOWN`, keeping its other sync settings.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"reason": schema.StringAttribute{
+				MarkdownDescription: "Why the host was isolated, e.g. an incident ticket. Only recorded in state and the provider logs.",
+				Optional:            true,
+			},
+			"previous_tags": schema.ListAttribute{
+				MarkdownDescription: "The host's tags before it was isolated, in precedence order. Restored when the resource is destroyed.",
+				Computed:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"hostname": schema.StringAttribute{
+				MarkdownDescription: "The host's name when it was isolated.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The same as `machine_id`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *HostIsolationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(*NPSProviderResourceData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			codeInternal.summary("Unexpected Resource Configure Type"),
+			fmt.Sprintf("Expected NPSProviderResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = pd.Client
+}
+
+func (r *HostIsolationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data HostIsolationResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	machineID := data.MachineID.ValueString()
+	isolationTag := data.IsolationTag.ValueString()
+
+	ret, err := r.client.GetHost(ctx, apipb.GetHostRequest_builder{Uuid: proto.String(machineID)}.Build())
+	if err != nil {
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to read host %q: %v", machineID, err))
+		return
+	}
+	host := ret.GetHost()
+	if slices.Equal(host.GetTags(), []string{isolationTag}) {
+		resp.Diagnostics.AddError(
+			codeFailedPrecondition.summary("Host already isolated"),
+			fmt.Sprintf("Host %q is already on %q alone, so its previous tags are unknown. Restore its tags before isolating it with Terraform.", machineID, isolationTag),
+		)
+		return
+	}
+
+	// Lock the tag down before moving the host onto it, so the host is never on
+	// the tag in a more permissive mode.
+	_, diags := lockDownTag(ctx, r.client, isolationTag)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setHostTags(ctx, machineID, []string{isolationTag}); err != nil {
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to isolate host %q: %v. Tag %q has already been locked down.", machineID, err, isolationTag))
+		return
+	}
+
+	previous, diags := types.ListValueFrom(ctx, types.StringType, host.GetTags())
+	resp.Diagnostics.Append(diags...)
+	data.PreviousTags = previous
+	data.Hostname = types.StringValue(host.GetHostname())
+	data.Id = data.MachineID
+
+	tflog.Warn(ctx, "Host isolated", map[string]any{"machine_id": machineID, "tag": isolationTag, "reason": data.Reason.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read only checks that the host is still isolated; previous_tags and
+// hostname record what was true at creation.
+func (r *HostIsolationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data HostIsolationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ret, err := r.client.GetHost(ctx, apipb.GetHostRequest_builder{Uuid: proto.String(data.MachineID.ValueString())}.Build())
+	if isDeleteNoOp(err) {
+		tflog.Info(ctx, fmt.Sprintf("Host %q not found", data.MachineID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to read host %q: %v", data.MachineID.ValueString(), err))
+		return
+	}
+	if !slices.Equal(ret.GetHost().GetTags(), []string{data.IsolationTag.ValueString()}) {
+		tflog.Warn(ctx, "Host is no longer isolated", map[string]any{"machine_id": data.MachineID.ValueString(), "tags": ret.GetHost().GetTags()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update only ever changes reason, which is not sent to Workshop.
+func (r *HostIsolationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data HostIsolationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *HostIsolationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data HostIsolationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var previous []string
+	resp.Diagnostics.Append(data.PreviousTags.ElementsAs(ctx, &previous, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.setHostTags(ctx, data.MachineID.ValueString(), previous)
+	if err != nil && !isDeleteNoOp(err) {
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to restore the tags of host %q: %v", data.MachineID.ValueString(), err))
+		return
+	}
+}
+
+// setHostTags replaces the host's tags and asks it to clean sync its execution
+// and file access rules, so rules from tags it no longer has are dropped.
+func (r *HostIsolationResource) setHostTags(ctx context.Context, machineID string, tags []string) error {
+	_, err := r.client.UpdateHost(ctx, apipb.UpdateHostRequest_builder{
+		Uuid:     proto.String(machineID),
+		SyncType: apipb.SyncType_CLEAN.Enum(),
+		Tags:     apipb.RepeatedString_builder{Values: tags}.Build(),
+	}.Build())
+	return err
+}
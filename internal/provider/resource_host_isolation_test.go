@@ -0,0 +1,91 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+func TestHostIsolationLifecycle(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeWorkshopClient{
+		host: apipb.Host_builder{Uuid: "A1B2", Hostname: "laptop-1", Tags: []string{"eng", "global"}}.Build(),
+	}
+	r := &HostIsolationResource{client: fake}
+
+	var sResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &sResp)
+
+	plan := tfsdk.Plan{Schema: sResp.Schema}
+	diags := plan.Set(ctx, HostIsolationResourceModel{
+		MachineID:    types.StringValue("A1B2"),
+		IsolationTag: types.StringValue("isolated"),
+		Reason:       types.StringValue("INC-7"),
+		PreviousTags: types.ListUnknown(types.StringType),
+		Hostname:     types.StringUnknown(),
+		Id:           types.StringUnknown(),
+	})
+	if diags.HasError() {
+		t.Fatalf("failed to build plan: %v", diags)
+	}
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: sResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("create failed: %v", createResp.Diagnostics)
+	}
+
+	if len(fake.syncUpdates) != 1 || fake.syncUpdates[0].GetTag() != "isolated" || fake.syncUpdates[0].GetClientMode() != apipb.ClientMode_LOCKDOWN {
+		t.Errorf("sync settings updates = %v, want isolated in LOCKDOWN", fake.syncUpdates)
+	}
+	if len(fake.hostUpdates) != 1 {
+		t.Fatalf("UpdateHost called %d times, want 1", len(fake.hostUpdates))
+	}
+	if got := fake.hostUpdates[0]; !slices.Equal(got.GetTags().GetValues(), []string{"isolated"}) || got.GetSyncType() != apipb.SyncType_CLEAN {
+		t.Errorf("UpdateHost = %v, want tags [isolated] with a clean sync", got)
+	}
+
+	var got HostIsolationResourceModel
+	createResp.State.Get(ctx, &got)
+	wantPrevious := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("eng"), types.StringValue("global")})
+	if !got.PreviousTags.Equal(wantPrevious) || got.Hostname.ValueString() != "laptop-1" {
+		t.Errorf("state = %+v", got)
+	}
+
+	for _, c := range []struct {
+		tags        []string
+		wantRemoved bool
+	}{
+		{tags: []string{"isolated"}},
+		// The tags were changed outside Terraform, so the host is no longer
+		// isolated.
+		{tags: []string{"eng"}, wantRemoved: true},
+	} {
+		fake.host.SetTags(c.tags)
+		readResp := &resource.ReadResponse{State: createResp.State}
+		r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+		if readResp.Diagnostics.HasError() {
+			t.Fatalf("read failed: %v", readResp.Diagnostics)
+		}
+		if removed := readResp.State.Raw.IsNull(); removed != c.wantRemoved {
+			t.Errorf("with tags %v, read removed the resource = %t, want %t", c.tags, removed, c.wantRemoved)
+		}
+	}
+
+	deleteResp := &resource.DeleteResponse{State: createResp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: createResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("delete failed: %v", deleteResp.Diagnostics)
+	}
+	if len(fake.hostUpdates) != 2 || !slices.Equal(fake.hostUpdates[1].GetTags().GetValues(), []string{"eng", "global"}) {
+		t.Errorf("UpdateHost requests = %v, want the previous tags restored", fake.hostUpdates)
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+)
+
+// The rule resources' identities hold the rule's natural key alongside its
+// server ID, which changes on every upsert, so that an import block can name
+// a rule by what it is, e.g. identity = { tag = "global", name = "ssh" }, and
+// a for_each can import many. Their version 0 identities only held the ID.
+
+// idIdentityUpgraders upgrades a version 0 identity, {id}, by passing the ID
+// to identity. The natural key is left null for the next Read to fill in.
+func idIdentityUpgraders[T any](idAttr identityschema.Attribute, identity func(id T) any) map[int64]resource.IdentityUpgrader {
+	return map[int64]resource.IdentityUpgrader{
+		0: {
+			PriorSchema: &identityschema.Schema{
+				Attributes: map[string]identityschema.Attribute{"id": idAttr},
+			},
+			IdentityUpgrader: func(ctx context.Context, req resource.UpgradeIdentityRequest, resp *resource.UpgradeIdentityResponse) {
+				var id T
+				resp.Diagnostics.Append(req.Identity.GetAttribute(ctx, path.Root("id"), &id)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				resp.Diagnostics.Append(resp.Identity.Set(ctx, identity(id))...)
+			},
+		},
+	}
+}
+
+// importIdentity reads the identity of an import block into identity. It
+// reports false when the import is by ID instead, e.g. terraform import.
+func importIdentity(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, identity any) bool {
+	if req.ID != "" || req.Identity == nil || req.Identity.Raw.IsNull() {
+		return false
+	}
+	resp.Diagnostics.Append(req.Identity.Get(ctx, identity)...)
+	return true
+}
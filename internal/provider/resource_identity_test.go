@@ -0,0 +1,121 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// importByIdentity runs ImportState for an import block with identity.
+func importByIdentity(t *testing.T, r resource.Resource, identity any) *resource.ImportStateResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	var sResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &sResp)
+	var iResp resource.IdentitySchemaResponse
+	r.(resource.ResourceWithIdentity).IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &iResp)
+
+	req := resource.ImportStateRequest{Identity: &tfsdk.ResourceIdentity{Schema: iResp.IdentitySchema}}
+	if diags := req.Identity.Set(ctx, identity); diags.HasError() {
+		t.Fatalf("failed to build identity: %v", diags)
+	}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: sResp.Schema,
+			Raw:    tftypes.NewValue(sResp.Schema.Type().TerraformType(ctx), nil),
+		},
+		Identity: req.Identity,
+	}
+	r.(resource.ResourceWithImportState).ImportState(ctx, req, resp)
+	return resp
+}
+
+func TestImportByIdentity(t *testing.T) {
+	ctx := context.Background()
+
+	resp := importByIdentity(t, &FileAccessRuleResource{}, FileAccessRuleIdentityModel{
+		Id:   types.Int64Null(),
+		Tag:  types.StringValue("global"),
+		Name: types.StringValue("protect-ssh-keys"),
+	})
+	var far FileAccessRuleResourceModel
+	resp.State.GetAttribute(ctx, path.Root("id"), &far.Id)
+	resp.State.GetAttribute(ctx, path.Root("name"), &far.Name)
+	if resp.Diagnostics.HasError() || far.Id.ValueInt64() != 0 || far.Name.ValueString() != "protect-ssh-keys" {
+		t.Errorf("file access rule import = %v, %v, %v", far.Id, far.Name, resp.Diagnostics)
+	}
+
+	resp = importByIdentity(t, &PackageRuleResource{}, PackageRuleIdentityModel{
+		Id:     types.Int64Value(7),
+		Tag:    types.StringNull(),
+		Source: types.StringNull(),
+		Name:   types.StringNull(),
+	})
+	var pkgID types.Int64
+	resp.State.GetAttribute(ctx, path.Root("id"), &pkgID)
+	if resp.Diagnostics.HasError() || pkgID.ValueInt64() != 7 {
+		t.Errorf("package rule import by id = %v, %v", pkgID, resp.Diagnostics)
+	}
+
+	resp = importByIdentity(t, &RuleResource{}, RuleIdentityModel{
+		Id:         types.StringNull(),
+		RuleType:   types.StringValue("SIGNINGID"),
+		Identifier: types.StringValue("platform:com.apple.curl"),
+		Tag:        types.StringValue("global"),
+	})
+	var identifier types.String
+	resp.State.GetAttribute(ctx, path.Root("identifier"), &identifier)
+	if resp.Diagnostics.HasError() || identifier.ValueString() != "platform:com.apple.curl" {
+		t.Errorf("rule import = %v, %v", identifier, resp.Diagnostics)
+	}
+
+	// A partial natural key can't resolve to a rule.
+	for name, tt := range map[string]struct {
+		r        resource.Resource
+		identity any
+	}{
+		"file access rule": {&FileAccessRuleResource{}, FileAccessRuleIdentityModel{Id: types.Int64Null(), Tag: types.StringValue("global"), Name: types.StringNull()}},
+		"package rule":     {&PackageRuleResource{}, PackageRuleIdentityModel{Id: types.Int64Null(), Tag: types.StringValue("global"), Source: types.StringValue("NPM"), Name: types.StringValue("left-pad")}},
+		"rule":             {&RuleResource{}, RuleIdentityModel{Id: types.StringNull(), RuleType: types.StringNull(), Identifier: types.StringValue("abc"), Tag: types.StringValue("global")}},
+	} {
+		if resp := importByIdentity(t, tt.r, tt.identity); !resp.Diagnostics.HasError() {
+			t.Errorf("%s: import of a partial identity succeeded", name)
+		}
+	}
+}
+
+func TestUpgradeIdentity(t *testing.T) {
+	ctx := context.Background()
+	r := &NetworkFlowRuleResource{}
+	upgrader := r.UpgradeIdentity(ctx)[0]
+
+	req := resource.UpgradeIdentityRequest{Identity: &tfsdk.ResourceIdentity{Schema: *upgrader.PriorSchema}}
+	req.Identity.Raw = tftypes.NewValue(upgrader.PriorSchema.Type().TerraformType(ctx), map[string]tftypes.Value{
+		"id": tftypes.NewValue(tftypes.Number, 42),
+	})
+
+	var iResp resource.IdentitySchemaResponse
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &iResp)
+	resp := &resource.UpgradeIdentityResponse{Identity: &tfsdk.ResourceIdentity{Schema: iResp.IdentitySchema}}
+	upgrader.IdentityUpgrader(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("upgrade failed: %v", resp.Diagnostics)
+	}
+
+	var got NetworkFlowRuleIdentityModel
+	resp.Identity.Get(ctx, &got)
+	if got.Id.ValueInt64() != 42 || !got.Tag.IsNull() || !got.Name.IsNull() {
+		t.Errorf("upgraded identity = %+v", got)
+	}
+	if _, ok := upgrader.PriorSchema.Attributes["id"].(identityschema.Int64Attribute); !ok {
+		t.Errorf("prior id attribute = %T", upgrader.PriorSchema.Attributes["id"])
+	}
+}
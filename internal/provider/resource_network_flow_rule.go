@@ -9,7 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
-	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/list"
 	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
@@ -22,7 +22,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/northpolesec/terraform-provider-nps/internal/utils"
+	"github.com/northpolesec/terraform-provider-nps/internal/filter"
 	"google.golang.org/protobuf/proto"
 
 	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
@@ -34,6 +34,7 @@ var _ resource.Resource = &NetworkFlowRuleResource{}
 var _ resource.ResourceWithConfigure = &NetworkFlowRuleResource{}
 var _ resource.ResourceWithImportState = &NetworkFlowRuleResource{}
 var _ resource.ResourceWithIdentity = &NetworkFlowRuleResource{}
+var _ resource.ResourceWithUpgradeIdentity = &NetworkFlowRuleResource{}
 var _ resource.ResourceWithConfigValidators = &NetworkFlowRuleResource{}
 var _ list.ListResource = &NetworkFlowRuleResource{}
 var _ list.ListResourceWithConfigure = &NetworkFlowRuleResource{}
@@ -49,7 +50,14 @@ type NetworkFlowRuleResource struct {
 
 // NetworkFlowRuleIdentityModel describes the identity data model.
 type NetworkFlowRuleIdentityModel struct {
-	Id types.Int64 `tfsdk:"id"`
+	Id   types.Int64  `tfsdk:"id"`
+	Tag  types.String `tfsdk:"tag"`
+	Name types.String `tfsdk:"name"`
+}
+
+// networkFlowRuleIdentity is the identity of the network flow rule in data.
+func networkFlowRuleIdentity(data NetworkFlowRuleResourceModel) NetworkFlowRuleIdentityModel {
+	return NetworkFlowRuleIdentityModel{Id: data.Id, Tag: data.Tag, Name: data.Name}
 }
 
 // NetworkFlowRuleResourceModel describes the resource data model.
@@ -126,7 +134,7 @@ func (r *NetworkFlowRuleResource) Schema(ctx context.Context, req resource.Schem
 				MarkdownDescription: "The action to take on network flows matching this rule. The possible values are: `NETWORK_FLOW_RULE_ACTION_ALLOW`, `NETWORK_FLOW_RULE_ACTION_DENY`, `NETWORK_FLOW_RULE_ACTION_SILENT_DENY`, `NETWORK_FLOW_RULE_ACTION_AUDIT`.",
 				Required:            true,
 				Validators: []validator.String{
-					stringvalidator.OneOf(utils.ProtoEnumToList(apipb.NetworkFlowRuleAction(0).Descriptor())...),
+					oneOf(enumValues(apipb.NetworkFlowRuleAction(0).Descriptor())...),
 				},
 			},
 			"direction": schema.StringAttribute{
@@ -134,7 +142,7 @@ func (r *NetworkFlowRuleResource) Schema(ctx context.Context, req resource.Schem
 				MarkdownDescription: "The direction of network flows this rule applies to, relative to the host. The possible values are: `NETWORK_FLOW_DIRECTION_ANY`, `NETWORK_FLOW_DIRECTION_OUTGOING`, `NETWORK_FLOW_DIRECTION_INCOMING`.",
 				Required:            true,
 				Validators: []validator.String{
-					stringvalidator.OneOf(utils.ProtoEnumToList(apipb.NetworkFlowDirection(0).Descriptor())...),
+					oneOf(enumValues(apipb.NetworkFlowDirection(0).Descriptor())...),
 				},
 			},
 			"priority": schema.BoolAttribute{
@@ -196,6 +204,9 @@ func (r *NetworkFlowRuleResource) Schema(ctx context.Context, req resource.Schem
 				Description:         "A custom message to display to the user when this rule blocks a network flow.",
 				MarkdownDescription: "A custom message to display to the user when this rule blocks a network flow.",
 				Optional:            true,
+				Validators: []validator.String{
+					customMsgValidator{},
+				},
 			},
 			"custom_url": schema.StringAttribute{
 				Description:         "A custom URL to redirect the user to when this rule blocks a network flow.",
@@ -267,7 +278,7 @@ func (r *NetworkFlowRuleResource) Configure(ctx context.Context, req resource.Co
 	pd, ok := req.ProviderData.(*NPSProviderResourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
+			codeInternal.summary("Unexpected Resource Configure Type"),
 			fmt.Sprintf("Expected NPSProviderResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
@@ -294,7 +305,7 @@ func (r *NetworkFlowRuleResource) Create(ctx context.Context, req resource.Creat
 		Rule: rule,
 	}.Build())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to create network flow rule: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to create network flow rule: %v", err))
 		return
 	}
 
@@ -302,7 +313,7 @@ func (r *NetworkFlowRuleResource) Create(ctx context.Context, req resource.Creat
 	tflog.Info(ctx, fmt.Sprintf("Created network flow rule: %d", data.Id.ValueInt64()))
 
 	// Set the identity
-	resp.Diagnostics.Append(resp.Identity.Set(ctx, NetworkFlowRuleIdentityModel{Id: data.Id})...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, networkFlowRuleIdentity(data))...)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -319,15 +330,17 @@ func (r *NetworkFlowRuleResource) Read(ctx context.Context, req resource.ReadReq
 	}
 
 	// Query for the rule by ID, name, and tag
-	filter := fmt.Sprintf(`rule_id = %d OR (name = "%s" AND tag = "%s")`,
-		data.Id.ValueInt64(), data.Name.ValueString(), data.Tag.ValueString())
+	query := filter.Or(
+		filter.Eq("rule_id", data.Id.ValueInt64()),
+		filter.And(filter.Eq("name", data.Name.ValueString()), filter.Eq("tag", data.Tag.ValueString())),
+	)
 
 	ret, err := r.client.ListNetworkFlowRules(ctx, apipb.ListNetworkFlowRulesRequest_builder{
-		Filter:   proto.String(filter),
+		Filter:   proto.String(query.String()),
 		PageSize: proto.Uint32(1),
 	}.Build())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to list network flow rules: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to list network flow rules: %v", err))
 		return
 	}
 	if len(ret.GetRules()) == 0 {
@@ -340,7 +353,23 @@ func (r *NetworkFlowRuleResource) Read(ctx context.Context, req resource.ReadReq
 
 	// Now that we've found the rule, overwrite the state data with the actual
 	// values retrieved via the API.
-	rule := ret.GetRules()[0]
+	applyNetworkFlowRuleProto(ctx, &data, ret.GetRules()[0], &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set the identity
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, networkFlowRuleIdentity(data))...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// applyNetworkFlowRuleProto overwrites data with the values of rule, as
+// returned by ListNetworkFlowRules. Optional strings and lists the server
+// reports as empty keep their value in data, so Read passes the prior state
+// and List an empty model.
+func applyNetworkFlowRuleProto(ctx context.Context, data *NetworkFlowRuleResourceModel, rule *apipb.NetworkFlowRule, diags *diag.Diagnostics) {
 	data.Id = types.Int64Value(rule.GetRuleId())
 	data.Tag = types.StringValue(rule.GetTag())
 	data.Name = types.StringValue(rule.GetName())
@@ -367,32 +396,19 @@ func (r *NetworkFlowRuleResource) Read(ctx context.Context, req resource.ReadReq
 		data.Comment = types.StringValue(rule.GetComment())
 	}
 
-	// Convert slices to list types
-	if len(rule.GetProcessCdHashes()) > 0 {
-		data.ProcessCdHashes, _ = types.ListValueFrom(ctx, types.StringType, rule.GetProcessCdHashes())
-	}
-	if len(rule.GetProcessSigningIds()) > 0 {
-		data.ProcessSigningIds, _ = types.ListValueFrom(ctx, types.StringType, rule.GetProcessSigningIds())
-	}
-	if len(rule.GetProcessTeamIds()) > 0 {
-		data.ProcessTeamIds, _ = types.ListValueFrom(ctx, types.StringType, rule.GetProcessTeamIds())
-	}
-	if len(rule.GetRemoteHostnames()) > 0 {
-		data.RemoteHostnames, _ = types.ListValueFrom(ctx, types.StringType, rule.GetRemoteHostnames())
-	}
-	if len(rule.GetRemoteDomains()) > 0 {
-		data.RemoteDomains, _ = types.ListValueFrom(ctx, types.StringType, rule.GetRemoteDomains())
-	}
-	if len(rule.GetRemoteAddresses()) > 0 {
-		data.RemoteAddresses, _ = types.ListValueFrom(ctx, types.StringType, rule.GetRemoteAddresses())
-	}
-	if len(rule.GetProtocols()) > 0 {
-		protocols := make([]int64, 0, len(rule.GetProtocols()))
-		for _, p := range rule.GetProtocols() {
-			protocols = append(protocols, int64(p))
-		}
-		data.Protocols, _ = types.ListValueFrom(ctx, types.Int64Type, protocols)
+	data.ProcessCdHashes = networkFlowRuleList(ctx, types.StringType, rule.GetProcessCdHashes(), data.ProcessCdHashes, diags)
+	data.ProcessSigningIds = networkFlowRuleList(ctx, types.StringType, rule.GetProcessSigningIds(), data.ProcessSigningIds, diags)
+	data.ProcessTeamIds = networkFlowRuleList(ctx, types.StringType, rule.GetProcessTeamIds(), data.ProcessTeamIds, diags)
+	data.RemoteHostnames = networkFlowRuleList(ctx, types.StringType, rule.GetRemoteHostnames(), data.RemoteHostnames, diags)
+	data.RemoteDomains = networkFlowRuleList(ctx, types.StringType, rule.GetRemoteDomains(), data.RemoteDomains, diags)
+	data.RemoteAddresses = networkFlowRuleList(ctx, types.StringType, rule.GetRemoteAddresses(), data.RemoteAddresses, diags)
+
+	protocols := make([]int64, 0, len(rule.GetProtocols()))
+	for _, p := range rule.GetProtocols() {
+		protocols = append(protocols, int64(p))
 	}
+	data.Protocols = networkFlowRuleList(ctx, types.Int64Type, protocols, data.Protocols, diags)
+
 	if len(rule.GetPorts()) > 0 {
 		data.Ports = make([]NetworkFlowRulePortRangeModel, 0, len(rule.GetPorts()))
 		for _, p := range rule.GetPorts() {
@@ -405,12 +421,21 @@ func (r *NetworkFlowRuleResource) Read(ctx context.Context, req resource.ReadReq
 			data.Ports = append(data.Ports, port)
 		}
 	}
+}
 
-	// Set the identity
-	resp.Diagnostics.Append(resp.Identity.Set(ctx, NetworkFlowRuleIdentityModel{Id: data.Id})...)
-
-	// Save updated data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+// networkFlowRuleList converts a list from the API into its Terraform value.
+// An empty list keeps prior, or is a null list of elemType when prior is the
+// zero value.
+func networkFlowRuleList[T any](ctx context.Context, elemType attr.Type, values []T, prior types.List, diags *diag.Diagnostics) types.List {
+	if len(values) == 0 {
+		if prior.IsNull() {
+			return types.ListNull(elemType)
+		}
+		return prior
+	}
+	l, d := types.ListValueFrom(ctx, elemType, values)
+	diags.Append(d...)
+	return l
 }
 
 // buildNetworkFlowRule builds the (upsert) NetworkFlowRule from the model.
@@ -482,7 +507,7 @@ func (r *NetworkFlowRuleResource) Update(ctx context.Context, req resource.Updat
 	plan.Id = newID
 	tflog.Info(ctx, fmt.Sprintf("Updated network flow rule: %d", plan.Id.ValueInt64()))
 
-	resp.Diagnostics.Append(resp.Identity.Set(ctx, NetworkFlowRuleIdentityModel{Id: plan.Id})...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, networkFlowRuleIdentity(plan))...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -505,7 +530,7 @@ func (r *NetworkFlowRuleResource) upsertNetworkFlowRule(ctx context.Context, pla
 		Rule: rule,
 	}.Build())
 	if err != nil {
-		diags.AddError("Client Error", fmt.Sprintf("Failed to update network flow rule: %v", err))
+		diags.AddError(clientError(err), fmt.Sprintf("Failed to update network flow rule: %v", err))
 		return types.Int64Null(), diags
 	}
 	return types.Int64Value(crResp.GetRuleId()), diags
@@ -527,7 +552,7 @@ func (r *NetworkFlowRuleResource) Delete(ctx context.Context, req resource.Delet
 		RuleId: proto.Int64(ruleId),
 	}.Build())
 	if err != nil && !isRuleDeleteNoOp(err) {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete network flow rule: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to delete network flow rule: %v", err))
 		return
 	}
 
@@ -535,10 +560,27 @@ func (r *NetworkFlowRuleResource) Delete(ctx context.Context, req resource.Delet
 }
 
 func (r *NetworkFlowRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var identity NetworkFlowRuleIdentityModel
+	if importIdentity(ctx, req, resp, &identity) {
+		switch {
+		case resp.Diagnostics.HasError():
+		case !identity.Id.IsNull():
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.Id)...)
+		case identity.Tag.ValueString() != "" && identity.Name.ValueString() != "":
+			// Read resolves the tag and name to the rule's current ID.
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), int64(0))...)
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tag"), identity.Tag)...)
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), identity.Name)...)
+		default:
+			resp.Diagnostics.AddError(codeInvalidConfig.summary("Invalid import identity"), "Expected an identity with id, or with tag and name.")
+		}
+		return
+	}
+
 	// Import a network flow rule by ID, which will trigger a Read.
 	id, err := strconv.ParseInt(req.ID, 10, 64)
 	if err != nil {
-		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Failed to parse ID %q as integer: %v", req.ID, err))
+		resp.Diagnostics.AddError(codeInvalidConfig.summary("Invalid ID"), fmt.Sprintf("Failed to parse ID %q as integer: %v", req.ID, err))
 		return
 	}
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
@@ -546,14 +588,30 @@ func (r *NetworkFlowRuleResource) ImportState(ctx context.Context, req resource.
 
 func (r *NetworkFlowRuleResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
 	resp.IdentitySchema = identityschema.Schema{
+		Version: 1,
 		Attributes: map[string]identityschema.Attribute{
 			"id": identityschema.Int64Attribute{
-				RequiredForImport: true,
+				Description:       "The rule's ID. Set either `id`, or `tag` and `name`.",
+				OptionalForImport: true,
+			},
+			"tag": identityschema.StringAttribute{
+				Description:       "The tag the rule applies to.",
+				OptionalForImport: true,
+			},
+			"name": identityschema.StringAttribute{
+				Description:       "The rule's name, which is unique per tag.",
+				OptionalForImport: true,
 			},
 		},
 	}
 }
 
+func (r *NetworkFlowRuleResource) UpgradeIdentity(ctx context.Context) map[int64]resource.IdentityUpgrader {
+	return idIdentityUpgraders(identityschema.Int64Attribute{RequiredForImport: true}, func(id types.Int64) any {
+		return NetworkFlowRuleIdentityModel{Id: id}
+	})
+}
+
 func NewNetworkFlowRuleListResource() list.ListResource {
 	return &NetworkFlowRuleResource{}
 }
@@ -570,7 +628,7 @@ func (r *NetworkFlowRuleResource) List(ctx context.Context, req list.ListRequest
 		ret, err := r.client.ListNetworkFlowRules(ctx, apipb.ListNetworkFlowRulesRequest_builder{}.Build())
 		if err != nil {
 			result := req.NewListResult(ctx)
-			result.Diagnostics.AddError("Client Error", "Failed to list network flow rules: "+err.Error())
+			result.Diagnostics.AddError(clientError(err), "Failed to list network flow rules: "+err.Error())
 			push(result)
 			return
 		}
@@ -580,69 +638,14 @@ func (r *NetworkFlowRuleResource) List(ctx context.Context, req list.ListRequest
 			result.DisplayName = rule.GetName()
 
 			result.Diagnostics.Append(result.Identity.Set(ctx, NetworkFlowRuleIdentityModel{
-				Id: types.Int64Value(rule.GetRuleId()),
+				Id:   types.Int64Value(rule.GetRuleId()),
+				Tag:  types.StringValue(rule.GetTag()),
+				Name: types.StringValue(rule.GetName()),
 			})...)
 
 			if req.IncludeResource {
-				toListOrNull := func(slice []string) types.List {
-					if len(slice) > 0 {
-						l, _ := types.ListValueFrom(ctx, types.StringType, slice)
-						return l
-					}
-					return types.ListNull(types.StringType)
-				}
-
-				model := NetworkFlowRuleResourceModel{
-					Id:                types.Int64Value(rule.GetRuleId()),
-					Tag:               types.StringValue(rule.GetTag()),
-					Name:              types.StringValue(rule.GetName()),
-					Action:            types.StringValue(rule.GetAction().String()),
-					Direction:         types.StringValue(rule.GetDirection().String()),
-					Priority:          types.BoolNull(),
-					Rank:              types.Int64Null(),
-					ProcessCdHashes:   toListOrNull(rule.GetProcessCdHashes()),
-					ProcessSigningIds: toListOrNull(rule.GetProcessSigningIds()),
-					ProcessTeamIds:    toListOrNull(rule.GetProcessTeamIds()),
-					RemoteHostnames:   toListOrNull(rule.GetRemoteHostnames()),
-					RemoteDomains:     toListOrNull(rule.GetRemoteDomains()),
-					RemoteAddresses:   toListOrNull(rule.GetRemoteAddresses()),
-					Protocols:         types.ListNull(types.Int64Type),
-				}
-
-				if rule.HasPriority() {
-					model.Priority = types.BoolValue(rule.GetPriority())
-				}
-				if rule.HasRank() {
-					model.Rank = types.Int64Value(rule.GetRank())
-				}
-				if rule.GetCustomMsg() != "" {
-					model.CustomMsg = types.StringValue(rule.GetCustomMsg())
-				}
-				if rule.GetCustomUrl() != "" {
-					model.CustomUrl = types.StringValue(rule.GetCustomUrl())
-				}
-				if rule.GetComment() != "" {
-					model.Comment = types.StringValue(rule.GetComment())
-				}
-				if len(rule.GetProtocols()) > 0 {
-					protocols := make([]int64, 0, len(rule.GetProtocols()))
-					for _, p := range rule.GetProtocols() {
-						protocols = append(protocols, int64(p))
-					}
-					model.Protocols, _ = types.ListValueFrom(ctx, types.Int64Type, protocols)
-				}
-				if len(rule.GetPorts()) > 0 {
-					model.Ports = make([]NetworkFlowRulePortRangeModel, 0, len(rule.GetPorts()))
-					for _, p := range rule.GetPorts() {
-						port := NetworkFlowRulePortRangeModel{
-							Low: types.Int64Value(int64(p.GetLow())),
-						}
-						if p.GetHigh() != 0 {
-							port.High = types.Int64Value(int64(p.GetHigh()))
-						}
-						model.Ports = append(model.Ports, port)
-					}
-				}
+				var model NetworkFlowRuleResourceModel
+				applyNetworkFlowRuleProto(ctx, &model, rule, &result.Diagnostics)
 
 				result.Diagnostics.Append(result.Resource.Set(ctx, model)...)
 			}
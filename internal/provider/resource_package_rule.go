@@ -4,10 +4,15 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/list"
 	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
@@ -20,7 +25,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/northpolesec/terraform-provider-nps/internal/utils"
+	"github.com/northpolesec/terraform-provider-nps/internal/filter"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -33,6 +38,8 @@ var _ resource.Resource = &PackageRuleResource{}
 var _ resource.ResourceWithConfigure = &PackageRuleResource{}
 var _ resource.ResourceWithImportState = &PackageRuleResource{}
 var _ resource.ResourceWithIdentity = &PackageRuleResource{}
+var _ resource.ResourceWithUpgradeIdentity = &PackageRuleResource{}
+var _ resource.ResourceWithModifyPlan = &PackageRuleResource{}
 var _ list.ListResource = &PackageRuleResource{}
 var _ list.ListResourceWithConfigure = &PackageRuleResource{}
 
@@ -42,12 +49,26 @@ func NewPackageRuleResource() resource.Resource {
 
 // PackageRuleResource defines the resource implementation.
 type PackageRuleResource struct {
-	client svcpb.WorkshopServiceClient
+	client              svcpb.WorkshopServiceClient
+	strictRead          bool
+	acceptNewEnumValues bool
+	defaultTag          string
+	guardrails          ruleGuardrails
+	backup              *destroyBackup
+	validatePackages    bool
 }
 
 // PackageRuleIdentityModel describes the identity data model.
 type PackageRuleIdentityModel struct {
-	Id types.Int64 `tfsdk:"id"`
+	Id     types.Int64  `tfsdk:"id"`
+	Tag    types.String `tfsdk:"tag"`
+	Source types.String `tfsdk:"source"`
+	Name   types.String `tfsdk:"name"`
+}
+
+// packageRuleIdentity is the identity of the package rule in data.
+func packageRuleIdentity(data PackageRuleResourceModel) PackageRuleIdentityModel {
+	return PackageRuleIdentityModel{Id: data.Id, Tag: data.Tag, Source: data.Source, Name: data.Name}
 }
 
 // PackageRuleResourceModel describes the resource data model.
@@ -60,6 +81,12 @@ type PackageRuleResourceModel struct {
 	MinDate       types.String `tfsdk:"min_date"`
 	MaxDate       types.String `tfsdk:"max_date"`
 	VersionRegexp types.String `tfsdk:"version_regexp"`
+	Versions      types.List   `tfsdk:"versions"`
+
+	SyncStatus           types.String `tfsdk:"sync_status"`
+	LastSyncedAt         types.String `tfsdk:"last_synced_at"`
+	GeneratedRuleCount   types.Int64  `tfsdk:"generated_rule_count"`
+	GeneratedIdentifiers types.List   `tfsdk:"generated_identifiers"`
 
 	Id types.Int64 `tfsdk:"id"`
 }
@@ -78,13 +105,16 @@ func (r *PackageRuleResource) Schema(ctx context.Context, req resource.SchemaReq
 
 		Attributes: map[string]schema.Attribute{
 			"tag": schema.StringAttribute{
-				Description:         "The tag for this package rule. The tag determines which hosts this rule will apply to. The tag must already exist in Workshop.",
-				MarkdownDescription: "The tag for this package rule. The tag determines which hosts this rule will apply to. The tag must already exist in Workshop.",
-				Required:            true,
+				Description:         "The tag for this package rule. The tag determines which hosts this rule will apply to. The tag must already exist in Workshop. Defaults to the provider's default_tag; one of the two must be set.",
+				MarkdownDescription: "The tag for this package rule. The tag determines which hosts this rule will apply to. The tag must already exist in Workshop. Defaults to the provider's `default_tag`; one of the two must be set.",
+				Optional:            true,
+				Computed:            true,
 				// Part of the natural key (tag, name, source). The upsert only
 				// supersedes the old rule when the key matches, so changing the key
 				// must replace rather than update in place.
+				// An omitted tag takes the provider's default_tag; see applyDefaultTag.
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -93,7 +123,7 @@ func (r *PackageRuleResource) Schema(ctx context.Context, req resource.SchemaReq
 				MarkdownDescription: "The package source (e.g., `PACKAGE_SOURCE_HOMEBREW`, `PACKAGE_SOURCE_NPM`).",
 				Required:            true,
 				Validators: []validator.String{
-					stringvalidator.OneOf(utils.ProtoEnumToList(apipb.PackageSource(0).Descriptor())...),
+					oneOf(enumValues(apipb.PackageSource(0).Descriptor())...),
 				},
 				// Part of the natural key; see tag.
 				PlanModifiers: []planmodifier.String{
@@ -114,7 +144,7 @@ func (r *PackageRuleResource) Schema(ctx context.Context, req resource.SchemaReq
 				MarkdownDescription: "The policy for execution rules created from this package rule.",
 				Required:            true,
 				Validators: []validator.String{
-					stringvalidator.OneOf(utils.ProtoEnumToList(apipb.Policy(0).Descriptor())...),
+					oneOf(enumValues(apipb.Policy(0).Descriptor())...),
 				},
 			},
 			"rule_type": schema.StringAttribute{
@@ -122,7 +152,7 @@ func (r *PackageRuleResource) Schema(ctx context.Context, req resource.SchemaReq
 				MarkdownDescription: "What type of rule should be created. Uses the broadest available type from GAL, falling back to more specific types if the preferred type isn't available. Only `TEAMID`, `CERTIFICATE`, `SIGNINGID`, `CDHASH`, and `BINARY` are supported.",
 				Required:            true,
 				Validators: []validator.String{
-					stringvalidator.OneOf(utils.ProtoEnumToList(apipb.RuleType(0).Descriptor())...),
+					oneOf(enumValues(apipb.RuleType(0).Descriptor())...),
 				},
 			},
 			"min_date": schema.StringAttribute{
@@ -140,6 +170,46 @@ func (r *PackageRuleResource) Schema(ctx context.Context, req resource.SchemaReq
 				MarkdownDescription: "Optional: Regex to filter version strings.",
 				Optional:            true,
 			},
+			"versions": schema.ListAttribute{
+				Description:         "Optional: Only include these exact versions, e.g. [\"1.24.5\", \"1.25.0\"]. Sent to Workshop as an anchored version_regexp, so it can't be combined with version_regexp, min_date, or max_date.",
+				MarkdownDescription: "Optional: Only include these exact versions, e.g. `[\"1.24.5\", \"1.25.0\"]`. Sent to Workshop as an anchored `version_regexp`, so it can't be combined with `version_regexp`, `min_date`, or `max_date`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+					listvalidator.ConflictsWith(
+						path.MatchRoot("version_regexp"),
+						path.MatchRoot("min_date"),
+						path.MatchRoot("max_date"),
+					),
+				},
+			},
+
+			// Refreshed on Read; an upsert starts a new sync, so these plan as
+			// "known after apply" whenever the rule changes.
+			"sync_status": schema.StringAttribute{
+				Description:         "The outcome of the rule's last sync with GAL, e.g. PACKAGE_RULE_SYNC_STATUS_SUCCESS or PACKAGE_RULE_SYNC_STATUS_NO_BINARIES. Null until the first sync after a create or update.",
+				MarkdownDescription: "The outcome of the rule's last sync with GAL, e.g. `PACKAGE_RULE_SYNC_STATUS_SUCCESS` or `PACKAGE_RULE_SYNC_STATUS_NO_BINARIES`. Null until the first sync after a create or update.",
+				Computed:            true,
+			},
+			"last_synced_at": schema.StringAttribute{
+				Description:         "When the rule last synced with GAL, as an RFC3339 timestamp. Null until the first sync after a create or update.",
+				MarkdownDescription: "When the rule last synced with GAL, as an RFC3339 timestamp. Null until the first sync after a create or update.",
+				Computed:            true,
+			},
+			"generated_rule_count": schema.Int64Attribute{
+				Description:         "The number of execution rules Workshop has generated from this package rule. Zero until the rule's first sync with GAL after a create or update; zero after a sync means the package resolved to nothing.",
+				MarkdownDescription: "The number of execution rules Workshop has generated from this package rule. Zero until the rule's first sync with GAL after a create or update; zero after a sync means the package resolved to nothing.",
+				Computed:            true,
+			},
+			"generated_identifiers": schema.ListAttribute{
+				Description:         "The identifiers of the execution rules Workshop has generated from this package rule, sorted, e.g. to check that the package resolved to the binaries expected.",
+				MarkdownDescription: "The identifiers of the execution rules Workshop has generated from this package rule, sorted, e.g. to check that the package resolved to the binaries expected.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
 
 			// Computed value, returned from Create. The ID changes on every
 			// upsert (including in-place updates), so it is intentionally left
@@ -162,12 +232,110 @@ func (r *PackageRuleResource) Configure(ctx context.Context, req resource.Config
 	pd, ok := req.ProviderData.(*NPSProviderResourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
+			codeInternal.summary("Unexpected Resource Configure Type"),
 			fmt.Sprintf("Expected NPSProviderResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 	r.client = pd.Client
+	r.strictRead = pd.StrictRead
+	r.acceptNewEnumValues = pd.AcceptNewEnumValues
+	r.defaultTag = pd.DefaultTag
+	r.guardrails = pd.Guardrails
+	r.backup = pd.DestroyBackup
+	r.validatePackages = pd.ValidatePackages
+}
+
+// ModifyPlan applies the provider's default_tag, enforces its forbid_policies,
+// and runs its validate_packages check, which are only available once the
+// provider is configured.
+func (r *PackageRuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	if r.client != nil {
+		applyDefaultTag(ctx, r.defaultTag, req, resp)
+	}
+
+	var policy types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("policy"), &policy)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.guardrails.checkPolicy(policy)...)
+	if resp.Diagnostics.HasError() || !r.validatePackages {
+		return
+	}
+
+	var data, prior PackageRuleResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &data)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// The package is checked once, when the rule is created or what it
+	// resolves changes, so that a package leaving GAL doesn't fail every later
+	// plan.
+	if packageRuleKnown(data) && (req.State.Raw.IsNull() || !samePackageResolution(prior, data)) {
+		resp.Diagnostics.Append(r.checkPackageResolves(ctx, data)...)
+	}
+}
+
+// packageRuleKnown reports whether everything the rule resolves by is known,
+// which it may not be at plan when interpolated from another resource.
+func packageRuleKnown(data PackageRuleResourceModel) bool {
+	for _, v := range []types.String{data.Source, data.Name, data.RuleType, data.MinDate, data.MaxDate, data.VersionRegexp} {
+		if v.IsUnknown() {
+			return false
+		}
+	}
+	return !data.Versions.IsUnknown() && !slices.ContainsFunc(data.Versions.Elements(), attr.Value.IsUnknown)
+}
+
+// samePackageResolution reports whether a and b resolve the same identifiers.
+func samePackageResolution(a, b PackageRuleResourceModel) bool {
+	return a.Source.Equal(b.Source) && a.Name.Equal(b.Name) && a.RuleType.Equal(b.RuleType) &&
+		a.MinDate.Equal(b.MinDate) && a.MaxDate.Equal(b.MaxDate) &&
+		packageRuleVersionRegexp(a).Equal(packageRuleVersionRegexp(b))
+}
+
+// checkPackageResolves asks GAL how many identifiers the rule would resolve to
+// and fails if none. A package GAL hasn't ingested can't be judged, and
+// creating its rule is what starts ingestion, so it only warns.
+func (r *PackageRuleResource) checkPackageResolves(ctx context.Context, data PackageRuleResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	countReq := packageRulePreviewRequest(PackageRulePreviewDataSourceModel{
+		Source:        data.Source,
+		Name:          data.Name,
+		RuleType:      data.RuleType,
+		MinDate:       data.MinDate,
+		MaxDate:       data.MaxDate,
+		VersionRegexp: packageRuleVersionRegexp(data),
+	}, &diags)
+	if diags.HasError() {
+		return diags
+	}
+	ret, err := r.client.CountPackageRuleIdentifiers(ctx, countReq)
+	if err != nil {
+		diags.AddAttributeError(path.Root("name"), clientError(err), fmt.Sprintf("Failed to look up package %q in GAL: %v", data.Name.ValueString(), err))
+		return diags
+	}
+
+	pkg := fmt.Sprintf("%s package %q", data.Source.ValueString(), data.Name.ValueString())
+	switch {
+	case ret.GetStatus() == apipb.CountPackageRuleIdentifiersResponse_PACKAGE_STATUS_CHECK_BACK_LATER:
+		diags.AddAttributeWarning(path.Root("name"), codeNotice.summary("Package not yet ingested"),
+			fmt.Sprintf("GAL has not ingested the %s yet, so it can't be checked; creating the rule starts ingestion. If the name is misspelled, the rule will resolve to no identifiers.", pkg))
+	case ret.GetStatus() == apipb.CountPackageRuleIdentifiersResponse_PACKAGE_STATUS_NO_MACOS_BINARIES:
+		diags.AddAttributeError(path.Root("name"), codeInvalidConfig.summary("Package has no macOS binaries"),
+			fmt.Sprintf("GAL found no macOS binaries in the %s, so the rule would resolve to no identifiers.", pkg))
+	case ret.GetIdentifierCount() == 0:
+		diags.AddError(codeInvalidConfig.summary("Package rule resolves to no identifiers"),
+			fmt.Sprintf("No %s identifiers of the %s match the rule's filters, so the rule would do nothing. Check the package name, rule_type, and version filters, e.g. with nps_workshop_package_rule_preview.", data.RuleType.ValueString(), pkg))
+	}
+	return diags
 }
 
 func (r *PackageRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -189,15 +357,16 @@ func (r *PackageRuleResource) Create(ctx context.Context, req resource.CreateReq
 		Rule: rule,
 	}.Build())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to create package rule: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to create package rule: %v", err))
 		return
 	}
 
 	data.Id = types.Int64Value(crResp.GetRuleId())
 	tflog.Info(ctx, fmt.Sprintf("Created package rule: %d", data.Id.ValueInt64()))
+	r.resetSyncState(ctx, &data, &resp.Diagnostics)
 
 	// Set the identity
-	resp.Diagnostics.Append(resp.Identity.Set(ctx, PackageRuleIdentityModel{Id: data.Id})...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, packageRuleIdentity(data))...)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -216,18 +385,21 @@ func (r *PackageRuleResource) Read(ctx context.Context, req resource.ReadRequest
 	// Query for the rule by ID, or by (name, source, tag) combination.
 	// During import, only ID is set, so we must avoid sending empty enum values
 	// (like source) which the server would reject.
-	filter := fmt.Sprintf(`rule_id = %d`, data.Id.ValueInt64())
+	query := filter.Eq("rule_id", data.Id.ValueInt64())
 	if !data.Source.IsNull() && !data.Source.IsUnknown() && data.Source.ValueString() != "" {
-		filter += fmt.Sprintf(` OR (name = "%s" AND source = "%s" AND tag = "%s")`,
-			data.Name.ValueString(), data.Source.ValueString(), data.Tag.ValueString())
+		query = filter.Or(query, filter.And(
+			filter.Eq("name", data.Name.ValueString()),
+			filter.Eq("source", data.Source.ValueString()),
+			filter.Eq("tag", data.Tag.ValueString()),
+		))
 	}
 
 	ret, err := r.client.ListPackageRules(ctx, apipb.ListPackageRulesRequest_builder{
-		Filter:   proto.String(filter),
+		Filter:   proto.String(query.String()),
 		PageSize: proto.Uint32(1),
 	}.Build())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to list package rules: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to list package rules: %v", err))
 		return
 	}
 	if len(ret.GetRules()) == 0 {
@@ -240,29 +412,88 @@ func (r *PackageRuleResource) Read(ctx context.Context, req resource.ReadRequest
 
 	// Now that we've found the rule, overwrite the state data with the actual
 	// values retrieved via the API.
-	rule := ret.GetRules()[0]
+	dec := newReadDecoder(r.strictRead, &resp.Diagnostics).withNewEnumValues(r.acceptNewEnumValues)
+	applyPackageRuleProto(&data, ret.GetRules()[0], dec)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.refreshGeneratedRules(ctx, &data, &resp.Diagnostics)
+
+	// Set the identity
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, packageRuleIdentity(data))...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// applyPackageRuleProto overwrites data with the values of rule, as returned
+// by ListPackageRules. Filters the server reports as unset keep their value in
+// data, so Read passes the prior state and List an empty model.
+func applyPackageRuleProto(data *PackageRuleResourceModel, rule *apipb.PackageRule, dec readDecoder) {
 	data.Id = types.Int64Value(rule.GetRuleId())
 	data.Tag = types.StringValue(rule.GetTag())
-	data.Source = types.StringValue(rule.GetSource().String())
+	data.Source = dec.enum(path.Root("source"), rule.GetSource(), data.Source)
 	data.Name = types.StringValue(rule.GetName())
-	data.Policy = types.StringValue(rule.GetPolicy().String())
-	data.RuleType = types.StringValue(rule.GetRuleType().String())
-
-	if rule.GetVersionRegexp() != "" {
-		data.VersionRegexp = types.StringValue(rule.GetVersionRegexp())
+	data.Policy = dec.enum(path.Root("policy"), rule.GetPolicy(), data.Policy)
+	data.RuleType = dec.enum(path.Root("rule_type"), rule.GetRuleType(), data.RuleType)
+
+	// versions is only kept while the server still has the regexp it
+	// compiles to; any other regexp was set outside Terraform.
+	if re := rule.GetVersionRegexp(); re != "" && (data.Versions.IsNull() || re != versionsRegexp(data.Versions)) {
+		data.VersionRegexp = types.StringValue(re)
+		data.Versions = types.ListNull(types.StringType)
 	}
 	if rule.HasMinDate() {
-		data.MinDate = types.StringValue(rule.GetMinDate().AsTime().Format(time.RFC3339))
+		data.MinDate = dec.timestamp(path.Root("min_date"), rule.GetMinDate(), data.MinDate)
 	}
 	if rule.HasMaxDate() {
-		data.MaxDate = types.StringValue(rule.GetMaxDate().AsTime().Format(time.RFC3339))
+		data.MaxDate = dec.timestamp(path.Root("max_date"), rule.GetMaxDate(), data.MaxDate)
 	}
 
-	// Set the identity
-	resp.Diagnostics.Append(resp.Identity.Set(ctx, PackageRuleIdentityModel{Id: data.Id})...)
+	data.SyncStatus = types.StringNull()
+	if code := rule.GetSyncStatusCode(); code != apipb.PackageRuleSyncStatus_PACKAGE_RULE_SYNC_STATUS_UNSPECIFIED {
+		data.SyncStatus = types.StringValue(code.String())
+	}
+	data.LastSyncedAt = dec.timestamp(path.Root("last_synced_at"), rule.GetLastSyncedAt(), data.LastSyncedAt)
+}
 
-	// Save updated data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+// resetSyncState sets the sync attributes after an upsert, which replaces the
+// rule with one that hasn't synced yet.
+func (r *PackageRuleResource) resetSyncState(ctx context.Context, data *PackageRuleResourceModel, diags *diag.Diagnostics) {
+	data.SyncStatus = types.StringNull()
+	data.LastSyncedAt = types.StringNull()
+	data.GeneratedRuleCount = types.Int64Null()
+	data.GeneratedIdentifiers = types.ListNull(types.StringType)
+	r.refreshGeneratedRules(ctx, data, diags)
+}
+
+// refreshGeneratedRules lists the execution rules generated from the package
+// rule. They are informational, so if they can't be listed, the prior values
+// are kept with a warning rather than failing the apply or refresh.
+func (r *PackageRuleResource) refreshGeneratedRules(ctx context.Context, data *PackageRuleResourceModel, diags *diag.Diagnostics) {
+	query := filter.Eq("package_rule_id", data.Id.ValueInt64()).String()
+	pages := listPages(ctx, "generated rules", func(page uint32) ([]*apipb.Rule, bool, error) {
+		ret, err := r.client.ListRules(ctx, apipb.ListRulesRequest_builder{
+			Filter:   proto.String(query),
+			PageSize: proto.Int32(listPageSize),
+			Page:     proto.Int32(int32(page)),
+		}.Build())
+		return ret.GetRules(), ret.GetMore(), err
+	})
+	identifiers := []string{}
+	for rule, err := range pages {
+		if err != nil {
+			diags.AddWarning(clientError(err), fmt.Sprintf("Failed to list the rules generated by package rule %q: %v", data.Name.ValueString(), err))
+			return
+		}
+		identifiers = append(identifiers, rule.GetIdentifier())
+	}
+	slices.Sort(identifiers)
+
+	list, listDiags := types.ListValueFrom(ctx, types.StringType, identifiers)
+	diags.Append(listDiags...)
+	data.GeneratedRuleCount = types.Int64Value(int64(len(identifiers)))
+	data.GeneratedIdentifiers = list
 }
 
 // buildPackageRule builds the (upsert) PackageRule from the model.
@@ -277,13 +508,13 @@ func buildPackageRule(data PackageRuleResourceModel, diags *diag.Diagnostics) *a
 		Name:          data.Name.ValueString(),
 		Policy:        apipb.Policy(policy),
 		RuleType:      apipb.RuleType(ruleType),
-		VersionRegexp: data.VersionRegexp.ValueString(),
+		VersionRegexp: packageRuleVersionRegexp(data).ValueString(),
 	}
 
 	if !data.MinDate.IsNull() && !data.MinDate.IsUnknown() {
 		t, err := time.Parse(time.RFC3339, data.MinDate.ValueString())
 		if err != nil {
-			diags.AddError("Invalid min_date", fmt.Sprintf("Failed to parse min_date: %v", err))
+			diags.AddError(codeInvalidConfig.summary("Invalid min_date"), fmt.Sprintf("Failed to parse min_date: %v", err))
 		} else {
 			builder.MinDate = timestamppb.New(t)
 		}
@@ -292,7 +523,7 @@ func buildPackageRule(data PackageRuleResourceModel, diags *diag.Diagnostics) *a
 	if !data.MaxDate.IsNull() && !data.MaxDate.IsUnknown() {
 		t, err := time.Parse(time.RFC3339, data.MaxDate.ValueString())
 		if err != nil {
-			diags.AddError("Invalid max_date", fmt.Sprintf("Failed to parse max_date: %v", err))
+			diags.AddError(codeInvalidConfig.summary("Invalid max_date"), fmt.Sprintf("Failed to parse max_date: %v", err))
 		} else {
 			builder.MaxDate = timestamppb.New(t)
 		}
@@ -301,6 +532,27 @@ func buildPackageRule(data PackageRuleResourceModel, diags *diag.Diagnostics) *a
 	return builder.Build()
 }
 
+// packageRuleVersionRegexp returns the version_regexp to send for the model:
+// its versions compiled to a regexp if set, otherwise its version_regexp.
+func packageRuleVersionRegexp(data PackageRuleResourceModel) types.String {
+	if data.Versions.IsNull() || data.Versions.IsUnknown() {
+		return data.VersionRegexp
+	}
+	return types.StringValue(versionsRegexp(data.Versions))
+}
+
+// versionsRegexp compiles versions into a regexp matching exactly those
+// versions, e.g. ^(1\.2\.3|1\.2\.4)$.
+func versionsRegexp(versions types.List) string {
+	var quoted []string
+	for _, v := range versions.Elements() {
+		if s, ok := v.(types.String); ok {
+			quoted = append(quoted, regexp.QuoteMeta(s.ValueString()))
+		}
+	}
+	return "^(" + strings.Join(quoted, "|") + ")$"
+}
+
 func (r *PackageRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var plan PackageRuleResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -315,8 +567,9 @@ func (r *PackageRuleResource) Update(ctx context.Context, req resource.UpdateReq
 	}
 	plan.Id = newID
 	tflog.Info(ctx, fmt.Sprintf("Updated package rule: %d", plan.Id.ValueInt64()))
+	r.resetSyncState(ctx, &plan, &resp.Diagnostics)
 
-	resp.Diagnostics.Append(resp.Identity.Set(ctx, PackageRuleIdentityModel{Id: plan.Id})...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, packageRuleIdentity(plan))...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -339,7 +592,7 @@ func (r *PackageRuleResource) upsertPackageRule(ctx context.Context, plan Packag
 		Rule: rule,
 	}.Build())
 	if err != nil {
-		diags.AddError("Client Error", fmt.Sprintf("Failed to update package rule: %v", err))
+		diags.AddError(clientError(err), fmt.Sprintf("Failed to update package rule: %v", err))
 		return types.Int64Null(), diags
 	}
 	return types.Int64Value(crResp.GetRuleId()), diags
@@ -356,12 +609,17 @@ func (r *PackageRuleResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
+	backupBeforeDelete(ctx, r.backup, data.Tag.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	ruleId := data.Id.ValueInt64()
 	_, err := r.client.DeletePackageRule(ctx, apipb.DeletePackageRuleRequest_builder{
 		RuleId: proto.Int64(ruleId),
 	}.Build())
 	if err != nil && !isRuleDeleteNoOp(err) {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete package rule: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to delete package rule: %v", err))
 		return
 	}
 
@@ -369,10 +627,28 @@ func (r *PackageRuleResource) Delete(ctx context.Context, req resource.DeleteReq
 }
 
 func (r *PackageRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var identity PackageRuleIdentityModel
+	if importIdentity(ctx, req, resp, &identity) {
+		switch {
+		case resp.Diagnostics.HasError():
+		case !identity.Id.IsNull():
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.Id)...)
+		case identity.Tag.ValueString() != "" && identity.Name.ValueString() != "" && apipb.PackageSource_value[identity.Source.ValueString()] != 0:
+			// Read resolves the natural key to the rule's current ID.
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), int64(0))...)
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tag"), identity.Tag)...)
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("source"), identity.Source)...)
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), identity.Name)...)
+		default:
+			resp.Diagnostics.AddError(codeInvalidConfig.summary("Invalid import identity"), "Expected an identity with id, or with tag, source, and name.")
+		}
+		return
+	}
+
 	// Import a package rule by ID, which will trigger a Read.
 	id, err := strconv.ParseInt(req.ID, 10, 64)
 	if err != nil {
-		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Failed to parse ID %q as integer: %v", req.ID, err))
+		resp.Diagnostics.AddError(codeInvalidConfig.summary("Invalid ID"), fmt.Sprintf("Failed to parse ID %q as integer: %v", req.ID, err))
 		return
 	}
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
@@ -380,14 +656,34 @@ func (r *PackageRuleResource) ImportState(ctx context.Context, req resource.Impo
 
 func (r *PackageRuleResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
 	resp.IdentitySchema = identityschema.Schema{
+		Version: 1,
 		Attributes: map[string]identityschema.Attribute{
 			"id": identityschema.Int64Attribute{
-				RequiredForImport: true,
+				Description:       "The rule's ID. Set either `id`, or `tag`, `source`, and `name`.",
+				OptionalForImport: true,
+			},
+			"tag": identityschema.StringAttribute{
+				Description:       "The tag the rule applies to.",
+				OptionalForImport: true,
+			},
+			"source": identityschema.StringAttribute{
+				Description:       "The package's source, e.g. `PACKAGE_SOURCE_HOMEBREW`.",
+				OptionalForImport: true,
+			},
+			"name": identityschema.StringAttribute{
+				Description:       "The package's name.",
+				OptionalForImport: true,
 			},
 		},
 	}
 }
 
+func (r *PackageRuleResource) UpgradeIdentity(ctx context.Context) map[int64]resource.IdentityUpgrader {
+	return idIdentityUpgraders(identityschema.Int64Attribute{RequiredForImport: true}, func(id types.Int64) any {
+		return PackageRuleIdentityModel{Id: id}
+	})
+}
+
 func NewPackageRuleListResource() list.ListResource {
 	return &PackageRuleResource{}
 }
@@ -401,41 +697,34 @@ func (r *PackageRuleResource) ListResourceConfigSchema(ctx context.Context, req
 
 func (r *PackageRuleResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
 	stream.Results = func(push func(list.ListResult) bool) {
-		ret, err := r.client.ListPackageRules(ctx, apipb.ListPackageRulesRequest_builder{}.Build())
-		if err != nil {
-			result := req.NewListResult(ctx)
-			result.Diagnostics.AddError("Client Error", "Failed to list package rules: "+err.Error())
-			push(result)
-			return
-		}
+		pages := listPages(ctx, "package rules", func(page uint32) ([]*apipb.PackageRule, bool, error) {
+			ret, err := r.client.ListPackageRules(ctx, apipb.ListPackageRulesRequest_builder{
+				PageSize: proto.Uint32(listPageSize),
+				Page:     proto.Uint32(page),
+			}.Build())
+			return ret.GetRules(), ret.GetMore(), err
+		})
+		for rule, err := range pages {
+			if err != nil {
+				result := req.NewListResult(ctx)
+				result.Diagnostics.AddError(clientError(err), "Failed to list package rules: "+err.Error())
+				push(result)
+				return
+			}
 
-		for _, rule := range ret.GetRules() {
 			result := req.NewListResult(ctx)
 			result.DisplayName = rule.GetName()
 
 			result.Diagnostics.Append(result.Identity.Set(ctx, PackageRuleIdentityModel{
-				Id: types.Int64Value(rule.GetRuleId()),
+				Id:     types.Int64Value(rule.GetRuleId()),
+				Tag:    types.StringValue(rule.GetTag()),
+				Source: types.StringValue(rule.GetSource().String()),
+				Name:   types.StringValue(rule.GetName()),
 			})...)
 
 			if req.IncludeResource {
-				model := PackageRuleResourceModel{
-					Id:       types.Int64Value(rule.GetRuleId()),
-					Tag:      types.StringValue(rule.GetTag()),
-					Source:   types.StringValue(rule.GetSource().String()),
-					Name:     types.StringValue(rule.GetName()),
-					Policy:   types.StringValue(rule.GetPolicy().String()),
-					RuleType: types.StringValue(rule.GetRuleType().String()),
-				}
-
-				if rule.GetVersionRegexp() != "" {
-					model.VersionRegexp = types.StringValue(rule.GetVersionRegexp())
-				}
-				if rule.HasMinDate() {
-					model.MinDate = types.StringValue(rule.GetMinDate().AsTime().Format(time.RFC3339))
-				}
-				if rule.HasMaxDate() {
-					model.MaxDate = types.StringValue(rule.GetMaxDate().AsTime().Format(time.RFC3339))
-				}
+				model := PackageRuleResourceModel{Versions: types.ListNull(types.StringType), GeneratedIdentifiers: types.ListNull(types.StringType)}
+				applyPackageRuleProto(&model, rule, newReadDecoder(r.strictRead, &result.Diagnostics).withNewEnumValues(r.acceptNewEnumValues))
 
 				result.Diagnostics.Append(result.Resource.Set(ctx, model)...)
 			}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 )
 
 func TestAccPackageRule(t *testing.T) {
@@ -24,6 +25,7 @@ func TestAccPackageRule(t *testing.T) {
 					resource.TestCheckResourceAttr("nps_workshop_package_rule.test", "source", "PACKAGE_SOURCE_HOMEBREW"),
 					resource.TestCheckResourceAttr("nps_workshop_package_rule.test", "policy", "ALLOWLIST"),
 					resource.TestCheckResourceAttr("nps_workshop_package_rule.test", "rule_type", "SIGNINGID"),
+					testAccCheckGeneratedConfig("nps_workshop_package_rule.test", "id"),
 				),
 			},
 			// ImportState testing
@@ -32,6 +34,33 @@ func TestAccPackageRule(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			// Changing the version filters updates the rule in place.
+			{
+				Config: testAccPackageRuleResourceConfigWithFilters("wget", "global"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("nps_workshop_package_rule.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("nps_workshop_package_rule.test", "min_date", "2024-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr("nps_workshop_package_rule.test", "max_date", "2024-12-31T23:59:59Z"),
+					resource.TestCheckResourceAttr("nps_workshop_package_rule.test", "version_regexp", "^1\\."),
+				),
+			},
+			// Removing them again is also an in-place update.
+			{
+				Config: testAccPackageRuleResourceConfig("wget", "global"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("nps_workshop_package_rule.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("nps_workshop_package_rule.test", "min_date"),
+					resource.TestCheckNoResourceAttr("nps_workshop_package_rule.test", "version_regexp"),
+				),
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
@@ -52,3 +81,22 @@ resource "nps_workshop_package_rule" "test" {
 }
 `, name, tag)
 }
+
+func testAccPackageRuleResourceConfigWithFilters(name string, tag string) string {
+	return fmt.Sprintf(`
+provider "nps" {
+  endpoint = "localhost:8080"
+}
+
+resource "nps_workshop_package_rule" "test" {
+  name           = %[1]q
+  tag            = %[2]q
+  source         = "PACKAGE_SOURCE_HOMEBREW"
+  policy         = "ALLOWLIST"
+  rule_type      = "SIGNINGID"
+  min_date       = "2024-01-01T00:00:00Z"
+  max_date       = "2024-12-31T23:59:59Z"
+  version_regexp = "^1\\."
+}
+`, name, tag)
+}
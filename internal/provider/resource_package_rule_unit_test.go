@@ -0,0 +1,156 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+func TestPackageRuleReadSyncState(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeWorkshopClient{
+		listPackageRules: []*apipb.PackageRule{apipb.PackageRule_builder{
+			RuleId:         7,
+			Tag:            "global",
+			Source:         apipb.PackageSource_PACKAGE_SOURCE_HOMEBREW,
+			Name:           "wget",
+			Policy:         apipb.Policy_ALLOWLIST,
+			RuleType:       apipb.RuleType_SIGNINGID,
+			LastSyncedAt:   timestamppb.New(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)),
+			SyncStatusCode: apipb.PackageRuleSyncStatus_PACKAGE_RULE_SYNC_STATUS_SUCCESS,
+		}.Build()},
+		listRules: []*apipb.Rule{
+			apipb.Rule_builder{Identifier: "platform:com.example.wget2", PackageRuleId: proto.Int64(7)}.Build(),
+			apipb.Rule_builder{Identifier: "platform:com.example.wget", PackageRuleId: proto.Int64(7)}.Build(),
+		},
+	}
+	r := &PackageRuleResource{client: client}
+
+	var sResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &sResp)
+	var iResp resource.IdentitySchemaResponse
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &iResp)
+
+	read := func(prior PackageRuleResourceModel) (PackageRuleResourceModel, *resource.ReadResponse) {
+		t.Helper()
+		req := resource.ReadRequest{State: tfsdk.State{Schema: sResp.Schema}}
+		if diags := req.State.Set(ctx, prior); diags.HasError() {
+			t.Fatalf("failed to build state: %v", diags)
+		}
+		resp := &resource.ReadResponse{
+			State:    req.State,
+			Identity: &tfsdk.ResourceIdentity{Schema: iResp.IdentitySchema},
+		}
+		r.Read(ctx, req, resp)
+		var got PackageRuleResourceModel
+		resp.State.Get(ctx, &got)
+		return got, resp
+	}
+
+	got, resp := read(PackageRuleResourceModel{Id: types.Int64Value(7), Versions: types.ListNull(types.StringType), GeneratedIdentifiers: types.ListNull(types.StringType)})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("read failed: %v", resp.Diagnostics)
+	}
+	if got.SyncStatus.ValueString() != "PACKAGE_RULE_SYNC_STATUS_SUCCESS" || got.LastSyncedAt.ValueString() != "2026-03-01T12:00:00Z" {
+		t.Errorf("sync state = %v, %v", got.SyncStatus, got.LastSyncedAt)
+	}
+	if want := "package_rule_id = 7"; client.listRulesFilter != want {
+		t.Errorf("ListRules filter = %s, want %s", client.listRulesFilter, want)
+	}
+	wantIdentifiers := stringList("platform:com.example.wget", "platform:com.example.wget2")
+	if got.GeneratedRuleCount.ValueInt64() != 2 || !got.GeneratedIdentifiers.Equal(wantIdentifiers) {
+		t.Errorf("generated rules = %v, %v; want 2, %v", got.GeneratedRuleCount, got.GeneratedIdentifiers, wantIdentifiers)
+	}
+
+	// A failed listing keeps the prior values and only warns.
+	client.listRulesErr = errors.New("unavailable")
+	got, resp = read(got)
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("diags = %v, want one warning", resp.Diagnostics)
+	}
+	if got.GeneratedRuleCount.ValueInt64() != 2 || !got.GeneratedIdentifiers.Equal(wantIdentifiers) {
+		t.Errorf("generated rules = %v, %v; want the prior values", got.GeneratedRuleCount, got.GeneratedIdentifiers)
+	}
+}
+
+func TestPackageRuleVersionsDrift(t *testing.T) {
+	prior := PackageRuleResourceModel{
+		VersionRegexp: types.StringNull(),
+		Versions:      stringList("1.24.5", "1.25.0"),
+	}
+	if got := versionsRegexp(prior.Versions); got != `^(1\.24\.5|1\.25\.0)$` {
+		t.Fatalf("versionsRegexp() = %s", got)
+	}
+
+	for _, tt := range []struct {
+		serverRegexp string
+		wantRegexp   types.String
+		wantVersions types.List
+	}{
+		{`^(1\.24\.5|1\.25\.0)$`, types.StringNull(), prior.Versions},
+		{`^(1\.24\.5|1\.25\.0|1\.26\.0)$`, types.StringValue(`^(1\.24\.5|1\.25\.0|1\.26\.0)$`), types.ListNull(types.StringType)},
+	} {
+		var diags diag.Diagnostics
+		got := prior
+		applyPackageRuleProto(&got, apipb.PackageRule_builder{VersionRegexp: tt.serverRegexp}.Build(), newReadDecoder(true, &diags))
+		if !got.VersionRegexp.Equal(tt.wantRegexp) || !got.Versions.Equal(tt.wantVersions) {
+			t.Errorf("server regexp %s: version_regexp = %v, versions = %v; want %v, %v", tt.serverRegexp, got.VersionRegexp, got.Versions, tt.wantRegexp, tt.wantVersions)
+		}
+	}
+}
+
+func TestCheckPackageResolves(t *testing.T) {
+	data := PackageRuleResourceModel{
+		Source:        types.StringValue("PACKAGE_SOURCE_HOMEBREW"),
+		Name:          types.StringValue("wget"),
+		RuleType:      types.StringValue("SIGNINGID"),
+		MinDate:       types.StringNull(),
+		MaxDate:       types.StringNull(),
+		VersionRegexp: types.StringNull(),
+		Versions:      stringList(),
+	}
+	for _, tt := range []struct {
+		name         string
+		client       fakeWorkshopClient
+		wantErr      bool
+		wantWarnings int
+	}{
+		{"resolves", fakeWorkshopClient{identifierCount: 3, packageStatus: apipb.CountPackageRuleIdentifiersResponse_PACKAGE_STATUS_RULES_AVAILABLE}, false, 0},
+		{"filtered out", fakeWorkshopClient{packageStatus: apipb.CountPackageRuleIdentifiersResponse_PACKAGE_STATUS_RULES_AVAILABLE}, true, 0},
+		{"no binaries", fakeWorkshopClient{packageStatus: apipb.CountPackageRuleIdentifiersResponse_PACKAGE_STATUS_NO_MACOS_BINARIES}, true, 0},
+		{"not ingested", fakeWorkshopClient{packageStatus: apipb.CountPackageRuleIdentifiersResponse_PACKAGE_STATUS_CHECK_BACK_LATER}, false, 1},
+		{"lookup failed", fakeWorkshopClient{identifierCountErr: errors.New("unavailable")}, true, 0},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &PackageRuleResource{client: &tt.client}
+			diags := r.checkPackageResolves(context.Background(), data)
+			if diags.HasError() != tt.wantErr || diags.WarningsCount() != tt.wantWarnings {
+				t.Errorf("diags = %v, want error %v and %d warnings", diags, tt.wantErr, tt.wantWarnings)
+			}
+		})
+	}
+
+	// Changing only the policy or tag doesn't re-check the package.
+	moved := data
+	moved.Tag = types.StringValue("dev")
+	moved.Policy = types.StringValue("BLOCKLIST")
+	if !samePackageResolution(data, moved) {
+		t.Error("samePackageResolution() = false for a policy and tag change")
+	}
+	pinned := data
+	pinned.Versions = stringList("1.24.5")
+	if samePackageResolution(data, pinned) {
+		t.Error("samePackageResolution() = true after pinning versions")
+	}
+}
@@ -4,9 +4,12 @@ package provider
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -37,8 +40,205 @@ type fakeWorkshopClient struct {
 	validateCELCall     int    // number of ValidateCELRule calls
 	validateCELSeatbelt bool   // CanReturnSeatbelt on the ValidateCELRule response
 
-	syncDeleteCalls int // number of DeleteSyncSettings calls
-	syncUpdateCalls int // number of UpdateSyncSettings calls
+	syncDeleteCalls int                        // number of DeleteSyncSettings calls
+	syncUpdateCalls int                        // number of UpdateSyncSettings calls
+	syncUpdates     []*apipb.SyncSettings      // captured UpdateSyncSettings payloads
+	listSync        []*apipb.SyncSettings      // returned by ListSyncSettings
+	createRuleReqs  []*apipb.CreateRuleRequest // every CreateRule request
+	createFARules   []*apipb.FileAccessRule    // every CreateFileAccessRule payload
+
+	listRules           []*apipb.Rule             // returned by ListRules
+	listRulesCount      int64                     // Count on the ListRules response
+	listRulesFilter     string                    // captured ListRules filter
+	listRulesErr        error                     // returned by ListRules
+	listRulesCalls      int                       // number of ListRules calls
+	listPackageRules    []*apipb.PackageRule      // returned by ListPackageRules
+	listPkgRulesFilter  string                    // captured ListPackageRules filter
+	identifierCount     uint32                    // returned by CountPackageRuleIdentifiers
+	identifierCountErr  error                     // returned by CountPackageRuleIdentifiers
+	listFileAccessRules []*apipb.FileAccessRule   // returned by ListFileAccessRules
+	listFARulesCount    int64                     // Count on the ListFileAccessRules response
+	listFARulesCalls    int                       // number of full (not count-only) ListFileAccessRules calls
+	listFARulesFilter   string                    // captured ListFileAccessRules filter
+	listTags            []*apipb.TagStats         // returned by ListTags
+	listTagsErr         error                     // returned by ListTags
+	tagRenames          []*apipb.RenameTagRequest // captured RenameTag requests
+	listAPIKeys         []*apipb.APIKey           // returned by ListAPIKeys
+	auditEventsUsed     []apipb.AuditEvent        // ListAuditEvents counts one for each of these the filter names
+	auditEventsErr      error                     // returned by ListAuditEvents
+	auditEventsFilters  []string                  // captured ListAuditEvents filters
+
+	// Status on the CountPackageRuleIdentifiers response.
+	packageStatus apipb.CountPackageRuleIdentifiersResponse_PackageStatus
+
+	listNetworkFlowRules []*apipb.NetworkFlowRule // returned by ListNetworkFlowRules
+	listSignals          []*apipb.Signal          // returned by ListSignals
+
+	apiKeyUpdates []*apipb.UpdateAPIKeyRequest // captured UpdateAPIKey requests
+
+	listUsers       []*apipb.User              // returned by ListUsers; CreateUser, UpdateUser, and DeleteUser edit it
+	listUsersFilter string                     // captured ListUsers filter
+	userCreates     []*apipb.CreateUserRequest // captured CreateUser requests
+	userUpdates     []*apipb.UpdateUserRequest // captured UpdateUser requests
+	userDeletes     []string                   // IDs passed to DeleteUser
+
+	host            *apipb.Host                // returned by GetHost; nil means NotFound
+	hostUpdates     []*apipb.UpdateHostRequest // captured UpdateHost requests
+	listHosts       []*apipb.Host              // returned by ListHosts
+	listHostsFilter string                     // captured ListHosts filter
+
+	role        *apipb.Role   // returned by GetRole; nil means NotFound
+	roleUpdates []*apipb.Role // captured UpdateRole payloads
+
+	serverVersion    string // CurrentVersion on the GetLatestWorkshopRelease response
+	serverVersionErr error  // returned by GetLatestWorkshopRelease
+}
+
+func (f *fakeWorkshopClient) ListRules(ctx context.Context, in *apipb.ListRulesRequest, _ ...grpc.CallOption) (*apipb.ListRulesResponse, error) {
+	f.listRulesCalls++
+	f.listRulesFilter = in.GetFilter()
+	if f.listRulesErr != nil {
+		return nil, f.listRulesErr
+	}
+	return apipb.ListRulesResponse_builder{Rules: f.listRules, Count: proto.Int64(f.listRulesCount)}.Build(), nil
+}
+
+func (f *fakeWorkshopClient) ListPackageRules(ctx context.Context, in *apipb.ListPackageRulesRequest, _ ...grpc.CallOption) (*apipb.ListPackageRulesResponse, error) {
+	f.listPkgRulesFilter = in.GetFilter()
+	return apipb.ListPackageRulesResponse_builder{Rules: f.listPackageRules}.Build(), nil
+}
+
+func (f *fakeWorkshopClient) ListFileAccessRules(ctx context.Context, in *apipb.ListFileAccessRulesRequest, _ ...grpc.CallOption) (*apipb.ListFileAccessRulesResponse, error) {
+	if in.GetCountOnly() {
+		return apipb.ListFileAccessRulesResponse_builder{Count: proto.Int64(f.listFARulesCount)}.Build(), nil
+	}
+	f.listFARulesCalls++
+	f.listFARulesFilter = in.GetFilter()
+	return apipb.ListFileAccessRulesResponse_builder{Rules: f.listFileAccessRules}.Build(), nil
+}
+
+func (f *fakeWorkshopClient) ListNetworkFlowRules(ctx context.Context, in *apipb.ListNetworkFlowRulesRequest, _ ...grpc.CallOption) (*apipb.ListNetworkFlowRulesResponse, error) {
+	return apipb.ListNetworkFlowRulesResponse_builder{Rules: f.listNetworkFlowRules}.Build(), nil
+}
+
+func (f *fakeWorkshopClient) ListSignals(ctx context.Context, in *apipb.ListSignalsRequest, _ ...grpc.CallOption) (*apipb.ListSignalsResponse, error) {
+	return apipb.ListSignalsResponse_builder{Signals: f.listSignals}.Build(), nil
+}
+
+func (f *fakeWorkshopClient) RenameTag(ctx context.Context, in *apipb.RenameTagRequest, _ ...grpc.CallOption) (*apipb.RenameTagResponse, error) {
+	f.tagRenames = append(f.tagRenames, in)
+	return &apipb.RenameTagResponse{}, nil
+}
+
+func (f *fakeWorkshopClient) ListTags(ctx context.Context, in *apipb.ListTagsRequest, _ ...grpc.CallOption) (*apipb.ListTagsResponse, error) {
+	if f.listTagsErr != nil {
+		return nil, f.listTagsErr
+	}
+	return apipb.ListTagsResponse_builder{Tags: f.listTags}.Build(), nil
+}
+
+func (f *fakeWorkshopClient) GetLatestWorkshopRelease(ctx context.Context, in *apipb.GetLatestWorkshopReleaseRequest, _ ...grpc.CallOption) (*apipb.GetLatestWorkshopReleaseResponse, error) {
+	if f.serverVersionErr != nil {
+		return nil, f.serverVersionErr
+	}
+	return apipb.GetLatestWorkshopReleaseResponse_builder{CurrentVersion: proto.String(f.serverVersion)}.Build(), nil
+}
+
+func (f *fakeWorkshopClient) ListUsers(ctx context.Context, in *apipb.ListUsersRequest, _ ...grpc.CallOption) (*apipb.ListUsersResponse, error) {
+	f.listUsersFilter = in.GetFilter()
+	return apipb.ListUsersResponse_builder{Users: f.listUsers}.Build(), nil
+}
+
+func (f *fakeWorkshopClient) CreateUser(ctx context.Context, in *apipb.CreateUserRequest, _ ...grpc.CallOption) (*apipb.CreateUserResponse, error) {
+	f.userCreates = append(f.userCreates, in)
+	id := fmt.Sprintf("user-%d", len(f.userCreates))
+	f.listUsers = append(f.listUsers, apipb.User_builder{
+		Id:                id,
+		Username:          in.GetUsername(),
+		ManagerEmail:      in.GetManagerEmail(),
+		ProfilePictureUrl: in.GetProfilePictureUrl(),
+		CostCenterName:    in.GetCostCenterName(),
+		DepartmentName:    in.GetDepartmentName(),
+		Type:              apipb.DirectoryType_DIRECTORY_TYPE_LOCAL,
+	}.Build())
+	return apipb.CreateUserResponse_builder{Id: proto.String(id)}.Build(), nil
+}
+
+func (f *fakeWorkshopClient) UpdateUser(ctx context.Context, in *apipb.UpdateUserRequest, _ ...grpc.CallOption) (*apipb.UpdateUserResponse, error) {
+	f.userUpdates = append(f.userUpdates, in)
+	for _, u := range f.listUsers {
+		if u.GetId() == in.GetId() {
+			u.SetUsername(in.GetUsername())
+			u.SetManagerEmail(in.GetManagerEmail())
+			u.SetProfilePictureUrl(in.GetProfilePictureUrl())
+			u.SetCostCenterName(in.GetCostCenterName())
+			u.SetDepartmentName(in.GetDepartmentName())
+			return apipb.UpdateUserResponse_builder{}.Build(), nil
+		}
+	}
+	return nil, status.Error(codes.NotFound, "user not found")
+}
+
+func (f *fakeWorkshopClient) DeleteUser(ctx context.Context, in *apipb.DeleteUserRequest, _ ...grpc.CallOption) (*apipb.DeleteUserResponse, error) {
+	f.userDeletes = append(f.userDeletes, in.GetId())
+	f.listUsers = slices.DeleteFunc(f.listUsers, func(u *apipb.User) bool { return u.GetId() == in.GetId() })
+	return apipb.DeleteUserResponse_builder{}.Build(), nil
+}
+
+func (f *fakeWorkshopClient) GetHost(ctx context.Context, in *apipb.GetHostRequest, _ ...grpc.CallOption) (*apipb.GetHostResponse, error) {
+	if f.host == nil || f.host.GetUuid() != in.GetUuid() {
+		return nil, status.Error(codes.NotFound, "host not found")
+	}
+	return apipb.GetHostResponse_builder{Host: f.host}.Build(), nil
+}
+
+func (f *fakeWorkshopClient) ListHosts(ctx context.Context, in *apipb.ListHostsRequest, _ ...grpc.CallOption) (*apipb.ListHostsResponse, error) {
+	f.listHostsFilter = in.GetFilter()
+	return apipb.ListHostsResponse_builder{Hosts: f.listHosts}.Build(), nil
+}
+
+func (f *fakeWorkshopClient) UpdateHost(ctx context.Context, in *apipb.UpdateHostRequest, _ ...grpc.CallOption) (*apipb.UpdateHostResponse, error) {
+	f.hostUpdates = append(f.hostUpdates, in)
+	return &apipb.UpdateHostResponse{}, nil
+}
+
+func (f *fakeWorkshopClient) GetRole(ctx context.Context, in *apipb.GetRoleRequest, _ ...grpc.CallOption) (*apipb.GetRoleResponse, error) {
+	if f.role == nil || f.role.GetName() != in.GetName() {
+		return nil, status.Error(codes.NotFound, "role not found")
+	}
+	return apipb.GetRoleResponse_builder{Role: f.role}.Build(), nil
+}
+
+func (f *fakeWorkshopClient) UpdateRole(ctx context.Context, in *apipb.UpdateRoleRequest, _ ...grpc.CallOption) (*apipb.UpdateRoleResponse, error) {
+	f.roleUpdates = append(f.roleUpdates, in.GetRole())
+	return &apipb.UpdateRoleResponse{}, nil
+}
+
+func (f *fakeWorkshopClient) ListAPIKeys(ctx context.Context, in *apipb.ListAPIKeysRequest, _ ...grpc.CallOption) (*apipb.ListAPIKeysResponse, error) {
+	return apipb.ListAPIKeysResponse_builder{Keys: f.listAPIKeys}.Build(), nil
+}
+
+func (f *fakeWorkshopClient) UpdateAPIKey(ctx context.Context, in *apipb.UpdateAPIKeyRequest, _ ...grpc.CallOption) (*apipb.UpdateAPIKeyResponse, error) {
+	f.apiKeyUpdates = append(f.apiKeyUpdates, in)
+	return &apipb.UpdateAPIKeyResponse{}, nil
+}
+
+func (f *fakeWorkshopClient) ListAuditEvents(ctx context.Context, in *apipb.ListAuditEventsRequest, _ ...grpc.CallOption) (*apipb.ListAuditEventsResponse, error) {
+	f.auditEventsFilters = append(f.auditEventsFilters, in.GetFilter())
+	if f.auditEventsErr != nil {
+		return nil, f.auditEventsErr
+	}
+	var count int64
+	for _, e := range f.auditEventsUsed {
+		if strings.Contains(in.GetFilter(), `"`+e.String()+`"`) {
+			count++
+		}
+	}
+	return apipb.ListAuditEventsResponse_builder{Count: proto.Int64(count)}.Build(), nil
+}
+
+func (f *fakeWorkshopClient) ListSyncSettings(ctx context.Context, in *apipb.ListSyncSettingsRequest, _ ...grpc.CallOption) (*apipb.ListSyncSettingsResponse, error) {
+	return apipb.ListSyncSettingsResponse_builder{SyncSettings: f.listSync}.Build(), nil
 }
 
 func (f *fakeWorkshopClient) DeleteSyncSettings(ctx context.Context, in *apipb.DeleteSyncSettingsRequest, _ ...grpc.CallOption) (*apipb.DeleteSyncSettingsResponse, error) {
@@ -48,6 +248,7 @@ func (f *fakeWorkshopClient) DeleteSyncSettings(ctx context.Context, in *apipb.D
 
 func (f *fakeWorkshopClient) UpdateSyncSettings(ctx context.Context, in *apipb.UpdateSyncSettingsRequest, _ ...grpc.CallOption) (*apipb.UpdateSyncSettingsResponse, error) {
 	f.syncUpdateCalls++
+	f.syncUpdates = append(f.syncUpdates, in.GetSyncSettings())
 	return apipb.UpdateSyncSettingsResponse_builder{}.Build(), nil
 }
 
@@ -68,6 +269,7 @@ func (f *fakeWorkshopClient) CreateRule(ctx context.Context, in *apipb.CreateRul
 	}
 	f.created = true
 	f.lastCreateReq = in
+	f.createRuleReqs = append(f.createRuleReqs, in)
 	return apipb.CreateRuleResponse_builder{RuleId: proto.String("rule-new")}.Build(), nil
 }
 
@@ -81,6 +283,7 @@ func (f *fakeWorkshopClient) CreateFileAccessRule(ctx context.Context, in *apipb
 		return nil, f.createErr
 	}
 	f.created = true
+	f.createFARules = append(f.createFARules, in.GetRule())
 	return apipb.CreateFileAccessRuleResponse_builder{RuleId: &f.newID}.Build(), nil
 }
 
@@ -97,6 +300,16 @@ func (f *fakeWorkshopClient) CreatePackageRule(ctx context.Context, in *apipb.Cr
 	return apipb.CreatePackageRuleResponse_builder{RuleId: &f.newID}.Build(), nil
 }
 
+func (f *fakeWorkshopClient) CountPackageRuleIdentifiers(ctx context.Context, in *apipb.CountPackageRuleIdentifiersRequest, _ ...grpc.CallOption) (*apipb.CountPackageRuleIdentifiersResponse, error) {
+	if f.identifierCountErr != nil {
+		return nil, f.identifierCountErr
+	}
+	return apipb.CountPackageRuleIdentifiersResponse_builder{
+		IdentifierCount: proto.Uint32(f.identifierCount),
+		Status:          f.packageStatus.Enum(),
+	}.Build(), nil
+}
+
 func (f *fakeWorkshopClient) DeletePackageRule(ctx context.Context, in *apipb.DeletePackageRuleRequest, _ ...grpc.CallOption) (*apipb.DeletePackageRuleResponse, error) {
 	f.deleteCalls++
 	return apipb.DeletePackageRuleResponse_builder{}.Build(), nil
@@ -133,6 +346,93 @@ func TestResolveBlockReason(t *testing.T) {
 
 // --- workshop_rule -------------------------------------------------------
 
+func TestBuildCreateRuleRequestSilent(t *testing.T) {
+	cases := []struct {
+		policy string
+		silent types.Bool
+		want   apipb.Policy
+	}{
+		{"BLOCKLIST", types.BoolValue(true), apipb.Policy_SILENT_BLOCKLIST},
+		{"BLOCKLIST", types.BoolValue(false), apipb.Policy_BLOCKLIST},
+		{"BLOCKLIST", types.BoolNull(), apipb.Policy_BLOCKLIST},
+		{"SILENT_BLOCKLIST", types.BoolValue(true), apipb.Policy_SILENT_BLOCKLIST},
+		{"ALLOWLIST", types.BoolValue(false), apipb.Policy_ALLOWLIST},
+	}
+	for _, c := range cases {
+		req := buildCreateRuleRequest(RuleResourceModel{
+			Identifier: types.StringValue("abc"),
+			RuleType:   types.StringValue("BINARY"),
+			Tag:        types.StringValue("global"),
+			Policy:     types.StringValue(c.policy),
+			Silent:     c.silent,
+		})
+		if got := req.GetRule().GetPolicy(); got != c.want {
+			t.Errorf("policy %s, silent %s: sent %v, want %v", c.policy, c.silent, got, c.want)
+		}
+	}
+}
+
+func TestValidateCompilerRuleType(t *testing.T) {
+	for ruleType, wantErr := range map[string]bool{
+		"BINARY":      false,
+		"SIGNINGID":   false,
+		"CDHASH":      false,
+		"TEAMID":      true,
+		"CERTIFICATE": true,
+	} {
+		var diags diag.Diagnostics
+		validatePolicyAttributes(RuleResourceModel{
+			Policy:   types.StringValue("ALLOWLIST_COMPILER"),
+			RuleType: types.StringValue(ruleType),
+		}, &diags)
+		if diags.HasError() != wantErr {
+			t.Errorf("%s compiler rule: error = %v, want %v", ruleType, diags.HasError(), wantErr)
+		}
+	}
+
+	var diags diag.Diagnostics
+	validatePolicyAttributes(RuleResourceModel{
+		Policy:   types.StringValue("ALLOWLIST"),
+		RuleType: types.StringValue("TEAMID"),
+	}, &diags)
+	if diags.HasError() {
+		t.Errorf("TEAMID allowlist rule rejected: %v", diags)
+	}
+}
+
+func TestValidateSilent(t *testing.T) {
+	cases := []struct {
+		name      string
+		policy    string
+		silent    types.Bool
+		customMsg types.String
+		errors    int
+		warnings  int
+	}{
+		{"silenced blocklist", "BLOCKLIST", types.BoolValue(true), types.StringNull(), 0, 0},
+		{"silent blocklist", "SILENT_BLOCKLIST", types.BoolNull(), types.StringNull(), 0, 0},
+		{"silent allowlist", "ALLOWLIST", types.BoolValue(true), types.StringNull(), 1, 0},
+		{"contradiction", "SILENT_BLOCKLIST", types.BoolValue(false), types.StringNull(), 1, 0},
+		{"message on silent policy", "SILENT_BLOCKLIST", types.BoolNull(), types.StringValue("Blocked"), 0, 1},
+		{"message on silenced blocklist", "BLOCKLIST", types.BoolValue(true), types.StringValue("Blocked"), 0, 1},
+		{"message on blocklist", "BLOCKLIST", types.BoolNull(), types.StringValue("Blocked"), 0, 0},
+		{"unknown silent", "ALLOWLIST", types.BoolUnknown(), types.StringNull(), 0, 0},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			validateSilent(RuleResourceModel{
+				Policy:    types.StringValue(c.policy),
+				Silent:    c.silent,
+				CustomMsg: c.customMsg,
+			}, &diags)
+			if diags.ErrorsCount() != c.errors || diags.WarningsCount() != c.warnings {
+				t.Errorf("got %d errors and %d warnings, want %d and %d: %v", diags.ErrorsCount(), diags.WarningsCount(), c.errors, c.warnings, diags)
+			}
+		})
+	}
+}
+
 func TestUpsertRuleUpsertsAndNeverDeletes(t *testing.T) {
 	fake := &fakeWorkshopClient{}
 	r := &RuleResource{client: fake}
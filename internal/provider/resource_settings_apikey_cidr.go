@@ -76,7 +76,7 @@ func (r *APIKeyCIDRSettingsResource) Configure(ctx context.Context, req resource
 	pd, ok := req.ProviderData.(*NPSProviderResourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
+			codeInternal.summary("Unexpected Resource Configure Type"),
 			fmt.Sprintf("Expected NPSProviderResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
@@ -104,7 +104,7 @@ func (r *APIKeyCIDRSettingsResource) Create(ctx context.Context, req resource.Cr
 func (r *APIKeyCIDRSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	ret, err := r.client.GetAPIKeyCIDRSettings(ctx, apipb.GetAPIKeyCIDRSettingsRequest_builder{}.Build())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to get API key CIDR settings: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to get API key CIDR settings: %v", err))
 		return
 	}
 
@@ -175,7 +175,7 @@ func (r *APIKeyCIDRSettingsResource) set(ctx context.Context, m *APIKeyCIDRSetti
 	}.Build()
 
 	if _, err := r.client.SetAPIKeyCIDRSettings(ctx, apipb.SetAPIKeyCIDRSettingsRequest_builder{Settings: settings}.Build()); err != nil {
-		diags.AddError("Client Error", fmt.Sprintf("Failed to set API key CIDR settings: %v", err))
+		diags.AddError(clientError(err), fmt.Sprintf("Failed to set API key CIDR settings: %v", err))
 	}
 	return diags
 }
@@ -214,6 +214,6 @@ func (cidrValidator) ValidateString(ctx context.Context, req validator.StringReq
 		return
 	}
 	if _, _, err := net.ParseCIDR(req.ConfigValue.ValueString()); err != nil {
-		resp.Diagnostics.AddAttributeError(req.Path, "Invalid CIDR", fmt.Sprintf("%q is not valid CIDR notation: %v", req.ConfigValue.ValueString(), err))
+		resp.Diagnostics.AddAttributeError(req.Path, codeInvalidConfig.summary("Invalid CIDR"), fmt.Sprintf("%q is not valid CIDR notation: %v", req.ConfigValue.ValueString(), err))
 	}
 }
@@ -6,7 +6,6 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
-	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -56,7 +55,7 @@ func (r *AutoUpdateSettingsResource) Schema(ctx context.Context, req resource.Sc
 				MarkdownDescription: "The auto-update mode. Must be one of: `AUTO_UPDATE_MODE_DISABLED`, `AUTO_UPDATE_MODE_ENABLED_ALL`, `AUTO_UPDATE_MODE_ENABLED_SECURITY_ONLY`.",
 				Required:            true,
 				Validators: []validator.String{
-					stringvalidator.OneOf(
+					oneOf(
 						"AUTO_UPDATE_MODE_DISABLED",
 						"AUTO_UPDATE_MODE_ENABLED_ALL",
 						"AUTO_UPDATE_MODE_ENABLED_SECURITY_ONLY",
@@ -91,7 +90,7 @@ func (r *AutoUpdateSettingsResource) Configure(ctx context.Context, req resource
 	pd, ok := req.ProviderData.(*NPSProviderResourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
+			codeInternal.summary("Unexpected Resource Configure Type"),
 			fmt.Sprintf("Expected NPSProviderResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
@@ -114,7 +113,7 @@ func (r *AutoUpdateSettingsResource) Create(ctx context.Context, req resource.Cr
 	}.Build()
 
 	if _, err := r.client.UpdateAutoUpdateSettings(ctx, apipb.UpdateAutoUpdateSettingsRequest_builder{Settings: settings}.Build()); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to update auto-update settings: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to update auto-update settings: %v", err))
 		return
 	}
 
@@ -127,7 +126,7 @@ func (r *AutoUpdateSettingsResource) Create(ctx context.Context, req resource.Cr
 func (r *AutoUpdateSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	ret, err := r.client.GetAutoUpdateSettings(ctx, apipb.GetAutoUpdateSettingsRequest_builder{}.Build())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to get auto-update settings: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to get auto-update settings: %v", err))
 		return
 	}
 
@@ -163,7 +162,7 @@ func (r *AutoUpdateSettingsResource) Update(ctx context.Context, req resource.Up
 	}.Build()
 
 	if _, err := r.client.UpdateAutoUpdateSettings(ctx, apipb.UpdateAutoUpdateSettingsRequest_builder{Settings: settings}.Build()); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to update auto-update settings: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to update auto-update settings: %v", err))
 		return
 	}
 
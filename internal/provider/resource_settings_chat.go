@@ -118,7 +118,7 @@ func (r *ChatSettingsResource) Configure(ctx context.Context, req resource.Confi
 	pd, ok := req.ProviderData.(*NPSProviderResourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
+			codeInternal.summary("Unexpected Resource Configure Type"),
 			fmt.Sprintf("Expected NPSProviderResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
@@ -142,7 +142,7 @@ func (r *ChatSettingsResource) Create(ctx context.Context, req resource.CreateRe
 	if slack != nil {
 		upReq := apipb.UpdateChatSettingsRequest_builder{SlackBotSettings: slack}.Build()
 		if _, err := r.client.UpdateChatSettings(ctx, upReq); err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to update chat settings: %v", err))
+			resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to update chat settings: %v", err))
 			return
 		}
 	}
@@ -156,7 +156,7 @@ func (r *ChatSettingsResource) Create(ctx context.Context, req resource.CreateRe
 func (r *ChatSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	ret, err := r.client.GetChatSettings(ctx, apipb.GetChatSettingsRequest_builder{}.Build())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to get chat settings: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to get chat settings: %v", err))
 		return
 	}
 
@@ -194,7 +194,7 @@ func (r *ChatSettingsResource) Update(ctx context.Context, req resource.UpdateRe
 				ChatType: apipb.DeleteChatSettingsRequest_CHAT_TYPE_SLACK.Enum(),
 			}.Build()
 			if _, err := r.client.DeleteChatSettings(ctx, delReq); err != nil {
-				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete chat settings: %v", err))
+				resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to delete chat settings: %v", err))
 				return
 			}
 			tflog.Info(ctx, "Deleted chat settings")
@@ -204,7 +204,7 @@ func (r *ChatSettingsResource) Update(ctx context.Context, req resource.UpdateRe
 			SlackBotSettings: planSlack,
 		}.Build()
 		if _, err := r.client.UpdateChatSettings(ctx, upReq); err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to update chat settings: %v", err))
+			resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to update chat settings: %v", err))
 			return
 		}
 		tflog.Info(ctx, "Updated chat settings")
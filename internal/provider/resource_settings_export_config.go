@@ -67,7 +67,7 @@ func (r *ExportConfigSettingsResource) Configure(ctx context.Context, req resour
 	pd, ok := req.ProviderData.(*NPSProviderResourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
+			codeInternal.summary("Unexpected Resource Configure Type"),
 			fmt.Sprintf("Expected NPSProviderResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
@@ -90,7 +90,7 @@ func (r *ExportConfigSettingsResource) Create(ctx context.Context, req resource.
 		NetworkMountEventBucketUrl: tfStringToPtr(data.NetworkMountEventBucketUrl),
 	}
 	if _, err := r.client.UpdateExportConfig(ctx, b.Build()); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to update export config: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to update export config: %v", err))
 		return
 	}
 
@@ -103,7 +103,7 @@ func (r *ExportConfigSettingsResource) Create(ctx context.Context, req resource.
 func (r *ExportConfigSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	ret, err := r.client.GetExportConfig(ctx, apipb.GetExportConfigRequest_builder{}.Build())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to get export config: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to get export config: %v", err))
 		return
 	}
 
@@ -168,7 +168,7 @@ func (r *ExportConfigSettingsResource) Update(ctx context.Context, req resource.
 
 	if any {
 		if _, err := r.client.UpdateExportConfig(ctx, b.Build()); err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to update export config: %v", err))
+			resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to update export config: %v", err))
 			return
 		}
 		tflog.Info(ctx, "Updated export config")
@@ -80,7 +80,7 @@ func (r *MCPServerSettingsResource) Configure(ctx context.Context, req resource.
 	pd, ok := req.ProviderData.(*NPSProviderResourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
+			codeInternal.summary("Unexpected Resource Configure Type"),
 			fmt.Sprintf("Expected NPSProviderResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
@@ -100,7 +100,7 @@ func (r *MCPServerSettingsResource) Create(ctx context.Context, req resource.Cre
 		ReadWrite: tfBoolToPtr(data.ReadWrite),
 	}
 	if _, err := r.client.UpdateMCPServerSettings(ctx, b.Build()); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to update MCP server settings: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to update MCP server settings: %v", err))
 		return
 	}
 
@@ -124,7 +124,7 @@ func (r *MCPServerSettingsResource) Create(ctx context.Context, req resource.Cre
 func (r *MCPServerSettingsResource) fetchMCPServerSettings(ctx context.Context, diags *diag.Diagnostics) (MCPServerSettingsResourceModel, bool) {
 	ret, err := r.client.GetMCPServerSettings(ctx, apipb.GetMCPServerSettingsRequest_builder{}.Build())
 	if err != nil {
-		diags.AddError("Client Error", fmt.Sprintf("Failed to get MCP server settings: %v", err))
+		diags.AddError(clientError(err), fmt.Sprintf("Failed to get MCP server settings: %v", err))
 		return MCPServerSettingsResourceModel{}, false
 	}
 	return MCPServerSettingsResourceModel{
@@ -164,7 +164,7 @@ func (r *MCPServerSettingsResource) Update(ctx context.Context, req resource.Upd
 
 	if b.Enabled != nil || b.ReadWrite != nil {
 		if _, err := r.client.UpdateMCPServerSettings(ctx, b.Build()); err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to update MCP server settings: %v", err))
+			resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to update MCP server settings: %v", err))
 			return
 		}
 		tflog.Info(ctx, "Updated MCP server settings")
@@ -89,7 +89,7 @@ func (r *MPASettingsResource) Configure(ctx context.Context, req resource.Config
 	pd, ok := req.ProviderData.(*NPSProviderResourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
+			codeInternal.summary("Unexpected Resource Configure Type"),
 			fmt.Sprintf("Expected NPSProviderResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
@@ -106,7 +106,7 @@ func (r *MPASettingsResource) Create(ctx context.Context, req resource.CreateReq
 
 	maxDuration, err := tfStringToDuration(data.MaxDuration)
 	if err != nil {
-		resp.Diagnostics.AddAttributeError(path.Root("max_duration"), "Invalid duration", err.Error())
+		resp.Diagnostics.AddAttributeError(path.Root("max_duration"), codeInvalidConfig.summary("Invalid duration"), err.Error())
 		return
 	}
 
@@ -118,12 +118,12 @@ func (r *MPASettingsResource) Create(ctx context.Context, req resource.CreateReq
 	}
 	ret, err := r.client.SetMultipartyApprovalSettings(ctx, b.Build())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to set MPA settings: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to set MPA settings: %v", err))
 		return
 	}
 	if ret.GetApprovalRequired() {
 		resp.Diagnostics.AddError(
-			"MPA approval required",
+			codeApprovalRequired.summary("MPA approval required"),
 			fmt.Sprintf("Changing MPA settings requires approval from %d additional admin(s). An approval request has been created on the server; resolve it out-of-band and re-run apply.", ret.GetNumberOfApprovalsNeeded()),
 		)
 		return
@@ -138,7 +138,7 @@ func (r *MPASettingsResource) Create(ctx context.Context, req resource.CreateReq
 func (r *MPASettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	ret, err := r.client.GetMultipartyApprovalSettings(ctx, apipb.GetMultipartyApprovalSettingsRequest_builder{}.Build())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to get MPA settings: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to get MPA settings: %v", err))
 		return
 	}
 
@@ -167,7 +167,7 @@ func (r *MPASettingsResource) Update(ctx context.Context, req resource.UpdateReq
 	if !plan.MaxDuration.Equal(state.MaxDuration) {
 		d, err := tfStringToDuration(plan.MaxDuration)
 		if err != nil {
-			resp.Diagnostics.AddAttributeError(path.Root("max_duration"), "Invalid duration", err.Error())
+			resp.Diagnostics.AddAttributeError(path.Root("max_duration"), codeInvalidConfig.summary("Invalid duration"), err.Error())
 			return
 		}
 		b.MaxDuration = d
@@ -185,7 +185,7 @@ func (r *MPASettingsResource) Update(ctx context.Context, req resource.UpdateReq
 	if any {
 		ret, err := r.client.SetMultipartyApprovalSettings(ctx, b.Build())
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to set MPA settings: %v", err))
+			resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to set MPA settings: %v", err))
 			return
 		}
 		if ret.GetApprovalRequired() {
@@ -193,7 +193,7 @@ func (r *MPASettingsResource) Update(ctx context.Context, req resource.UpdateReq
 			// approval from additional admins. Terraform cannot wait for
 			// asynchronous approval, so surface this as an error.
 			resp.Diagnostics.AddError(
-				"MPA approval required",
+				codeApprovalRequired.summary("MPA approval required"),
 				fmt.Sprintf("Changing MPA settings requires approval from %d additional admin(s). An approval request has been created on the server; resolve it out-of-band and re-run apply.", ret.GetNumberOfApprovalsNeeded()),
 			)
 			return
@@ -273,7 +273,7 @@ func (r *RiskEngineSettingsResource) Configure(ctx context.Context, req resource
 	pd, ok := req.ProviderData.(*NPSProviderResourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
+			codeInternal.summary("Unexpected Resource Configure Type"),
 			fmt.Sprintf("Expected NPSProviderResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
@@ -295,7 +295,7 @@ func (r *RiskEngineSettingsResource) Create(ctx context.Context, req resource.Cr
 	}
 
 	if _, err := r.client.UpdateRiskEngineSettings(ctx, apipb.UpdateRiskEngineSettingsRequest_builder{RiskEngineSettings: settings}.Build()); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to update risk engine settings: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to update risk engine settings: %v", err))
 		return
 	}
 
@@ -308,7 +308,7 @@ func (r *RiskEngineSettingsResource) Create(ctx context.Context, req resource.Cr
 func (r *RiskEngineSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	ret, err := r.client.GetRiskEngineSettings(ctx, apipb.GetRiskEngineSettingsRequest_builder{}.Build())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to get risk engine settings: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to get risk engine settings: %v", err))
 		return
 	}
 
@@ -338,7 +338,7 @@ func (r *RiskEngineSettingsResource) Update(ctx context.Context, req resource.Up
 	}
 
 	if _, err := r.client.UpdateRiskEngineSettings(ctx, apipb.UpdateRiskEngineSettingsRequest_builder{RiskEngineSettings: settings}.Build()); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to update risk engine settings: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to update risk engine settings: %v", err))
 		return
 	}
 
@@ -399,7 +399,7 @@ func riskEngineModelToProto(ctx context.Context, m *RiskEngineSettingsResourceMo
 
 	pluginTimeout, err := tfStringToDuration(m.PluginTimeout)
 	if err != nil {
-		diags.AddAttributeError(path.Root("plugin_timeout"), "Invalid duration", err.Error())
+		diags.AddAttributeError(path.Root("plugin_timeout"), codeInvalidConfig.summary("Invalid duration"), err.Error())
 		return nil, diags
 	}
 
@@ -509,7 +509,7 @@ func riskVirusTotalObjectToProto(ctx context.Context, obj types.Object) (*apipb.
 
 	cacheTtl, err := tfStringToDuration(m.CacheTtl)
 	if err != nil {
-		d.AddAttributeError(path.Root("local_plugins").AtName("virus_total").AtName("cache_ttl"), "Invalid duration", err.Error())
+		d.AddAttributeError(path.Root("local_plugins").AtName("virus_total").AtName("cache_ttl"), codeInvalidConfig.summary("Invalid duration"), err.Error())
 		return nil, d
 	}
 
@@ -558,7 +558,7 @@ func riskReversingLabsObjectToProto(ctx context.Context, obj types.Object) (*api
 
 	cacheTtl, err := tfStringToDuration(m.CacheTtl)
 	if err != nil {
-		d.AddAttributeError(path.Root("local_plugins").AtName("reversing_labs").AtName("cache_ttl"), "Invalid duration", err.Error())
+		d.AddAttributeError(path.Root("local_plugins").AtName("reversing_labs").AtName("cache_ttl"), codeInvalidConfig.summary("Invalid duration"), err.Error())
 		return nil, d
 	}
 
@@ -704,7 +704,7 @@ func riskRemotePluginsListToProto(ctx context.Context, list types.List) ([]*apip
 	for i, m := range ms {
 		ttl, err := tfStringToDuration(m.Ttl)
 		if err != nil {
-			diags.AddAttributeError(path.Root("remote_plugins").AtListIndex(i).AtName("ttl"), "Invalid duration", err.Error())
+			diags.AddAttributeError(path.Root("remote_plugins").AtListIndex(i).AtName("ttl"), codeInvalidConfig.summary("Invalid duration"), err.Error())
 			return nil, diags
 		}
 
@@ -4,6 +4,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"math"
 	"regexp"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
@@ -19,6 +20,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/northpolesec/terraform-provider-nps/internal/filter"
 	"github.com/northpolesec/terraform-provider-nps/internal/utils"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
@@ -50,7 +52,10 @@ type SyncSettingsResourceModel struct {
 	Tag types.String `tfsdk:"tag"`
 
 	ClientMode                 types.String `tfsdk:"client_mode"`
+	BatchSize                  types.Int64  `tfsdk:"batch_size"`
 	EnableTransitiveRules      types.Bool   `tfsdk:"enable_transitive_rules"`
+	EnableAllEventUpload       types.Bool   `tfsdk:"enable_all_event_upload"`
+	AutoBundleInventory        types.Bool   `tfsdk:"auto_bundle_inventory"`
 	TelemetryEnabled           types.Bool   `tfsdk:"telemetry_enabled"`
 	NetworkExtensionEnabled    types.Bool   `tfsdk:"network_extension_enabled"`
 	AllowedPathRegex           types.String `tfsdk:"allowed_path_regex"`
@@ -150,7 +155,15 @@ func (r *SyncSettingsResource) Schema(ctx context.Context, req resource.SchemaRe
 				MarkdownDescription: "Santa client mode for hosts in this tag. One of: `MONITOR`, `LOCKDOWN`, `STANDALONE`.",
 				Optional:            true,
 				Validators: []validator.String{
-					stringvalidator.OneOf(syncSettingsClientModeValues...),
+					oneOf(syncSettingsClientModeValues...),
+				},
+			},
+			"batch_size": schema.Int64Attribute{
+				Description:         "Number of events Santa uploads in a single request during sync. Must be at least 1 when set.",
+				MarkdownDescription: "Number of events Santa uploads in a single request during sync. Must be at least `1` when set.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, math.MaxUint32),
 				},
 			},
 			"enable_transitive_rules": schema.BoolAttribute{
@@ -158,6 +171,16 @@ func (r *SyncSettingsResource) Schema(ctx context.Context, req resource.SchemaRe
 				MarkdownDescription: "Whether transitive rule creation is enabled.",
 				Optional:            true,
 			},
+			"enable_all_event_upload": schema.BoolAttribute{
+				Description:         "Whether Santa uploads all execution events, rather than only blocked and unknown ones.",
+				MarkdownDescription: "Whether Santa uploads all execution events, rather than only blocked and unknown ones.",
+				Optional:            true,
+			},
+			"auto_bundle_inventory": schema.BoolAttribute{
+				Description:         "Whether Workshop automatically requests bundle inventory from hosts for allow-unknown events. Unset inherits from lower-precedence tags; the global default enables it.",
+				MarkdownDescription: "Whether Workshop automatically requests bundle inventory from hosts for allow-unknown events. Unset inherits from lower-precedence tags; the `global` default enables it.",
+				Optional:            true,
+			},
 			"telemetry_enabled": schema.BoolAttribute{
 				Description:         "Whether telemetry upload is enabled for hosts in this tag. Backed by the tag's TelemetryConfig (managed via the UpdateTelemetryConfig RPC), not by SyncSettings. Leaving it unset removes any TelemetryConfig for the tag so a lower-precedence tag applies. Requires the telemetry feature to be enabled for the tenant.",
 				MarkdownDescription: "Whether telemetry upload is enabled for hosts in this tag. Backed by the tag's `TelemetryConfig` (managed via the `UpdateTelemetryConfig` RPC), not by `SyncSettings`. Leaving it unset removes any `TelemetryConfig` for the tag so a lower-precedence tag applies. Requires the telemetry feature to be enabled for the tenant.",
@@ -217,11 +240,17 @@ func (r *SyncSettingsResource) Schema(ctx context.Context, req resource.SchemaRe
 							Description:         "Optional custom message shown to the user when the rule blocks.",
 							MarkdownDescription: "Optional custom message shown to the user when the rule blocks.",
 							Optional:            true,
+							Validators: []validator.String{
+								customMsgValidator{},
+							},
 						},
 						"custom_url": schema.StringAttribute{
 							Description:         "Optional custom URL shown to the user when the rule blocks.",
 							MarkdownDescription: "Optional custom URL shown to the user when the rule blocks.",
 							Optional:            true,
+							Validators: []validator.String{
+								executionEventURLValidator(),
+							},
 						},
 					},
 				},
@@ -235,7 +264,7 @@ func (r *SyncSettingsResource) Schema(ctx context.Context, req resource.SchemaRe
 						MarkdownDescription: "Whether on-demand monitor mode is enabled. One of: `ON_DEMAND_MONITOR_MODE_STATE_ENABLED`, `ON_DEMAND_MONITOR_MODE_STATE_DISABLED`.",
 						Optional:            true,
 						Validators: []validator.String{
-							stringvalidator.OneOf(syncSettingsOnDemandModeStateValues...),
+							oneOf(syncSettingsOnDemandModeStateValues...),
 						},
 					},
 					"max_minutes": schema.Int64Attribute{
@@ -265,7 +294,7 @@ func (r *SyncSettingsResource) Schema(ctx context.Context, req resource.SchemaRe
 						MarkdownDescription: "Whether on-demand admin mode is enabled. One of: `ON_DEMAND_ADMIN_MODE_STATE_ENABLED`, `ON_DEMAND_ADMIN_MODE_STATE_DISABLED`. Must be set whenever the `on_demand_admin_mode` block is present.",
 						Optional:            true,
 						Validators: []validator.String{
-							stringvalidator.OneOf(syncSettingsOnDemandAdminModeStateValues...),
+							oneOf(syncSettingsOnDemandAdminModeStateValues...),
 						},
 					},
 					"max_minutes": schema.Int64Attribute{
@@ -300,7 +329,7 @@ func (r *SyncSettingsResource) Schema(ctx context.Context, req resource.SchemaRe
 						MarkdownDescription: "Whether network mounts are blocked. One of: `BLOCK_MOUNT_ENABLED`, `BLOCK_MOUNT_DISABLED`.",
 						Optional:            true,
 						Validators: []validator.String{
-							stringvalidator.OneOf(syncSettingsNetworkMountBlockMountValues...),
+							oneOf(syncSettingsNetworkMountBlockMountValues...),
 						},
 					},
 					"banned_message": schema.StringAttribute{
@@ -332,7 +361,7 @@ func removableMediaPolicyBlockSchema(desc string) schema.SingleNestedBlock {
 				MarkdownDescription: "Policy action. One of: `ALLOW`, `BLOCK`, `REMOUNT`. When `REMOUNT`, `remount_flags` specifies the mount flags to apply.",
 				Optional:            true,
 				Validators: []validator.String{
-					stringvalidator.OneOf(syncSettingsRemovableActionValues...),
+					oneOf(syncSettingsRemovableActionValues...),
 				},
 			},
 			"remount_flags": schema.ListAttribute{
@@ -372,14 +401,14 @@ func (r *SyncSettingsResource) ConfigValidators(ctx context.Context) []resource.
 			if odmm.State.IsNull() {
 				resp.Diagnostics.AddAttributeError(
 					path.Root("on_demand_monitor_mode").AtName("state"),
-					"state is required",
+					codeInvalidConfig.summary("state is required"),
 					"state must be set when the on_demand_monitor_mode block is present",
 				)
 			}
 			if odmm.State.ValueString() == "ON_DEMAND_MONITOR_MODE_STATE_ENABLED" && odmm.MaxMinutes.IsNull() {
 				resp.Diagnostics.AddAttributeError(
 					path.Root("on_demand_monitor_mode").AtName("max_minutes"),
-					"max_minutes is required",
+					codeInvalidConfig.summary("max_minutes is required"),
 					"max_minutes must be set when state is ON_DEMAND_MONITOR_MODE_STATE_ENABLED",
 				)
 			}
@@ -387,7 +416,7 @@ func (r *SyncSettingsResource) ConfigValidators(ctx context.Context) []resource.
 				odmm.DefaultDurationMinutes.ValueInt64() > odmm.MaxMinutes.ValueInt64() {
 				resp.Diagnostics.AddAttributeError(
 					path.Root("on_demand_monitor_mode").AtName("default_duration_minutes"),
-					"default_duration_minutes exceeds max_minutes",
+					codeInvalidConfig.summary("default_duration_minutes exceeds max_minutes"),
 					"default_duration_minutes must not exceed max_minutes",
 				)
 			}
@@ -405,14 +434,14 @@ func (r *SyncSettingsResource) ConfigValidators(ctx context.Context) []resource.
 			if odam.State.IsNull() {
 				resp.Diagnostics.AddAttributeError(
 					path.Root("on_demand_admin_mode").AtName("state"),
-					"state is required",
+					codeInvalidConfig.summary("state is required"),
 					"state must be set when the on_demand_admin_mode block is present",
 				)
 			}
 			if odam.State.ValueString() == "ON_DEMAND_ADMIN_MODE_STATE_ENABLED" && odam.MaxMinutes.IsNull() {
 				resp.Diagnostics.AddAttributeError(
 					path.Root("on_demand_admin_mode").AtName("max_minutes"),
-					"max_minutes is required",
+					codeInvalidConfig.summary("max_minutes is required"),
 					"max_minutes must be set when state is ON_DEMAND_ADMIN_MODE_STATE_ENABLED",
 				)
 			}
@@ -420,7 +449,7 @@ func (r *SyncSettingsResource) ConfigValidators(ctx context.Context) []resource.
 				odam.DefaultDurationMinutes.ValueInt64() > odam.MaxMinutes.ValueInt64() {
 				resp.Diagnostics.AddAttributeError(
 					path.Root("on_demand_admin_mode").AtName("default_duration_minutes"),
-					"default_duration_minutes exceeds max_minutes",
+					codeInvalidConfig.summary("default_duration_minutes exceeds max_minutes"),
 					"default_duration_minutes must not exceed max_minutes",
 				)
 			}
@@ -435,7 +464,7 @@ func validateRemovablePolicyBlock(p path.Path, m *SyncSettingsRemovableMediaPoli
 	if m.Action.IsNull() {
 		diags.AddAttributeError(
 			p.AtName("action"),
-			"action is required",
+			codeInvalidConfig.summary("action is required"),
 			"action must be set when the policy block is present",
 		)
 		return
@@ -445,14 +474,14 @@ func validateRemovablePolicyBlock(p path.Path, m *SyncSettingsRemovableMediaPoli
 	if action == "REMOUNT" && !hasFlags {
 		diags.AddAttributeError(
 			p.AtName("remount_flags"),
-			"remount_flags is required",
+			codeInvalidConfig.summary("remount_flags is required"),
 			"remount_flags must be set when action is REMOUNT",
 		)
 	}
 	if action != "REMOUNT" && hasFlags {
 		diags.AddAttributeError(
 			p.AtName("remount_flags"),
-			"remount_flags is only valid when action is REMOUNT",
+			codeInvalidConfig.summary("remount_flags is only valid when action is REMOUNT"),
 			"remount_flags must not be set unless action is REMOUNT",
 		)
 	}
@@ -481,7 +510,7 @@ func (r *SyncSettingsResource) Configure(ctx context.Context, req resource.Confi
 	pd, ok := req.ProviderData.(*NPSProviderResourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
+			codeInternal.summary("Unexpected Resource Configure Type"),
 			fmt.Sprintf("Expected NPSProviderResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
@@ -514,9 +543,9 @@ func (r *SyncSettingsResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
-	ss, found, err := r.fetchSyncSettings(ctx, data.Tag.ValueString())
+	ss, found, err := fetchSyncSettings(ctx, r.client, data.Tag.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to list sync settings: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to list sync settings: %v", err))
 		return
 	}
 	if !found {
@@ -563,7 +592,7 @@ func (r *SyncSettingsResource) Delete(ctx context.Context, req resource.DeleteRe
 
 	delReq := apipb.DeleteSyncSettingsRequest_builder{Tag: proto.String(data.Tag.ValueString())}.Build()
 	if _, err := r.client.DeleteSyncSettings(ctx, delReq); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete sync settings for tag %q: %v", data.Tag.ValueString(), err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to delete sync settings for tag %q: %v", data.Tag.ValueString(), err))
 		return
 	}
 
@@ -571,7 +600,7 @@ func (r *SyncSettingsResource) Delete(ctx context.Context, req resource.DeleteRe
 	// avoid calling the feature-gated telemetry RPC for tags that never set it.
 	if !data.TelemetryEnabled.IsNull() {
 		if _, err := r.client.DeleteTelemetryConfig(ctx, apipb.DeleteTelemetryConfigRequest_builder{Tag: proto.String(data.Tag.ValueString())}.Build()); err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete telemetry config for tag %q: %v", data.Tag.ValueString(), err))
+			resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to delete telemetry config for tag %q: %v", data.Tag.ValueString(), err))
 			return
 		}
 	}
@@ -583,21 +612,35 @@ func (r *SyncSettingsResource) ImportState(ctx context.Context, req resource.Imp
 	// Validate up front so we fail with a clear message instead of writing an
 	// invalid tag into state and erroring later at Read time when the value is
 	// interpolated into the ListSyncSettings filter.
-	if l := len(req.ID); l < 1 || l > 42 {
-		resp.Diagnostics.AddError(
-			"Invalid import ID",
-			fmt.Sprintf("tag %q must be between 1 and 42 characters, got %d", req.ID, l),
-		)
+	tag := req.ID
+	var identity SyncSettingsIdentityModel
+	if importIdentity(ctx, req, resp, &identity) {
+		tag = identity.Tag.ValueString()
+	}
+	if resp.Diagnostics.HasError() || !validateTagImportID(tag, &resp.Diagnostics) {
 		return
 	}
-	if !syncSettingsTagRegex.MatchString(req.ID) {
-		resp.Diagnostics.AddError(
-			"Invalid import ID",
-			fmt.Sprintf("tag %q must contain only letters, digits, periods, colons, hyphens, and underscores", req.ID),
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tag"), tag)...)
+}
+
+// validateTagImportID checks an import ID that names a tag against the same
+// rules as the tag attribute, reporting whether it is valid.
+func validateTagImportID(id string, diags *diag.Diagnostics) bool {
+	if l := len(id); l < 1 || l > 42 {
+		diags.AddError(
+			codeInvalidConfig.summary("Invalid import ID"),
+			fmt.Sprintf("tag %q must be between 1 and 42 characters, got %d", id, l),
 		)
-		return
+		return false
 	}
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tag"), req.ID)...)
+	if !syncSettingsTagRegex.MatchString(id) {
+		diags.AddError(
+			codeInvalidConfig.summary("Invalid import ID"),
+			fmt.Sprintf("tag %q must contain only letters, digits, periods, colons, hyphens, and underscores", id),
+		)
+		return false
+	}
+	return true
 }
 
 func (r *SyncSettingsResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -641,13 +684,13 @@ func (r *SyncSettingsResource) replaceTagSettings(ctx context.Context, data *Syn
 	// caveat in the resource description.
 	delReq := apipb.DeleteSyncSettingsRequest_builder{Tag: proto.String(data.Tag.ValueString())}.Build()
 	if _, err := r.client.DeleteSyncSettings(ctx, delReq); err != nil {
-		diags.AddError("Client Error", fmt.Sprintf("Failed to clear existing sync settings for tag %q: %v", data.Tag.ValueString(), err))
+		diags.AddError(clientError(err), fmt.Sprintf("Failed to clear existing sync settings for tag %q: %v", data.Tag.ValueString(), err))
 		return false
 	}
 
 	upReq := apipb.UpdateSyncSettingsRequest_builder{SyncSettings: ss}.Build()
 	if _, err := r.client.UpdateSyncSettings(ctx, upReq); err != nil {
-		diags.AddError("Client Error", fmt.Sprintf("Failed to update sync settings for tag %q: %v", data.Tag.ValueString(), err))
+		diags.AddError(clientError(err), fmt.Sprintf("Failed to update sync settings for tag %q: %v", data.Tag.ValueString(), err))
 		return false
 	}
 
@@ -672,7 +715,7 @@ func (r *SyncSettingsResource) validateCelFallbackRules(ctx context.Context, rul
 			}
 			diags.AddAttributeError(
 				path.Root("cel_fallback_rule").AtListIndex(i).AtName("expression"),
-				"Invalid CEL expression",
+				codeInvalidConfig.summary("Invalid CEL expression"),
 				fmt.Sprintf("The CEL fallback expression failed validation: %s", msg),
 			)
 		}
@@ -693,14 +736,14 @@ func (r *SyncSettingsResource) applyTelemetryConfig(ctx context.Context, tag str
 			Enabled: proto.Bool(planEnabled.ValueBool()),
 		}.Build()
 		if _, err := r.client.UpdateTelemetryConfig(ctx, apipb.UpdateTelemetryConfigRequest_builder{TelemetryConfig: tc}.Build()); err != nil {
-			diags.AddError("Client Error", fmt.Sprintf("Failed to update telemetry config for tag %q: %v", tag, err))
+			diags.AddError(clientError(err), fmt.Sprintf("Failed to update telemetry config for tag %q: %v", tag, err))
 			return false
 		}
 	case !priorEnabled.IsNull():
 		// telemetry_enabled was previously set and is now removed: delete the
 		// tag's TelemetryConfig so lower-precedence tags apply.
 		if _, err := r.client.DeleteTelemetryConfig(ctx, apipb.DeleteTelemetryConfigRequest_builder{Tag: proto.String(tag)}.Build()); err != nil {
-			diags.AddError("Client Error", fmt.Sprintf("Failed to delete telemetry config for tag %q: %v", tag, err))
+			diags.AddError(clientError(err), fmt.Sprintf("Failed to delete telemetry config for tag %q: %v", tag, err))
 			return false
 		}
 	}
@@ -712,9 +755,8 @@ func (r *SyncSettingsResource) applyTelemetryConfig(ctx context.Context, tag str
 // tenant telemetry feature; if it is disabled the call fails, in which case we
 // report null rather than failing the read (no config can exist anyway).
 func (r *SyncSettingsResource) fetchTelemetryEnabled(ctx context.Context, tag string) types.Bool {
-	filter := fmt.Sprintf(`tag = "%s"`, tag)
 	ret, err := r.client.ListTelemetryConfigs(ctx, apipb.ListTelemetryConfigsRequest_builder{
-		Filter:   proto.String(filter),
+		Filter:   proto.String(filter.Eq("tag", tag).String()),
 		PageSize: proto.Uint32(1),
 	}.Build())
 	if err != nil {
@@ -729,10 +771,10 @@ func (r *SyncSettingsResource) fetchTelemetryEnabled(ctx context.Context, tag st
 	return types.BoolNull()
 }
 
-func (r *SyncSettingsResource) fetchSyncSettings(ctx context.Context, tag string) (*apipb.SyncSettings, bool, error) {
-	filter := fmt.Sprintf(`tag = "%s"`, tag)
-	ret, err := r.client.ListSyncSettings(ctx, apipb.ListSyncSettingsRequest_builder{
-		Filter:   proto.String(filter),
+// fetchSyncSettings returns the sync settings record for tag, if it has one.
+func fetchSyncSettings(ctx context.Context, client svcpb.WorkshopServiceClient, tag string) (*apipb.SyncSettings, bool, error) {
+	ret, err := client.ListSyncSettings(ctx, apipb.ListSyncSettingsRequest_builder{
+		Filter:   proto.String(filter.Eq("tag", tag).String()),
 		PageSize: proto.Uint32(1),
 	}.Build())
 	if err != nil {
@@ -755,7 +797,10 @@ func syncSettingsModelToProto(ctx context.Context, m *SyncSettingsResourceModel)
 
 	b := apipb.SyncSettings_builder{
 		Tag:                                     m.Tag.ValueString(),
+		BatchSize:                               tfInt64ToUint32Ptr(m.BatchSize),
 		EnableTransitiveRules:                   tfBoolToPtr(m.EnableTransitiveRules),
+		EnableAllEventUpload:                    tfBoolToPtr(m.EnableAllEventUpload),
+		AutoBundleInventory:                     tfBoolToPtr(m.AutoBundleInventory),
 		AllowedPathRegex:                        tfStringToPtr(m.AllowedPathRegex),
 		BlockedPathRegex:                        tfStringToPtr(m.BlockedPathRegex),
 		FullSyncIntervalSeconds:                 tfInt64ToUint32Ptr(m.FullSyncInterval),
@@ -900,7 +945,10 @@ func syncSettingsProtoToModel(ctx context.Context, ss *apipb.SyncSettings) (Sync
 
 	m := SyncSettingsResourceModel{
 		Tag:                   types.StringValue(ss.GetTag()),
+		BatchSize:             uint32PtrToTFInt64(ss.BatchSize),
 		EnableTransitiveRules: boolPtrToTF(ss.EnableTransitiveRules),
+		EnableAllEventUpload:  boolPtrToTF(ss.EnableAllEventUpload),
+		AutoBundleInventory:   boolPtrToTF(ss.AutoBundleInventory),
 		AllowedPathRegex:      stringPtrToTF(ss.AllowedPathRegex),
 		BlockedPathRegex:      stringPtrToTF(ss.BlockedPathRegex),
 		FullSyncInterval:      uint32PtrToTFInt64(ss.FullSyncIntervalSeconds),
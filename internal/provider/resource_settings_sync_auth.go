@@ -78,7 +78,7 @@ func (r *SyncAuthSettingsResource) Configure(ctx context.Context, req resource.C
 	pd, ok := req.ProviderData.(*NPSProviderResourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
+			codeInternal.summary("Unexpected Resource Configure Type"),
 			fmt.Sprintf("Expected NPSProviderResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
@@ -108,7 +108,7 @@ func (r *SyncAuthSettingsResource) Create(ctx context.Context, req resource.Crea
 	}.Build()
 
 	if _, err := r.client.UpdateSyncAuthSettings(ctx, apipb.UpdateSyncAuthSettingsRequest_builder{SyncAuthSettings: settings}.Build()); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to update sync auth settings: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to update sync auth settings: %v", err))
 		return
 	}
 
@@ -121,7 +121,7 @@ func (r *SyncAuthSettingsResource) Create(ctx context.Context, req resource.Crea
 func (r *SyncAuthSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	ret, err := r.client.GetSyncAuthSettings(ctx, apipb.GetSyncAuthSettingsRequest_builder{}.Build())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to get sync auth settings: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to get sync auth settings: %v", err))
 		return
 	}
 
@@ -159,7 +159,7 @@ func (r *SyncAuthSettingsResource) Update(ctx context.Context, req resource.Upda
 	}.Build()
 
 	if _, err := r.client.UpdateSyncAuthSettings(ctx, apipb.UpdateSyncAuthSettingsRequest_builder{SyncAuthSettings: settings}.Build()); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to update sync auth settings: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to update sync auth settings: %v", err))
 		return
 	}
 
@@ -133,7 +133,10 @@ func TestSyncSettingsRoundtrip(t *testing.T) {
 	original := apipb.SyncSettings_builder{
 		Tag:                                     "dev",
 		ClientMode:                              apipb.ClientMode_MONITOR,
+		BatchSize:                               proto.Uint32(100),
 		EnableTransitiveRules:                   proto.Bool(true),
+		EnableAllEventUpload:                    proto.Bool(false),
+		AutoBundleInventory:                     proto.Bool(true),
 		AllowedPathRegex:                        proto.String(""),
 		BlockedPathRegex:                        proto.String("/tmp/.*"),
 		FullSyncIntervalSeconds:                 proto.Uint32(600),
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
@@ -182,6 +183,9 @@ func (r *WebhookSettingsResource) Schema(ctx context.Context, req resource.Schem
 						MarkdownDescription: "Audit event types to deliver (e.g. `AUDIT_EVENT_RULE_UPSERT`). Empty delivers all types.",
 						Optional:            true,
 						ElementType:         types.StringType,
+						Validators: []validator.List{
+							listValuesOneOf(enumValues(apipb.AuditEvent(0).Descriptor())...),
+						},
 					},
 				}),
 			},
@@ -195,6 +199,9 @@ func (r *WebhookSettingsResource) Schema(ctx context.Context, req resource.Schem
 						MarkdownDescription: "Signal report states to deliver on (e.g. `SIGNAL_REPORT_STATE_NEW`). Empty delivers all states.",
 						Optional:            true,
 						ElementType:         types.StringType,
+						Validators: []validator.List{
+							listValuesOneOf(enumValues(apipb.SignalReportState(0).Descriptor())...),
+						},
 					},
 				}),
 			},
@@ -215,7 +222,7 @@ func (r *WebhookSettingsResource) Configure(ctx context.Context, req resource.Co
 	pd, ok := req.ProviderData.(*NPSProviderResourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
+			codeInternal.summary("Unexpected Resource Configure Type"),
 			fmt.Sprintf("Expected NPSProviderResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
@@ -256,7 +263,7 @@ func (r *WebhookSettingsResource) apply(ctx context.Context, config tfsdk.Config
 
 	upReq := apipb.UpdateWebhookSettingsRequest_builder{Settings: settings}.Build()
 	if _, err := r.client.UpdateWebhookSettings(ctx, upReq); err != nil {
-		diags.AddError("Client Error", fmt.Sprintf("Failed to update webhook settings: %v", err))
+		diags.AddError(clientError(err), fmt.Sprintf("Failed to update webhook settings: %v", err))
 		return
 	}
 
@@ -280,7 +287,7 @@ func (r *WebhookSettingsResource) Read(ctx context.Context, req resource.ReadReq
 
 	ret, err := r.client.GetWebhookSettings(ctx, apipb.GetWebhookSettingsRequest_builder{}.Build())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to get webhook settings: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to get webhook settings: %v", err))
 		return
 	}
 
@@ -321,7 +328,7 @@ func (r *WebhookSettingsResource) Delete(ctx context.Context, req resource.Delet
 
 	upReq := apipb.UpdateWebhookSettingsRequest_builder{Settings: settings}.Build()
 	if _, err := r.client.UpdateWebhookSettings(ctx, upReq); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to disable webhook settings: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to disable webhook settings: %v", err))
 		return
 	}
 	tflog.Info(ctx, "Disabled all configured webhooks")
@@ -385,7 +392,7 @@ func webhookBasicToProto(ctx context.Context, enabled types.Bool, url, secret, s
 	sec := secret
 	if !secretWo.IsNull() && !secretWo.IsUnknown() {
 		if !secret.IsNull() && !secret.IsUnknown() {
-			diags.AddAttributeError(p.AtName("secret_wo"), "Conflicting secret",
+			diags.AddAttributeError(p.AtName("secret_wo"), codeInvalidConfig.summary("Conflicting secret"),
 				"Set only one of secret or secret_wo for a webhook source.")
 			return nil, diags
 		}
@@ -421,7 +428,7 @@ func auditEventsToProto(ctx context.Context, l types.List, p path.Path) ([]apipb
 	for i, s := range ss {
 		v, ok := apipb.AuditEvent_value[s]
 		if !ok {
-			diags.AddAttributeError(p.AtListIndex(i), "Invalid audit event", fmt.Sprintf("%q is not a valid AuditEvent.", s))
+			diags.AddAttributeError(p.AtListIndex(i), codeInvalidConfig.summary("Invalid audit event"), fmt.Sprintf("%q is not a valid AuditEvent.", s))
 			continue
 		}
 		out[i] = apipb.AuditEvent(v)
@@ -440,7 +447,7 @@ func signalStatesToProto(ctx context.Context, l types.List, p path.Path) ([]apip
 	for i, s := range ss {
 		v, ok := apipb.SignalReportState_value[s]
 		if !ok {
-			diags.AddAttributeError(p.AtListIndex(i), "Invalid signal report state", fmt.Sprintf("%q is not a valid SignalReportState.", s))
+			diags.AddAttributeError(p.AtListIndex(i), codeInvalidConfig.summary("Invalid signal report state"), fmt.Sprintf("%q is not a valid SignalReportState.", s))
 			continue
 		}
 		out[i] = apipb.SignalReportState(v)
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/list"
 	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -19,7 +18,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/northpolesec/terraform-provider-nps/internal/utils"
+	"github.com/northpolesec/terraform-provider-nps/internal/filter"
 	"google.golang.org/protobuf/proto"
 
 	commonpb "buf.build/gen/go/northpolesec/protos/protocolbuffers/go/common"
@@ -107,7 +106,7 @@ func (r *SignalResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				MarkdownDescription: "The severity assigned to reports produced by this signal.",
 				Required:            true,
 				Validators: []validator.String{
-					stringvalidator.OneOf(utils.ProtoEnumToList(commonpb.Severity(0).Descriptor())...),
+					oneOf(enumValues(commonpb.Severity(0).Descriptor())...),
 				},
 			},
 			"expression": schema.StringAttribute{
@@ -140,7 +139,7 @@ func (r *SignalResource) Configure(ctx context.Context, req resource.ConfigureRe
 	pd, ok := req.ProviderData.(*NPSProviderResourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
+			codeInternal.summary("Unexpected Resource Configure Type"),
 			fmt.Sprintf("Expected NPSProviderResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
@@ -175,7 +174,7 @@ func (r *SignalResource) ValidateConfig(ctx context.Context, req resource.Valida
 	if !ret.GetValid() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("expression"),
-			"Invalid CEL expression",
+			codeInvalidConfig.summary("Invalid CEL expression"),
 			ret.GetError(),
 		)
 	}
@@ -213,7 +212,7 @@ func (r *SignalResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	if err := r.upsert(ctx, data); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to create signal: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to create signal: %v", err))
 		return
 	}
 	tflog.Info(ctx, fmt.Sprintf("Created signal: %q (tag %q)", data.Name.ValueString(), data.Tag.ValueString()))
@@ -235,7 +234,7 @@ func (r *SignalResource) Read(ctx context.Context, req resource.ReadRequest, res
 		PageSize: proto.Uint32(1),
 	}.Build())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to list signals: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to list signals: %v", err))
 		return
 	}
 	if len(ret.GetSignals()) == 0 {
@@ -273,7 +272,7 @@ func (r *SignalResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	if err := r.upsert(ctx, plan); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to update signal: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to update signal: %v", err))
 		return
 	}
 	tflog.Info(ctx, fmt.Sprintf("Updated signal: %q (tag %q)", plan.Name.ValueString(), plan.Tag.ValueString()))
@@ -295,7 +294,7 @@ func (r *SignalResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		Tag:  proto.String(data.Tag.ValueString()),
 	}.Build())
 	if err != nil && !isDeleteNoOp(err) {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete signal: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to delete signal: %v", err))
 		return
 	}
 	tflog.Info(ctx, fmt.Sprintf("Deleted signal: %q (tag %q)", data.Name.ValueString(), data.Tag.ValueString()))
@@ -304,7 +303,7 @@ func (r *SignalResource) Delete(ctx context.Context, req resource.DeleteRequest,
 // signalReadFilter builds the ListSignals filter that selects the single
 // signal identified by the (name, tag) primary key.
 func signalReadFilter(name, tag string) string {
-	return fmt.Sprintf(`name = %q AND tag = %q`, name, tag)
+	return filter.And(filter.Eq("name", name), filter.Eq("tag", tag)).String()
 }
 
 // parseSignalImportID splits a "tag/name" import ID into its components.
@@ -318,9 +317,16 @@ func parseSignalImportID(id string) (tag, name string, err error) {
 }
 
 func (r *SignalResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var identity SignalIdentityModel
+	if importIdentity(ctx, req, resp, &identity) {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tag"), identity.Tag)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), identity.Name)...)
+		return
+	}
+
 	tag, name, err := parseSignalImportID(req.ID)
 	if err != nil {
-		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+		resp.Diagnostics.AddError(codeInvalidConfig.summary("Invalid Import ID"), err.Error())
 		return
 	}
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tag"), tag)...)
@@ -352,7 +358,7 @@ func (r *SignalResource) List(ctx context.Context, req list.ListRequest, stream
 		ret, err := r.client.ListSignals(ctx, apipb.ListSignalsRequest_builder{}.Build())
 		if err != nil {
 			result := req.NewListResult(ctx)
-			result.Diagnostics.AddError("Client Error", "Failed to list signals: "+err.Error())
+			result.Diagnostics.AddError(clientError(err), "Failed to list signals: "+err.Error())
 			push(result)
 			return
 		}
@@ -74,7 +74,7 @@ func (r *TagOrderResource) Configure(ctx context.Context, req resource.Configure
 	pd, ok := req.ProviderData.(*NPSProviderResourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
+			codeInternal.summary("Unexpected Resource Configure Type"),
 			fmt.Sprintf("Expected NPSProviderResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
@@ -98,7 +98,7 @@ func validateTagOrderSize(tags types.List, maxSize int64, diags *diag.Diagnostic
 	if got := int64(len(tags.Elements())); got > maxSize {
 		diags.AddAttributeError(
 			path.Root("tags"),
-			"Tag order exceeds configured maximum",
+			codePolicyViolation.summary("Tag order exceeds configured maximum"),
 			fmt.Sprintf("The tag order contains %d tags, but the provider's tag_order_max_size is %d.", got, maxSize),
 		)
 	}
@@ -135,7 +135,7 @@ func (r *TagOrderResource) updateOrder(ctx context.Context, data TagOrderResourc
 	if _, err := r.client.UpdateTagOrder(ctx, apipb.UpdateTagOrderRequest_builder{
 		Tags: tags,
 	}.Build()); err != nil {
-		diags.AddError("Client Error", fmt.Sprintf("Failed to update tag order: %v", err))
+		diags.AddError(clientError(err), fmt.Sprintf("Failed to update tag order: %v", err))
 	}
 }
 
@@ -159,7 +159,7 @@ func (r *TagOrderResource) Create(ctx context.Context, req resource.CreateReques
 func (r *TagOrderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	ret, err := r.client.GetTagOrder(ctx, apipb.GetTagOrderRequest_builder{}.Build())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to get tag order: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to get tag order: %v", err))
 		return
 	}
 
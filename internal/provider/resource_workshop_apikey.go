@@ -4,22 +4,29 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/list"
 	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/northpolesec/terraform-provider-nps/internal/filter"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
 	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
@@ -48,12 +55,22 @@ type APIKeyIdentityModel struct {
 
 // APIKeyResourceModel describes the resource data model.
 type APIKeyResourceModel struct {
+	Id          types.String `tfsdk:"id"`
 	Name        types.String `tfsdk:"name"`
 	Permissions types.List   `tfsdk:"permissions"`
 	Lifetime    types.Int64  `tfsdk:"lifetime"`
 	Secret      types.String `tfsdk:"secret"`
+	ExpiresAt   types.String `tfsdk:"expires_at"`
+	Keepers     types.Map    `tfsdk:"keepers"`
+
+	UsageWindowDays       types.Int64 `tfsdk:"usage_window_days"`
+	UnusedPermissions     types.List  `tfsdk:"unused_permissions"`
+	WarnUnusedPermissions types.Bool  `tfsdk:"warn_unused_permissions"`
 }
 
+// defaultAPIKeyLifetime is the lifetime of keys created without one.
+const defaultAPIKeyLifetime = 24 * 30 * time.Hour
+
 func (r *APIKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_workshop_apikey"
 }
@@ -61,7 +78,7 @@ func (r *APIKeyResource) Metadata(ctx context.Context, req resource.MetadataRequ
 func (r *APIKeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "The `nps_workshop_apikey` resource manages API keys.",
+		MarkdownDescription: "The `nps_workshop_apikey` resource manages API keys.\n\nTo rotate a key, change a value in `keepers`: the key is replaced and the new secret is stored in state. Key names are unique, so rotating without a window where no key exists requires both `create_before_destroy` and a name that changes with the keepers:\n\n```hcl\nresource \"time_rotating\" \"ci\" {\n  rotation_days = 30\n}\n\nresource \"nps_workshop_apikey\" \"ci\" {\n  name        = \"ci-${time_rotating.ci.unix}\"\n  permissions = [\"read:rules\", \"write:rules\"]\n  keepers = {\n    rotation = time_rotating.ci.id\n  }\n\n  lifecycle {\n    create_before_destroy = true\n  }\n}\n```\n\nDo not use `create_before_destroy` with a fixed name: deleting the old key would delete the new one.\n\nTo down-scope a key over time, set `usage_window_days` and `lifetime`: each refresh then lists the permissions the key hasn't used in that many days in `unused_permissions`, and `warn_unused_permissions` raises a warning about them. Use is read from the audit log, one query per write permission, so only write permissions can be reported. Removing a permission updates the key in place.\n\nKeys can be imported by name, but the server never returns a key's secret after it is created, so `secret` is null for an imported key.",
 
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
@@ -69,19 +86,46 @@ func (r *APIKeyResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Required:            true,
 			},
 			"permissions": schema.ListAttribute{
-				MarkdownDescription: "The permissions for this key",
+				MarkdownDescription: "The permissions for this key. The `nps_workshop_permissions` data source lists the valid values.",
 				Required:            true,
 				ElementType:         types.StringType,
 				Validators: []validator.List{
 					listvalidator.SizeAtLeast(1),
+					listValuesOneOf(workshopPermissions...),
 				},
 			},
 			"lifetime": schema.Int64Attribute{
-				MarkdownDescription: "The lifetime for this key in hours",
+				MarkdownDescription: "The lifetime for this key in hours. It sets the expiry when the key is created; changing it later doesn't change `expires_at`.",
+				Optional:            true,
+			},
+			"keepers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that, when changed, force the key to be replaced with a new one. Use this to rotate keys, e.g. from a `time_rotating` resource.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"usage_window_days": schema.Int64Attribute{
+				MarkdownDescription: "Enables the permission advisor: on refresh, the audit log is checked for which of the key's `permissions` it used in this many days, and the rest are reported in `unused_permissions`. Requires `lifetime` to be set, as the server doesn't return when a key was created, and the provider's credentials to be able to read the audit log.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"warn_unused_permissions": schema.BoolAttribute{
+				MarkdownDescription: "Whether to emit a warning on refresh when `unused_permissions` isn't empty.",
 				Optional:            true,
 			},
 
 			// Computed value, returned from Create
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "An identifier for this key, the same as `name`. Key names are unique.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"secret": schema.StringAttribute{
 				Computed:            true,
 				Sensitive:           true,
@@ -90,6 +134,21 @@ func (r *APIKeyResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"expires_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When this key expires, as an RFC3339 timestamp. Useful for rotating keys ahead of expiry, e.g. with a `time_rotating` trigger.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"unused_permissions": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The permissions the key hasn't used in the last `usage_window_days` days. Only permissions whose use the audit log records can be reported, which in practice means write permissions: a read permission is never listed, whether it is used or not. Null while the advisor is disabled, while `lifetime` is unset, and until the key is `usage_window_days` days old.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 	}
 }
@@ -103,7 +162,7 @@ func (r *APIKeyResource) Configure(ctx context.Context, req resource.ConfigureRe
 	pd, ok := req.ProviderData.(*NPSProviderResourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
+			codeInternal.summary("Unexpected Resource Configure Type"),
 			fmt.Sprintf("Expected NPSProviderResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
@@ -131,7 +190,7 @@ func (r *APIKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	lifetimeHours := time.Duration(data.Lifetime.ValueInt64()) * time.Hour
 	if lifetimeHours == 0 {
-		lifetimeHours = 24 * 30 * time.Hour
+		lifetimeHours = defaultAPIKeyLifetime
 	}
 
 	ckResp, err := r.client.CreateAPIKey(ctx, apipb.CreateAPIKeyRequest_builder{
@@ -140,15 +199,19 @@ func (r *APIKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 		Lifetime:    durationpb.New(lifetimeHours),
 	}.Build())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to create API key: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to create API key: %v", err))
 		return
 	}
 	if ckResp.GetSecret() == "" {
-		resp.Diagnostics.AddError("Client Error", "Failed to get secret for new rule")
+		resp.Diagnostics.AddError(codeUnexpectedResponse.summary("Client Error"), "Failed to get secret for new rule")
 		return
 	}
 
+	data.Id = data.Name
 	data.Secret = types.StringValue(ckResp.GetSecret())
+	data.ExpiresAt = apiKeyExpiresAt(ckResp.GetExpires())
+	// A new key hasn't had the chance to use anything yet.
+	data.UnusedPermissions = types.ListNull(types.StringType)
 	tflog.Info(ctx, fmt.Sprintf("Created API key: %q", data.Secret))
 
 	// Set the identity
@@ -168,23 +231,33 @@ func (r *APIKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	// Keys have no server-side ID, so id is the name; fall back to it for
+	// state that only has an id.
+	name := data.Name.ValueString()
+	if name == "" {
+		name = data.Id.ValueString()
+	}
+
 	ret, err := r.client.ListAPIKeys(ctx, apipb.ListAPIKeysRequest_builder{
-		Filter:   proto.String("name = \"" + data.Name.ValueString() + "\""),
+		Filter:   proto.String(filter.Eq("name", name).String()),
 		PageSize: proto.Uint32(1),
 	}.Build())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to list API keys: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to list API keys: %v", err))
 		return
 	}
 	if len(ret.GetKeys()) == 0 {
-		tflog.Info(ctx, fmt.Sprintf("API key %q not found", data.Name.ValueString()))
+		tflog.Info(ctx, fmt.Sprintf("API key %q not found", name))
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
 	key := ret.GetKeys()[0]
+	data.Id = types.StringValue(key.GetName())
 	data.Name = types.StringValue(key.GetName())
 	data.Permissions, _ = types.ListValueFrom(ctx, types.StringType, key.GetPermissions())
+	data.ExpiresAt = apiKeyExpiresAt(key.GetExpires())
+	r.adviseUnusedPermissions(ctx, &data, key, &resp.Diagnostics)
 
 	// Set the identity
 	resp.Diagnostics.Append(resp.Identity.Set(ctx, APIKeyIdentityModel{Name: data.Name})...)
@@ -199,10 +272,37 @@ func (r *APIKeyResource) Update(ctx context.Context, req resource.UpdateRequest,
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = data.Name
+
+	perms := make([]string, 0, len(data.Permissions.Elements()))
+	resp.Diagnostics.Append(data.Permissions.ElementsAs(ctx, &perms, false)...)
+	// UpdateAPIKey replaces the expiry too, so send the current one to keep
+	// it: lifetime only applies when the key is created.
+	var expires *timestamppb.Timestamp
+	if !data.ExpiresAt.IsNull() {
+		t, err := time.Parse(time.RFC3339, data.ExpiresAt.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("expires_at"), codeInternal.summary("Invalid Expiry"), fmt.Sprintf("Failed to parse expires_at %q: %v", data.ExpiresAt.ValueString(), err))
+		}
+		expires = timestamppb.New(t)
+	}
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	_, err := r.client.UpdateAPIKey(ctx, apipb.UpdateAPIKeyRequest_builder{
+		Name:        proto.String(data.Name.ValueString()),
+		Permissions: perms,
+		Expires:     expires,
+	}.Build())
+	if err != nil {
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to update API key: %v", err))
+		return
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -221,13 +321,15 @@ func (r *APIKeyResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		Name: proto.String(data.Name.ValueString()),
 	}.Build())
 	if err != nil && !isDeleteNoOp(err) {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete API key: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to delete API key: %v", err))
 		return
 	}
 }
 
 func (r *APIKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// Keys are looked up by name and Read fills in id. The secret can't be
+	// recovered, so it stays null.
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("name"), path.Root("name"), req, resp)
 }
 
 func (r *APIKeyResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -256,7 +358,7 @@ func (r *APIKeyResource) List(ctx context.Context, req list.ListRequest, stream
 		ret, err := r.client.ListAPIKeys(ctx, apipb.ListAPIKeysRequest_builder{}.Build())
 		if err != nil {
 			result := req.NewListResult(ctx)
-			result.Diagnostics.AddError("Client Error", "Failed to list API keys: "+err.Error())
+			result.Diagnostics.AddError(clientError(err), "Failed to list API keys: "+err.Error())
 			push(result)
 			return
 		}
@@ -272,8 +374,13 @@ func (r *APIKeyResource) List(ctx context.Context, req list.ListRequest, stream
 			if req.IncludeResource {
 				permissions, _ := types.ListValueFrom(ctx, types.StringType, key.GetPermissions())
 				result.Diagnostics.Append(result.Resource.Set(ctx, APIKeyResourceModel{
+					Id:          types.StringValue(key.GetName()),
 					Name:        types.StringValue(key.GetName()),
 					Permissions: permissions,
+					ExpiresAt:   apiKeyExpiresAt(key.GetExpires()),
+					Keepers:     types.MapNull(types.StringType),
+
+					UnusedPermissions: types.ListNull(types.StringType),
 				})...)
 			}
 
@@ -283,3 +390,51 @@ func (r *APIKeyResource) List(ctx context.Context, req list.ListRequest, stream
 		}
 	}
 }
+
+// apiKeyExpiresAt formats a key's expiry for state; keys without an expiry
+// are null.
+func apiKeyExpiresAt(ts *timestamppb.Timestamp) types.String {
+	if ts == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(ts.AsTime().Format(time.RFC3339))
+}
+
+// adviseUnusedPermissions sets data.UnusedPermissions from the audit log once
+// key is at least usage_window_days old, and warns about them if asked to.
+// The server doesn't return a key's creation time, so it is worked out from
+// the expiry and lifetime, and without a lifetime (an imported key's isn't
+// known) there is nothing to advise. A failed lookup is a warning that keeps
+// the previous value: the advisor shouldn't block managing the key.
+func (r *APIKeyResource) adviseUnusedPermissions(ctx context.Context, data *APIKeyResourceModel, key *apipb.APIKey, diags *diag.Diagnostics) {
+	if data.UsageWindowDays.IsNull() || data.Lifetime.IsNull() || data.Lifetime.IsUnknown() || !key.HasExpires() {
+		data.UnusedPermissions = types.ListNull(types.StringType)
+		return
+	}
+
+	lifetime := time.Duration(data.Lifetime.ValueInt64()) * time.Hour
+	window := time.Duration(data.UsageWindowDays.ValueInt64()) * 24 * time.Hour
+	now := time.Now()
+	if created := key.GetExpires().AsTime().Add(-lifetime); now.Sub(created) < window {
+		data.UnusedPermissions = types.ListNull(types.StringType)
+		return
+	}
+
+	unused, err := unusedPermissions(ctx, r.client, key.GetName(), key.GetPermissions(), now.Add(-window))
+	if err != nil {
+		diags.AddWarning(clientError(err), fmt.Sprintf("Failed to check API key %q for unused permissions: %v", key.GetName(), err))
+		if data.UnusedPermissions.IsUnknown() {
+			data.UnusedPermissions = types.ListNull(types.StringType)
+		}
+		return
+	}
+	data.UnusedPermissions, _ = types.ListValueFrom(ctx, types.StringType, unused)
+
+	if data.WarnUnusedPermissions.ValueBool() && len(unused) > 0 {
+		diags.AddWarning(
+			codeNotice.summary("Unused API Key Permissions"),
+			fmt.Sprintf("API key %q hasn't used %s in the last %d days. Consider removing them from its permissions.",
+				key.GetName(), strings.Join(unused, ", "), data.UsageWindowDays.ValueInt64()),
+		)
+	}
+}
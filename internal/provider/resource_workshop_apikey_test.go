@@ -2,13 +2,37 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
 )
 
+func TestAPIKeyImportByName(t *testing.T) {
+	client := &fakeWorkshopClient{listAPIKeys: []*apipb.APIKey{apipb.APIKey_builder{
+		Name:        "ci",
+		Permissions: []string{"read:rules"},
+	}.Build()}}
+	state := importedState(t, &APIKeyResource{client: client}, "ci")
+
+	var got APIKeyResourceModel
+	if diags := state.Get(context.Background(), &got); diags.HasError() {
+		t.Fatalf("reading imported state: %v", diags)
+	}
+	if got.Id.ValueString() != "ci" || got.Name.ValueString() != "ci" || len(got.Permissions.Elements()) != 1 {
+		t.Errorf("imported key = %+v", got)
+	}
+	// The secret is only returned when a key is created.
+	if !got.Secret.IsNull() {
+		t.Errorf("imported secret = %s, want null", got.Secret)
+	}
+}
+
 func TestAccWorkshopAPIKey(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -26,8 +50,37 @@ func TestAccWorkshopAPIKey(t *testing.T) {
 					resource.TestCheckResourceAttr("nps_workshop_apikey.test-key-1", "permissions.#", "2"),
 					resource.TestCheckResourceAttr("nps_workshop_apikey.test-key-1", "permissions.0", "read:hosts"),
 					resource.TestCheckResourceAttr("nps_workshop_apikey.test-key-1", "permissions.1", "write:hosts"),
+					resource.TestCheckResourceAttrSet("nps_workshop_apikey.test-key-1", "expires_at"),
+					testAccCheckGeneratedConfig("nps_workshop_apikey.test-key-1", "name"),
 				),
 			},
+			// Changing permissions updates the key in place.
+			{
+				Config: testAccExampleAPIKeyResourceConfig("test-key-1", []string{"read:hosts"}),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("nps_workshop_apikey.test-key-1", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("nps_workshop_apikey.test-key-1", "permissions.#", "1"),
+					resource.TestCheckResourceAttr("nps_workshop_apikey.test-key-1", "permissions.0", "read:hosts"),
+				),
+			},
+			// Changing keepers replaces the key with a new secret.
+			{
+				Config: testAccAPIKeyResourceConfigWithKeepers("test-key-1", "1"),
+				Check:  resource.TestCheckResourceAttr("nps_workshop_apikey.test-key-1", "keepers.rotation", "1"),
+			},
+			{
+				Config: testAccAPIKeyResourceConfigWithKeepers("test-key-1", "2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("nps_workshop_apikey.test-key-1", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("nps_workshop_apikey.test-key-1", "keepers.rotation", "2"),
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
@@ -49,3 +102,19 @@ resource "nps_workshop_apikey" %[1]q {
 }
 `, name, strings.Join(quoted, ", "))
 }
+
+func testAccAPIKeyResourceConfigWithKeepers(name, rotation string) string {
+	return fmt.Sprintf(`
+provider "nps" {
+  endpoint = "localhost:8080"
+}
+
+resource "nps_workshop_apikey" %[1]q {
+  name        = %[1]q
+  permissions = ["read:hosts"]
+  keepers = {
+    rotation = %[2]q
+  }
+}
+`, name, rotation)
+}
@@ -0,0 +1,187 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+func callAPIKeyRead(t *testing.T, r *APIKeyResource, prior APIKeyResourceModel) (APIKeyResourceModel, diag.Diagnostics) {
+	t.Helper()
+	ctx := context.Background()
+
+	var sResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &sResp)
+	var iResp resource.IdentitySchemaResponse
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &iResp)
+
+	req := resource.ReadRequest{State: tfsdk.State{Schema: sResp.Schema}}
+	if diags := req.State.Set(ctx, prior); diags.HasError() {
+		t.Fatalf("failed to build state: %v", diags)
+	}
+	resp := &resource.ReadResponse{
+		State:    req.State,
+		Identity: &tfsdk.ResourceIdentity{Schema: iResp.IdentitySchema},
+	}
+	r.Read(ctx, req, resp)
+
+	var got APIKeyResourceModel
+	resp.State.Get(ctx, &got)
+	return got, resp.Diagnostics
+}
+
+// testAPIKey returns a key with the default lifetime created age ago.
+func testAPIKey(age time.Duration, permissions ...string) *apipb.APIKey {
+	return apipb.APIKey_builder{
+		Name:        "ci",
+		Permissions: permissions,
+		Expires:     timestamppb.New(time.Now().Add(defaultAPIKeyLifetime - age)),
+	}.Build()
+}
+
+func testAPIKeyPriorState(windowDays int64, warn bool) APIKeyResourceModel {
+	return APIKeyResourceModel{
+		Id:                    types.StringValue("ci"),
+		Name:                  types.StringValue("ci"),
+		Permissions:           types.ListNull(types.StringType),
+		Lifetime:              types.Int64Value(int64(defaultAPIKeyLifetime / time.Hour)),
+		Secret:                types.StringNull(),
+		ExpiresAt:             types.StringNull(),
+		Keepers:               types.MapNull(types.StringType),
+		UsageWindowDays:       types.Int64Value(windowDays),
+		UnusedPermissions:     types.ListNull(types.StringType),
+		WarnUnusedPermissions: types.BoolValue(warn),
+	}
+}
+
+func TestAPIKeyReadUnusedPermissions(t *testing.T) {
+	client := &fakeWorkshopClient{
+		listAPIKeys:     []*apipb.APIKey{testAPIKey(20*24*time.Hour, "read:rules", "write:rules", "write:tags")},
+		auditEventsUsed: []apipb.AuditEvent{apipb.AuditEvent_AUDIT_EVENT_FILE_ACCESS_RULE_UPSERT},
+	}
+	r := &APIKeyResource{client: client}
+
+	got, diags := callAPIKeyRead(t, r, testAPIKeyPriorState(14, true))
+	if diags.HasError() {
+		t.Fatalf("read failed: %v", diags)
+	}
+
+	var unused []string
+	got.UnusedPermissions.ElementsAs(context.Background(), &unused, false)
+	if strings.Join(unused, ",") != "write:tags" {
+		t.Errorf("unused_permissions = %v, want [write:tags]", unused)
+	}
+	// read:rules emits no audit events, so it isn't queried.
+	if len(client.auditEventsFilters) != 2 {
+		t.Fatalf("made %d audit log queries, want 2", len(client.auditEventsFilters))
+	}
+	if f := client.auditEventsFilters[0]; !strings.Contains(f, `actor = "apikey:ci"`) || !strings.Contains(f, `event = "AUDIT_EVENT_RULE_UPSERT"`) {
+		t.Errorf("filter = %s", f)
+	}
+	if diags.WarningsCount() != 1 || !strings.Contains(diags.Warnings()[0].Detail(), "write:tags") {
+		t.Errorf("warnings = %v, want one naming write:tags", diags.Warnings())
+	}
+}
+
+func TestAPIKeyReadUnusedPermissionsSkipped(t *testing.T) {
+	tests := []struct {
+		name       string
+		window     int64
+		age        time.Duration
+		noLifetime bool
+	}{
+		{"advisor disabled", 0, 20 * 24 * time.Hour, false},
+		{"younger than the window", 14, 3 * 24 * time.Hour, false},
+		// An imported key's lifetime isn't known, so neither is its age.
+		{"lifetime unset", 14, 20 * 24 * time.Hour, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeWorkshopClient{listAPIKeys: []*apipb.APIKey{testAPIKey(tt.age, "write:tags")}}
+			prior := testAPIKeyPriorState(tt.window, true)
+			if tt.window == 0 {
+				prior.UsageWindowDays = types.Int64Null()
+			}
+			if tt.noLifetime {
+				prior.Lifetime = types.Int64Null()
+			}
+
+			got, diags := callAPIKeyRead(t, &APIKeyResource{client: client}, prior)
+			if diags.HasError() || diags.WarningsCount() != 0 {
+				t.Fatalf("read diagnostics: %v", diags)
+			}
+			if !got.UnusedPermissions.IsNull() {
+				t.Errorf("unused_permissions = %v, want null", got.UnusedPermissions)
+			}
+			if len(client.auditEventsFilters) != 0 {
+				t.Errorf("made %d audit log queries, want none", len(client.auditEventsFilters))
+			}
+		})
+	}
+}
+
+func TestAPIKeyReadUnusedPermissionsError(t *testing.T) {
+	client := &fakeWorkshopClient{
+		listAPIKeys:    []*apipb.APIKey{testAPIKey(20*24*time.Hour, "write:tags")},
+		auditEventsErr: errors.New("permission denied"),
+	}
+	prior := testAPIKeyPriorState(14, false)
+	prior.UnusedPermissions, _ = types.ListValueFrom(context.Background(), types.StringType, []string{"write:tags"})
+
+	got, diags := callAPIKeyRead(t, &APIKeyResource{client: client}, prior)
+	if diags.HasError() {
+		t.Fatalf("a failed audit log query must not fail the read: %v", diags)
+	}
+	if diags.WarningsCount() != 1 {
+		t.Errorf("warnings = %v, want one", diags.Warnings())
+	}
+	if !got.UnusedPermissions.Equal(prior.UnusedPermissions) {
+		t.Errorf("unused_permissions = %v, want the prior value", got.UnusedPermissions)
+	}
+}
+
+func TestAPIKeyUpdatePermissions(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeWorkshopClient{}
+	r := &APIKeyResource{client: client}
+
+	var sResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &sResp)
+
+	expires := time.Now().Add(defaultAPIKeyLifetime).Truncate(time.Second).UTC()
+	data := testAPIKeyPriorState(14, true)
+	data.Permissions, _ = types.ListValueFrom(ctx, types.StringType, []string{"read:rules"})
+	data.ExpiresAt = types.StringValue(expires.Format(time.RFC3339))
+	plan := tfsdk.Plan{Schema: sResp.Schema}
+	if diags := plan.Set(ctx, data); diags.HasError() {
+		t.Fatalf("failed to build plan: %v", diags)
+	}
+
+	resp := &resource.UpdateResponse{State: tfsdk.State{Schema: sResp.Schema}}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("update failed: %v", resp.Diagnostics)
+	}
+	if len(client.apiKeyUpdates) != 1 {
+		t.Fatalf("UpdateAPIKey called %d times, want 1", len(client.apiKeyUpdates))
+	}
+	req := client.apiKeyUpdates[0]
+	if req.GetName() != "ci" || strings.Join(req.GetPermissions(), ",") != "read:rules" {
+		t.Errorf("UpdateAPIKey request = %v, want ci with read:rules", req)
+	}
+	// The expiry is sent back unchanged, not recomputed from lifetime.
+	if !req.GetExpires().AsTime().Equal(expires) {
+		t.Errorf("expires = %v, want %v", req.GetExpires().AsTime(), expires)
+	}
+}
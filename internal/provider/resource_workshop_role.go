@@ -0,0 +1,208 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"google.golang.org/protobuf/proto"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoleResource{}
+var _ resource.ResourceWithConfigure = &RoleResource{}
+var _ resource.ResourceWithImportState = &RoleResource{}
+var _ resource.ResourceWithIdentity = &RoleResource{}
+
+func NewRoleResource() resource.Resource {
+	return &RoleResource{}
+}
+
+// RoleResource manages the permissions granted to one of Workshop's built-in
+// roles. Roles can't be created or deleted through the API.
+type RoleResource struct {
+	client svcpb.WorkshopServiceClient
+}
+
+// RoleIdentityModel describes the identity data model.
+type RoleIdentityModel struct {
+	Name types.String `tfsdk:"name"`
+}
+
+// RoleResourceModel describes the resource data model.
+type RoleResourceModel struct {
+	Name        types.String `tfsdk:"name"`
+	Permissions types.Set    `tfsdk:"permissions"`
+}
+
+func (r *RoleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workshop_role"
+}
+
+func (r *RoleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The `nps_workshop_role` resource manages the permissions granted to an existing Workshop role. The configured permissions replace the role's current ones.\n\n" +
+			"Workshop's roles are fixed: the API can't create or delete them, and users are assigned to roles through the directory integration rather than the API. API keys carry their own permissions (see `nps_workshop_apikey`). Destroying the resource removes it from state without changing the role's permissions.\n\n" +
+			"Requires the `read:roles` and `write:roles` permissions.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the role.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"permissions": schema.SetAttribute{
+				MarkdownDescription: "The permissions granted to the role. The `nps_workshop_permissions` data source lists the valid values.",
+				Required:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setValuesOneOf(workshopPermissions...),
+				},
+			},
+		},
+	}
+}
+
+func (r *RoleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(*NPSProviderResourceData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			codeInternal.summary("Unexpected Resource Configure Type"),
+			fmt.Sprintf("Expected NPSProviderResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = pd.Client
+}
+
+func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check that the role exists, so that a typo in name fails clearly rather
+	// than depending on how UpdateRole treats an unknown role.
+	if _, err := r.client.GetRole(ctx, apipb.GetRoleRequest_builder{Name: proto.String(data.Name.ValueString())}.Build()); err != nil {
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to read role %q: %v", data.Name.ValueString(), err))
+		return
+	}
+
+	if !r.updateRole(ctx, data, &resp.Diagnostics) {
+		return
+	}
+
+	tflog.Info(ctx, "Adopted role", map[string]any{"name": data.Name.ValueString()})
+
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RoleIdentityModel{Name: data.Name})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ret, err := r.client.GetRole(ctx, apipb.GetRoleRequest_builder{Name: proto.String(data.Name.ValueString())}.Build())
+	if isDeleteNoOp(err) {
+		tflog.Info(ctx, fmt.Sprintf("Role %q not found", data.Name.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to read role %q: %v", data.Name.ValueString(), err))
+		return
+	}
+
+	permissions := slices.Clone(ret.GetRole().GetPermissions())
+	slices.Sort(permissions)
+	set, diags := types.SetValueFrom(ctx, types.StringType, slices.Compact(permissions))
+	resp.Diagnostics.Append(diags...)
+	data.Permissions = set
+
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RoleIdentityModel{Name: data.Name})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RoleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.updateRole(ctx, data, &resp.Diagnostics) {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RoleIdentityModel{Name: data.Name})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// updateRole replaces the role's permissions with data's, reporting whether it
+// succeeded.
+func (r *RoleResource) updateRole(ctx context.Context, data RoleResourceModel, diags *diag.Diagnostics) bool {
+	var permissions []string
+	diags.Append(data.Permissions.ElementsAs(ctx, &permissions, false)...)
+	if diags.HasError() {
+		return false
+	}
+	slices.Sort(permissions)
+
+	_, err := r.client.UpdateRole(ctx, apipb.UpdateRoleRequest_builder{
+		Role: apipb.Role_builder{Name: data.Name.ValueString(), Permissions: permissions}.Build(),
+	}.Build())
+	if err != nil {
+		diags.AddError(clientError(err), fmt.Sprintf("Failed to update role %q: %v", data.Name.ValueString(), err))
+		return false
+	}
+	return true
+}
+
+func (r *RoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "Removed role from Terraform state (server-side permissions unchanged)")
+}
+
+func (r *RoleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("name"), path.Root("name"), req, resp)
+}
+
+func (r *RoleResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"name": identityschema.StringAttribute{
+				RequiredForImport: true,
+			},
+		},
+	}
+}
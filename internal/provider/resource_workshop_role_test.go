@@ -0,0 +1,73 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+func TestRoleCreateAndRead(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeWorkshopClient{
+		role: apipb.Role_builder{Name: "viewer", Permissions: []string{"read:rules"}}.Build(),
+	}
+	r := &RoleResource{client: fake}
+
+	var sResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &sResp)
+	var iResp resource.IdentitySchemaResponse
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &iResp)
+
+	create := func(name string) *resource.CreateResponse {
+		plan := tfsdk.Plan{Schema: sResp.Schema}
+		diags := plan.Set(ctx, RoleResourceModel{
+			Name:        types.StringValue(name),
+			Permissions: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("read:tags"), types.StringValue("read:rules")}),
+		})
+		if diags.HasError() {
+			t.Fatalf("failed to build plan: %v", diags)
+		}
+		resp := &resource.CreateResponse{
+			State:    tfsdk.State{Schema: sResp.Schema},
+			Identity: &tfsdk.ResourceIdentity{Schema: iResp.IdentitySchema},
+		}
+		r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+		return resp
+	}
+
+	if resp := create("viewers"); !resp.Diagnostics.HasError() {
+		t.Error("create succeeded for a role that doesn't exist")
+	}
+	if len(fake.roleUpdates) != 0 {
+		t.Fatalf("UpdateRole called for a role that doesn't exist")
+	}
+
+	resp := create("viewer")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("create failed: %v", resp.Diagnostics)
+	}
+	if len(fake.roleUpdates) != 1 || !slices.Equal(fake.roleUpdates[0].GetPermissions(), []string{"read:rules", "read:tags"}) {
+		t.Fatalf("UpdateRole payloads = %v, want viewer with read:rules and read:tags", fake.roleUpdates)
+	}
+
+	// Drift: someone removed read:tags in the UI.
+	readResp := &resource.ReadResponse{State: resp.State, Identity: resp.Identity}
+	r.Read(ctx, resource.ReadRequest{State: resp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read failed: %v", readResp.Diagnostics)
+	}
+	var got RoleResourceModel
+	readResp.State.Get(ctx, &got)
+	want := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("read:rules")})
+	if !got.Permissions.Equal(want) {
+		t.Errorf("permissions = %v, want %v", got.Permissions, want)
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
-	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/list"
 	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
@@ -19,6 +18,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/northpolesec/terraform-provider-nps/internal/filter"
 	"github.com/northpolesec/terraform-provider-nps/internal/utils"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
@@ -32,6 +32,7 @@ var _ resource.Resource = &RuleResource{}
 var _ resource.ResourceWithConfigure = &RuleResource{}
 var _ resource.ResourceWithImportState = &RuleResource{}
 var _ resource.ResourceWithIdentity = &RuleResource{}
+var _ resource.ResourceWithUpgradeIdentity = &RuleResource{}
 var _ resource.ResourceWithModifyPlan = &RuleResource{}
 var _ list.ListResource = &RuleResource{}
 var _ list.ListResourceWithConfigure = &RuleResource{}
@@ -42,12 +43,29 @@ func NewRuleResource() resource.Resource {
 
 // RuleResource defines the resource implementation.
 type RuleResource struct {
-	client svcpb.WorkshopServiceClient
+	client              svcpb.WorkshopServiceClient
+	strictRead          bool
+	acceptNewEnumValues bool
+	defaultTag          string
+	guardrails          ruleGuardrails
+	reads               *ruleReadBatcher
+	autoReconcile       bool
+	verifyScopedTags    bool
+	conflicts           *ruleConflicts
+	backup              *destroyBackup
 }
 
 // RuleIdentityModel describes the identity data model.
 type RuleIdentityModel struct {
-	Id types.String `tfsdk:"id"`
+	Id         types.String `tfsdk:"id"`
+	RuleType   types.String `tfsdk:"rule_type"`
+	Identifier types.String `tfsdk:"identifier"`
+	Tag        types.String `tfsdk:"tag"`
+}
+
+// ruleIdentity is the identity of the rule in data.
+func ruleIdentity(data RuleResourceModel) RuleIdentityModel {
+	return RuleIdentityModel{Id: data.Id, RuleType: data.RuleType, Identifier: data.Identifier, Tag: data.Tag}
 }
 
 // RuleResourceModel describes the resource data model.
@@ -55,6 +73,7 @@ type RuleResourceModel struct {
 	Identifier            types.String                    `tfsdk:"identifier"`
 	RuleType              types.String                    `tfsdk:"rule_type"`
 	Policy                types.String                    `tfsdk:"policy"`
+	Silent                types.Bool                      `tfsdk:"silent"`
 	BlockReason           types.String                    `tfsdk:"block_reason"`
 	Tag                   types.String                    `tfsdk:"tag"`
 	Comment               types.String                    `tfsdk:"comment"`
@@ -63,6 +82,7 @@ type RuleResourceModel struct {
 	CELExpr               types.String                    `tfsdk:"cel_expr"`
 	SeatbeltPolicy        types.String                    `tfsdk:"seatbelt_policy"`
 	AffectedHostThreshold *RuleAffectedHostThresholdModel `tfsdk:"affected_host_threshold"`
+	IgnoreServerChanges   []string                        `tfsdk:"ignore_server_changes"`
 
 	Id types.String `tfsdk:"id"`
 }
@@ -115,12 +135,66 @@ func (m blockReasonDefault) PlanModifyString(ctx context.Context, req planmodifi
 // the rule's policy: blocklist-family policies default to BLOCK_REASON_POLICY
 // (matching the server), everything else resolves to null.
 func resolveBlockReason(policy string) types.String {
-	if strings.Contains(policy, "BLOCKLIST") {
+	if isBlocklistPolicy(policy) {
 		return types.StringValue("BLOCK_REASON_POLICY")
 	}
 	return types.StringNull()
 }
 
+// isBlocklistPolicy reports whether policy is one of the blocklist-family
+// policies (BLOCKLIST and its SILENT_* variants), the only policies the server
+// accepts a block reason on.
+func isBlocklistPolicy(policy string) bool {
+	return strings.Contains(policy, "BLOCKLIST")
+}
+
+// silentDefault resolves an unset silent from the policy, so a rule whose
+// policy is SILENT_BLOCKLIST plans silent = true and every other rule false.
+type silentDefault struct{}
+
+func (m silentDefault) Description(context.Context) string {
+	return "Defaults silent to whether the policy is SILENT_BLOCKLIST when unset."
+}
+
+func (m silentDefault) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m silentDefault) PlanModifyBool(ctx context.Context, req planmodifier.BoolRequest, resp *planmodifier.BoolResponse) {
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+
+	var policy types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("policy"), &policy)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if policy.IsUnknown() {
+		resp.PlanValue = types.BoolUnknown()
+		return
+	}
+	resp.PlanValue = types.BoolValue(policy.ValueString() == apipb.Policy_SILENT_BLOCKLIST.String())
+}
+
+// rulePolicy returns the policy sent to the server: silent turns BLOCKLIST
+// into SILENT_BLOCKLIST. It is unknown while either attribute is.
+func rulePolicy(data RuleResourceModel) types.String {
+	if data.Policy.IsUnknown() || data.Policy.IsNull() {
+		return data.Policy
+	}
+	if data.Policy.ValueString() != apipb.Policy_BLOCKLIST.String() {
+		return data.Policy
+	}
+	if data.Silent.IsUnknown() {
+		return types.StringUnknown()
+	}
+	if data.Silent.ValueBool() {
+		return types.StringValue(apipb.Policy_SILENT_BLOCKLIST.String())
+	}
+	return data.Policy
+}
+
 func (r *RuleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_workshop_rule"
 	// The rule ID (used as the identity) changes on every upsert, including
@@ -135,14 +209,15 @@ func (r *RuleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 
 		Attributes: map[string]schema.Attribute{
 			"identifier": schema.StringAttribute{
-				Description:         "The identifier for this rule. The format of this identifier depends on the rule type.",
-				MarkdownDescription: "The identifier for this rule. The format of this identifier depends on the rule type.",
+				Description:         "The identifier for this rule. The format of this identifier depends on the rule type. Hashes, Team IDs (including the one a signing ID starts with), and the platform prefix of a signing ID are case-insensitive; writing them in another case does not change the rule.",
+				MarkdownDescription: "The identifier for this rule. The format of this identifier depends on the rule type. Hashes, Team IDs (including the one a signing ID starts with), and the `platform` prefix of a signing ID are case-insensitive; writing them in another case does not change the rule.",
 				Required:            true,
 				// Part of the natural key (identifier, rule_type, tag). The upsert
 				// only supersedes the old rule when the key matches, so changing the
-				// key must replace rather than update in place.
+				// key must replace rather than update in place. Rewriting the
+				// identifier in another form keeps the key.
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					identifierRequiresReplace(),
 				},
 			},
 			"rule_type": schema.StringAttribute{
@@ -150,7 +225,7 @@ func (r *RuleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				MarkdownDescription: "The type of this rule. The possible values are: `BINARY`, `CERTIFICATE`, `TEAMID`, `SIGNINGID`, and `CDHASH`.",
 				Required:            true,
 				Validators: []validator.String{
-					stringvalidator.OneOf(utils.ProtoEnumToList(apipb.RuleType(0).Descriptor())...),
+					oneOf(enumValues(apipb.RuleType(0).Descriptor())...),
 				},
 				// Part of the natural key; see identifier.
 				PlanModifiers: []planmodifier.String{
@@ -158,11 +233,20 @@ func (r *RuleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				},
 			},
 			"policy": schema.StringAttribute{
-				Description:         "The policy for this rule. The possible values are: ALLOWLIST, ALLOWLIST_COMPILER, BLOCKLIST, SILENT_BLOCKLIST, CEL, and SEATBELT.",
-				MarkdownDescription: "The policy for this rule. The possible values are: `ALLOWLIST`, `ALLOWLIST_COMPILER`, `BLOCKLIST`, `SILENT_BLOCKLIST`, `CEL`, and `SEATBELT`.",
+				Description:         "The policy for this rule. The possible values are: ALLOWLIST, ALLOWLIST_COMPILER, BLOCKLIST, SILENT_BLOCKLIST, SILENT_GUI_BLOCKLIST, SILENT_TTY_BLOCKLIST, REMOVE, CEL, and SEATBELT. ALLOWLIST_COMPILER allowlists a compiler and, transitively, the binaries it writes; it is only valid for BINARY, SIGNINGID, and CDHASH rules. SILENT_BLOCKLIST blocks without notifying the user, SILENT_GUI_BLOCKLIST without the GUI notification, and SILENT_TTY_BLOCKLIST without the message in the terminal. REMOVE deletes a rule hosts already have, and takes no custom_msg or custom_url. CEL requires cel_expr, and takes seatbelt_policy when the expression can return SEATBELT. SEATBELT requires seatbelt_policy. Other policies take neither attribute.",
+				MarkdownDescription: "The policy for this rule. The possible values are: `ALLOWLIST`, `ALLOWLIST_COMPILER`, `BLOCKLIST`, `SILENT_BLOCKLIST`, `SILENT_GUI_BLOCKLIST`, `SILENT_TTY_BLOCKLIST`, `REMOVE`, `CEL`, and `SEATBELT`. `ALLOWLIST_COMPILER` allowlists a compiler and, transitively, the binaries it writes; it is only valid for `BINARY`, `SIGNINGID`, and `CDHASH` rules. `SILENT_BLOCKLIST` blocks without notifying the user, `SILENT_GUI_BLOCKLIST` without the GUI notification, and `SILENT_TTY_BLOCKLIST` without the message in the terminal. `REMOVE` deletes a rule hosts already have, and takes no `custom_msg` or `custom_url`. `CEL` requires `cel_expr`, and takes `seatbelt_policy` when the expression can return `SEATBELT`. `SEATBELT` requires `seatbelt_policy`. Other policies take neither attribute.",
 				Required:            true,
 				Validators: []validator.String{
-					stringvalidator.OneOf(utils.ProtoEnumToList(apipb.Policy(0).Descriptor())...),
+					oneOf(rulePolicyNames()...),
+				},
+			},
+			"silent": schema.BoolAttribute{
+				Description:         "Whether the rule blocks without notifying the user. Setting silent on a BLOCKLIST rule applies it as SILENT_BLOCKLIST, so silencing a rule is a one-attribute change. Defaults to whether the policy is SILENT_BLOCKLIST; it can't be set on other policies.",
+				MarkdownDescription: "Whether the rule blocks without notifying the user. Setting `silent` on a `BLOCKLIST` rule applies it as `SILENT_BLOCKLIST`, so silencing a rule is a one-attribute change. Defaults to whether the policy is `SILENT_BLOCKLIST`; it can't be set on other policies.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					silentDefault{},
 				},
 			},
 			"block_reason": schema.StringAttribute{
@@ -177,18 +261,24 @@ func (r *RuleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Validators: []validator.String{
 					// Only the meaningful block reasons; BLOCK_REASON_UNSPECIFIED is
 					// reserved for the unset/default case handled by blockReasonDefault.
-					stringvalidator.OneOf("BLOCK_REASON_POLICY", "BLOCK_REASON_MALICIOUS"),
+					oneOf("BLOCK_REASON_POLICY", "BLOCK_REASON_MALICIOUS"),
 				},
 				PlanModifiers: []planmodifier.String{
 					blockReasonDefault{},
 				},
 			},
 			"tag": schema.StringAttribute{
-				Description:         "The tag for this rule. The tag determines which hosts this rule will apply to. The tag must already exist in Workshop.",
-				MarkdownDescription: "The tag for this rule. The tag determines which hosts this rule will apply to. The tag must already exist in Workshop.",
-				Required:            true,
+				Description:         "The tag for this rule. The tag determines which hosts this rule will apply to: global for every host, a tag that already exists in Workshop, host:<machine ID> for a single host (the machine_id of an nps_workshop_host), or user:<email> for the hosts of a single user. Defaults to the provider's default_tag; one of the two must be set.",
+				MarkdownDescription: "The tag for this rule. The tag determines which hosts this rule will apply to: `global` for every host, a tag that already exists in Workshop, `host:<machine ID>` for a single host (the `machine_id` of an `nps_workshop_host`), or `user:<email>` for the hosts of a single user. Set the provider's `verify_scoped_tags` to check at plan time that the host or user exists. Defaults to the provider's `default_tag`; one of the two must be set.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					ruleTagValidator{},
+				},
 				// Part of the natural key; see identifier.
+				// An omitted tag takes the provider's default_tag; see applyDefaultTag.
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -198,8 +288,8 @@ func (r *RuleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Optional:            true,
 			},
 			"seatbelt_policy": schema.StringAttribute{
-				Description:         "The seatbelt policy to apply when running the targeted process under `santactl sandbox`. Required when the policy is set to SEATBELT, or when the policy is CEL and the CEL expression can return SEATBELT.",
-				MarkdownDescription: "The seatbelt policy to apply when running the targeted process under `santactl sandbox`. Required when the policy is set to `SEATBELT`, or when the policy is `CEL` and the CEL expression can return `SEATBELT`.",
+				Description:         "The seatbelt policy to apply when running the targeted process under `santactl sandbox`. Required when the policy is set to SEATBELT, or when the policy is CEL and the CEL expression can return SEATBELT. Only valid for those two policies.",
+				MarkdownDescription: "The seatbelt policy to apply when running the targeted process under `santactl sandbox`. Required when the policy is set to `SEATBELT`, or when the policy is `CEL` and the CEL expression can return `SEATBELT`. Only valid for those two policies.",
 				Optional:            true,
 			},
 			"comment": schema.StringAttribute{
@@ -207,14 +297,21 @@ func (r *RuleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Optional:            true,
 			},
 			"custom_msg": schema.StringAttribute{
-				MarkdownDescription: "A custom message to display to the user when this rule causes Santa to block the execution.",
+				MarkdownDescription: "A custom message to display to the user when this rule causes Santa to block the execution. Silent rules show no message, so it has no effect on them. Can't be set on `REMOVE` rules.",
 				Optional:            true,
+				Validators: []validator.String{
+					customMsgValidator{},
+				},
 			},
 			"custom_url": schema.StringAttribute{
-				Description:         "A custom URL to redirect the user to when this rule causes Santa to block the execution. Setting a custom URL will override the EventDetailURL used by the Open button.",
-				MarkdownDescription: "A custom URL to redirect the user to when this rule causes Santa to block the execution. Setting a custom URL will override the `EventDetailURL` used by the Open button.",
+				Description:         "A custom URL to redirect the user to when this rule causes Santa to block the execution. Setting a custom URL will override the EventDetailURL used by the Open button. Can't be set on REMOVE rules.",
+				MarkdownDescription: "A custom URL to redirect the user to when this rule causes Santa to block the execution. Setting a custom URL will override the `EventDetailURL` used by the Open button. Can't be set on `REMOVE` rules.",
 				Optional:            true,
+				Validators: []validator.String{
+					executionEventURLValidator(),
+				},
 			},
+			"ignore_server_changes": ignoreServerChangesAttribute("comment", "custom_msg", "custom_url"),
 
 			// Computed value, returned from Create. The ID changes on every
 			// upsert (including in-place updates), so it is intentionally left
@@ -259,32 +356,31 @@ func (r *RuleResource) ConfigValidators(ctx context.Context) []resource.ConfigVa
 			var data RuleResourceModel
 			resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 
-			if data.BlockReason.ValueString() != "" && data.Policy.ValueString() != "BLOCKLIST" && data.Policy.ValueString() != "SILENT_BLOCKLIST" {
-				resp.Diagnostics.AddError("Block reason is only valid for BLOCKLIST rules", "")
+			// A policy interpolated from another resource is unknown at validate
+			// time; skip the check and let a later plan/apply resolve it.
+			if data.BlockReason.ValueString() != "" && !data.Policy.IsUnknown() && !isBlocklistPolicy(data.Policy.ValueString()) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("block_reason"),
+					codeInvalidConfig.summary("Block reason is only valid for BLOCKLIST rules"),
+					"block_reason may only be set when policy is BLOCKLIST, SILENT_BLOCKLIST, SILENT_GUI_BLOCKLIST, or SILENT_TTY_BLOCKLIST",
+				)
 			}
 
-			if data.Policy.ValueString() == "CEL" && data.CELExpr.ValueString() == "" {
-				resp.Diagnostics.AddError("CEL expression is required", "CEL expression is required when policy is set to CEL")
-			}
-
-			// A seatbelt_policy interpolated from another resource is unknown at
-			// validate time; skip the check and let a later plan/apply resolve it.
-			if data.Policy.ValueString() == "SEATBELT" && !data.SeatbeltPolicy.IsUnknown() && data.SeatbeltPolicy.ValueString() == "" {
-				resp.Diagnostics.AddError("Seatbelt policy is required", "seatbelt_policy is required when policy is set to SEATBELT")
-			}
+			validateSilent(data, &resp.Diagnostics)
+			validatePolicyAttributes(data, &resp.Diagnostics)
 
 			if data.AffectedHostThreshold != nil {
 				if data.AffectedHostThreshold.HostCount.IsNull() {
 					resp.Diagnostics.AddAttributeError(
 						path.Root("affected_host_threshold").AtName("host_count"),
-						"host_count is required",
+						codeInvalidConfig.summary("host_count is required"),
 						"host_count is required when affected_host_threshold is set",
 					)
 				}
 				if data.AffectedHostThreshold.Days.IsNull() {
 					resp.Diagnostics.AddAttributeError(
 						path.Root("affected_host_threshold").AtName("days"),
-						"days is required",
+						codeInvalidConfig.summary("days is required"),
 						"days is required when affected_host_threshold is set",
 					)
 				}
@@ -293,24 +389,117 @@ func (r *RuleResource) ConfigValidators(ctx context.Context) []resource.ConfigVa
 	}
 }
 
-// ModifyPlan validates the CEL expression against the server during plan. This
-// can't live in ConfigValidators because those run during the validate walk,
-// before the provider (and thus the client) is configured. ModifyPlan runs at
-// plan time when the client is available.
+// validateSilent checks silent against the policy, and warns about block
+// messages on silent rules: Santa shows nothing when a silent rule blocks, so
+// custom_msg and custom_url would never reach anyone.
+func validateSilent(data RuleResourceModel, diags *diag.Diagnostics) {
+	if data.Policy.IsUnknown() || data.Silent.IsUnknown() {
+		return
+	}
+	policy := data.Policy.ValueString()
+	switch {
+	case data.Silent.ValueBool() && policy != apipb.Policy_BLOCKLIST.String() && policy != apipb.Policy_SILENT_BLOCKLIST.String():
+		diags.AddAttributeError(
+			path.Root("silent"),
+			codeInvalidConfig.summary("Silent is only valid for BLOCKLIST rules"),
+			fmt.Sprintf("silent may only be set when policy is BLOCKLIST or SILENT_BLOCKLIST, not %s.", policy),
+		)
+		return
+	case !data.Silent.IsNull() && !data.Silent.ValueBool() && policy == apipb.Policy_SILENT_BLOCKLIST.String():
+		diags.AddAttributeError(
+			path.Root("silent"),
+			codeInvalidConfig.summary("Conflicting silent and policy"),
+			"silent = false contradicts policy SILENT_BLOCKLIST. To notify users, set policy to BLOCKLIST.",
+		)
+		return
+	}
+
+	if rulePolicy(data).ValueString() != apipb.Policy_SILENT_BLOCKLIST.String() {
+		return
+	}
+	for _, attr := range []struct {
+		name  string
+		value types.String
+	}{
+		{"custom_msg", data.CustomMsg},
+		{"custom_url", data.CustomURL},
+	} {
+		if attr.value.ValueString() != "" {
+			diags.AddAttributeWarning(
+				path.Root(attr.name),
+				codeInvalidConfig.summary("Block message on a silent rule"),
+				fmt.Sprintf("Santa doesn't notify users when a SILENT_BLOCKLIST rule blocks an execution, so %s is never shown. Remove it, or set silent = false to notify users.", attr.name),
+			)
+		}
+	}
+}
+
+// ModifyPlan validates the CEL expression against the server, enforces the
+// provider's rule guardrails, and checks for conflicting rules. This can't
+// live in ConfigValidators because those run during the validate walk, before
+// the provider (and thus the client) is configured. ModifyPlan runs at plan
+// time when the client is available.
 func (r *RuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
-	// No plan to validate on destroy, and the client may be unset if the
-	// provider isn't fully configured (e.g. during validate).
-	if req.Plan.Raw.IsNull() || r.client == nil {
+	// No plan to validate on destroy, but a destroyed rule no longer counts
+	// toward its tag's limits.
+	if req.Plan.Raw.IsNull() {
+		if !req.State.Raw.IsNull() {
+			var prior RuleResourceModel
+			resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+			if !resp.Diagnostics.HasError() {
+				r.guardrails.recordRemoval(prior, nil)
+			}
+		}
+		return
+	}
+	// The client may be unset if the provider isn't fully configured (e.g.
+	// during validate).
+	if r.client == nil {
+		return
+	}
+
+	applyDefaultTag(ctx, r.defaultTag, req, resp)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	var data RuleResourceModel
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(r.validateCELExpr(ctx, data.Policy, data.CELExpr, data.SeatbeltPolicy)...)
+	resp.Diagnostics.Append(r.guardrails.checkPolicy(rulePolicy(data))...)
+	resp.Diagnostics.Append(r.guardrails.checkTrustedTeamID(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var prior *RuleResourceModel
+	if !req.State.Raw.IsNull() {
+		prior = &RuleResourceModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, prior)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if prior != nil {
+		r.guardrails.recordRemoval(*prior, &data)
+	}
+	resp.Diagnostics.Append(r.guardrails.checkTeamIDAllowlist(ctx, r.client, data, prior)...)
+	resp.Diagnostics.Append(r.conflicts.check(ctx, r.client, data, prior)...)
+
+	// Scoped tags are checked once, when the rule is created or moved to the
+	// tag, so that a host leaving Workshop doesn't fail every later plan.
+	if r.verifyScopedTags && !data.Tag.IsUnknown() {
+		var priorTag types.String
+		if !req.State.Raw.IsNull() {
+			resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("tag"), &priorTag)...)
+		}
+		if priorTag.ValueString() != data.Tag.ValueString() {
+			resp.Diagnostics.Append(checkScopedTagExists(ctx, r.client, data.Tag.ValueString())...)
+		}
+	}
 }
 
 // validateCELExpr asks the server to validate the rule's CEL expression. It is a
@@ -334,7 +523,7 @@ func (r *RuleResource) validateCELExpr(ctx context.Context, policy, celExpr, sea
 		}
 		diags.AddAttributeError(
 			path.Root("cel_expr"),
-			"Invalid CEL expression",
+			codeInvalidConfig.summary("Invalid CEL expression"),
 			fmt.Sprintf("The CEL expression failed validation: %s", msg),
 		)
 		return diags
@@ -345,7 +534,7 @@ func (r *RuleResource) validateCELExpr(ctx context.Context, policy, celExpr, sea
 	if valResp.GetCanReturnSeatbelt() && !seatbeltPolicy.IsUnknown() && seatbeltPolicy.ValueString() == "" {
 		diags.AddAttributeError(
 			path.Root("seatbelt_policy"),
-			"Seatbelt policy is required",
+			codeInvalidConfig.summary("Seatbelt policy is required"),
 			"seatbelt_policy is required because this CEL expression can return SEATBELT",
 		)
 	}
@@ -361,13 +550,22 @@ func (r *RuleResource) Configure(ctx context.Context, req resource.ConfigureRequ
 	pd, ok := req.ProviderData.(*NPSProviderResourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
+			codeInternal.summary("Unexpected Resource Configure Type"),
 			fmt.Sprintf("Expected NPSProviderResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
 	r.client = pd.Client
+	r.strictRead = pd.StrictRead
+	r.acceptNewEnumValues = pd.AcceptNewEnumValues
+	r.defaultTag = pd.DefaultTag
+	r.guardrails = pd.Guardrails
+	r.reads = pd.RuleReads
+	r.autoReconcile = pd.AutoReconcile
+	r.verifyScopedTags = pd.VerifyScopedTags
+	r.conflicts = pd.RuleConflicts
+	r.backup = pd.DestroyBackup
 }
 
 func (r *RuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -381,15 +579,16 @@ func (r *RuleResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 
 	crResp, err := r.client.CreateRule(ctx, buildCreateRuleRequest(data))
+	r.reads.Invalidate(data.Tag.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to create rule: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to create rule: %v", err))
 		return
 	}
 
 	data.Id = types.StringValue(crResp.GetRuleId())
 
 	// Set the identity
-	resp.Diagnostics.Append(resp.Identity.Set(ctx, RuleIdentityModel{Id: data.Id})...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ruleIdentity(data))...)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -398,12 +597,12 @@ func (r *RuleResource) Create(ctx context.Context, req resource.CreateRequest, r
 // buildCreateRuleRequest builds the (upsert) CreateRuleRequest from the model.
 func buildCreateRuleRequest(data RuleResourceModel) *apipb.CreateRuleRequest {
 	ruleType := apipb.RuleType_value[data.RuleType.ValueString()]
-	rulePolicy := apipb.Policy_value[data.Policy.ValueString()]
+	policy := apipb.Policy_value[rulePolicy(data).ValueString()]
 
 	ruleBuilder := apipb.Rule_builder{
-		Identifier:     data.Identifier.ValueString(),
+		Identifier:     normalizeIdentifier(data.RuleType.ValueString(), data.Identifier.ValueString()),
 		RuleType:       apipb.RuleType(ruleType),
-		Policy:         apipb.Policy(rulePolicy),
+		Policy:         apipb.Policy(policy),
 		Tag:            data.Tag.ValueString(),
 		Comment:        data.Comment.ValueString(),
 		CustomMsg:      data.CustomMsg.ValueString(),
@@ -446,34 +645,89 @@ func (r *RuleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	// rule ID will change, so we need to query by the triplet of identifier, rule_type,
 	// and tag instead. This lets Terraform show a diff instead of appearing to create
 	// the rule from scratch.
-	filter := ruleReadFilter(data)
-
-	ret, err := r.client.ListRules(ctx, apipb.ListRulesRequest_builder{
-		Filter:   proto.String(filter),
-		PageSize: proto.Int32(1),
-	}.Build())
+	rule, err := r.lookupRule(ctx, data)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to list rules: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to list rules: %v", err))
 		return
 	}
-	if len(ret.GetRules()) == 0 {
+	if rule == nil {
 		// The rule was not found, remove it from the state so Terraform will offer
 		// to create it.
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
+	// With auto_reconcile, a rule changed outside Terraform is re-applied
+	// rather than refreshed, and the state keeps its managed values. An
+	// import has no prior values to re-apply.
+	if r.autoReconcile && data.Id.ValueString() != "" {
+		newID, drifted, diags := r.reconcileRule(ctx, data, rule)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !newID.IsNull() {
+			data.Id = newID
+			resp.Diagnostics.AddWarning(
+				codeNotice.summary("Rule reconciled"),
+				fmt.Sprintf("The %s rule for %s on tag %s had changed outside Terraform (%s), so auto_reconcile re-applied it.",
+					data.RuleType.ValueString(), data.Identifier.ValueString(), data.Tag.ValueString(), strings.Join(drifted, ", ")),
+			)
+			resp.Diagnostics.Append(resp.Identity.Set(ctx, ruleIdentity(data))...)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+	}
+
 	// Now that we've found the rule, overwrite the state data with the actual
 	// values retrieved via the API.
-	rule := ret.GetRules()[0]
+	dec := newReadDecoder(r.strictRead, &resp.Diagnostics).withNewEnumValues(r.acceptNewEnumValues)
+	applyRuleProto(&data, rule, dec)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set the identity
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ruleIdentity(data))...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	keepIgnoredServerChanges(ctx, req.State, &resp.State, &resp.Diagnostics)
+}
+
+// applyRuleProto overwrites data with the values of rule, as returned by
+// ListRules. Optional strings the server reports as empty keep their value in
+// data, so Read passes the prior state and List an empty model.
+func applyRuleProto(data *RuleResourceModel, rule *apipb.Rule, dec readDecoder) {
 	data.Id = types.StringValue(rule.GetRuleId())
-	data.Identifier = types.StringValue(rule.GetIdentifier())
-	data.RuleType = types.StringValue(rule.GetRuleType().String())
-	data.Policy = types.StringValue(rule.GetPolicy().String())
+	data.RuleType = dec.enum(path.Root("rule_type"), rule.GetRuleType(), data.RuleType)
+	// The server stores identifiers normalized; a prior value that is the same
+	// identifier written differently is kept so the configuration doesn't diff.
+	if !sameIdentifier(rule.GetRuleType().String(), data.Identifier.ValueString(), rule.GetIdentifier()) {
+		data.Identifier = types.StringValue(rule.GetIdentifier())
+	}
+
+	// A SILENT_BLOCKLIST rule configured as BLOCKLIST with silent = true keeps
+	// that form; otherwise silent follows the server's policy.
+	silentForm := data.Policy.ValueString() == apipb.Policy_BLOCKLIST.String() && data.Silent.ValueBool()
+	data.Policy = dec.enum(path.Root("policy"), rule.GetPolicy(), data.Policy)
+	data.Silent = types.BoolValue(rule.GetPolicy() == apipb.Policy_SILENT_BLOCKLIST)
+	if silentForm && rule.GetPolicy() == apipb.Policy_SILENT_BLOCKLIST {
+		data.Policy = types.StringValue(apipb.Policy_BLOCKLIST.String())
+	}
 	data.Tag = types.StringValue(rule.GetTag())
 
-	if rule.GetBlockReason() != apipb.Rule_BLOCK_REASON_UNSPECIFIED {
-		data.BlockReason = types.StringValue(rule.GetBlockReason().String())
+	// An unspecified block reason means the server applied its default, which
+	// resolves the same way as an unset config value. Resolving it here rather
+	// than keeping the prior value clears a stale reason after the policy moves
+	// out of the blocklist family, and gives import the same value a plan would.
+	// A reason the server reports on any other policy is ignored: block_reason
+	// can't be configured there, so keeping it would make configuration
+	// generated on import fail validation.
+	if rule.GetBlockReason() != apipb.Rule_BLOCK_REASON_UNSPECIFIED && isBlocklistPolicy(data.Policy.ValueString()) {
+		data.BlockReason = dec.enum(path.Root("block_reason"), rule.GetBlockReason(), data.BlockReason)
+	} else {
+		data.BlockReason = resolveBlockReason(data.Policy.ValueString())
 	}
 	if rule.GetComment() != "" {
 		data.Comment = types.StringValue(rule.GetComment())
@@ -490,12 +744,6 @@ func (r *RuleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	if rule.GetSeatbeltPolicy() != "" {
 		data.SeatbeltPolicy = types.StringValue(rule.GetSeatbeltPolicy())
 	}
-
-	// Set the identity
-	resp.Diagnostics.Append(resp.Identity.Set(ctx, RuleIdentityModel{Id: data.Id})...)
-
-	// Save updated data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *RuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -512,7 +760,7 @@ func (r *RuleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 	plan.Id = newID
 
-	resp.Diagnostics.Append(resp.Identity.Set(ctx, RuleIdentityModel{Id: plan.Id})...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ruleIdentity(plan))...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -527,8 +775,9 @@ func (r *RuleResource) upsertRule(ctx context.Context, plan RuleResourceModel) (
 	var diags diag.Diagnostics
 
 	crResp, err := r.client.CreateRule(ctx, buildCreateRuleRequest(plan))
+	r.reads.Invalidate(plan.Tag.ValueString())
 	if err != nil {
-		diags.AddError("Client Error", fmt.Sprintf("Failed to update rule: %v", err))
+		diags.AddError(clientError(err), fmt.Sprintf("Failed to update rule: %v", err))
 		return types.StringNull(), diags
 	}
 	return types.StringValue(crResp.GetRuleId()), diags
@@ -545,30 +794,103 @@ func (r *RuleResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	backupBeforeDelete(ctx, r.backup, data.Tag.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	_, err := r.client.DeleteRule(ctx, apipb.DeleteRuleRequest_builder{
 		RuleId: proto.String(data.Id.ValueString()),
 	}.Build())
+	r.reads.Invalidate(data.Tag.ValueString())
 	if err != nil && !isRuleDeleteNoOp(err) {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete rule: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to delete rule: %v", err))
 		return
 	}
 }
 
 func (r *RuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importID := req.ID
+	var identity RuleIdentityModel
+	if importIdentity(ctx, req, resp, &identity) {
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if identity.Id.ValueString() != "" {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.Id)...)
+			return
+		}
+		importID = fmt.Sprintf("%s:%s@%s", identity.RuleType.ValueString(), identity.Identifier.ValueString(), identity.Tag.ValueString())
+		if _, _, _, ok := parseRuleImportID(importID); !ok {
+			resp.Diagnostics.AddError(codeInvalidConfig.summary("Invalid import identity"), "Expected an identity with id, or with rule_type, identifier, and tag.")
+			return
+		}
+	}
+
+	// Rule IDs change on every upsert, so a rule can also be imported by its
+	// natural key. Setting the triplet with an empty ID lets Read resolve the
+	// rule through ruleReadFilter just as it does after an update.
+	if ruleType, identifier, tag, ok := parseRuleImportID(importID); ok {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("identifier"), identifier)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("rule_type"), ruleType)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tag"), tag)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), "")...)
+		return
+	}
+
 	// Import a rule by ID, which will trigger a Read.
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
+// parseRuleImportID parses an import ID of the form
+// RULE_TYPE:identifier@tag, e.g. SIGNINGID:platform:com.apple.curl@global.
+// Identifiers never contain "@", so the first one separates the tag, which
+// may. ok is false for anything else, including plain rule IDs.
+func parseRuleImportID(id string) (ruleType, identifier, tag string, ok bool) {
+	ruleType, rest, found := strings.Cut(id, ":")
+	if !found {
+		return "", "", "", false
+	}
+	if t, valid := apipb.RuleType_value[ruleType]; !valid || t == int32(apipb.RuleType_RULETYPE_UNKNOWN) {
+		return "", "", "", false
+	}
+	identifier, tag, found = strings.Cut(rest, "@")
+	if !found || identifier == "" || tag == "" {
+		return "", "", "", false
+	}
+	return ruleType, identifier, tag, true
+}
+
 func (r *RuleResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
 	resp.IdentitySchema = identityschema.Schema{
+		Version: 1,
 		Attributes: map[string]identityschema.Attribute{
 			"id": identityschema.StringAttribute{
-				RequiredForImport: true,
+				Description:       "The rule's ID. Rule IDs change whenever a rule is updated, so prefer the natural key.",
+				OptionalForImport: true,
+			},
+			"rule_type": identityschema.StringAttribute{
+				Description:       "The rule's type, e.g. `SIGNINGID`.",
+				OptionalForImport: true,
+			},
+			"identifier": identityschema.StringAttribute{
+				Description:       "The rule's identifier. Set with `rule_type` and `tag` when `id` is unset.",
+				OptionalForImport: true,
+			},
+			"tag": identityschema.StringAttribute{
+				Description:       "The tag the rule applies to.",
+				OptionalForImport: true,
 			},
 		},
 	}
 }
 
+func (r *RuleResource) UpgradeIdentity(ctx context.Context) map[int64]resource.IdentityUpgrader {
+	return idIdentityUpgraders(identityschema.StringAttribute{RequiredForImport: true}, func(id types.String) any {
+		return RuleIdentityModel{Id: id}
+	})
+}
+
 func NewRuleListResource() list.ListResource {
 	return &RuleResource{}
 }
@@ -582,49 +904,34 @@ func (r *RuleResource) ListResourceConfigSchema(ctx context.Context, req list.Li
 
 func (r *RuleResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
 	stream.Results = func(push func(list.ListResult) bool) {
-		ret, err := r.client.ListRules(ctx, apipb.ListRulesRequest_builder{}.Build())
-		if err != nil {
-			result := req.NewListResult(ctx)
-			result.Diagnostics.AddError("Client Error", "Failed to list rules: "+err.Error())
-			push(result)
-			return
-		}
+		pages := listPages(ctx, "rules", func(page uint32) ([]*apipb.Rule, bool, error) {
+			ret, err := r.client.ListRules(ctx, apipb.ListRulesRequest_builder{
+				PageSize: proto.Int32(listPageSize),
+				Page:     proto.Int32(int32(page)),
+			}.Build())
+			return ret.GetRules(), ret.GetMore(), err
+		})
+		for rule, err := range pages {
+			if err != nil {
+				result := req.NewListResult(ctx)
+				result.Diagnostics.AddError(clientError(err), "Failed to list rules: "+err.Error())
+				push(result)
+				return
+			}
 
-		for _, rule := range ret.GetRules() {
 			result := req.NewListResult(ctx)
 			result.DisplayName = fmt.Sprintf("%s %s", rule.GetRuleType().String(), rule.GetIdentifier())
 
 			result.Diagnostics.Append(result.Identity.Set(ctx, RuleIdentityModel{
-				Id: types.StringValue(rule.GetRuleId()),
+				Id:         types.StringValue(rule.GetRuleId()),
+				RuleType:   types.StringValue(rule.GetRuleType().String()),
+				Identifier: types.StringValue(rule.GetIdentifier()),
+				Tag:        types.StringValue(rule.GetTag()),
 			})...)
 
 			if req.IncludeResource {
-				model := RuleResourceModel{
-					Id:         types.StringValue(rule.GetRuleId()),
-					Identifier: types.StringValue(rule.GetIdentifier()),
-					RuleType:   types.StringValue(rule.GetRuleType().String()),
-					Policy:     types.StringValue(rule.GetPolicy().String()),
-					Tag:        types.StringValue(rule.GetTag()),
-				}
-
-				if rule.GetBlockReason() != apipb.Rule_BLOCK_REASON_UNSPECIFIED {
-					model.BlockReason = types.StringValue(rule.GetBlockReason().String())
-				}
-				if rule.GetComment() != "" {
-					model.Comment = types.StringValue(rule.GetComment())
-				}
-				if rule.GetCustomMsg() != "" {
-					model.CustomMsg = types.StringValue(rule.GetCustomMsg())
-				}
-				if rule.GetCustomUrl() != "" {
-					model.CustomURL = types.StringValue(rule.GetCustomUrl())
-				}
-				if rule.GetCelExpr() != "" {
-					model.CELExpr = types.StringValue(rule.GetCelExpr())
-				}
-				if rule.GetSeatbeltPolicy() != "" {
-					model.SeatbeltPolicy = types.StringValue(rule.GetSeatbeltPolicy())
-				}
+				var model RuleResourceModel
+				applyRuleProto(&model, rule, newReadDecoder(r.strictRead, &result.Diagnostics).withNewEnumValues(r.acceptNewEnumValues))
 
 				result.Diagnostics.Append(result.Resource.Set(ctx, model)...)
 			}
@@ -636,14 +943,40 @@ func (r *RuleResource) List(ctx context.Context, req list.ListRequest, stream *l
 	}
 }
 
+// lookupRule finds the rule data describes for Read, or returns nil if it
+// doesn't exist. A refresh reads every rule at once, so lookups go through the
+// shared batcher, except on import, when only the ID is known.
+func (r *RuleResource) lookupRule(ctx context.Context, data RuleResourceModel) (*apipb.Rule, error) {
+	if r.reads != nil && data.RuleType.ValueString() != "" && !data.Tag.IsUnknown() {
+		return r.reads.Lookup(ctx, ruleLookup{
+			id:         data.Id.ValueString(),
+			identifier: normalizeIdentifier(data.RuleType.ValueString(), data.Identifier.ValueString()),
+			ruleType:   data.RuleType.ValueString(),
+			tag:        data.Tag.ValueString(),
+		})
+	}
+
+	ret, err := r.client.ListRules(ctx, apipb.ListRulesRequest_builder{
+		Filter:   proto.String(ruleReadFilter(data)),
+		PageSize: proto.Int32(1),
+	}.Build())
+	if err != nil || len(ret.GetRules()) == 0 {
+		return nil, err
+	}
+	return ret.GetRules()[0], nil
+}
+
 // ruleReadFilter builds the filter string for the ListRules API call in Read.
 // During import, only the ID is set, so we must avoid sending empty enum values
 // (like rule_type) which the server would reject.
 func ruleReadFilter(data RuleResourceModel) string {
-	filter := fmt.Sprintf(`rule_id = "%s"`, data.Id.ValueString())
+	query := filter.Eq("rule_id", data.Id.ValueString())
 	if !data.RuleType.IsNull() && !data.RuleType.IsUnknown() && data.RuleType.ValueString() != "" {
-		filter += fmt.Sprintf(` OR (identifier = "%s" AND rule_type = "%s" AND tag = "%s")`,
-			data.Identifier.ValueString(), data.RuleType.ValueString(), data.Tag.ValueString())
+		query = filter.Or(query, filter.And(
+			filter.Eq("identifier", normalizeIdentifier(data.RuleType.ValueString(), data.Identifier.ValueString())),
+			filter.Eq("rule_type", data.RuleType.ValueString()),
+			filter.Eq("tag", data.Tag.ValueString()),
+		))
 	}
-	return filter
+	return query.String()
 }
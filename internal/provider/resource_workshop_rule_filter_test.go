@@ -30,6 +30,26 @@ func TestRuleReadFilter(t *testing.T) {
 			},
 			expected: `rule_id = "rule-123" OR (identifier = "platform:com.apple.yes" AND rule_type = "SIGNINGID" AND tag = "global")`,
 		},
+		{
+			name: "import: natural key is set",
+			data: RuleResourceModel{
+				Id:         types.StringValue(""),
+				Identifier: types.StringValue("platform:com.apple.curl"),
+				RuleType:   types.StringValue("SIGNINGID"),
+				Tag:        types.StringValue("global"),
+			},
+			expected: `rule_id = "" OR (identifier = "platform:com.apple.curl" AND rule_type = "SIGNINGID" AND tag = "global")`,
+		},
+		{
+			name: "quotes in the tag are escaped",
+			data: RuleResourceModel{
+				Id:         types.StringValue("rule-123"),
+				Identifier: types.StringValue("platform:com.apple.yes"),
+				RuleType:   types.StringValue("SIGNINGID"),
+				Tag:        types.StringValue(`a" OR tag != "`),
+			},
+			expected: `rule_id = "rule-123" OR (identifier = "platform:com.apple.yes" AND rule_type = "SIGNINGID" AND tag = "a\" OR tag != \"")`,
+		},
 		{
 			name: "rule_type is null",
 			data: RuleResourceModel{
@@ -61,3 +81,29 @@ func TestRuleReadFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestParseRuleImportID(t *testing.T) {
+	tests := []struct {
+		id                        string
+		ruleType, identifier, tag string
+		ok                        bool
+	}{
+		{id: "SIGNINGID:platform:com.apple.curl@global", ruleType: "SIGNINGID", identifier: "platform:com.apple.curl", tag: "global", ok: true},
+		{id: "TEAMID:EQHXZ8M8AV@user:alice@example.com", ruleType: "TEAMID", identifier: "EQHXZ8M8AV", tag: "user:alice@example.com", ok: true},
+		{id: "90216EA0-B60E-42EF-996A-37212B8F378D"},
+		{id: "SIGNINGID:platform:com.apple.curl"},
+		{id: "RULETYPE_UNKNOWN:x@global"},
+		{id: "NOTATYPE:x@global"},
+		{id: "BINARY:@global"},
+		{id: "BINARY:abc@"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			ruleType, identifier, tag, ok := parseRuleImportID(tt.id)
+			if ok != tt.ok || ruleType != tt.ruleType || identifier != tt.identifier || tag != tt.tag {
+				t.Errorf("parseRuleImportID(%q) = %q, %q, %q, %v; want %q, %q, %q, %v",
+					tt.id, ruleType, identifier, tag, ok, tt.ruleType, tt.identifier, tt.tag, tt.ok)
+			}
+		})
+	}
+}
@@ -3,9 +3,12 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/northpolesec/terraform-provider-nps/internal/testserver"
 )
 
 func TestAccWorkshopRule(t *testing.T) {
@@ -23,6 +26,7 @@ func TestAccWorkshopRule(t *testing.T) {
 					resource.TestCheckResourceAttr("nps_workshop_rule.yes", "rule_type", "SIGNINGID"),
 					resource.TestCheckResourceAttr("nps_workshop_rule.yes", "policy", "BLOCKLIST"),
 					resource.TestCheckResourceAttr("nps_workshop_rule.yes", "comment", "block yes"),
+					testAccCheckGeneratedConfig("nps_workshop_rule.yes", "id"),
 				),
 			},
 			{
@@ -32,6 +36,14 @@ func TestAccWorkshopRule(t *testing.T) {
 					resource.TestCheckResourceAttr("nps_workshop_rule.yes", "rule_type", "SIGNINGID"),
 					resource.TestCheckResourceAttr("nps_workshop_rule.yes", "policy", "BLOCKLIST"),
 					resource.TestCheckResourceAttr("nps_workshop_rule.yes", "tag", "rule-test-tag"),
+					// Moving the rule to another tag must not leave it behind on global.
+					testAccCheckServer(func(s *testserver.Server) error {
+						rules := s.Rules()
+						if len(rules) != 1 || rules[0].GetTag() != "rule-test-tag" {
+							return fmt.Errorf("server has rules %v, want only the rule on rule-test-tag", rules)
+						}
+						return nil
+					}),
 				),
 			},
 			{
@@ -50,11 +62,99 @@ func TestAccWorkshopRule(t *testing.T) {
 				ImportStateVerify:       true,
 				ImportStateVerifyIgnore: []string{"comment"},
 			},
+			// Import by natural key rather than by ID.
+			{
+				ResourceName:            "nps_workshop_rule.yes",
+				ImportState:             true,
+				ImportStateId:           "SIGNINGID:platform:com.apple.yes@global",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"comment"},
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
 }
 
+// TestAccWorkshopRuleBlockReason round-trips each BlockReason through create,
+// read, and import, and checks an unset block_reason on a blocklist rule
+// resolves to the server's BLOCK_REASON_POLICY default without a diff.
+func TestAccWorkshopRuleBlockReason(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRuleResourceConfigBlockReason("reason", "BLOCKLIST", "BLOCK_REASON_POLICY"),
+				Check:  resource.TestCheckResourceAttr("nps_workshop_rule.reason", "block_reason", "BLOCK_REASON_POLICY"),
+			},
+			{
+				ResourceName:      "nps_workshop_rule.reason",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccRuleResourceConfigBlockReason("reason", "SILENT_BLOCKLIST", "BLOCK_REASON_MALICIOUS"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("nps_workshop_rule.reason", "policy", "SILENT_BLOCKLIST"),
+					resource.TestCheckResourceAttr("nps_workshop_rule.reason", "block_reason", "BLOCK_REASON_MALICIOUS"),
+				),
+			},
+			{
+				ResourceName:      "nps_workshop_rule.reason",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccRuleResourceConfigBlockReason("reason", "BLOCKLIST", ""),
+				Check:  resource.TestCheckResourceAttr("nps_workshop_rule.reason", "block_reason", "BLOCK_REASON_POLICY"),
+			},
+			{
+				Config: testAccRuleResourceConfigBlockReason("reason", "ALLOWLIST", ""),
+				Check:  resource.TestCheckNoResourceAttr("nps_workshop_rule.reason", "block_reason"),
+			},
+		},
+	})
+}
+
+// TestAccWorkshopRuleCompiler covers transitive allowlisting: compiler rules
+// on the rule types that support them round-trip, and other rule types are
+// rejected at plan.
+func TestAccWorkshopRuleCompiler(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRuleResourceConfigBlockReason("compiler", "ALLOWLIST_COMPILER", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("nps_workshop_rule.compiler", "policy", "ALLOWLIST_COMPILER"),
+					resource.TestCheckNoResourceAttr("nps_workshop_rule.compiler", "block_reason"),
+				),
+			},
+			{
+				ResourceName:      "nps_workshop_rule.compiler",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccRuleResourceConfigCompiler("compiler", "platform:com.apple.clang", "SIGNINGID"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("nps_workshop_rule.compiler", "rule_type", "SIGNINGID"),
+					resource.TestCheckResourceAttr("nps_workshop_rule.compiler", "policy", "ALLOWLIST_COMPILER"),
+				),
+			},
+			{
+				Config:      testAccRuleResourceConfigCompiler("compiler", "EQHXZ8M8AV", "TEAMID"),
+				ExpectError: regexp.MustCompile(`Unsupported rule type for a compiler rule`),
+			},
+		},
+	})
+}
+
 func testAccExampleRuleResourceConfigGlobal(name, identifier, ruleType, policy, comment string) string {
 	return fmt.Sprintf(`
 provider "nps" {
@@ -92,3 +192,40 @@ resource "nps_workshop_rule" %[1]q {
 }
 `, name, identifier, ruleType, policy, tag, comment, "BLOCK_REASON_POLICY")
 }
+
+// testAccRuleResourceConfigBlockReason renders a global BINARY rule; an empty
+// blockReason leaves the attribute unset.
+func testAccRuleResourceConfigBlockReason(name, policy, blockReason string) string {
+	reason := ""
+	if blockReason != "" {
+		reason = fmt.Sprintf("block_reason = %q", blockReason)
+	}
+	return fmt.Sprintf(`
+provider "nps" {
+  endpoint = "localhost:8080"
+}
+
+resource "nps_workshop_rule" %[1]q {
+  identifier = "7ae2ea4a8e4c3a5a9d1c6b8e1e5e2f9c5d8a3b1c7e4f6a2d9b0c8e1f3a5d7b9c"
+  rule_type  = "BINARY"
+  policy     = %[2]q
+  tag        = "global"
+  %[3]s
+}
+`, name, policy, reason)
+}
+
+func testAccRuleResourceConfigCompiler(name, identifier, ruleType string) string {
+	return fmt.Sprintf(`
+provider "nps" {
+  endpoint = "localhost:8080"
+}
+
+resource "nps_workshop_rule" %[1]q {
+  identifier = %[2]q
+  rule_type  = %[3]q
+  policy     = "ALLOWLIST_COMPILER"
+  tag        = "global"
+}
+`, name, identifier, ruleType)
+}
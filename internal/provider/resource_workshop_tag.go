@@ -14,10 +14,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/northpolesec/terraform-provider-nps/internal/filter"
 	"google.golang.org/protobuf/proto"
 
 	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
@@ -75,6 +74,8 @@ type resolvedGroupRef struct {
 
 func (r *TagResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_workshop_tag"
+	// The tag's name is its identity, and renaming a tag updates it in place.
+	resp.ResourceBehavior.MutableIdentity = true
 }
 
 func (r *TagResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -84,12 +85,9 @@ func (r *TagResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
-				Description:         "The name for this tag. Changing the name forces replacement.",
-				MarkdownDescription: "The name for this tag. Changing the name forces replacement.",
+				Description:         "The name for this tag. Changing the name renames the tag in place: Workshop keeps its group assignments, rules, and settings under the new name. nps_workshop_rule resources that refer to the tag by name plan a replacement onto the new name, which rewrites the same rules; give them a create_before_destroy lifecycle to avoid a window without them.",
+				MarkdownDescription: "The name for this tag. Changing the name renames the tag in place: Workshop keeps its group assignments, rules, and settings under the new name. `nps_workshop_rule` resources that refer to the tag by name plan a replacement onto the new name, which rewrites the same rules; give them a `create_before_destroy` lifecycle to avoid a window without them.",
 				Required:            true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"group_names": schema.SetAttribute{
 				Description:         "Names of directory groups this tag should be assigned to. Workshop manages group tags by internal ID; the provider resolves each name via ListGroups and merges this tag into the group's existing tags. A name that matches zero or more than one group is an error.",
@@ -115,7 +113,7 @@ func (r *TagResource) Configure(ctx context.Context, req resource.ConfigureReque
 	pd, ok := req.ProviderData.(*NPSProviderResourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
+			codeInternal.summary("Unexpected Resource Configure Type"),
 			fmt.Sprintf("Expected NPSProviderResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
@@ -147,12 +145,12 @@ func modelGroupRefs(ctx context.Context, data TagResourceModel, diags *diag.Diag
 // resolveGroup looks up the single group matching ref. It returns
 // errGroupNotFound if no group matches, and an error if more than one does.
 func (r *TagResource) resolveGroup(ctx context.Context, ref groupRef) (*apipb.Group, error) {
-	filter := fmt.Sprintf("%s = %q", ref.field, ref.value)
+	query := filter.Eq(ref.field, ref.value).String()
 	ret, err := r.client.ListGroups(ctx, apipb.ListGroupsRequest_builder{
-		Filter: proto.String(filter),
+		Filter: proto.String(query),
 	}.Build())
 	if err != nil {
-		return nil, fmt.Errorf("failed to list groups with filter %q: %w", filter, err)
+		return nil, fmt.Errorf("failed to list groups with filter %q: %w", query, err)
 	}
 
 	// Filter to exact matches defensively, in case the server treats the
@@ -228,7 +226,7 @@ func (r *TagResource) Create(ctx context.Context, req resource.CreateRequest, re
 	for _, ref := range refs {
 		g, err := r.resolveGroup(ctx, ref)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to resolve group %s %q: %v", ref.field, ref.value, err))
+			resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to resolve group %s %q: %v", ref.field, ref.value, err))
 			return
 		}
 		resolved = append(resolved, resolvedGroupRef{ref, g})
@@ -238,7 +236,7 @@ func (r *TagResource) Create(ctx context.Context, req resource.CreateRequest, re
 	if _, err := r.client.CreateTag(ctx, apipb.CreateTagRequest_builder{
 		Tag: proto.String(tag),
 	}.Build()); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to create tag: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to create tag: %v", err))
 		return
 	}
 	tflog.Info(ctx, fmt.Sprintf("Created tag: %q", tag))
@@ -248,7 +246,7 @@ func (r *TagResource) Create(ctx context.Context, req resource.CreateRequest, re
 	var assigned []resolvedGroupRef
 	for _, rr := range resolved {
 		if err := r.setGroupTag(ctx, rr.g, tag, true); err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to assign tag to group %s %q: %v", rr.ref.field, rr.ref.value, err))
+			resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to assign tag to group %s %q: %v", rr.ref.field, rr.ref.value, err))
 			r.rollbackCreate(ctx, tag, assigned)
 			return
 		}
@@ -293,11 +291,11 @@ func (r *TagResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 	}
 
 	ret, err := r.client.ListTags(ctx, apipb.ListTagsRequest_builder{
-		Filter:   proto.String("tag = \"" + data.Name.ValueString() + "\""),
+		Filter:   proto.String(filter.Eq("tag", data.Name.ValueString()).String()),
 		PageSize: proto.Uint32(1),
 	}.Build())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to list tags: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to list tags: %v", err))
 		return
 	}
 	if len(ret.GetTags()) == 0 {
@@ -322,7 +320,7 @@ func (r *TagResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 			continue
 		}
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to resolve group %s %q: %v", ref.field, ref.value, err))
+			resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to resolve group %s %q: %v", ref.field, ref.value, err))
 			return
 		}
 		if !slices.Contains(g.GetTags(), tag) {
@@ -372,6 +370,30 @@ func (r *TagResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		return
 	}
 
+	tag := plan.Name.ValueString()
+
+	// Rename before diffing the groups: Workshop carries the tag's group
+	// assignments over, so the groups already carry the new name. Record the
+	// rename straight away so that a failure below doesn't leave state
+	// pointing at a tag that no longer exists.
+	if oldTag := state.Name.ValueString(); oldTag != tag {
+		if _, err := r.client.RenameTag(ctx, apipb.RenameTagRequest_builder{
+			Tag:    proto.String(oldTag),
+			NewTag: proto.String(tag),
+		}.Build()); err != nil {
+			resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to rename tag %q to %q: %v", oldTag, tag, err))
+			return
+		}
+		tflog.Info(ctx, fmt.Sprintf("Renamed tag %q to %q", oldTag, tag))
+
+		state.Name = plan.Name
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, TagIdentityModel{Name: plan.Name})...)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// Key the diff by the resolved group ID so two refs that alias the same
 	// group (e.g. one by name, one by idp_id) collapse to a single target and
 	// don't produce spurious remove-then-add operations or, worse, strip the
@@ -385,7 +407,7 @@ func (r *TagResource) Update(ctx context.Context, req resource.UpdateRequest, re
 				if skipMissing && errors.Is(err, errGroupNotFound) {
 					continue
 				}
-				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to resolve group %s %q: %v", ref.field, ref.value, err))
+				resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to resolve group %s %q: %v", ref.field, ref.value, err))
 				return nil, false
 			}
 			// First ref wins; arbitrary but stable per Update call.
@@ -405,13 +427,11 @@ func (r *TagResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		return
 	}
 
-	tag := plan.Name.ValueString()
-
 	// Remove the tag from groups no longer in the plan.
 	for id, rr := range stateSet {
 		if _, ok := planSet[id]; !ok {
 			if err := r.setGroupTag(ctx, rr.g, tag, false); err != nil {
-				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to remove tag from group %s %q: %v", rr.ref.field, rr.ref.value, err))
+				resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to remove tag from group %s %q: %v", rr.ref.field, rr.ref.value, err))
 			}
 		}
 	}
@@ -419,7 +439,7 @@ func (r *TagResource) Update(ctx context.Context, req resource.UpdateRequest, re
 	for id, rr := range planSet {
 		if _, ok := stateSet[id]; !ok {
 			if err := r.setGroupTag(ctx, rr.g, tag, true); err != nil {
-				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to assign tag to group %s %q: %v", rr.ref.field, rr.ref.value, err))
+				resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to assign tag to group %s %q: %v", rr.ref.field, rr.ref.value, err))
 			}
 		}
 	}
@@ -427,6 +447,7 @@ func (r *TagResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		return
 	}
 
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, TagIdentityModel{Name: plan.Name})...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -453,11 +474,11 @@ func (r *TagResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 			continue
 		}
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to resolve group %s %q: %v", ref.field, ref.value, err))
+			resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to resolve group %s %q: %v", ref.field, ref.value, err))
 			return
 		}
 		if err := r.setGroupTag(ctx, g, tag, false); err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to remove tag from group %s %q: %v", ref.field, ref.value, err))
+			resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to remove tag from group %s %q: %v", ref.field, ref.value, err))
 			return
 		}
 	}
@@ -466,13 +487,13 @@ func (r *TagResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 		Tag: proto.String(tag),
 	}.Build())
 	if err != nil && !isDeleteNoOp(err) {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete tag: %v", err))
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to delete tag: %v", err))
 		return
 	}
 }
 
 func (r *TagResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("name"), path.Root("name"), req, resp)
 }
 
 func (r *TagResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
@@ -494,15 +515,21 @@ func (r *TagResource) ListResourceConfigSchema(ctx context.Context, req list.Lis
 
 func (r *TagResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
 	stream.Results = func(push func(list.ListResult) bool) {
-		ret, err := r.client.ListTags(ctx, apipb.ListTagsRequest_builder{}.Build())
-		if err != nil {
-			result := req.NewListResult(ctx)
-			result.Diagnostics.AddError("Client Error", "Failed to list tags: "+err.Error())
-			push(result)
-			return
-		}
+		pages := listPages(ctx, "tags", func(page uint32) ([]*apipb.TagStats, bool, error) {
+			ret, err := r.client.ListTags(ctx, apipb.ListTagsRequest_builder{
+				PageSize: proto.Uint32(listPageSize),
+				Page:     proto.Uint32(page),
+			}.Build())
+			return ret.GetTags(), ret.GetMore(), err
+		})
+		for tagStats, err := range pages {
+			if err != nil {
+				result := req.NewListResult(ctx)
+				result.Diagnostics.AddError(clientError(err), "Failed to list tags: "+err.Error())
+				push(result)
+				return
+			}
 
-		for _, tagStats := range ret.GetTags() {
 			tagName := tagStats.GetTag()
 			result := req.NewListResult(ctx)
 			result.DisplayName = tagName
@@ -20,6 +20,8 @@ func TestAccWorkshopTag(t *testing.T) {
 				Config: testAccExampleTagResourceConfig("test-tag-1"),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("nps_workshop_tag.test-tag-1", "name", "test-tag-1"),
+					testAccCheckGeneratedConfig("nps_workshop_tag.test-tag-1", "name"),
+					testAccCheckServerTag("test-tag-1"),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
@@ -0,0 +1,64 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestTagResourceUpdateRenames(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeWorkshopClient{}
+	r := &TagResource{client: fake}
+
+	var sResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &sResp)
+	var iResp resource.IdentitySchemaResponse
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &iResp)
+
+	model := func(name string) TagResourceModel {
+		return TagResourceModel{
+			Name:        types.StringValue(name),
+			GroupNames:  types.SetNull(types.StringType),
+			GroupIdpIds: types.SetNull(types.StringType),
+		}
+	}
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: sResp.Schema},
+		State: tfsdk.State{Schema: sResp.Schema},
+	}
+	req.Plan.Set(ctx, model("eng-macs"))
+	req.State.Set(ctx, model("engineering"))
+	resp := &resource.UpdateResponse{
+		State:    tfsdk.State{Schema: sResp.Schema},
+		Identity: &tfsdk.ResourceIdentity{Schema: iResp.IdentitySchema},
+	}
+
+	r.Update(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("update failed: %v", resp.Diagnostics)
+	}
+	if len(fake.tagRenames) != 1 || fake.tagRenames[0].GetTag() != "engineering" || fake.tagRenames[0].GetNewTag() != "eng-macs" {
+		t.Fatalf("RenameTag requests = %v, want engineering to eng-macs", fake.tagRenames)
+	}
+
+	var got TagResourceModel
+	resp.State.Get(ctx, &got)
+	var identity TagIdentityModel
+	resp.Identity.Get(ctx, &identity)
+	if got.Name.ValueString() != "eng-macs" || identity.Name.ValueString() != "eng-macs" {
+		t.Errorf("state name %s, identity %s; want eng-macs", got.Name, identity.Name)
+	}
+
+	// Updates that keep the name don't rename.
+	fake.tagRenames = nil
+	req.State.Set(ctx, model("eng-macs"))
+	r.Update(ctx, req, resp)
+	if len(fake.tagRenames) != 0 {
+		t.Errorf("RenameTag called without a name change: %v", fake.tagRenames)
+	}
+}
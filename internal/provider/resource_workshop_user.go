@@ -0,0 +1,262 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"google.golang.org/protobuf/proto"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &UserResource{}
+var _ resource.ResourceWithConfigure = &UserResource{}
+var _ resource.ResourceWithImportState = &UserResource{}
+var _ resource.ResourceWithIdentity = &UserResource{}
+
+func NewUserResource() resource.Resource {
+	return &UserResource{}
+}
+
+// UserResource manages a Workshop user in the local directory. Users
+// provisioned by the directory integration belong to it and can't be managed.
+type UserResource struct {
+	client svcpb.WorkshopServiceClient
+}
+
+// UserIdentityModel describes the identity data model.
+type UserIdentityModel struct {
+	Username types.String `tfsdk:"username"`
+}
+
+// UserResourceModel describes the resource data model.
+type UserResourceModel struct {
+	Username          types.String `tfsdk:"username"`
+	ManagerEmail      types.String `tfsdk:"manager_email"`
+	ProfilePictureUrl types.String `tfsdk:"profile_picture_url"`
+	CostCenterName    types.String `tfsdk:"cost_center_name"`
+	DepartmentName    types.String `tfsdk:"department_name"`
+
+	Id types.String `tfsdk:"id"`
+}
+
+func (r *UserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workshop_user"
+	// The username is the identity, and renaming a user updates it in place.
+	resp.ResourceBehavior.MutableIdentity = true
+}
+
+func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The `nps_workshop_user` resource manages a user in Workshop's local directory, e.g. a break-glass administrator or a user at a site without a directory integration.\n\n" +
+			"Only `DIRECTORY_TYPE_LOCAL` users can be managed: users provisioned by the directory (SCIM) integration are owned by the directory, and creating or importing one fails. Use the `nps_workshop_user` data source to look those up. " +
+			"The resource doesn't assign roles: they come from the directory integration's group mappings, and `nps_workshop_role` manages what each role may do.\n\n" +
+			"Requires the `read:scim` and `write:scim` permissions.",
+
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				MarkdownDescription: "The user's username, usually their email address.",
+				Required:            true,
+			},
+			"manager_email": schema.StringAttribute{
+				MarkdownDescription: "The email address of the user's manager.",
+				Optional:            true,
+			},
+			"profile_picture_url": schema.StringAttribute{
+				MarkdownDescription: "The URL of the user's profile picture.",
+				Optional:            true,
+			},
+			"cost_center_name": schema.StringAttribute{
+				MarkdownDescription: "The user's cost center.",
+				Optional:            true,
+			},
+			"department_name": schema.StringAttribute{
+				MarkdownDescription: "The user's department.",
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The directory ID of the user.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *UserResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(*NPSProviderResourceData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			codeInternal.summary("Unexpected Resource Configure Type"),
+			fmt.Sprintf("Expected NPSProviderResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = pd.Client
+}
+
+func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data UserResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// CreateUser would fail for a directory user too, but not with a message
+	// that says why Terraform can't take it over.
+	existing, err := findUser(ctx, r.client, data.Username.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to read user %q: %v", data.Username.ValueString(), err))
+		return
+	}
+	if existing != nil {
+		resp.Diagnostics.Append(userExistsError(existing))
+		return
+	}
+
+	ret, err := r.client.CreateUser(ctx, apipb.CreateUserRequest_builder{
+		Username:          proto.String(data.Username.ValueString()),
+		ManagerEmail:      proto.String(data.ManagerEmail.ValueString()),
+		ProfilePictureUrl: proto.String(data.ProfilePictureUrl.ValueString()),
+		CostCenterName:    proto.String(data.CostCenterName.ValueString()),
+		DepartmentName:    proto.String(data.DepartmentName.ValueString()),
+	}.Build())
+	if err != nil {
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to create user %q: %v", data.Username.ValueString(), err))
+		return
+	}
+	data.Id = types.StringValue(ret.GetId())
+
+	tflog.Info(ctx, "Created user", map[string]any{"username": data.Username.ValueString(), "id": data.Id.ValueString()})
+
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, UserIdentityModel{Username: data.Username})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data UserResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := findUser(ctx, r.client, data.Username.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to read user %q: %v", data.Username.ValueString(), err))
+		return
+	}
+	if user == nil || (!data.Id.IsNull() && user.GetId() != data.Id.ValueString()) {
+		tflog.Info(ctx, fmt.Sprintf("User %q not found", data.Username.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if user.GetType() != apipb.DirectoryType_DIRECTORY_TYPE_LOCAL {
+		// Only reachable by importing a directory user.
+		resp.Diagnostics.Append(userExistsError(user))
+		return
+	}
+
+	data.ManagerEmail = emptyStringToNull(user.GetManagerEmail())
+	data.ProfilePictureUrl = emptyStringToNull(user.GetProfilePictureUrl())
+	data.CostCenterName = emptyStringToNull(user.GetCostCenterName())
+	data.DepartmentName = emptyStringToNull(user.GetDepartmentName())
+	data.Id = types.StringValue(user.GetId())
+
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, UserIdentityModel{Username: data.Username})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data UserResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// UpdateUser replaces every field, so unset attributes clear them.
+	_, err := r.client.UpdateUser(ctx, apipb.UpdateUserRequest_builder{
+		Id:                proto.String(data.Id.ValueString()),
+		Username:          proto.String(data.Username.ValueString()),
+		ManagerEmail:      proto.String(data.ManagerEmail.ValueString()),
+		ProfilePictureUrl: proto.String(data.ProfilePictureUrl.ValueString()),
+		CostCenterName:    proto.String(data.CostCenterName.ValueString()),
+		DepartmentName:    proto.String(data.DepartmentName.ValueString()),
+	}.Build())
+	if err != nil {
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to update user %q: %v", data.Username.ValueString(), err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, UserIdentityModel{Username: data.Username})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data UserResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.DeleteUser(ctx, apipb.DeleteUserRequest_builder{
+		Id: proto.String(data.Id.ValueString()),
+	}.Build())
+	if err != nil && !isDeleteNoOp(err) {
+		resp.Diagnostics.AddError(clientError(err), fmt.Sprintf("Failed to delete user %q: %v", data.Username.ValueString(), err))
+	}
+}
+
+func (r *UserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("username"), path.Root("username"), req, resp)
+}
+
+func (r *UserResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"username": identityschema.StringAttribute{
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// userExistsError reports that user can't be created, or can't be managed if
+// the directory integration provisioned it.
+func userExistsError(user *apipb.User) diag.Diagnostic {
+	if user.GetType() != apipb.DirectoryType_DIRECTORY_TYPE_LOCAL {
+		return diag.NewErrorDiagnostic(
+			codeInvalidConfig.summary("User managed by the directory"),
+			fmt.Sprintf("User %q was provisioned by the directory integration (%s). Only local users can be managed by nps_workshop_user; use the nps_workshop_user data source to look up directory users.", user.GetUsername(), user.GetType()),
+		)
+	}
+	return diag.NewErrorDiagnostic(
+		codeInvalidConfig.summary("User already exists"),
+		fmt.Sprintf("A local user with username %q already exists. Import it with: terraform import <address> %s", user.GetUsername(), user.GetUsername()),
+	)
+}
@@ -0,0 +1,195 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+func TestUserCRUD(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeWorkshopClient{}
+	r := &UserResource{client: fake}
+
+	var sResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &sResp)
+	var iResp resource.IdentitySchemaResponse
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &iResp)
+
+	plan := func(m UserResourceModel) tfsdk.Plan {
+		p := tfsdk.Plan{Schema: sResp.Schema}
+		if diags := p.Set(ctx, m); diags.HasError() {
+			t.Fatalf("failed to build plan: %v", diags)
+		}
+		return p
+	}
+	create := func() *resource.CreateResponse {
+		resp := &resource.CreateResponse{
+			State:    tfsdk.State{Schema: sResp.Schema},
+			Identity: &tfsdk.ResourceIdentity{Schema: iResp.IdentitySchema},
+		}
+		r.Create(ctx, resource.CreateRequest{Plan: plan(UserResourceModel{
+			Username:          types.StringValue("breakglass@example.com"),
+			ManagerEmail:      types.StringNull(),
+			ProfilePictureUrl: types.StringNull(),
+			CostCenterName:    types.StringValue("IT"),
+			DepartmentName:    types.StringNull(),
+			Id:                types.StringUnknown(),
+		})}, resp)
+		return resp
+	}
+	read := func(state tfsdk.State) *resource.ReadResponse {
+		resp := &resource.ReadResponse{State: state, Identity: &tfsdk.ResourceIdentity{Schema: iResp.IdentitySchema}}
+		r.Read(ctx, resource.ReadRequest{State: state}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("read failed: %v", resp.Diagnostics)
+		}
+		return resp
+	}
+
+	resp := create()
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("create failed: %v", resp.Diagnostics)
+	}
+	if len(fake.userCreates) != 1 {
+		t.Fatalf("CreateUser called %d times, want 1", len(fake.userCreates))
+	}
+	if req := fake.userCreates[0]; req.GetUsername() != "breakglass@example.com" || req.GetCostCenterName() != "IT" || req.GetManagerEmail() != "" {
+		t.Errorf("CreateUser request = %v", req)
+	}
+	var got UserResourceModel
+	resp.State.Get(ctx, &got)
+	if got.Id.ValueString() != "user-1" {
+		t.Errorf("id = %v, want user-1", got.Id)
+	}
+
+	if again := create(); !again.Diagnostics.HasError() || !strings.Contains(again.Diagnostics[0].Summary(), "User already exists") {
+		t.Errorf("creating an existing user = %v, want a User already exists error", again.Diagnostics)
+	}
+
+	// Drift: someone set the department in the UI.
+	fake.listUsers[0].SetDepartmentName("Security")
+	readResp := read(resp.State)
+	readResp.State.Get(ctx, &got)
+	if got.DepartmentName.ValueString() != "Security" || !got.ManagerEmail.IsNull() {
+		t.Errorf("read = %+v, want department Security and no manager", got)
+	}
+
+	updateResp := &resource.UpdateResponse{State: readResp.State, Identity: readResp.Identity}
+	r.Update(ctx, resource.UpdateRequest{State: readResp.State, Plan: plan(UserResourceModel{
+		Username:          types.StringValue("breakglass@example.com"),
+		ManagerEmail:      types.StringValue("ciso@example.com"),
+		ProfilePictureUrl: types.StringNull(),
+		CostCenterName:    types.StringNull(),
+		DepartmentName:    types.StringNull(),
+		Id:                types.StringValue("user-1"),
+	})}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("update failed: %v", updateResp.Diagnostics)
+	}
+	if len(fake.userUpdates) != 1 {
+		t.Fatalf("UpdateUser called %d times, want 1", len(fake.userUpdates))
+	}
+	if req := fake.userUpdates[0]; req.GetId() != "user-1" || req.GetManagerEmail() != "ciso@example.com" || req.GetDepartmentName() != "" || req.GetCostCenterName() != "" {
+		t.Errorf("UpdateUser request = %v, want the manager set and the rest cleared", req)
+	}
+
+	// Renaming updates the user in place, so the identity changes with it;
+	// the framework only allows that when the resource declares it.
+	var mResp resource.MetadataResponse
+	r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "nps"}, &mResp)
+	if !mResp.ResourceBehavior.MutableIdentity {
+		t.Fatal("MutableIdentity is false, so a rename would fail with Unexpected Identity Change")
+	}
+	renameResp := &resource.UpdateResponse{State: updateResp.State, Identity: updateResp.Identity}
+	r.Update(ctx, resource.UpdateRequest{State: updateResp.State, Plan: plan(UserResourceModel{
+		Username:          types.StringValue("breakglass-2@example.com"),
+		ManagerEmail:      types.StringValue("ciso@example.com"),
+		ProfilePictureUrl: types.StringNull(),
+		CostCenterName:    types.StringNull(),
+		DepartmentName:    types.StringNull(),
+		Id:                types.StringValue("user-1"),
+	})}, renameResp)
+	if renameResp.Diagnostics.HasError() {
+		t.Fatalf("rename failed: %v", renameResp.Diagnostics)
+	}
+	if req := fake.userUpdates[len(fake.userUpdates)-1]; req.GetId() != "user-1" || req.GetUsername() != "breakglass-2@example.com" {
+		t.Errorf("UpdateUser request = %v, want user-1 renamed", req)
+	}
+	var identity UserIdentityModel
+	renameResp.Identity.Get(ctx, &identity)
+	if identity.Username.ValueString() != "breakglass-2@example.com" {
+		t.Errorf("identity = %v, want the new username", identity.Username)
+	}
+	readResp = read(renameResp.State)
+	readResp.State.Get(ctx, &got)
+	if got.Username.ValueString() != "breakglass-2@example.com" || got.Id.ValueString() != "user-1" {
+		t.Errorf("read after rename = %+v", got)
+	}
+	updateResp = renameResp
+
+	deleteResp := &resource.DeleteResponse{}
+	r.Delete(ctx, resource.DeleteRequest{State: updateResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("delete failed: %v", deleteResp.Diagnostics)
+	}
+	if !slices.Equal(fake.userDeletes, []string{"user-1"}) {
+		t.Errorf("DeleteUser IDs = %v, want [user-1]", fake.userDeletes)
+	}
+	if gone := read(updateResp.State); !gone.State.Raw.IsNull() {
+		t.Error("read kept a deleted user in state")
+	}
+}
+
+func TestUserImport(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeWorkshopClient{listUsers: []*apipb.User{
+		apipb.User_builder{Id: "7", Username: "local@example.com", DepartmentName: "IT", Type: apipb.DirectoryType_DIRECTORY_TYPE_LOCAL}.Build(),
+		apipb.User_builder{Id: "8", Username: "scim@example.com", Type: apipb.DirectoryType_DIRECTORY_TYPE_DSYNC}.Build(),
+	}}
+	r := &UserResource{client: fake}
+
+	var sResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &sResp)
+	var iResp resource.IdentitySchemaResponse
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &iResp)
+
+	importUser := func(username string) *resource.ReadResponse {
+		t.Helper()
+		importResp := &resource.ImportStateResponse{
+			State:    tfsdk.State{Schema: sResp.Schema},
+			Identity: &tfsdk.ResourceIdentity{Schema: iResp.IdentitySchema},
+		}
+		importResp.State.RemoveResource(ctx)
+		r.ImportState(ctx, resource.ImportStateRequest{ID: username}, importResp)
+		if importResp.Diagnostics.HasError() {
+			t.Fatalf("import failed: %v", importResp.Diagnostics)
+		}
+		resp := &resource.ReadResponse{State: importResp.State, Identity: importResp.Identity}
+		r.Read(ctx, resource.ReadRequest{State: importResp.State}, resp)
+		return resp
+	}
+
+	resp := importUser("local@example.com")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("read failed: %v", resp.Diagnostics)
+	}
+	var got UserResourceModel
+	resp.State.Get(ctx, &got)
+	if got.Id.ValueString() != "7" || got.DepartmentName.ValueString() != "IT" {
+		t.Errorf("imported user = %+v, want id 7 in department IT", got)
+	}
+
+	resp = importUser("scim@example.com")
+	if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics[0].Summary(), "User managed by the directory") {
+		t.Errorf("importing a directory user = %v, want a User managed by the directory error", resp.Diagnostics)
+	}
+}
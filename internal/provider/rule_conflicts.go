@@ -0,0 +1,127 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/northpolesec/terraform-provider-nps/internal/filter"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+const (
+	ruleConflictActionWarn  = "warn"
+	ruleConflictActionError = "error"
+)
+
+// ruleKey is what Workshop upserts rules by: two nps_workshop_rule resources
+// with the same key manage the same rule.
+type ruleKey struct {
+	identifier, ruleType, tag string
+}
+
+// ruleConflicts detects nps_workshop_rule resources that would overwrite each
+// other, or a rule that already exists in Workshop, for rule_conflict_action.
+// Resources can't read each other's configuration, but every rule in a run is
+// planned by the same configured provider, so the policy each planned rule
+// gives its key is recorded here and later rules are checked against it. A nil
+// ruleConflicts checks nothing.
+type ruleConflicts struct {
+	asError bool
+
+	mu      sync.Mutex
+	planned map[ruleKey]string
+}
+
+// newRuleConflicts returns the conflict checker for rule_conflict_action, or
+// nil if it is unset.
+func newRuleConflicts(action string) *ruleConflicts {
+	if action == "" {
+		return nil
+	}
+	return &ruleConflicts{asError: action == ruleConflictActionError, planned: map[ruleKey]string{}}
+}
+
+// keyOf returns the key Workshop upserts data's rule by.
+func keyOf(data RuleResourceModel) ruleKey {
+	return ruleKey{
+		identifier: normalizeIdentifier(data.RuleType.ValueString(), data.Identifier.ValueString()),
+		ruleType:   data.RuleType.ValueString(),
+		tag:        data.Tag.ValueString(),
+	}
+}
+
+// check reports a planned rule whose key another rule in the configuration
+// plans with a different policy and, when the rule is being created or moved
+// to a new key (prior is nil, or has a different key), a rule with its key
+// that already exists in Workshop with a different policy, which creating it
+// would silently replace.
+func (c *ruleConflicts) check(ctx context.Context, client svcpb.WorkshopServiceClient, data RuleResourceModel, prior *RuleResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	policy := rulePolicy(data)
+	if c == nil || !known(data.Identifier, data.RuleType, data.Tag, policy) {
+		return diags
+	}
+	key := keyOf(data)
+
+	c.mu.Lock()
+	other, ok := c.planned[key]
+	if !ok {
+		c.planned[key] = policy.ValueString()
+	}
+	c.mu.Unlock()
+	if ok && other != policy.ValueString() {
+		c.add(&diags, codeInvalidConfig.summary("Conflicting rules in configuration"),
+			fmt.Sprintf("Another nps_workshop_rule in this configuration manages the %s rule for %s on tag %q with the %s policy, and this one with %s. Workshop keeps one rule per identifier, rule type, and tag, so each apply would overwrite the other. Remove one of them.",
+				key.ruleType, key.identifier, key.tag, other, policy.ValueString()))
+	}
+	// A rule that keeps its key already owns it in Workshop.
+	if prior != nil && keyOf(*prior) == key {
+		return diags
+	}
+
+	ret, err := client.ListRules(ctx, apipb.ListRulesRequest_builder{
+		Filter: proto.String(filter.And(
+			filter.Eq("identifier", key.identifier),
+			filter.Eq("rule_type", key.ruleType),
+			filter.Eq("tag", key.tag),
+		).String()),
+		PageSize: proto.Int32(1),
+	}.Build())
+	if err != nil {
+		diags.AddError(clientError(err), fmt.Sprintf("Failed to check for a conflicting rule: %v", err))
+		return diags
+	}
+	if rules := ret.GetRules(); len(rules) > 0 && rules[0].GetPolicy().String() != policy.ValueString() {
+		c.add(&diags, codeAlreadyExists.summary("Conflicting rule in Workshop"),
+			fmt.Sprintf("Workshop already has a %s rule for %s on tag %q with the %s policy (ID %s). Creating this rule would replace it with %s. Import it to manage it here, or delete it first.",
+				key.ruleType, key.identifier, key.tag, rules[0].GetPolicy(), rules[0].GetRuleId(), policy.ValueString()))
+	}
+	return diags
+}
+
+func (c *ruleConflicts) add(diags *diag.Diagnostics, summary, detail string) {
+	if c.asError {
+		diags.AddAttributeError(path.Root("policy"), summary, detail)
+	} else {
+		diags.AddAttributeWarning(path.Root("policy"), summary, detail)
+	}
+}
+
+// known reports whether every value is known and set.
+func known(values ...types.String) bool {
+	for _, v := range values {
+		if v.IsUnknown() || v.IsNull() {
+			return false
+		}
+	}
+	return true
+}
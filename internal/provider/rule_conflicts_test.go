@@ -0,0 +1,105 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+func TestRuleConflictsInConfiguration(t *testing.T) {
+	ctx := context.Background()
+	rule := func(identifier, policy string) RuleResourceModel {
+		return RuleResourceModel{
+			Identifier: types.StringValue(identifier),
+			RuleType:   types.StringValue("SIGNINGID"),
+			Policy:     types.StringValue(policy),
+			Silent:     types.BoolValue(false),
+			Tag:        types.StringValue("global"),
+		}
+	}
+	client := &fakeWorkshopClient{}
+
+	var unset *ruleConflicts
+	if diags := unset.check(ctx, client, rule("platform:com.apple.curl", "ALLOWLIST"), nil); len(diags) != 0 {
+		t.Errorf("unset rule_conflict_action reported %v", diags)
+	}
+
+	c := newRuleConflicts(ruleConflictActionWarn)
+	for _, r := range []RuleResourceModel{
+		rule("platform:com.apple.curl", "ALLOWLIST"),
+		rule("platform:com.apple.ls", "BLOCKLIST"),
+		// The same rule planned again, as for a replacement, is no conflict.
+		rule("platform:com.apple.curl", "ALLOWLIST"),
+		// An unknown identifier can't be checked yet.
+		{Identifier: types.StringUnknown(), RuleType: types.StringValue("SIGNINGID"), Policy: types.StringValue("BLOCKLIST"), Tag: types.StringValue("global")},
+	} {
+		if diags := c.check(ctx, client, r, &r); len(diags) != 0 {
+			t.Errorf("check(%s, %s) = %v, want no diagnostics", r.Identifier, r.Policy, diags)
+		}
+	}
+
+	blocklist := rule("platform:com.apple.curl", "BLOCKLIST")
+	diags := c.check(ctx, client, blocklist, &blocklist)
+	if len(diags) != 1 || diags.HasError() || !strings.Contains(diags[0].Detail(), "ALLOWLIST policy") {
+		t.Errorf("conflicting rule reported %v, want one warning naming ALLOWLIST", diags)
+	}
+
+	c = newRuleConflicts(ruleConflictActionError)
+	allowlist := rule("platform:com.apple.curl", "ALLOWLIST")
+	c.check(ctx, client, allowlist, &allowlist)
+	// silent = true makes BLOCKLIST a SILENT_BLOCKLIST rule.
+	silent := rule("platform:com.apple.curl", "BLOCKLIST")
+	silent.Silent = types.BoolValue(true)
+	if diags := c.check(ctx, client, silent, &silent); !diags.HasError() || !strings.Contains(diags[0].Detail(), "with SILENT_BLOCKLIST") {
+		t.Errorf("conflicting rule reported %v, want an error", diags)
+	}
+}
+
+func TestRuleConflictsInWorkshop(t *testing.T) {
+	ctx := context.Background()
+	data := RuleResourceModel{
+		Identifier: types.StringValue("EQHXZ8M8AV"),
+		RuleType:   types.StringValue("TEAMID"),
+		Policy:     types.StringValue("ALLOWLIST"),
+		Tag:        types.StringValue("global"),
+	}
+	client := &fakeWorkshopClient{listRules: []*apipb.Rule{
+		apipb.Rule_builder{RuleId: "rule-1", Identifier: "EQHXZ8M8AV", RuleType: apipb.RuleType_TEAMID, Policy: apipb.Policy_BLOCKLIST, Tag: "global"}.Build(),
+	}}
+	c := newRuleConflicts(ruleConflictActionError)
+
+	// Only a rule being created or moved to a new key can replace one it
+	// doesn't manage.
+	if diags := c.check(ctx, client, data, &data); len(diags) != 0 {
+		t.Errorf("update reported %v", diags)
+	}
+	if client.listRulesFilter != "" {
+		t.Errorf("update queried rules with %q", client.listRulesFilter)
+	}
+
+	// Moving the rule from another tag replaces it, onto the existing rule.
+	moved := data
+	moved.Tag = types.StringValue("engineering")
+	if diags := c.check(ctx, client, data, &moved); !diags.HasError() || !strings.Contains(diags[0].Detail(), "rule-1") {
+		t.Errorf("moving onto an existing BLOCKLIST rule reported %v, want an error naming rule-1", diags)
+	}
+
+	diags := c.check(ctx, client, data, nil)
+	if !diags.HasError() || !strings.Contains(diags[0].Detail(), "rule-1") {
+		t.Errorf("create over an existing BLOCKLIST rule reported %v, want an error naming rule-1", diags)
+	}
+	if want := `identifier = "EQHXZ8M8AV" AND rule_type = "TEAMID" AND tag = "global"`; client.listRulesFilter != want {
+		t.Errorf("filter = %q, want %q", client.listRulesFilter, want)
+	}
+
+	// A rule with the same policy would be adopted unchanged.
+	client.listRules[0].SetPolicy(apipb.Policy_ALLOWLIST)
+	if diags := c.check(ctx, client, data, nil); len(diags) != 0 {
+		t.Errorf("create over an identical rule reported %v", diags)
+	}
+}
@@ -0,0 +1,247 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/northpolesec/terraform-provider-nps/internal/filter"
+	"google.golang.org/protobuf/proto"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// ruleGuardrails holds the organization-wide rule policy configured on the
+// provider block. Resources can't see each other's configuration, so these are
+// enforced per rule during ModifyPlan, with aggregate limits counted against
+// the rules that already exist in Workshop plus those planned so far.
+type ruleGuardrails struct {
+	// ForbidPolicies lists Policy enum names no rule may use.
+	ForbidPolicies []string
+	// MaxTeamIDAllowlistPerTag caps the number of TEAMID ALLOWLIST rules on a
+	// single tag. Zero means no limit.
+	MaxTeamIDAllowlistPerTag int64
+	// TrustedTeamIDs maps each registered Team ID to its owner. Nil means no
+	// registry is configured and Team IDs aren't checked.
+	TrustedTeamIDs map[string]string
+	// UntrustedTeamIDError makes allowlisting an unregistered Team ID an error
+	// rather than a warning.
+	UntrustedTeamIDError bool
+	// PlannedAllowlists records the TEAMID ALLOWLIST rules planned in this
+	// run. Every copy of the guardrails shares it.
+	PlannedAllowlists *plannedAllowlists
+}
+
+// plannedAllowlists records the TEAMID ALLOWLIST rules planned in a run, by
+// tag, so max_teamid_allowlist_per_tag counts rules in the configuration that
+// don't exist in Workshop yet, and the rules planned to be destroyed or moved
+// off a tag, so those don't count. Like ruleConflicts, it relies on every rule
+// in a run being planned by the same configured provider. It also caches each
+// tag's allowlisted Team IDs in Workshop, so a tag is listed once per run
+// however many rules are planned on it. A nil plannedAllowlists records and
+// caches nothing.
+type plannedAllowlists struct {
+	mu       sync.Mutex
+	byTag    map[string][]string
+	removed  map[string][]string
+	existing map[string][]string
+}
+
+func newPlannedAllowlists() *plannedAllowlists {
+	return &plannedAllowlists{byTag: map[string][]string{}, removed: map[string][]string{}, existing: map[string][]string{}}
+}
+
+// remove records identifier as planned to stop being a TEAMID ALLOWLIST rule
+// on tag. Terraform doesn't order the plans of unrelated resources, so this
+// only affects rules counted after it.
+func (p *plannedAllowlists) remove(tag, identifier string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !slices.Contains(p.removed[tag], identifier) {
+		p.removed[tag] = append(p.removed[tag], identifier)
+	}
+}
+
+// count records identifier as planned on tag, and returns how many other
+// TEAMID ALLOWLIST rules the tag has in Workshop and in this run. A rule that
+// is both is counted as planned, and one planned to be removed isn't counted
+// unless it is also planned.
+func (p *plannedAllowlists) count(ctx context.Context, client svcpb.WorkshopServiceClient, tag, identifier string) (existing, planned int, err error) {
+	if p == nil {
+		ids, err := listTeamIDAllowlist(ctx, client, tag)
+		return len(slices.DeleteFunc(ids, func(id string) bool { return id == identifier })), 0, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ids, ok := p.existing[tag]
+	if !ok {
+		if ids, err = listTeamIDAllowlist(ctx, client, tag); err != nil {
+			return 0, 0, err
+		}
+		p.existing[tag] = ids
+	}
+	if !slices.Contains(p.byTag[tag], identifier) {
+		p.byTag[tag] = append(p.byTag[tag], identifier)
+	}
+	for _, id := range ids {
+		if !slices.Contains(p.byTag[tag], id) && !slices.Contains(p.removed[tag], id) {
+			existing++
+		}
+	}
+	return existing, len(p.byTag[tag]) - 1, nil
+}
+
+// listTeamIDAllowlist returns the normalized identifiers of the TEAMID
+// ALLOWLIST rules on tag in Workshop.
+func listTeamIDAllowlist(ctx context.Context, client svcpb.WorkshopServiceClient, tag string) ([]string, error) {
+	query := filter.And(
+		filter.Eq("rule_type", "TEAMID"),
+		filter.Eq("policy", "ALLOWLIST"),
+		filter.Eq("tag", tag),
+	).String()
+	var ids []string
+	for rule, err := range listPages(ctx, "rules", func(page uint32) ([]*apipb.Rule, bool, error) {
+		ret, err := client.ListRules(ctx, apipb.ListRulesRequest_builder{
+			Filter:   proto.String(query),
+			PageSize: proto.Int32(listPageSize),
+			Page:     proto.Int32(int32(page)),
+		}.Build())
+		return ret.GetRules(), ret.GetMore(), err
+	}) {
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, normalizeIdentifier("TEAMID", rule.GetIdentifier()))
+	}
+	return ids, nil
+}
+
+const (
+	untrustedTeamIDActionWarn  = "warn"
+	untrustedTeamIDActionError = "error"
+)
+
+// teamIDRegex matches an Apple Developer Team ID.
+var teamIDRegex = regexp.MustCompile(`^[A-Z0-9]{10}$`)
+
+// forbidPoliciesValidator restricts forbid_policies to known Policy names.
+func forbidPoliciesValidator() validator.Set {
+	return setValuesOneOf(enumValues(apipb.Policy(0).Descriptor())...)
+}
+
+// checkPolicy rejects a planned policy listed in forbid_policies.
+func (g ruleGuardrails) checkPolicy(policy types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if policy.IsUnknown() || policy.IsNull() || !slices.Contains(g.ForbidPolicies, policy.ValueString()) {
+		return diags
+	}
+	diags.AddAttributeError(
+		path.Root("policy"),
+		codePolicyViolation.summary("Policy forbidden by provider configuration"),
+		fmt.Sprintf("The %s policy is listed in the provider's forbid_policies and cannot be used.", policy.ValueString()),
+	)
+	return diags
+}
+
+// checkTrustedTeamID reports a TEAMID rule that allowlists a Team ID missing
+// from trusted_team_ids, as a warning or an error depending on
+// untrusted_team_id_action.
+func (g ruleGuardrails) checkTrustedTeamID(ctx context.Context, data RuleResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if g.TrustedTeamIDs == nil || data.RuleType.ValueString() != "TEAMID" || data.Identifier.IsUnknown() {
+		return diags
+	}
+	if policy := data.Policy.ValueString(); policy != "ALLOWLIST" && policy != "ALLOWLIST_COMPILER" {
+		return diags
+	}
+
+	teamID := data.Identifier.ValueString()
+	if owner, ok := g.TrustedTeamIDs[teamID]; ok {
+		tflog.Debug(ctx, "Allowlisted Team ID is registered", map[string]any{"team_id": teamID, "owner": owner})
+		return diags
+	}
+
+	summary := "Team ID not in trusted registry"
+	detail := fmt.Sprintf("Team ID %q is not listed in the provider's trusted_team_ids. Register it with an owner before allowlisting it.", teamID)
+	if g.UntrustedTeamIDError {
+		diags.AddAttributeError(path.Root("identifier"), codePolicyViolation.summary(summary), detail)
+	} else {
+		diags.AddAttributeWarning(path.Root("identifier"), codePolicyViolation.summary(summary), detail)
+	}
+	return diags
+}
+
+// checkTeamIDAllowlist rejects a TEAMID ALLOWLIST rule that would push its tag
+// over max_teamid_allowlist_per_tag, counting the rules in Workshop and the
+// others planned in this run. Rules are upserted by identifier, rule_type, and
+// tag, so a rule for a planned identifier is counted once, whether or not it
+// exists yet. prior is the rule's state, or nil if it is being created; a rule
+// that was already a TEAMID ALLOWLIST on its tag isn't checked, so lowering the
+// limit doesn't fail plans of rules that don't change the count.
+func (g ruleGuardrails) checkTeamIDAllowlist(ctx context.Context, client svcpb.WorkshopServiceClient, data RuleResourceModel, prior *RuleResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if g.MaxTeamIDAllowlistPerTag <= 0 || !isTeamIDAllowlist(data) {
+		return diags
+	}
+	if data.Identifier.IsUnknown() || data.Tag.IsUnknown() {
+		return diags
+	}
+	tag := data.Tag.ValueString()
+	if prior != nil && isTeamIDAllowlist(*prior) && prior.Tag.ValueString() == tag {
+		return diags
+	}
+
+	identifier := normalizeIdentifier("TEAMID", data.Identifier.ValueString())
+	existing, planned, err := g.PlannedAllowlists.count(ctx, client, tag, identifier)
+	if err != nil {
+		diags.AddError(clientError(err), fmt.Sprintf("Failed to count TEAMID allowlist rules: %v", err))
+		return diags
+	}
+	if total := int64(existing + planned + 1); total > g.MaxTeamIDAllowlistPerTag {
+		diags.AddAttributeError(
+			path.Root("identifier"),
+			codePolicyViolation.summary("Too many TEAMID allowlist rules"),
+			fmt.Sprintf("This rule would make %d TEAMID ALLOWLIST rules on tag %q: %d others in Workshop and %d others planned in this configuration. The provider's max_teamid_allowlist_per_tag is %d.",
+				total, tag, existing, planned, g.MaxTeamIDAllowlistPerTag),
+		)
+	}
+	return diags
+}
+
+// recordRemoval records that prior, a rule's state, stops being a TEAMID
+// ALLOWLIST rule on its tag in this run, so it no longer counts toward the
+// tag's max_teamid_allowlist_per_tag: the rule is being destroyed (data is
+// nil), or data moves it to another tag or policy. A rule that stays an
+// allowlist on its tag under another identifier isn't recorded, as
+// checkTeamIDAllowlist doesn't count its new identifier either.
+func (g ruleGuardrails) recordRemoval(prior RuleResourceModel, data *RuleResourceModel) {
+	if g.MaxTeamIDAllowlistPerTag <= 0 || !isTeamIDAllowlist(prior) {
+		return
+	}
+	tag := prior.Tag.ValueString()
+	if data != nil {
+		if data.RuleType.IsUnknown() || data.Policy.IsUnknown() || data.Tag.IsUnknown() {
+			return
+		}
+		if isTeamIDAllowlist(*data) && data.Tag.ValueString() == tag {
+			return
+		}
+	}
+	g.PlannedAllowlists.remove(tag, normalizeIdentifier("TEAMID", prior.Identifier.ValueString()))
+}
+
+func isTeamIDAllowlist(data RuleResourceModel) bool {
+	return data.RuleType.ValueString() == "TEAMID" && data.Policy.ValueString() == "ALLOWLIST"
+}
@@ -0,0 +1,218 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+func TestRuleGuardrailsCheckPolicy(t *testing.T) {
+	g := ruleGuardrails{ForbidPolicies: []string{"ALLOWLIST_COMPILER"}}
+
+	if diags := g.checkPolicy(types.StringValue("ALLOWLIST_COMPILER")); !diags.HasError() {
+		t.Error("expected ALLOWLIST_COMPILER to be rejected")
+	}
+	for _, v := range []types.String{types.StringValue("ALLOWLIST"), types.StringUnknown(), types.StringNull()} {
+		if diags := g.checkPolicy(v); diags.HasError() {
+			t.Errorf("checkPolicy(%v) unexpectedly failed: %v", v, diags)
+		}
+	}
+}
+
+// teamIDAllowlist returns TEAMID ALLOWLIST rules on global for identifiers.
+func teamIDAllowlist(identifiers ...string) []*apipb.Rule {
+	var rules []*apipb.Rule
+	for _, id := range identifiers {
+		rules = append(rules, apipb.Rule_builder{Identifier: id, RuleType: apipb.RuleType_TEAMID, Policy: apipb.Policy_ALLOWLIST, Tag: "global"}.Build())
+	}
+	return rules
+}
+
+func TestRuleGuardrailsCheckTeamIDAllowlist(t *testing.T) {
+	ctx := context.Background()
+	rule := RuleResourceModel{
+		Identifier: types.StringValue("EQHXZ8M8AV"),
+		RuleType:   types.StringValue("TEAMID"),
+		Policy:     types.StringValue("ALLOWLIST"),
+		Tag:        types.StringValue("global"),
+	}
+	g := ruleGuardrails{MaxTeamIDAllowlistPerTag: 2}
+
+	// The rule itself existing in Workshop isn't another rule.
+	client := &fakeWorkshopClient{listRules: teamIDAllowlist("UBF8T346G9", "EQHXZ8M8AV")}
+	if diags := g.checkTeamIDAllowlist(ctx, client, rule, nil); diags.HasError() {
+		t.Fatalf("second rule should fit under the limit: %v", diags)
+	}
+	if want := `rule_type = "TEAMID" AND policy = "ALLOWLIST" AND tag = "global"`; client.listRulesFilter != want {
+		t.Errorf("filter = %q, want %q", client.listRulesFilter, want)
+	}
+
+	client = &fakeWorkshopClient{listRules: teamIDAllowlist("UBF8T346G9", "ZMCG7MLDV9")}
+	if diags := g.checkTeamIDAllowlist(ctx, client, rule, nil); !diags.HasError() {
+		t.Error("third rule should exceed the limit")
+	}
+
+	// Other rule types and policies are never counted.
+	rule.Policy = types.StringValue("BLOCKLIST")
+	client = &fakeWorkshopClient{listRules: teamIDAllowlist("UBF8T346G9", "ZMCG7MLDV9")}
+	if diags := g.checkTeamIDAllowlist(ctx, client, rule, nil); diags.HasError() || client.listRulesCalls != 0 {
+		t.Errorf("blocklist rule should not be checked: %v", diags)
+	}
+}
+
+func TestRuleGuardrailsCheckTeamIDAllowlistCountsPlannedRules(t *testing.T) {
+	ctx := context.Background()
+	rule := func(identifier string) RuleResourceModel {
+		return RuleResourceModel{
+			Identifier: types.StringValue(identifier),
+			RuleType:   types.StringValue("TEAMID"),
+			Policy:     types.StringValue("ALLOWLIST"),
+			Tag:        types.StringValue("global"),
+		}
+	}
+	g := ruleGuardrails{MaxTeamIDAllowlistPerTag: 3, PlannedAllowlists: newPlannedAllowlists()}
+	client := &fakeWorkshopClient{listRules: teamIDAllowlist("UBF8T346G9")}
+
+	for _, id := range []string{"EQHXZ8M8AV", "UBF8T346G9"} {
+		if diags := g.checkTeamIDAllowlist(ctx, client, rule(id), nil); diags.HasError() {
+			t.Fatalf("rule %s: %v", id, diags)
+		}
+	}
+	// Planning a rule again, as during apply, doesn't count it twice, and a
+	// planned rule that also exists in Workshop is counted once.
+	if diags := g.checkTeamIDAllowlist(ctx, client, rule("EQHXZ8M8AV"), nil); diags.HasError() {
+		t.Errorf("replanned rule: %v", diags)
+	}
+	if diags := g.checkTeamIDAllowlist(ctx, client, rule("ZMCG7MLDV9"), nil); diags.HasError() {
+		t.Errorf("third rule: %v", diags)
+	}
+	// A fourth rule exceeds the limit of three.
+	diags := g.checkTeamIDAllowlist(ctx, client, rule("A1B2C3D4E5"), nil)
+	if !diags.HasError() || !strings.Contains(diags[0].Detail(), "0 others in Workshop and 3 others planned") {
+		t.Errorf("fourth rule = %v, want an error counting three planned rules", diags)
+	}
+	// The tag is listed once, however many rules are planned on it.
+	if client.listRulesCalls != 1 {
+		t.Errorf("listed rules %d times, want once", client.listRulesCalls)
+	}
+	// Other tags are counted and listed separately.
+	client.listRules = nil
+	other := rule("A1B2C3D4E5")
+	other.Tag = types.StringValue("eng")
+	if diags := g.checkTeamIDAllowlist(ctx, client, other, nil); diags.HasError() {
+		t.Errorf("rule on another tag: %v", diags)
+	}
+	if client.listRulesCalls != 2 {
+		t.Errorf("listed rules %d times, want twice", client.listRulesCalls)
+	}
+}
+
+func TestRuleGuardrailsCheckTeamIDAllowlistSkipsExistingRules(t *testing.T) {
+	ctx := context.Background()
+	rule := RuleResourceModel{
+		Identifier: types.StringValue("EQHXZ8M8AV"),
+		RuleType:   types.StringValue("TEAMID"),
+		Policy:     types.StringValue("ALLOWLIST"),
+		Tag:        types.StringValue("global"),
+	}
+	// The tag is already over a lowered limit.
+	g := ruleGuardrails{MaxTeamIDAllowlistPerTag: 2, PlannedAllowlists: newPlannedAllowlists()}
+	client := &fakeWorkshopClient{listRules: teamIDAllowlist("A1B2C3D4E5", "UBF8T346G9", "ZMCG7MLDV9")}
+
+	prior := rule
+	prior.Comment = types.StringValue("before")
+	if diags := g.checkTeamIDAllowlist(ctx, client, rule, &prior); diags.HasError() || client.listRulesCalls != 0 {
+		t.Errorf("update of an existing allowlist rule = %v, listed rules %d times; want no check", diags, client.listRulesCalls)
+	}
+
+	// Moving the rule to another tag, or turning a blocklist rule into an
+	// allowlist, adds to the tag's count.
+	for _, p := range []RuleResourceModel{
+		{Identifier: rule.Identifier, RuleType: rule.RuleType, Policy: rule.Policy, Tag: types.StringValue("eng")},
+		{Identifier: rule.Identifier, RuleType: rule.RuleType, Policy: types.StringValue("BLOCKLIST"), Tag: rule.Tag},
+	} {
+		if diags := g.checkTeamIDAllowlist(ctx, client, rule, &p); !diags.HasError() {
+			t.Errorf("change from %s on %s should be checked", p.Policy, p.Tag)
+		}
+	}
+}
+
+func TestRuleGuardrailsCheckTeamIDAllowlistExcludesRemovedRules(t *testing.T) {
+	ctx := context.Background()
+	rule := func(identifier string) RuleResourceModel {
+		return RuleResourceModel{
+			Identifier: types.StringValue(identifier),
+			RuleType:   types.StringValue("TEAMID"),
+			Policy:     types.StringValue("ALLOWLIST"),
+			Tag:        types.StringValue("global"),
+		}
+	}
+	// The tag is at its limit of two.
+	g := ruleGuardrails{MaxTeamIDAllowlistPerTag: 2, PlannedAllowlists: newPlannedAllowlists()}
+	client := &fakeWorkshopClient{listRules: teamIDAllowlist("UBF8T346G9", "ZMCG7MLDV9")}
+
+	// Destroying one allowlisted Team ID makes room for another.
+	g.recordRemoval(rule("UBF8T346G9"), nil)
+	if diags := g.checkTeamIDAllowlist(ctx, client, rule("EQHXZ8M8AV"), nil); diags.HasError() {
+		t.Fatalf("rule replacing a destroyed one: %v", diags)
+	}
+
+	// Moving a rule to a blocklist makes room too, but changing only its
+	// comment doesn't.
+	blocklist := rule("ZMCG7MLDV9")
+	blocklist.Policy = types.StringValue("BLOCKLIST")
+	g.recordRemoval(rule("ZMCG7MLDV9"), &blocklist)
+	commented := rule("EQHXZ8M8AV")
+	commented.Comment = types.StringValue("after")
+	g.recordRemoval(rule("EQHXZ8M8AV"), &commented)
+	if diags := g.checkTeamIDAllowlist(ctx, client, rule("A1B2C3D4E5"), nil); diags.HasError() {
+		t.Errorf("rule replacing a blocklisted one: %v", diags)
+	}
+	if diags := g.checkTeamIDAllowlist(ctx, client, rule("B2C3D4E5F6"), nil); !diags.HasError() {
+		t.Error("third planned rule should exceed the limit")
+	}
+}
+
+func TestRuleGuardrailsCheckTrustedTeamID(t *testing.T) {
+	ctx := context.Background()
+	rule := RuleResourceModel{
+		Identifier: types.StringValue("EQHXZ8M8AV"),
+		RuleType:   types.StringValue("TEAMID"),
+		Policy:     types.StringValue("ALLOWLIST"),
+	}
+
+	// No registry configured: nothing is checked.
+	if diags := (ruleGuardrails{}).checkTrustedTeamID(ctx, rule); len(diags) != 0 {
+		t.Errorf("unconfigured registry reported %v", diags)
+	}
+
+	g := ruleGuardrails{TrustedTeamIDs: map[string]string{"EQHXZ8M8AV": "it@example.com"}}
+	if diags := g.checkTrustedTeamID(ctx, rule); len(diags) != 0 {
+		t.Errorf("registered Team ID reported %v", diags)
+	}
+
+	rule.Identifier = types.StringValue("UBF8T346G9")
+	if diags := g.checkTrustedTeamID(ctx, rule); diags.HasError() || diags.WarningsCount() != 1 {
+		t.Errorf("unregistered Team ID = %v, want one warning", diags)
+	}
+	g.UntrustedTeamIDError = true
+	if diags := g.checkTrustedTeamID(ctx, rule); !diags.HasError() {
+		t.Error("unregistered Team ID should be an error")
+	}
+
+	// Blocking rules and other rule types don't need registering.
+	for _, r := range []RuleResourceModel{
+		{Identifier: rule.Identifier, RuleType: rule.RuleType, Policy: types.StringValue("BLOCKLIST")},
+		{Identifier: types.StringValue("UBF8T346G9:com.example.app"), RuleType: types.StringValue("SIGNINGID"), Policy: rule.Policy},
+		{Identifier: types.StringUnknown(), RuleType: rule.RuleType, Policy: rule.Policy},
+	} {
+		if diags := g.checkTrustedTeamID(ctx, r); len(diags) != 0 {
+			t.Errorf("checkTrustedTeamID(%v) = %v, want no diagnostics", r, diags)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// platformSigningIDPrefix prefixes the signing IDs of Apple platform binaries,
+// which have no Team ID.
+const platformSigningIDPrefix = "platform"
+
+// normalizeIdentifier returns the form the server stores identifier in for a
+// rule of ruleType. Hashes are lower case and Team IDs upper case, including
+// the one a signing ID starts with, and the platform prefix of a signing ID is
+// lower case. The rest of a signing ID is case-sensitive and kept as is.
+func normalizeIdentifier(ruleType, identifier string) string {
+	switch ruleType {
+	case "BINARY", "CERTIFICATE", "CDHASH":
+		return normalizeHash(identifier)
+	case "TEAMID":
+		return strings.ToUpper(identifier)
+	case "SIGNINGID":
+		prefix, id, ok := strings.Cut(identifier, ":")
+		if !ok {
+			return identifier
+		}
+		if strings.EqualFold(prefix, platformSigningIDPrefix) {
+			return platformSigningIDPrefix + ":" + id
+		}
+		return strings.ToUpper(prefix) + ":" + id
+	}
+	return identifier
+}
+
+// normalizeHash returns the form the server stores a SHA-256 or CDHash in.
+func normalizeHash(hash string) string {
+	return strings.ToLower(hash)
+}
+
+// sameIdentifier reports whether a and b are the same identifier for a rule of
+// ruleType.
+func sameIdentifier(ruleType, a, b string) bool {
+	return normalizeIdentifier(ruleType, a) == normalizeIdentifier(ruleType, b)
+}
+
+// identifierRequiresReplace replaces a rule when its identifier changes, but
+// not when the change only rewrites it in another form of the same
+// identifier: the update then upserts the same rule.
+func identifierRequiresReplace() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			var ruleType types.String
+			resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("rule_type"), &ruleType)...)
+			resp.RequiresReplace = !sameIdentifier(ruleType.ValueString(), req.StateValue.ValueString(), req.PlanValue.ValueString())
+		},
+		"Changing the identifier replaces the rule, unless the new value is the same identifier written differently.",
+		"Changing the identifier replaces the rule, unless the new value is the same identifier written differently.",
+	)
+}
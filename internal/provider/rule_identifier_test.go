@@ -0,0 +1,93 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+func TestNormalizeIdentifier(t *testing.T) {
+	for _, c := range []struct {
+		ruleType, identifier, want string
+	}{
+		{"SIGNINGID", "eqhxz8m8av:com.google.Chrome", "EQHXZ8M8AV:com.google.Chrome"},
+		{"SIGNINGID", "EQHXZ8M8AV:com.google.Chrome", "EQHXZ8M8AV:com.google.Chrome"},
+		{"SIGNINGID", "Platform:com.apple.curl", "platform:com.apple.curl"},
+		{"SIGNINGID", "com.example.no-prefix", "com.example.no-prefix"},
+		{"TEAMID", "eqhxz8m8av", "EQHXZ8M8AV"},
+		{"CERTIFICATE", "ABCDEF0123", "abcdef0123"},
+		{"CDHASH", "A9F1C2", "a9f1c2"},
+		{"", "Mixed", "Mixed"},
+	} {
+		if got := normalizeIdentifier(c.ruleType, c.identifier); got != c.want {
+			t.Errorf("normalizeIdentifier(%s, %q) = %q, want %q", c.ruleType, c.identifier, got, c.want)
+		}
+	}
+}
+
+func TestBuildCreateRuleRequestNormalizesIdentifier(t *testing.T) {
+	data := RuleResourceModel{
+		Identifier: types.StringValue("eqhxz8m8av:com.google.Chrome"),
+		RuleType:   types.StringValue("SIGNINGID"),
+		Policy:     types.StringValue("ALLOWLIST"),
+		Tag:        types.StringValue("global"),
+	}
+	if got := buildCreateRuleRequest(data).GetRule().GetIdentifier(); got != "EQHXZ8M8AV:com.google.Chrome" {
+		t.Errorf("sent identifier %q, want the normalized form", got)
+	}
+}
+
+func TestApplyRuleProtoKeepsIdentifierForm(t *testing.T) {
+	rule := apipb.Rule_builder{
+		RuleId:     "rule-1",
+		Identifier: "EQHXZ8M8AV:com.google.Chrome",
+		RuleType:   apipb.RuleType_SIGNINGID,
+		Policy:     apipb.Policy_ALLOWLIST,
+		Tag:        "global",
+	}.Build()
+
+	var diags diag.Diagnostics
+	data := RuleResourceModel{Identifier: types.StringValue("eqhxz8m8av:com.google.Chrome")}
+	applyRuleProto(&data, rule, newReadDecoder(true, &diags))
+	if got := data.Identifier.ValueString(); got != "eqhxz8m8av:com.google.Chrome" {
+		t.Errorf("identifier = %q, want the configured form kept", got)
+	}
+
+	// A different identifier is refreshed from the server.
+	data = RuleResourceModel{Identifier: types.StringValue("EQHXZ8M8AV:com.google.Chrome.helper")}
+	applyRuleProto(&data, rule, newReadDecoder(true, &diags))
+	if got := data.Identifier.ValueString(); got != "EQHXZ8M8AV:com.google.Chrome" {
+		t.Errorf("identifier = %q, want the server's", got)
+	}
+	if diags.HasError() {
+		t.Fatalf("unexpected diags: %v", diags)
+	}
+}
+
+func TestBuildFileAccessRuleNormalizesHashes(t *testing.T) {
+	ctx := context.Background()
+	data := FileAccessRuleResourceModel{
+		ProcessCdHashes:           stringList("A9F1C2"),
+		ProcessCertificateSha256s: stringList("5A1B3C"),
+		ProcessTeamIds:            stringList("EQHXZ8M8AV"),
+	}
+	var diags diag.Diagnostics
+	rule := buildFileAccessRule(ctx, data, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diags: %v", diags)
+	}
+	if got := rule.GetProcessCdHashes(); len(got) != 1 || got[0] != "a9f1c2" {
+		t.Errorf("sent CDHashes %v, want lower case", got)
+	}
+	if got := rule.GetProcessCertificateSha256S(); len(got) != 1 || got[0] != "5a1b3c" {
+		t.Errorf("sent certificate hashes %v, want lower case", got)
+	}
+	if got := rule.GetProcessTeamIds(); len(got) != 1 || got[0] != "EQHXZ8M8AV" {
+		t.Errorf("sent Team IDs %v, want them unchanged", got)
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// attrUse is whether a rule policy takes one of the policy-specific
+// attributes.
+type attrUse int
+
+const (
+	attrForbidden attrUse = iota
+	attrOptional
+	attrRequired
+)
+
+// policyAttrs is which of nps_workshop_rule's policy-specific attributes a
+// policy takes. block_reason and silent have their own checks.
+type policyAttrs struct {
+	celExpr        attrUse
+	seatbeltPolicy attrUse
+	// blockMessage covers custom_msg and custom_url, which Santa shows when a
+	// rule blocks an execution.
+	blockMessage attrUse
+	// ruleTypes restricts the rule types the policy may be used with; nil
+	// allows every rule type.
+	ruleTypes []string
+}
+
+// rulePolicies is every policy nps_workshop_rule accepts, with the attributes
+// each takes. TestRulePoliciesCoverPinnedEnum fails when a pinned Policy is
+// missing, so a policy added to the API is reviewed here rather than passed
+// through unvalidated.
+var rulePolicies = map[string]policyAttrs{
+	"ALLOWLIST": {blockMessage: attrOptional},
+	// A Team ID or certificate would make every binary it signs a compiler.
+	"ALLOWLIST_COMPILER":   {blockMessage: attrOptional, ruleTypes: []string{"BINARY", "SIGNINGID", "CDHASH"}},
+	"BLOCKLIST":            {blockMessage: attrOptional},
+	"SILENT_BLOCKLIST":     {blockMessage: attrOptional},
+	"SILENT_GUI_BLOCKLIST": {blockMessage: attrOptional},
+	"SILENT_TTY_BLOCKLIST": {blockMessage: attrOptional},
+	// REMOVE deletes a rule Santa already has; nothing is ever blocked by it.
+	"REMOVE": {},
+	// A CEL expression can return SEATBELT; validateCELExpr asks the server
+	// whether seatbelt_policy is then required.
+	"CEL":      {celExpr: attrRequired, seatbeltPolicy: attrOptional, blockMessage: attrOptional},
+	"SEATBELT": {seatbeltPolicy: attrRequired, blockMessage: attrOptional},
+}
+
+// rulePolicyNames returns the policies nps_workshop_rule accepts, in the
+// order of the Policy enum.
+func rulePolicyNames() []string {
+	return slices.DeleteFunc(enumValues(apipb.Policy(0).Descriptor()), func(p string) bool {
+		_, ok := rulePolicies[p]
+		return !ok
+	})
+}
+
+// validatePolicyAttributes checks the policy-specific attributes of data
+// against its policy. Values interpolated from other resources are unknown at
+// validate time and are left to a later plan.
+func validatePolicyAttributes(data RuleResourceModel, diags *diag.Diagnostics) {
+	if data.Policy.IsUnknown() || data.Policy.IsNull() {
+		return
+	}
+	policy := data.Policy.ValueString()
+	attrs, ok := rulePolicies[policy]
+	if !ok {
+		// The policy's own validator reports it.
+		return
+	}
+
+	for _, attr := range []struct {
+		name  string
+		value types.String
+	}{
+		{"cel_expr", data.CELExpr},
+		{"seatbelt_policy", data.SeatbeltPolicy},
+		{"custom_msg", data.CustomMsg},
+		{"custom_url", data.CustomURL},
+	} {
+		if attr.value.IsUnknown() {
+			continue
+		}
+		set := attr.value.ValueString() != ""
+		switch use := attrs.use(attr.name); {
+		case use == attrRequired && !set:
+			diags.AddAttributeError(
+				path.Root(attr.name),
+				codeInvalidConfig.summary(fmt.Sprintf("%s is required", attr.name)),
+				fmt.Sprintf("%s is required when policy is set to %s", attr.name, policy),
+			)
+		case use == attrForbidden && set:
+			diags.AddAttributeError(
+				path.Root(attr.name),
+				codeInvalidConfig.summary(fmt.Sprintf("%s is not valid for %s rules", attr.name, policy)),
+				fmt.Sprintf("%s can't be set when policy is %s; it is only used by %s.", attr.name, policy, policiesTaking(attr.name)),
+			)
+		}
+	}
+
+	if attrs.ruleTypes != nil && !data.RuleType.IsUnknown() && !data.RuleType.IsNull() && !slices.Contains(attrs.ruleTypes, data.RuleType.ValueString()) {
+		diags.AddAttributeError(
+			path.Root("rule_type"),
+			codeInvalidConfig.summary(fmt.Sprintf("Unsupported rule type for %s", policy)),
+			fmt.Sprintf("%s rules must have rule_type %s, not %s.", policy, joinOr(attrs.ruleTypes), data.RuleType.ValueString()),
+		)
+	}
+}
+
+// use returns whether the policy takes attr.
+func (a policyAttrs) use(attr string) attrUse {
+	switch attr {
+	case "cel_expr":
+		return a.celExpr
+	case "seatbelt_policy":
+		return a.seatbeltPolicy
+	}
+	return a.blockMessage
+}
+
+// policiesTaking returns the policies that take attr, for error messages.
+func policiesTaking(attr string) string {
+	var policies []string
+	for _, p := range rulePolicyNames() {
+		if rulePolicies[p].use(attr) != attrForbidden {
+			policies = append(policies, p)
+		}
+	}
+	return joinOr(policies)
+}
+
+// joinOr joins values as "A, B, or C".
+func joinOr(values []string) string {
+	if len(values) <= 2 {
+		return strings.Join(values, " or ")
+	}
+	return strings.Join(values[:len(values)-1], ", ") + ", or " + values[len(values)-1]
+}
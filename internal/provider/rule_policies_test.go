@@ -0,0 +1,71 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+func TestRulePoliciesCoverPinnedEnum(t *testing.T) {
+	for _, p := range enumValues(apipb.Policy(0).Descriptor()) {
+		if _, ok := rulePolicies[p]; !ok && p != apipb.Policy_POLICY_UNKNOWN.String() {
+			t.Errorf("policy %s is pinned but missing from rulePolicies; decide which attributes it takes", p)
+		}
+	}
+	if slices.Contains(rulePolicyNames(), apipb.Policy_POLICY_UNKNOWN.String()) {
+		t.Error("POLICY_UNKNOWN is accepted")
+	}
+}
+
+func TestValidatePolicyAttributes(t *testing.T) {
+	cases := []struct {
+		name   string
+		policy string
+		cel    types.String
+		sb     types.String
+		msg    types.String
+		errors int
+	}{
+		{"CEL with an expression", "CEL", types.StringValue("true"), types.StringNull(), types.StringNull(), 0},
+		{"CEL without an expression", "CEL", types.StringNull(), types.StringNull(), types.StringNull(), 1},
+		{"CEL with an unknown expression", "CEL", types.StringUnknown(), types.StringNull(), types.StringNull(), 0},
+		{"CEL with a seatbelt policy", "CEL", types.StringValue("true"), types.StringValue("(version 1)"), types.StringNull(), 0},
+		{"SEATBELT without a seatbelt policy", "SEATBELT", types.StringNull(), types.StringNull(), types.StringNull(), 1},
+		{"SEATBELT with an expression", "SEATBELT", types.StringValue("true"), types.StringValue("(version 1)"), types.StringNull(), 1},
+		{"expression on a blocklist rule", "BLOCKLIST", types.StringValue("true"), types.StringNull(), types.StringValue("Blocked"), 1},
+		{"seatbelt policy on an allowlist rule", "ALLOWLIST", types.StringNull(), types.StringValue("(version 1)"), types.StringNull(), 1},
+		{"message on a GUI-silent rule", "SILENT_GUI_BLOCKLIST", types.StringNull(), types.StringNull(), types.StringValue("Blocked"), 0},
+		{"plain REMOVE", "REMOVE", types.StringNull(), types.StringNull(), types.StringNull(), 0},
+		{"message on REMOVE", "REMOVE", types.StringNull(), types.StringNull(), types.StringValue("Removed"), 1},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			validatePolicyAttributes(RuleResourceModel{
+				Policy:         types.StringValue(c.policy),
+				RuleType:       types.StringValue("SIGNINGID"),
+				CELExpr:        c.cel,
+				SeatbeltPolicy: c.sb,
+				CustomMsg:      c.msg,
+				CustomURL:      types.StringNull(),
+			}, &diags)
+			if diags.ErrorsCount() != c.errors {
+				t.Errorf("got %d errors, want %d: %v", diags.ErrorsCount(), c.errors, diags)
+			}
+		})
+	}
+
+	var diags diag.Diagnostics
+	validatePolicyAttributes(RuleResourceModel{
+		Policy:  types.StringValue("REMOVE"),
+		CELExpr: types.StringValue("true"),
+	}, &diags)
+	if want := "cel_expr can't be set when policy is REMOVE; it is only used by CEL."; diags.ErrorsCount() != 1 || diags[0].Detail() != want {
+		t.Errorf("diagnostics = %v, want %q", diags, want)
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/northpolesec/terraform-provider-nps/internal/filter"
+	"google.golang.org/protobuf/proto"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+const (
+	// ruleReadBatchWindow is how long the first lookup for a tag waits for
+	// others to join its batch. Terraform refreshes resources concurrently,
+	// so during a refresh the lookups for a tag arrive within milliseconds.
+	ruleReadBatchWindow = 10 * time.Millisecond
+
+	// ruleReadBatchMaxFilter bounds the length of a batch's filter; a lookup
+	// that would exceed it sends the batch and starts another.
+	ruleReadBatchMaxFilter = 4096
+
+	// ruleReadSnapshotThreshold is how many lookups a tag must see before its
+	// rules are fetched whole. Terraform only refreshes a few resources at a
+	// time (10 by default), so batches of a large tag stay small; past this
+	// many lookups, listing the tag once is cheaper than the remaining batches.
+	ruleReadSnapshotThreshold = 50
+
+	// ruleReadSnapshotTTL is how long a tag's snapshot answers lookups. It
+	// only has to outlast a refresh; writes through the provider drop it
+	// sooner.
+	ruleReadSnapshotTTL = time.Minute
+)
+
+// ruleReadBatcher coalesces the lookups nps_workshop_rule's Read makes into
+// one paged ListRules call per tag, instead of one call per rule. Once a tag
+// has seen many lookups, it lists the whole tag once and answers the rest of
+// the refresh from that snapshot. It is shared by every rule resource of a
+// provider configuration and is safe for concurrent use.
+type ruleReadBatcher struct {
+	client            svcpb.WorkshopServiceClient
+	window            time.Duration
+	maxFilter         int
+	snapshotThreshold int
+	snapshotTTL       time.Duration
+
+	mu        sync.Mutex
+	pending   map[string]*ruleReadBatch    // by tag
+	lookups   map[string]int               // by tag, since its last snapshot
+	snapshots map[string]*ruleReadSnapshot // by tag
+}
+
+func newRuleReadBatcher(client svcpb.WorkshopServiceClient) *ruleReadBatcher {
+	return &ruleReadBatcher{
+		client:            client,
+		window:            ruleReadBatchWindow,
+		maxFilter:         ruleReadBatchMaxFilter,
+		snapshotThreshold: ruleReadSnapshotThreshold,
+		snapshotTTL:       ruleReadSnapshotTTL,
+		pending:           map[string]*ruleReadBatch{},
+		lookups:           map[string]int{},
+		snapshots:         map[string]*ruleReadSnapshot{},
+	}
+}
+
+// ruleLookup is what Read knows about a rule: its last ID, and its natural
+// key, which finds it again after an update replaced the ID.
+type ruleLookup struct {
+	id, identifier, ruleType, tag string
+}
+
+// clause is the lookup's part of its batch's filter, less the tag. Its length
+// stands in for the lookup's share of the filter.
+func (l ruleLookup) clause() filter.Expr {
+	return filter.Or(
+		filter.Eq("rule_id", l.id),
+		filter.And(filter.Eq("identifier", l.identifier), filter.Eq("rule_type", l.ruleType)),
+	)
+}
+
+// match returns the rule among rules that l finds: the one with its ID if
+// there is one, otherwise the one with its natural key.
+func (l ruleLookup) match(rules []*apipb.Rule) *apipb.Rule {
+	for _, rule := range rules {
+		if rule.GetRuleId() == l.id {
+			return rule
+		}
+	}
+	for _, rule := range rules {
+		if rule.GetIdentifier() == l.identifier && rule.GetRuleType().String() == l.ruleType && rule.GetTag() == l.tag {
+			return rule
+		}
+	}
+	return nil
+}
+
+// ruleReadBatch is the lookups for one tag that share a ListRules call.
+type ruleReadBatch struct {
+	tag       string
+	lookups   []ruleLookup
+	filterLen int
+	sent      bool
+
+	done  chan struct{}
+	rules []*apipb.Rule
+	err   error
+}
+
+// ruleReadSnapshot is every rule of one tag, listed at fetched.
+type ruleReadSnapshot struct {
+	fetched time.Time
+
+	done  chan struct{}
+	rules []*apipb.Rule
+	err   error
+}
+
+// Lookup finds the rule l describes, or returns nil if it no longer exists.
+func (b *ruleReadBatcher) Lookup(ctx context.Context, l ruleLookup) (*apipb.Rule, error) {
+	if snap := b.snapshot(ctx, l.tag); snap != nil {
+		select {
+		case <-snap.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if snap.err == nil {
+			if rule := l.match(snap.rules); rule != nil {
+				return rule, nil
+			}
+		}
+		// The rule may have been deleted or moved to another tag, which only
+		// its ID finds; fall back to a batch.
+	}
+
+	n := len(l.clause().String())
+
+	b.mu.Lock()
+	batch := b.pending[l.tag]
+	if batch != nil && len(batch.lookups) > 0 && batch.filterLen+n > b.maxFilter {
+		b.sendLocked(ctx, batch)
+		batch = nil
+	}
+	if batch == nil {
+		batch = &ruleReadBatch{tag: l.tag, done: make(chan struct{})}
+		b.pending[l.tag] = batch
+		time.AfterFunc(b.window, func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			b.sendLocked(ctx, batch)
+		})
+	}
+	batch.lookups = append(batch.lookups, l)
+	batch.filterLen += n
+	b.mu.Unlock()
+
+	select {
+	case <-batch.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if batch.err != nil {
+		return nil, batch.err
+	}
+	return l.match(batch.rules), nil
+}
+
+// snapshot returns the snapshot that answers lookups for tag, fetching one in
+// the background once the tag has seen snapshotThreshold lookups, or nil if
+// the lookup should be batched.
+func (b *ruleReadBatcher) snapshot(ctx context.Context, tag string) *ruleReadSnapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if snap := b.snapshots[tag]; snap != nil && time.Since(snap.fetched) < b.snapshotTTL {
+		return snap
+	}
+	b.lookups[tag]++
+	if b.snapshotThreshold == 0 || b.lookups[tag] < b.snapshotThreshold {
+		return nil
+	}
+	b.lookups[tag] = 0
+
+	snap := &ruleReadSnapshot{fetched: time.Now(), done: make(chan struct{})}
+	b.snapshots[tag] = snap
+	query := filter.Eq("tag", tag).String()
+
+	// As with batches, the call outlives the lookup that starts it.
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		defer close(snap.done)
+		pages := listPages(ctx, "rules", func(page uint32) ([]*apipb.Rule, bool, error) {
+			ret, err := b.client.ListRules(ctx, apipb.ListRulesRequest_builder{
+				Filter:   proto.String(query),
+				PageSize: proto.Int32(listPageSize),
+				Page:     proto.Int32(int32(page)),
+			}.Build())
+			return ret.GetRules(), ret.GetMore(), err
+		})
+		for rule, err := range pages {
+			if err != nil {
+				snap.err = err
+				return
+			}
+			snap.rules = append(snap.rules, rule)
+		}
+	}()
+	return snap
+}
+
+// Invalidate drops the snapshot of tag, so that lookups after a write to one
+// of its rules see the write.
+func (b *ruleReadBatcher) Invalidate(tag string) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.snapshots, tag)
+}
+
+// sendLocked sends batch, unless it has been already, in the background. b.mu
+// must be held. The call outlives the lookup that happens to send it, so it
+// isn't cancelled with that lookup.
+func (b *ruleReadBatcher) sendLocked(ctx context.Context, batch *ruleReadBatch) {
+	if batch.sent {
+		return
+	}
+	batch.sent = true
+	if b.pending[batch.tag] == batch {
+		delete(b.pending, batch.tag)
+	}
+
+	ids := make([]filter.Expr, 0, len(batch.lookups))
+	keys := make([]filter.Expr, 0, len(batch.lookups))
+	for _, l := range batch.lookups {
+		ids = append(ids, filter.Eq("rule_id", l.id))
+		keys = append(keys, filter.And(filter.Eq("identifier", l.identifier), filter.Eq("rule_type", l.ruleType)))
+	}
+	query := filter.Or(append(ids, filter.And(filter.Eq("tag", batch.tag), filter.Or(keys...)))...).String()
+
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		defer close(batch.done)
+		pages := listPages(ctx, "rules", func(page uint32) ([]*apipb.Rule, bool, error) {
+			ret, err := b.client.ListRules(ctx, apipb.ListRulesRequest_builder{
+				Filter:   proto.String(query),
+				PageSize: proto.Int32(listPageSize),
+				Page:     proto.Int32(int32(page)),
+			}.Build())
+			return ret.GetRules(), ret.GetMore(), err
+		})
+		for rule, err := range pages {
+			if err != nil {
+				batch.err = err
+				return
+			}
+			batch.rules = append(batch.rules, rule)
+		}
+	}()
+}
@@ -0,0 +1,169 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// batchRulesClient serves ListRules from rules, ignoring the filter, and
+// records each call's filter. Unlike fakeWorkshopClient it is safe for the
+// concurrent calls batches make.
+type batchRulesClient struct {
+	svcpb.WorkshopServiceClient
+
+	rules []*apipb.Rule
+
+	mu      sync.Mutex
+	filters []string
+}
+
+func (c *batchRulesClient) ListRules(ctx context.Context, in *apipb.ListRulesRequest, _ ...grpc.CallOption) (*apipb.ListRulesResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.filters = append(c.filters, in.GetFilter())
+	return apipb.ListRulesResponse_builder{Rules: c.rules, More: proto.Bool(false)}.Build(), nil
+}
+
+func testBatchRule(id, identifier, tag string) *apipb.Rule {
+	return apipb.Rule_builder{
+		RuleId:     id,
+		Identifier: identifier,
+		RuleType:   apipb.RuleType_BINARY,
+		Policy:     apipb.Policy_BLOCKLIST,
+		Tag:        tag,
+	}.Build()
+}
+
+// lookupAll runs the lookups concurrently, as a refresh does, and returns the
+// rule IDs found, "" for none.
+func lookupAll(t *testing.T, b *ruleReadBatcher, lookups []ruleLookup) []string {
+	t.Helper()
+	got := make([]string, len(lookups))
+	var wg sync.WaitGroup
+	for i, l := range lookups {
+		wg.Go(func() {
+			rule, err := b.Lookup(context.Background(), l)
+			if err != nil {
+				t.Errorf("Lookup(%v) error: %v", l, err)
+				return
+			}
+			got[i] = rule.GetRuleId()
+		})
+	}
+	wg.Wait()
+	return got
+}
+
+func TestRuleReadBatcher(t *testing.T) {
+	client := &batchRulesClient{rules: []*apipb.Rule{
+		testBatchRule("r1", "aaa", "global"),
+		testBatchRule("r2-new", "bbb", "global"),
+		testBatchRule("r3", "ccc", "dev"),
+	}}
+	b := newRuleReadBatcher(client)
+
+	got := lookupAll(t, b, []ruleLookup{
+		{id: "r1", identifier: "aaa", ruleType: "BINARY", tag: "global"},
+		// Updated: the old ID is gone, but the natural key finds the rule.
+		{id: "r2-old", identifier: "bbb", ruleType: "BINARY", tag: "global"},
+		// Deleted.
+		{id: "r9", identifier: "zzz", ruleType: "BINARY", tag: "global"},
+		{id: "r3", identifier: "ccc", ruleType: "BINARY", tag: "dev"},
+	})
+	if want := []string{"r1", "r2-new", "", "r3"}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("found %q, want %q", got, want)
+	}
+
+	// One call per tag.
+	if len(client.filters) != 2 {
+		t.Fatalf("made %d ListRules calls, want 2: %q", len(client.filters), client.filters)
+	}
+	for _, f := range client.filters {
+		if !strings.Contains(f, `tag = "global"`) {
+			continue
+		}
+		// Lookups join the batch in whatever order they arrive.
+		for _, clause := range []string{`rule_id = "r2-old" OR `, `(tag = "global" AND (`, `(identifier = "bbb" AND rule_type = "BINARY")`} {
+			if !strings.Contains(f, clause) {
+				t.Errorf("filter %s lacks %s", f, clause)
+			}
+		}
+	}
+}
+
+func TestRuleReadBatcherChunks(t *testing.T) {
+	client := &batchRulesClient{}
+	b := newRuleReadBatcher(client)
+	b.maxFilter = 200
+
+	var lookups []ruleLookup
+	for i := range 10 {
+		lookups = append(lookups, ruleLookup{id: fmt.Sprintf("r%d", i), identifier: fmt.Sprintf("id%d", i), ruleType: "BINARY", tag: "global"})
+	}
+	lookupAll(t, b, lookups)
+
+	if len(client.filters) < 2 {
+		t.Fatalf("made %d ListRules calls, want the batch split", len(client.filters))
+	}
+	for _, f := range client.filters {
+		// The limit bounds the lookup clauses, not the shared tag clause.
+		if len(f) > b.maxFilter+len(`(tag = "global" AND ())`) {
+			t.Errorf("filter of %d bytes exceeds the limit: %s", len(f), f)
+		}
+	}
+}
+
+func TestRuleReadBatcherSnapshot(t *testing.T) {
+	client := &batchRulesClient{rules: []*apipb.Rule{
+		testBatchRule("r1", "aaa", "global"),
+		testBatchRule("r2", "bbb", "global"),
+	}}
+	b := newRuleReadBatcher(client)
+	b.snapshotThreshold = 3
+
+	lookup := func(l ruleLookup) string {
+		t.Helper()
+		rule, err := b.Lookup(context.Background(), l)
+		if err != nil {
+			t.Fatalf("Lookup(%v) error: %v", l, err)
+		}
+		return rule.GetRuleId()
+	}
+	r1 := ruleLookup{id: "r1", identifier: "aaa", ruleType: "BINARY", tag: "global"}
+
+	// One at a time, as a refresh at low parallelism looks rules up: the
+	// third lookup lists the tag, and later ones are answered from it.
+	for range 6 {
+		if got := lookup(r1); got != "r1" {
+			t.Fatalf("found %q, want r1", got)
+		}
+	}
+	if len(client.filters) != 3 || client.filters[2] != `tag = "global"` {
+		t.Fatalf("ListRules filters = %q, want two batches and then the tag", client.filters)
+	}
+
+	// A rule missing from the snapshot is looked up by ID.
+	if got := lookup(ruleLookup{id: "r9", identifier: "zzz", ruleType: "BINARY", tag: "global"}); got != "" {
+		t.Errorf("found %q for a deleted rule", got)
+	}
+	if len(client.filters) != 4 || !strings.Contains(client.filters[3], `rule_id = "r9"`) {
+		t.Errorf("ListRules filters = %q, want a batch for the missing rule", client.filters)
+	}
+
+	// A write drops the snapshot.
+	b.Invalidate("global")
+	lookup(r1)
+	if len(client.filters) != 5 || client.filters[4] == `tag = "global"` {
+		t.Errorf("ListRules filters = %q, want a batch after the write", client.filters)
+	}
+}
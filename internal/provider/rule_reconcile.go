@@ -0,0 +1,77 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// reconcileRule re-applies data, a rule's prior state, if rule, the server's
+// copy, has drifted from it. It returns the rule's new ID and the attributes
+// that had drifted, or a null ID if nothing had. Attributes in the rule's
+// ignore_server_changes neither count as drift nor are overwritten: the
+// upsert sends the server's values for them.
+func (r *RuleResource) reconcileRule(ctx context.Context, data RuleResourceModel, rule *apipb.Rule) (types.String, []string, diag.Diagnostics) {
+	desired := data
+	for _, name := range data.IgnoreServerChanges {
+		switch name {
+		case "comment":
+			desired.Comment = emptyStringToNull(rule.GetComment())
+		case "custom_msg":
+			desired.CustomMsg = emptyStringToNull(rule.GetCustomMsg())
+		case "custom_url":
+			desired.CustomURL = emptyStringToNull(rule.GetCustomUrl())
+		}
+	}
+
+	drifted := ruleDrift(buildCreateRuleRequest(desired).GetRule(), rule)
+	if len(drifted) == 0 {
+		return types.StringNull(), nil, nil
+	}
+	newID, diags := r.upsertRule(ctx, desired)
+	return newID, drifted, diags
+}
+
+// ruleDrift returns the attributes Terraform manages whose values differ
+// between want and got, two rules with the same natural key.
+func ruleDrift(want, got *apipb.Rule) []string {
+	var drifted []string
+	if want.GetPolicy() != got.GetPolicy() {
+		drifted = append(drifted, "policy")
+	}
+	if effectiveBlockReason(want) != effectiveBlockReason(got) {
+		drifted = append(drifted, "block_reason")
+	}
+	for _, f := range []struct {
+		name      string
+		want, got string
+	}{
+		{"comment", want.GetComment(), got.GetComment()},
+		{"custom_msg", want.GetCustomMsg(), got.GetCustomMsg()},
+		{"custom_url", want.GetCustomUrl(), got.GetCustomUrl()},
+		{"cel_expr", want.GetCelExpr(), got.GetCelExpr()},
+		{"seatbelt_policy", want.GetSeatbeltPolicy(), got.GetSeatbeltPolicy()},
+	} {
+		if f.want != f.got {
+			drifted = append(drifted, f.name)
+		}
+	}
+	return drifted
+}
+
+// effectiveBlockReason returns the block reason the server applies to rule:
+// an unspecified reason on a blocklist rule is BLOCK_REASON_POLICY, and other
+// rules have none.
+func effectiveBlockReason(rule *apipb.Rule) apipb.Rule_BlockReason {
+	if !isBlocklistPolicy(rule.GetPolicy().String()) {
+		return apipb.Rule_BLOCK_REASON_UNSPECIFIED
+	}
+	if rule.GetBlockReason() == apipb.Rule_BLOCK_REASON_UNSPECIFIED {
+		return apipb.Rule_BLOCK_REASON_POLICY
+	}
+	return rule.GetBlockReason()
+}
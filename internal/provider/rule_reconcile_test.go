@@ -0,0 +1,101 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+func reconcileState() RuleResourceModel {
+	return RuleResourceModel{
+		Identifier:  types.StringValue("EQHXZ8M8AV"),
+		RuleType:    types.StringValue("TEAMID"),
+		Policy:      types.StringValue("BLOCKLIST"),
+		Silent:      types.BoolValue(false),
+		BlockReason: types.StringValue("BLOCK_REASON_POLICY"),
+		Tag:         types.StringValue("global"),
+		Comment:     types.StringValue("baseline"),
+		Id:          types.StringValue("rule-1"),
+	}
+}
+
+func TestReconcileRuleUnchanged(t *testing.T) {
+	fake := &fakeWorkshopClient{}
+	r := &RuleResource{client: fake}
+
+	// The server leaves the default block reason unspecified.
+	server := apipb.Rule_builder{
+		RuleId:     "rule-1",
+		Identifier: "EQHXZ8M8AV",
+		RuleType:   apipb.RuleType_TEAMID,
+		Policy:     apipb.Policy_BLOCKLIST,
+		Tag:        "global",
+		Comment:    "baseline",
+	}.Build()
+	newID, drifted, diags := r.reconcileRule(context.Background(), reconcileState(), server)
+	if diags.HasError() {
+		t.Fatalf("unexpected error diags: %v", diags)
+	}
+	if !newID.IsNull() || drifted != nil || fake.created {
+		t.Errorf("reconciled an unchanged rule: id %s, drifted %v", newID, drifted)
+	}
+}
+
+func TestReconcileRuleDrifted(t *testing.T) {
+	fake := &fakeWorkshopClient{}
+	r := &RuleResource{client: fake}
+
+	server := apipb.Rule_builder{
+		RuleId:     "rule-2",
+		Identifier: "EQHXZ8M8AV",
+		RuleType:   apipb.RuleType_TEAMID,
+		Policy:     apipb.Policy_ALLOWLIST,
+		Tag:        "global",
+		Comment:    "temporarily allowed",
+	}.Build()
+	newID, drifted, diags := r.reconcileRule(context.Background(), reconcileState(), server)
+	if diags.HasError() {
+		t.Fatalf("unexpected error diags: %v", diags)
+	}
+	if newID.ValueString() != "rule-new" {
+		t.Errorf("new ID = %s, want rule-new", newID)
+	}
+	if got, want := strings.Join(drifted, ","), "policy,block_reason,comment"; got != want {
+		t.Errorf("drifted = %s, want %s", got, want)
+	}
+	sent := fake.lastCreateReq.GetRule()
+	if sent.GetPolicy() != apipb.Policy_BLOCKLIST || sent.GetComment() != "baseline" {
+		t.Errorf("re-applied policy %v, comment %q; want the state's", sent.GetPolicy(), sent.GetComment())
+	}
+}
+
+func TestReconcileRuleIgnoresServerChanges(t *testing.T) {
+	fake := &fakeWorkshopClient{}
+	r := &RuleResource{client: fake}
+
+	state := reconcileState()
+	state.IgnoreServerChanges = []string{"comment"}
+	server := apipb.Rule_builder{
+		RuleId:     "rule-1",
+		Identifier: "EQHXZ8M8AV",
+		RuleType:   apipb.RuleType_TEAMID,
+		Policy:     apipb.Policy_SILENT_BLOCKLIST,
+		Tag:        "global",
+		Comment:    "edited in the UI",
+	}.Build()
+	_, drifted, diags := r.reconcileRule(context.Background(), state, server)
+	if diags.HasError() {
+		t.Fatalf("unexpected error diags: %v", diags)
+	}
+	if got := strings.Join(drifted, ","); got != "policy" {
+		t.Errorf("drifted = %s, want policy", got)
+	}
+	if got := fake.lastCreateReq.GetRule().GetComment(); got != "edited in the UI" {
+		t.Errorf("re-applied comment %q, want the server's", got)
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// Besides the tags created in Workshop and the built-in global tag, a rule can
+// be scoped to a single host or user with a tag Workshop manages itself:
+// host:<machine ID> or user:<email>.
+const (
+	hostTagPrefix  = "host:"
+	userTagPrefix  = "user:"
+	scopedTagUsage = "Scoped tags are written host:<machine ID>, with the machine_id of an nps_workshop_host, or user:<email>."
+)
+
+// splitScopedTag returns the prefix and the host or user a scoped tag names,
+// or ok false if tag isn't scoped.
+func splitScopedTag(tag string) (prefix, name string, ok bool) {
+	for _, prefix := range []string{hostTagPrefix, userTagPrefix} {
+		if name, ok := strings.CutPrefix(tag, prefix); ok {
+			return prefix, name, true
+		}
+	}
+	return "", "", false
+}
+
+// ruleTagValidator checks the form of host: and user: scoped tags. Other tags
+// are left to the server, which rejects tags that don't exist.
+type ruleTagValidator struct{}
+
+func (v ruleTagValidator) Description(ctx context.Context) string {
+	return "must be global, a Workshop tag, host:<machine ID>, or user:<email>"
+}
+
+func (v ruleTagValidator) MarkdownDescription(ctx context.Context) string {
+	return "must be `global`, a Workshop tag, `host:<machine ID>`, or `user:<email>`"
+}
+
+func (v ruleTagValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if problem := scopedTagProblem(req.ConfigValue.ValueString()); problem != "" {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			codeInvalidConfig.summary("Invalid scoped tag"),
+			problem+" "+scopedTagUsage,
+		)
+	}
+}
+
+// scopedTagProblem describes what is wrong with a scoped tag, or returns "" if
+// tag is well formed or not scoped.
+func scopedTagProblem(tag string) string {
+	prefix, name, ok := splitScopedTag(tag)
+	switch {
+	case !ok:
+		return ""
+	case name == "":
+		return fmt.Sprintf("The tag %q doesn't name a host or user.", tag)
+	case strings.ContainsFunc(name, unicode.IsSpace):
+		return fmt.Sprintf("The tag %q contains whitespace.", tag)
+	case prefix == userTagPrefix:
+		if local, domain, ok := strings.Cut(name, "@"); !ok || local == "" || domain == "" {
+			return fmt.Sprintf("The tag %q doesn't name a user by email address.", tag)
+		}
+	}
+	return ""
+}
+
+// checkScopedTagExists looks up the host or user a scoped tag names, so that a
+// rule for a mistyped machine ID or email fails at plan rather than applying
+// to no one. It is a no-op for tags that aren't scoped.
+func checkScopedTagExists(ctx context.Context, client svcpb.WorkshopServiceClient, tag string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	prefix, name, ok := splitScopedTag(tag)
+	if !ok || scopedTagProblem(tag) != "" {
+		return diags
+	}
+
+	switch prefix {
+	case hostTagPrefix:
+		_, err := client.GetHost(ctx, apipb.GetHostRequest_builder{Uuid: proto.String(name)}.Build())
+		if err == nil {
+			return diags
+		}
+		if status.Code(err) != codes.NotFound {
+			diags.AddAttributeError(path.Root("tag"), clientError(err), fmt.Sprintf("Failed to look up the host of tag %q: %v", tag, err))
+			return diags
+		}
+		detail := fmt.Sprintf("No Workshop host has the machine ID %q, so the rule would apply to no host.", name)
+		// Serial numbers are the usual mix-up; point at the right tag.
+		if host, err := findHostBySerial(ctx, client, name); err == nil && host != nil {
+			detail = fmt.Sprintf("%q is the serial number of the host with machine ID %q; host tags name the machine ID, e.g. %q.", name, host.GetUuid(), hostTagPrefix+host.GetUuid())
+		}
+		diags.AddAttributeError(path.Root("tag"), codeNotFound.summary("Host not found"), detail)
+	case userTagPrefix:
+		user, err := findUser(ctx, client, name)
+		if err != nil {
+			diags.AddAttributeError(path.Root("tag"), clientError(err), fmt.Sprintf("Failed to look up the user of tag %q: %v", tag, err))
+			return diags
+		}
+		if user == nil {
+			diags.AddAttributeError(path.Root("tag"), codeNotFound.summary("User not found"),
+				fmt.Sprintf("No Workshop user has the email address %q, so the rule would apply to no host.", name))
+		}
+	}
+	return diags
+}
@@ -0,0 +1,59 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+func TestScopedTagProblem(t *testing.T) {
+	for _, c := range []struct {
+		tag string
+		ok  bool
+	}{
+		{"global", true},
+		{"engineering", true},
+		{"host:7E9A8B2C-1D3F-4A5B-9C6D-0E1F2A3B4C5D", true},
+		{"user:alice@example.com", true},
+		{"host:", false},
+		{"host:C02X K0AA", false},
+		{"user:alice", false},
+		{"user:@example.com", false},
+	} {
+		if got := scopedTagProblem(c.tag); (got == "") != c.ok {
+			t.Errorf("scopedTagProblem(%q) = %q, want ok=%t", c.tag, got, c.ok)
+		}
+	}
+}
+
+func TestCheckScopedTagExists(t *testing.T) {
+	ctx := context.Background()
+	host := apipb.Host_builder{Uuid: "7E9A8B2C", Serial: "C02XK0AAJG5H"}.Build()
+	client := &fakeWorkshopClient{
+		host:      host,
+		listHosts: []*apipb.Host{host},
+		listUsers: []*apipb.User{apipb.User_builder{Username: "alice@example.com"}.Build()},
+	}
+
+	for _, tag := range []string{"global", "engineering", "host:7E9A8B2C", "user:Alice@example.com"} {
+		if diags := checkScopedTagExists(ctx, client, tag); diags.HasError() {
+			t.Errorf("checkScopedTagExists(%q) failed: %v", tag, diags)
+		}
+	}
+
+	diags := checkScopedTagExists(ctx, client, "host:C02XK0AAJG5H")
+	if !diags.HasError() {
+		t.Fatal("host tag naming a serial number was accepted")
+	}
+	if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, `"host:7E9A8B2C"`) {
+		t.Errorf("detail %q doesn't suggest the machine ID tag", detail)
+	}
+
+	diags = checkScopedTagExists(ctx, client, "user:bob@example.com")
+	if !diags.HasError() || diags.Errors()[0].Summary() != "[NPS001 NotFound] User not found" {
+		t.Errorf("missing user: got %v", diags)
+	}
+}
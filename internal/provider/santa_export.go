@@ -0,0 +1,146 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/northpolesec/terraform-provider-nps/internal/filter"
+	"google.golang.org/protobuf/proto"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// The formats -export-santa-rules can write.
+const (
+	santaRulesJSON  = "json"
+	santaRulesPlist = "plist"
+)
+
+// santaRule is a rule in Santa's static rule format, as read from the
+// StaticRules configuration key and by santactl rule --import. Workshop's
+// policy and rule type names are the same as Santa's.
+type santaRule struct {
+	Identifier string `json:"identifier"`
+	RuleType   string `json:"rule_type"`
+	Policy     string `json:"policy"`
+	CELExpr    string `json:"cel_expr,omitempty"`
+	CustomMsg  string `json:"custom_msg,omitempty"`
+	CustomURL  string `json:"custom_url,omitempty"`
+	Comment    string `json:"comment,omitempty"`
+}
+
+// ExportSantaRules writes the rules for tag on the Workshop instance at
+// endpoint to w as Santa static rules, for the -export-santa-rules flag.
+// format is json, for santactl rule --import, or plist, for the StaticRules
+// key of a configuration profile.
+func ExportSantaRules(ctx context.Context, w io.Writer, endpoint, tag, format string) error {
+	if format != santaRulesJSON && format != santaRulesPlist {
+		return fmt.Errorf("unknown format %q, expected %s or %s", format, santaRulesJSON, santaRulesPlist)
+	}
+	clients := newClientPool()
+	defer clients.Close()
+	client, err := clients.Get(ctx, endpoint, "", connOptions{transport: transportGRPC})
+	if err != nil {
+		return err
+	}
+	return writeSantaRules(ctx, w, client, tag, format)
+}
+
+func writeSantaRules(ctx context.Context, w io.Writer, client svcpb.WorkshopServiceClient, tag, format string) error {
+	pages := listPages(ctx, "rules", func(page uint32) ([]*apipb.Rule, bool, error) {
+		ret, err := client.ListRules(ctx, apipb.ListRulesRequest_builder{
+			Filter:   proto.String(filter.Eq("tag", tag).String()),
+			PageSize: proto.Int32(listPageSize),
+			Page:     proto.Int32(int32(page)),
+		}.Build())
+		return ret.GetRules(), ret.GetMore(), err
+	})
+	var rules []santaRule
+	for rule, err := range pages {
+		if err != nil {
+			return fmt.Errorf("failed to list rules: %w", err)
+		}
+		rules = append(rules, santaRule{
+			Identifier: rule.GetIdentifier(),
+			RuleType:   rule.GetRuleType().String(),
+			Policy:     rule.GetPolicy().String(),
+			CELExpr:    rule.GetCelExpr(),
+			CustomMsg:  rule.GetCustomMsg(),
+			CustomURL:  rule.GetCustomUrl(),
+			Comment:    rule.GetComment(),
+		})
+	}
+	// A stable order keeps exports diffable against each other and against
+	// a machine's local configuration.
+	slices.SortFunc(rules, func(a, b santaRule) int {
+		return cmp.Or(cmp.Compare(a.RuleType, b.RuleType), cmp.Compare(a.Identifier, b.Identifier))
+	})
+
+	if format == santaRulesPlist {
+		return writeSantaRulesPlist(w, rules)
+	}
+	if rules == nil {
+		rules = []santaRule{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Rules []santaRule `json:"rules"`
+	}{rules})
+}
+
+// writeSantaRulesPlist writes rules as a property list with a StaticRules
+// array, ready to paste into a Santa configuration profile payload.
+func writeSantaRulesPlist(w io.Writer, rules []santaRule) error {
+	p := &plistWriter{w: w}
+	p.printf("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	p.printf("<!DOCTYPE plist PUBLIC \"-//Apple//DTD PLIST 1.0//EN\" \"http://www.apple.com/DTDs/PropertyList-1.0.dtd\">\n")
+	p.printf("<plist version=\"1.0\">\n<dict>\n\t<key>StaticRules</key>\n\t<array>\n")
+	for _, r := range rules {
+		p.printf("\t\t<dict>\n")
+		for _, kv := range [][2]string{
+			{"identifier", r.Identifier},
+			{"rule_type", r.RuleType},
+			{"policy", r.Policy},
+			{"cel_expr", r.CELExpr},
+			{"custom_msg", r.CustomMsg},
+			{"custom_url", r.CustomURL},
+			{"comment", r.Comment},
+		} {
+			if kv[1] != "" {
+				p.printf("\t\t\t<key>%s</key>\n\t\t\t<string>%s</string>\n", kv[0], plistEscape(kv[1]))
+			}
+		}
+		p.printf("\t\t</dict>\n")
+	}
+	p.printf("\t</array>\n</dict>\n</plist>\n")
+	return p.err
+}
+
+// plistWriter writes to w, keeping the first error.
+type plistWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (p *plistWriter) printf(format string, args ...any) {
+	if p.err == nil {
+		_, p.err = fmt.Fprintf(p.w, format, args...)
+	}
+}
+
+// plistEscape escapes s for a plist string element. Writes to a
+// strings.Builder can't fail.
+func plistEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
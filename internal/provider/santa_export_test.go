@@ -0,0 +1,59 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+func TestWriteSantaRules(t *testing.T) {
+	client := &fakeWorkshopClient{listRules: []*apipb.Rule{
+		apipb.Rule_builder{Identifier: "EQHXZ8M8AV", RuleType: apipb.RuleType_TEAMID, Policy: apipb.Policy_ALLOWLIST, Tag: "global"}.Build(),
+		apipb.Rule_builder{Identifier: "platform:com.apple.curl", RuleType: apipb.RuleType_SIGNINGID, Policy: apipb.Policy_BLOCKLIST, Tag: "global", CustomMsg: "curl & wget are blocked"}.Build(),
+	}}
+	ctx := context.Background()
+
+	var b strings.Builder
+	if err := writeSantaRules(ctx, &b, client, "global", santaRulesJSON); err != nil {
+		t.Fatalf("writeSantaRules(json) = %v", err)
+	}
+	if client.listRulesFilter != `tag = "global"` {
+		t.Errorf("ListRules filter = %s", client.listRulesFilter)
+	}
+	var got struct{ Rules []santaRule }
+	if err := json.Unmarshal([]byte(b.String()), &got); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, b.String())
+	}
+	want := []santaRule{
+		{Identifier: "platform:com.apple.curl", RuleType: "SIGNINGID", Policy: "BLOCKLIST", CustomMsg: "curl & wget are blocked"},
+		{Identifier: "EQHXZ8M8AV", RuleType: "TEAMID", Policy: "ALLOWLIST"},
+	}
+	if len(got.Rules) != len(want) || got.Rules[0] != want[0] || got.Rules[1] != want[1] {
+		t.Errorf("rules = %+v, want %+v", got.Rules, want)
+	}
+
+	b.Reset()
+	if err := writeSantaRules(ctx, &b, client, "global", santaRulesPlist); err != nil {
+		t.Fatalf("writeSantaRules(plist) = %v", err)
+	}
+	for _, want := range []string{
+		"<key>StaticRules</key>",
+		"<key>rule_type</key>\n\t\t\t<string>TEAMID</string>",
+		"<string>curl &amp; wget are blocked</string>",
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("plist is missing %q:\n%s", want, b.String())
+		}
+	}
+
+	// An empty tag exports an empty list rather than null.
+	client.listRules = nil
+	b.Reset()
+	if err := writeSantaRules(ctx, &b, client, "empty", santaRulesJSON); err != nil || !strings.Contains(b.String(), `"rules": []`) {
+		t.Errorf("empty export = %s, %v", b.String(), err)
+	}
+}
@@ -0,0 +1,10 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+// New resources start from a scaffold generated from the API message they
+// manage; see internal/cmd/scaffold. For example:
+//
+//	SCAFFOLD_MESSAGE=TelemetryQuery go generate ./internal/provider
+//
+// Without SCAFFOLD_MESSAGE this does nothing.
+//go:generate go run ../cmd/scaffold -dir ../..
@@ -0,0 +1,264 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// ExportedSchemas is the document written by the -schema-json flag. It is
+// intended for tooling (policy generators, UI form builders) that needs to
+// stay in sync with the provider without parsing the Go source.
+type ExportedSchemas struct {
+	ProviderVersion string                    `json:"provider_version"`
+	Provider        ExportedSchema            `json:"provider"`
+	Resources       map[string]ExportedSchema `json:"resources"`
+	DataSources     map[string]ExportedSchema `json:"data_sources"`
+}
+
+// ExportedSchema describes a single provider, resource, or data source schema,
+// or a nested block within one.
+type ExportedSchema struct {
+	Description string                       `json:"description,omitempty"`
+	Type        string                       `json:"type,omitempty"`
+	Attributes  map[string]ExportedAttribute `json:"attributes,omitempty"`
+	Blocks      map[string]ExportedSchema    `json:"blocks,omitempty"`
+}
+
+// ExportedAttribute describes a single schema attribute.
+type ExportedAttribute struct {
+	Type        string                       `json:"type"`
+	Description string                       `json:"description,omitempty"`
+	Required    bool                         `json:"required,omitempty"`
+	Optional    bool                         `json:"optional,omitempty"`
+	Computed    bool                         `json:"computed,omitempty"`
+	Sensitive   bool                         `json:"sensitive,omitempty"`
+	Deprecated  string                       `json:"deprecated,omitempty"`
+	Validators  []string                     `json:"validators,omitempty"`
+	EnumValues  []string                     `json:"enum_values,omitempty"`
+	Attributes  map[string]ExportedAttribute `json:"attributes,omitempty"`
+}
+
+// exportableAttribute is the subset of the framework's attribute interface
+// shared by provider, resource, and data source attributes.
+type exportableAttribute interface {
+	GetType() attr.Type
+	GetDescription() string
+	GetMarkdownDescription() string
+	GetDeprecationMessage() string
+	IsRequired() bool
+	IsOptional() bool
+	IsComputed() bool
+	IsSensitive() bool
+}
+
+// ExportSchemaJSON renders every provider, resource, and data source schema as
+// indented JSON.
+func ExportSchemaJSON(ctx context.Context, version string) ([]byte, error) {
+	p, ok := New(version)().(*NPSProvider)
+	if !ok {
+		return nil, fmt.Errorf("unexpected provider type")
+	}
+
+	var pResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &pResp)
+	if pResp.Diagnostics.HasError() {
+		return nil, fmt.Errorf("provider schema: %v", pResp.Diagnostics)
+	}
+
+	var mResp provider.MetadataResponse
+	p.Metadata(ctx, provider.MetadataRequest{}, &mResp)
+
+	out := ExportedSchemas{
+		ProviderVersion: version,
+		Provider:        exportSchema(ctx, pResp.Schema),
+		Resources:       map[string]ExportedSchema{},
+		DataSources:     map[string]ExportedSchema{},
+	}
+
+	for _, newResource := range p.Resources(ctx) {
+		r := newResource()
+		var rmResp resource.MetadataResponse
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: mResp.TypeName}, &rmResp)
+		var rsResp resource.SchemaResponse
+		r.Schema(ctx, resource.SchemaRequest{}, &rsResp)
+		if rsResp.Diagnostics.HasError() {
+			return nil, fmt.Errorf("%s schema: %v", rmResp.TypeName, rsResp.Diagnostics)
+		}
+		out.Resources[rmResp.TypeName] = exportSchema(ctx, rsResp.Schema)
+	}
+
+	for _, newDataSource := range p.DataSources(ctx) {
+		d := newDataSource()
+		var dmResp datasource.MetadataResponse
+		d.Metadata(ctx, datasource.MetadataRequest{ProviderTypeName: mResp.TypeName}, &dmResp)
+		var dsResp datasource.SchemaResponse
+		d.Schema(ctx, datasource.SchemaRequest{}, &dsResp)
+		if dsResp.Diagnostics.HasError() {
+			return nil, fmt.Errorf("%s schema: %v", dmResp.TypeName, dsResp.Diagnostics)
+		}
+		out.DataSources[dmResp.TypeName] = exportSchema(ctx, dsResp.Schema)
+	}
+
+	return json.MarshalIndent(out, "", "  ")
+}
+
+// exportSchema walks a framework schema (or nested block object). The
+// provider, resource, and data source schema packages share their method set
+// through an internal framework interface, so the walk is done reflectively.
+func exportSchema(ctx context.Context, s any) ExportedSchema {
+	var out ExportedSchema
+	if desc := callString(s, "GetMarkdownDescription"); desc != "" {
+		out.Description = desc
+	} else {
+		out.Description = callString(s, "GetDescription")
+	}
+	out.Attributes = exportAttributes(ctx, callMap(s, "GetAttributes"))
+
+	if blocks := callMap(s, "GetBlocks"); len(blocks) > 0 {
+		out.Blocks = make(map[string]ExportedSchema, len(blocks))
+		for name, b := range blocks {
+			nested := exportSchema(ctx, callValue(b, "GetNestedObject"))
+			if bt, ok := b.(interface{ Type() attr.Type }); ok {
+				nested.Type = terraformTypeName(ctx, bt.Type())
+			}
+			if nested.Description == "" {
+				nested.Description = callString(b, "GetMarkdownDescription")
+			}
+			out.Blocks[name] = nested
+		}
+	}
+	return out
+}
+
+func exportAttributes(ctx context.Context, attrs map[string]any) map[string]ExportedAttribute {
+	if len(attrs) == 0 {
+		return nil
+	}
+	out := make(map[string]ExportedAttribute, len(attrs))
+	for name, a := range attrs {
+		ea, ok := a.(exportableAttribute)
+		if !ok {
+			continue
+		}
+		desc := ea.GetMarkdownDescription()
+		if desc == "" {
+			desc = ea.GetDescription()
+		}
+		exported := ExportedAttribute{
+			Type:        terraformTypeName(ctx, ea.GetType()),
+			Description: desc,
+			Required:    ea.IsRequired(),
+			Optional:    ea.IsOptional(),
+			Computed:    ea.IsComputed(),
+			Sensitive:   ea.IsSensitive(),
+			Deprecated:  ea.GetDeprecationMessage(),
+		}
+		for _, v := range attributeValidators(a) {
+			exported.Validators = append(exported.Validators, v.Description(ctx))
+			if e, ok := v.(enumValidator); ok {
+				exported.EnumValues = append(exported.EnumValues, e.allowedValues()...)
+			}
+		}
+		if obj := callValue(a, "GetNestedObject"); obj != nil {
+			exported.Attributes = exportAttributes(ctx, callMap(obj, "GetAttributes"))
+		}
+		out[name] = exported
+	}
+	return out
+}
+
+// attributeValidators returns the validators attached to an attribute,
+// whatever its value type.
+func attributeValidators(a any) []validator.Describer {
+	var out []validator.Describer
+	add := func(vs ...validator.Describer) { out = append(out, vs...) }
+	switch a := a.(type) {
+	case interface{ StringValidators() []validator.String }:
+		for _, v := range a.StringValidators() {
+			add(v)
+		}
+	case interface{ BoolValidators() []validator.Bool }:
+		for _, v := range a.BoolValidators() {
+			add(v)
+		}
+	case interface{ Int32Validators() []validator.Int32 }:
+		for _, v := range a.Int32Validators() {
+			add(v)
+		}
+	case interface{ Int64Validators() []validator.Int64 }:
+		for _, v := range a.Int64Validators() {
+			add(v)
+		}
+	case interface{ Float64Validators() []validator.Float64 }:
+		for _, v := range a.Float64Validators() {
+			add(v)
+		}
+	case interface{ ListValidators() []validator.List }:
+		for _, v := range a.ListValidators() {
+			add(v)
+		}
+	case interface{ SetValidators() []validator.Set }:
+		for _, v := range a.SetValidators() {
+			add(v)
+		}
+	case interface{ MapValidators() []validator.Map }:
+		for _, v := range a.MapValidators() {
+			add(v)
+		}
+	case interface{ ObjectValidators() []validator.Object }:
+		for _, v := range a.ObjectValidators() {
+			add(v)
+		}
+	}
+	return out
+}
+
+// terraformTypeName renders a type in Terraform's own notation, e.g.
+// "List[String]", rather than the framework's Go type name.
+func terraformTypeName(ctx context.Context, t attr.Type) string {
+	return strings.ReplaceAll(t.TerraformType(ctx).String(), "tftypes.", "")
+}
+
+func callValue(v any, method string) any {
+	m := reflect.ValueOf(v).MethodByName(method)
+	if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+		return nil
+	}
+	ret := m.Call(nil)[0]
+	if (ret.Kind() == reflect.Interface || ret.Kind() == reflect.Pointer || ret.Kind() == reflect.Map) && ret.IsNil() {
+		return nil
+	}
+	return ret.Interface()
+}
+
+func callString(v any, method string) string {
+	s, _ := callValue(v, method).(string)
+	return s
+}
+
+func callMap(v any, method string) map[string]any {
+	ret := callValue(v, method)
+	if ret == nil {
+		return nil
+	}
+	rv := reflect.ValueOf(ret)
+	if rv.Kind() != reflect.Map {
+		return nil
+	}
+	out := make(map[string]any, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		out[iter.Key().String()] = iter.Value().Interface()
+	}
+	return out
+}
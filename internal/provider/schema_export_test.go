@@ -0,0 +1,71 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestExportSchemaJSON(t *testing.T) {
+	b, err := ExportSchemaJSON(context.Background(), "test")
+	if err != nil {
+		t.Fatalf("ExportSchemaJSON() error: %v", err)
+	}
+
+	var got ExportedSchemas
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+
+	if got.ProviderVersion != "test" {
+		t.Errorf("provider_version = %q, want test", got.ProviderVersion)
+	}
+	if apiKey := got.Provider.Attributes["api_key"]; !apiKey.Sensitive || !apiKey.Optional {
+		t.Errorf("provider api_key = %+v, want optional and sensitive", apiKey)
+	}
+
+	rule, ok := got.Resources["nps_workshop_rule"]
+	if !ok {
+		t.Fatal("nps_workshop_rule missing from export")
+	}
+	policy := rule.Attributes["policy"]
+	if !policy.Required || policy.Type != "String" {
+		t.Errorf("policy = %+v, want a required String", policy)
+	}
+	if !slices.Contains(policy.EnumValues, "BLOCKLIST") {
+		t.Errorf("policy enum_values = %v, want BLOCKLIST included", policy.EnumValues)
+	}
+	// Every OneOf attribute exports its values; a validator built with
+	// stringvalidator.OneOf directly would export none.
+	for _, tt := range []struct {
+		schema ExportedSchema
+		attr   string
+		want   string
+	}{
+		{rule, "rule_type", "TEAMID"},
+		{rule, "block_reason", "BLOCK_REASON_MALICIOUS"},
+		{got.Resources["nps_workshop_network_flow_rule"], "direction", "NETWORK_FLOW_DIRECTION_OUTGOING"},
+		{got.Resources["nps_workshop_signal"], "severity", "SEVERITY_HIGH"},
+		{got.Provider, "transport", "connect"},
+		// List and set attributes export the values their elements may take.
+		{got.Resources["nps_workshop_apikey"], "permissions", "read:rules"},
+		{got.Provider, "forbid_policies", "ALLOWLIST_COMPILER"},
+	} {
+		if !slices.Contains(tt.schema.Attributes[tt.attr].EnumValues, tt.want) {
+			t.Errorf("%s enum_values = %v, want %s included", tt.attr, tt.schema.Attributes[tt.attr].EnumValues, tt.want)
+		}
+	}
+	if id := rule.Attributes["id"]; !id.Computed || id.Optional {
+		t.Errorf("id = %+v, want computed only", id)
+	}
+
+	threshold, ok := rule.Blocks["affected_host_threshold"]
+	if !ok {
+		t.Fatal("affected_host_threshold block missing from export")
+	}
+	if len(threshold.Attributes["days"].Validators) == 0 {
+		t.Error("affected_host_threshold.days should export its validators")
+	}
+}
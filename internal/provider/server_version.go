@@ -0,0 +1,72 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"golang.org/x/mod/semver"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// canonicalVersion returns v as a semver string with a leading "v", or "" if
+// v isn't a version. Workshop reports versions both with and without the
+// prefix.
+func canonicalVersion(v string) string {
+	v = strings.TrimSpace(v)
+	if v != "" && !strings.HasPrefix(v, "v") {
+		v = "v" + v
+	}
+	return semver.Canonical(v)
+}
+
+// checkServerVersion fails when the Workshop deployment behind client is older
+// than minimum. Older servers silently drop request fields they don't know
+// about, so a configuration relying on newer rule fields would otherwise apply
+// cleanly and then drift on every refresh.
+func checkServerVersion(ctx context.Context, client svcpb.WorkshopServiceClient, endpoint, minimum string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	want := canonicalVersion(minimum)
+	if want == "" {
+		diags.AddError(
+			codeProviderConfig.summary("NPS Provider configuration error"),
+			fmt.Sprintf("minimum_server_version %q is not a version, e.g. \"1.42.0\".", minimum),
+		)
+		return diags
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, connectionCheckTimeout)
+	defer cancel()
+	resp, err := client.GetLatestWorkshopRelease(ctx, &apipb.GetLatestWorkshopReleaseRequest{})
+	if err != nil {
+		detail := fmt.Sprintf("Failed to read the version of Workshop at %s: %v", endpoint, err)
+		if status.Code(err) == codes.PermissionDenied {
+			detail += "\n\nminimum_server_version requires the read:workshopupdates permission."
+		}
+		diags.AddError(apiErrorCode(err).summary("NPS Provider server version error"), detail)
+		return diags
+	}
+
+	got := canonicalVersion(resp.GetCurrentVersion())
+	if got == "" {
+		diags.AddError(
+			codeServerVersion.summary("NPS Provider server version error"),
+			fmt.Sprintf("Workshop at %s reported version %q, which can't be compared with minimum_server_version %q.", endpoint, resp.GetCurrentVersion(), minimum),
+		)
+		return diags
+	}
+	if semver.Compare(got, want) < 0 {
+		diags.AddError(
+			codeServerVersion.summary("NPS Provider server version error"),
+			fmt.Sprintf("Workshop at %s is version %s, but this configuration requires at least %s (minimum_server_version). Upgrade Workshop before applying it; older servers ignore settings they don't know about.", endpoint, resp.GetCurrentVersion(), minimum),
+		)
+	}
+	return diags
+}
@@ -0,0 +1,36 @@
+// Copyright 2026 North Pole Security, Inc.
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCheckServerVersion(t *testing.T) {
+	for _, c := range []struct {
+		name    string
+		minimum string
+		server  string
+		err     error
+		wantErr bool
+	}{
+		{name: "newer", minimum: "1.42.0", server: "v1.43.1"},
+		{name: "equal", minimum: "v1.42", server: "1.42.0"},
+		{name: "older", minimum: "1.42.0", server: "1.41.9", wantErr: true},
+		{name: "prerelease is older", minimum: "1.42.0", server: "1.42.0-rc1", wantErr: true},
+		{name: "invalid minimum", minimum: "latest", server: "1.42.0", wantErr: true},
+		{name: "unparseable server version", minimum: "1.42.0", server: "main", wantErr: true},
+		{name: "permission denied", minimum: "1.42.0", err: status.Error(codes.PermissionDenied, "missing read:workshopupdates"), wantErr: true},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			client := &fakeWorkshopClient{serverVersion: c.server, serverVersionErr: c.err}
+			diags := checkServerVersion(context.Background(), client, "workshop.example", c.minimum)
+			if diags.HasError() != c.wantErr {
+				t.Errorf("checkServerVersion() = %v, want error %t", diags, c.wantErr)
+			}
+		})
+	}
+}
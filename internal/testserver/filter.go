@@ -0,0 +1,205 @@
+// Copyright 2026 North Pole Security, Inc.
+package testserver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// matcher reports whether a message matches a filter expression.
+type matcher func(m proto.Message) bool
+
+// parseFilter parses the subset of Workshop's filter language the provider
+// writes with the filter package: comparisons of a field with a literal,
+// combined with AND, OR, and parentheses. Fields are the messages' proto
+// field names, and enums compare by name.
+func parseFilter(s string) (matcher, error) {
+	if strings.TrimSpace(s) == "" {
+		return func(proto.Message) bool { return true }, nil
+	}
+	p := &filterParser{s: s}
+	m, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.skipSpace(); p.pos != len(p.s) {
+		return nil, fmt.Errorf("unexpected %q at offset %d", p.s[p.pos:], p.pos)
+	}
+	return m, nil
+}
+
+type filterParser struct {
+	s   string
+	pos int
+}
+
+func (p *filterParser) or() (matcher, error) {
+	return p.join("OR", p.and, func(a, b bool) bool { return a || b })
+}
+
+func (p *filterParser) and() (matcher, error) {
+	return p.join("AND", p.operand, func(a, b bool) bool { return a && b })
+}
+
+func (p *filterParser) join(keyword string, next func() (matcher, error), combine func(a, b bool) bool) (matcher, error) {
+	m, err := next()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword(keyword) {
+		rhs, err := next()
+		if err != nil {
+			return nil, err
+		}
+		lhs := m
+		m = func(msg proto.Message) bool { return combine(lhs(msg), rhs(msg)) }
+	}
+	return m, nil
+}
+
+func (p *filterParser) operand() (matcher, error) {
+	p.skipSpace()
+	if strings.HasPrefix(p.s[p.pos:], "(") {
+		p.pos++
+		m, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if p.skipSpace(); !strings.HasPrefix(p.s[p.pos:], ")") {
+			return nil, fmt.Errorf("missing ) at offset %d", p.pos)
+		}
+		p.pos++
+		return m, nil
+	}
+
+	field := p.word()
+	if field == "" {
+		return nil, fmt.Errorf("expected a field at offset %d", p.pos)
+	}
+	p.skipSpace()
+	var op string
+	for _, candidate := range []string{"!=", "<=", ">=", "=", "<", ">"} {
+		if strings.HasPrefix(p.s[p.pos:], candidate) {
+			op = candidate
+			break
+		}
+	}
+	if op == "" {
+		return nil, fmt.Errorf("expected a comparison after %s", field)
+	}
+	p.pos += len(op)
+	value, err := p.literal()
+	if err != nil {
+		return nil, err
+	}
+	return func(m proto.Message) bool {
+		got, ok := fieldValue(m, field)
+		return ok && compare(got, op, value)
+	}, nil
+}
+
+func (p *filterParser) literal() (string, error) {
+	p.skipSpace()
+	if !strings.HasPrefix(p.s[p.pos:], `"`) {
+		if w := p.word(); w != "" {
+			return w, nil
+		}
+		return "", fmt.Errorf("expected a value at offset %d", p.pos)
+	}
+	for i := p.pos + 1; i < len(p.s); i++ {
+		switch p.s[i] {
+		case '\\':
+			i++
+		case '"':
+			v, err := strconv.Unquote(p.s[p.pos : i+1])
+			p.pos = i + 1
+			return v, err
+		}
+	}
+	return "", fmt.Errorf("unterminated string at offset %d", p.pos)
+}
+
+func (p *filterParser) keyword(k string) bool {
+	p.skipSpace()
+	rest := p.s[p.pos:]
+	if len(rest) > len(k) && strings.EqualFold(rest[:len(k)], k) && unicode.IsSpace(rune(rest[len(k)])) {
+		p.pos += len(k)
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) word() string {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.s) {
+		c := rune(p.s[p.pos])
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '_' && c != '.' && c != '-' && c != ':' {
+			break
+		}
+		p.pos++
+	}
+	return p.s[start:p.pos]
+}
+
+func (p *filterParser) skipSpace() {
+	for p.pos < len(p.s) && unicode.IsSpace(rune(p.s[p.pos])) {
+		p.pos++
+	}
+}
+
+// fieldValue returns the text of m's field named name.
+func fieldValue(m proto.Message, name string) (string, bool) {
+	msg := m.ProtoReflect()
+	fd := msg.Descriptor().Fields().ByName(protoreflect.Name(name))
+	if fd == nil || fd.IsList() || fd.IsMap() {
+		return "", false
+	}
+	v := msg.Get(fd)
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name()), true
+		}
+		return strconv.Itoa(int(v.Enum())), true
+	case protoreflect.MessageKind, protoreflect.GroupKind, protoreflect.BytesKind:
+		return "", false
+	}
+	return v.String(), true
+}
+
+// compare compares got with want numerically when both are numbers, and as
+// strings otherwise.
+func compare(got, op, want string) bool {
+	c := strings.Compare(got, want)
+	g, gErr := strconv.ParseFloat(got, 64)
+	w, wErr := strconv.ParseFloat(want, 64)
+	if gErr == nil && wErr == nil {
+		switch {
+		case g < w:
+			c = -1
+		case g > w:
+			c = 1
+		default:
+			c = 0
+		}
+	}
+	switch op {
+	case "=":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	}
+	return c >= 0
+}
@@ -0,0 +1,52 @@
+// Copyright 2026 North Pole Security, Inc.
+package testserver
+
+import (
+	"testing"
+
+	"github.com/northpolesec/terraform-provider-nps/internal/filter"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+func TestParseFilter(t *testing.T) {
+	rule := apipb.Rule_builder{
+		Id:         42,
+		Identifier: `say "hi"`,
+		RuleType:   apipb.RuleType_SIGNINGID,
+		Tag:        "global",
+	}.Build()
+
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{"", true},
+		{filter.Eq("tag", "global").String(), true},
+		{filter.Eq("tag", "dev").String(), false},
+		{filter.Eq("identifier", `say "hi"`).String(), true},
+		{filter.Eq("rule_type", apipb.RuleType_SIGNINGID).String(), true},
+		{filter.Eq("id", int64(42)).String(), true},
+		{filter.Cmp("id", ">", 9).String(), true},
+		{filter.And(filter.Eq("tag", "dev"), filter.Eq("id", 42)).String(), false},
+		{filter.Or(filter.Eq("tag", "dev"), filter.And(filter.Eq("tag", "global"), filter.Ne("id", 1))).String(), true},
+		{filter.And(filter.Raw("tag = dev OR tag = global"), filter.Eq("id", 42)).String(), true},
+		{`no_such_field = "x"`, false},
+	}
+	for _, tt := range tests {
+		match, err := parseFilter(tt.filter)
+		if err != nil {
+			t.Errorf("parseFilter(%q) = %v", tt.filter, err)
+			continue
+		}
+		if got := match(rule); got != tt.want {
+			t.Errorf("parseFilter(%q) matched %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{`tag`, `tag = "x`, `(tag = x`, `tag = x )`} {
+		if _, err := parseFilter(bad); err == nil {
+			t.Errorf("parseFilter(%q) succeeded, want an error", bad)
+		}
+	}
+}
@@ -0,0 +1,459 @@
+// Copyright 2026 North Pole Security, Inc.
+
+// Package testserver is an in-memory Workshop for tests. It implements the
+// parts of WorkshopService that manage rules, tags, file access rules, and API
+// keys, so acceptance tests of those resources run without a live Workshop,
+// and it exposes its state so tests can assert what a configuration left on
+// the server. Every other RPC returns Unimplemented.
+//
+// Like Workshop, it reassigns a rule's ID whenever the rule is upserted, and
+// accepts any credentials.
+package testserver
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net"
+	"path"
+	"slices"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	svcpb "buf.build/gen/go/northpolesec/workshop-api/grpc/go/workshop/v1/workshopv1grpc"
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+// Server is an in-memory Workshop. The zero Server is not usable; call New.
+type Server struct {
+	svcpb.UnimplementedWorkshopServiceServer
+
+	mu              sync.Mutex
+	nextID          int64
+	tags            map[string]bool
+	rules           []*apipb.Rule
+	fileAccessRules []*apipb.FileAccessRule
+	apiKeys         []*apipb.APIKey
+
+	// failures are the errors RPCs return instead of running, by method
+	// name, as set by Fail.
+	failures map[string]error
+}
+
+// New returns an empty Server.
+func New() *Server {
+	return &Server{tags: map[string]bool{}, failures: map[string]error{}}
+}
+
+// Fail makes the RPC named rpc, e.g. CreateRule, return err instead of
+// running, so tests can exercise how callers handle server errors. A nil err
+// restores the RPC.
+func (s *Server) Fail(rpc string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err == nil {
+		delete(s.failures, rpc)
+		return
+	}
+	s.failures[rpc] = err
+}
+
+func (s *Server) intercept(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	s.mu.Lock()
+	err := s.failures[path.Base(info.FullMethod)]
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// Start serves s on addr, e.g. localhost:8080, without TLS, until stop is
+// called.
+func (s *Server) Start(addr string) (stop func(), err error) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return s.serve(lis), nil
+}
+
+// Client returns a client connected to s in memory, and a function that
+// closes it and stops s.
+func (s *Server) Client() (svcpb.WorkshopServiceClient, func(), error) {
+	lis := bufconn.Listen(1 << 20)
+	stop := s.serve(lis)
+	conn, err := grpc.NewClient("passthrough:///testserver",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		stop()
+		return nil, nil, err
+	}
+	return svcpb.NewWorkshopServiceClient(conn), func() { conn.Close(); stop() }, nil
+}
+
+func (s *Server) serve(lis net.Listener) func() {
+	gs := grpc.NewServer(grpc.UnaryInterceptor(s.intercept))
+	svcpb.RegisterWorkshopServiceServer(gs, s)
+	go gs.Serve(lis)
+	return gs.Stop
+}
+
+// Rules returns copies of the rules on s, ordered by tag, rule type, and
+// identifier.
+func (s *Server) Rules() []*apipb.Rule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rules := clone(s.rules)
+	slices.SortFunc(rules, func(a, b *apipb.Rule) int {
+		return cmp.Or(cmp.Compare(a.GetTag(), b.GetTag()), cmp.Compare(a.GetRuleType(), b.GetRuleType()), cmp.Compare(a.GetIdentifier(), b.GetIdentifier()))
+	})
+	return rules
+}
+
+// FileAccessRules returns copies of the file access rules on s, ordered by
+// tag and name.
+func (s *Server) FileAccessRules() []*apipb.FileAccessRule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rules := clone(s.fileAccessRules)
+	slices.SortFunc(rules, func(a, b *apipb.FileAccessRule) int {
+		return cmp.Or(cmp.Compare(a.GetTag(), b.GetTag()), cmp.Compare(a.GetName(), b.GetName()))
+	})
+	return rules
+}
+
+// Tags returns the names of the tags on s, sorted.
+func (s *Server) Tags() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Sorted(func(yield func(string) bool) {
+		for t := range s.tags {
+			if !yield(t) {
+				return
+			}
+		}
+	})
+}
+
+// APIKeys returns copies of the API keys on s, ordered by name.
+func (s *Server) APIKeys() []*apipb.APIKey {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := clone(s.apiKeys)
+	slices.SortFunc(keys, func(a, b *apipb.APIKey) int { return cmp.Compare(a.GetName(), b.GetName()) })
+	return keys
+}
+
+func clone[M proto.Message](ms []M) []M {
+	out := make([]M, len(ms))
+	for i, m := range ms {
+		out[i] = proto.Clone(m).(M)
+	}
+	return out
+}
+
+// page returns the page of the matching items a List RPC asked for, whether
+// more follow, and how many match in all. Pages are one-based, and a zero
+// page size returns every match.
+func page[M proto.Message](items []M, query string, pageNum, pageSize int) ([]M, bool, int64, error) {
+	match, err := parseFilter(query)
+	if err != nil {
+		return nil, false, 0, status.Errorf(codes.InvalidArgument, "invalid filter %q: %v", query, err)
+	}
+	var matches []M
+	for _, m := range items {
+		if match(m) {
+			matches = append(matches, m)
+		}
+	}
+	count := int64(len(matches))
+	if pageSize <= 0 {
+		return clone(matches), false, count, nil
+	}
+	start := (max(pageNum, 1) - 1) * pageSize
+	if start >= len(matches) {
+		return nil, false, count, nil
+	}
+	end := min(start+pageSize, len(matches))
+	return clone(matches[start:end]), end < len(matches), count, nil
+}
+
+func (s *Server) newID() int64 {
+	s.nextID++
+	return s.nextID
+}
+
+func (s *Server) CreateRule(ctx context.Context, req *apipb.CreateRuleRequest) (*apipb.CreateRuleResponse, error) {
+	rule := proto.Clone(req.GetRule()).(*apipb.Rule)
+	switch {
+	case rule.GetIdentifier() == "":
+		return nil, status.Error(codes.InvalidArgument, "identifier is required")
+	case rule.GetRuleType() == apipb.RuleType_RULETYPE_UNKNOWN:
+		return nil, status.Error(codes.InvalidArgument, "rule_type is required")
+	case rule.GetPolicy() == apipb.Policy_POLICY_UNKNOWN:
+		return nil, status.Error(codes.InvalidArgument, "policy is required")
+	}
+	if rule.GetTag() == "" {
+		rule.SetTag("global")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := s.newID()
+	rule.SetId(id)
+	rule.SetRuleId(fmt.Sprintf("00000000-0000-4000-8000-%012d", id))
+	rule.SetUpdatedAt(timestamppb.Now())
+	// An upsert replaces the rule with the same natural key, under a new ID.
+	i := slices.IndexFunc(s.rules, func(r *apipb.Rule) bool {
+		return r.GetIdentifier() == rule.GetIdentifier() && r.GetRuleType() == rule.GetRuleType() && r.GetTag() == rule.GetTag()
+	})
+	if i >= 0 {
+		rule.SetCreatedAt(s.rules[i].GetCreatedAt())
+		s.rules[i] = rule
+	} else {
+		rule.SetCreatedAt(rule.GetUpdatedAt())
+		s.rules = append(s.rules, rule)
+	}
+	s.tags[rule.GetTag()] = true
+	return apipb.CreateRuleResponse_builder{RuleId: proto.String(rule.GetRuleId()), Id: proto.Int64(id)}.Build(), nil
+}
+
+func (s *Server) DeleteRule(ctx context.Context, req *apipb.DeleteRuleRequest) (*apipb.DeleteRuleResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := slices.IndexFunc(s.rules, func(r *apipb.Rule) bool {
+		return req.GetRuleId() != "" && r.GetRuleId() == req.GetRuleId() || req.GetId() != 0 && r.GetId() == req.GetId()
+	})
+	if i < 0 {
+		return nil, status.Error(codes.NotFound, "rule not found")
+	}
+	s.rules = slices.Delete(s.rules, i, i+1)
+	return &apipb.DeleteRuleResponse{}, nil
+}
+
+func (s *Server) ListRules(ctx context.Context, req *apipb.ListRulesRequest) (*apipb.ListRulesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rules, more, count, err := page(s.rules, req.GetFilter(), int(req.GetPage()), int(req.GetPageSize()))
+	if err != nil {
+		return nil, err
+	}
+	if req.GetCountOnly() {
+		rules, more = nil, false
+	}
+	return apipb.ListRulesResponse_builder{Rules: rules, More: proto.Bool(more), Count: proto.Int64(count)}.Build(), nil
+}
+
+// ValidateCELRule accepts any non-empty expression, and never reports that it
+// can return SEATBELT.
+func (s *Server) ValidateCELRule(ctx context.Context, req *apipb.ValidateCELRuleRequest) (*apipb.ValidateCELRuleResponse, error) {
+	if req.GetExpression() == "" {
+		return nil, status.Error(codes.InvalidArgument, "expression is required")
+	}
+	return apipb.ValidateCELRuleResponse_builder{CanReturnSeatbelt: proto.Bool(false)}.Build(), nil
+}
+
+func (s *Server) CreateFileAccessRule(ctx context.Context, req *apipb.CreateFileAccessRuleRequest) (*apipb.CreateFileAccessRuleResponse, error) {
+	rule := proto.Clone(req.GetRule()).(*apipb.FileAccessRule)
+	if rule.GetName() == "" || rule.GetTag() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name and tag are required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rule.SetRuleId(s.newID())
+	// Names are unique per tag, so an upsert replaces the rule under a new ID.
+	i := slices.IndexFunc(s.fileAccessRules, func(r *apipb.FileAccessRule) bool {
+		return r.GetName() == rule.GetName() && r.GetTag() == rule.GetTag()
+	})
+	if i >= 0 {
+		s.fileAccessRules[i] = rule
+	} else {
+		s.fileAccessRules = append(s.fileAccessRules, rule)
+	}
+	s.tags[rule.GetTag()] = true
+	return apipb.CreateFileAccessRuleResponse_builder{RuleId: proto.Int64(rule.GetRuleId())}.Build(), nil
+}
+
+func (s *Server) DeleteFileAccessRule(ctx context.Context, req *apipb.DeleteFileAccessRuleRequest) (*apipb.DeleteFileAccessRuleResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := slices.IndexFunc(s.fileAccessRules, func(r *apipb.FileAccessRule) bool { return r.GetRuleId() == req.GetRuleId() })
+	if i < 0 {
+		return nil, status.Error(codes.NotFound, "file access rule not found")
+	}
+	s.fileAccessRules = slices.Delete(s.fileAccessRules, i, i+1)
+	return &apipb.DeleteFileAccessRuleResponse{}, nil
+}
+
+func (s *Server) ListFileAccessRules(ctx context.Context, req *apipb.ListFileAccessRulesRequest) (*apipb.ListFileAccessRulesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rules, more, count, err := page(s.fileAccessRules, req.GetFilter(), int(req.GetPage()), int(req.GetPageSize()))
+	if err != nil {
+		return nil, err
+	}
+	if req.GetCountOnly() {
+		rules, more = nil, false
+	}
+	return apipb.ListFileAccessRulesResponse_builder{Rules: rules, More: proto.Bool(more), Count: proto.Int64(count)}.Build(), nil
+}
+
+func (s *Server) CreateTag(ctx context.Context, req *apipb.CreateTagRequest) (*apipb.CreateTagResponse, error) {
+	if req.GetTag() == "" {
+		return nil, status.Error(codes.InvalidArgument, "tag is required")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tags[req.GetTag()] {
+		return nil, status.Errorf(codes.AlreadyExists, "tag %q already exists", req.GetTag())
+	}
+	s.tags[req.GetTag()] = true
+	return &apipb.CreateTagResponse{}, nil
+}
+
+func (s *Server) DeleteTag(ctx context.Context, req *apipb.DeleteTagRequest) (*apipb.DeleteTagResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.tags[req.GetTag()] {
+		return nil, status.Errorf(codes.NotFound, "tag %q not found", req.GetTag())
+	}
+	delete(s.tags, req.GetTag())
+	return &apipb.DeleteTagResponse{}, nil
+}
+
+// RenameTag renames a tag and moves its rules and file access rules with it.
+func (s *Server) RenameTag(ctx context.Context, req *apipb.RenameTagRequest) (*apipb.RenameTagResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case !s.tags[req.GetTag()]:
+		return nil, status.Errorf(codes.NotFound, "tag %q not found", req.GetTag())
+	case s.tags[req.GetNewTag()]:
+		return nil, status.Errorf(codes.AlreadyExists, "tag %q already exists", req.GetNewTag())
+	}
+	delete(s.tags, req.GetTag())
+	s.tags[req.GetNewTag()] = true
+	for _, r := range s.rules {
+		if r.GetTag() == req.GetTag() {
+			r.SetTag(req.GetNewTag())
+		}
+	}
+	for _, r := range s.fileAccessRules {
+		if r.GetTag() == req.GetTag() {
+			r.SetTag(req.GetNewTag())
+		}
+	}
+	return &apipb.RenameTagResponse{}, nil
+}
+
+func (s *Server) ListTags(ctx context.Context, req *apipb.ListTagsRequest) (*apipb.ListTagsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var stats []*apipb.TagStats
+	for _, tag := range slices.Sorted(func(yield func(string) bool) {
+		for t := range s.tags {
+			if !yield(t) {
+				return
+			}
+		}
+	}) {
+		var ruleCount, farCount uint32
+		for _, r := range s.rules {
+			if r.GetTag() == tag {
+				ruleCount++
+			}
+		}
+		for _, r := range s.fileAccessRules {
+			if r.GetTag() == tag {
+				farCount++
+			}
+		}
+		stats = append(stats, apipb.TagStats_builder{
+			Tag:                 tag,
+			RuleCount:           ruleCount,
+			FileAccessRuleCount: farCount,
+		}.Build())
+	}
+	tags, more, _, err := page(stats, req.GetFilter(), int(req.GetPage()), int(req.GetPageSize()))
+	if err != nil {
+		return nil, err
+	}
+	return apipb.ListTagsResponse_builder{Tags: tags, More: proto.Bool(more)}.Build(), nil
+}
+
+func (s *Server) CreateAPIKey(ctx context.Context, req *apipb.CreateAPIKeyRequest) (*apipb.CreateAPIKeyResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	lifetime := 365 * 24 * time.Hour
+	if req.HasLifetime() {
+		lifetime = req.GetLifetime().AsDuration()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if slices.ContainsFunc(s.apiKeys, func(k *apipb.APIKey) bool { return k.GetName() == req.GetName() }) {
+		return nil, status.Errorf(codes.AlreadyExists, "API key %q already exists", req.GetName())
+	}
+	expires := timestamppb.New(time.Now().Add(lifetime))
+	s.apiKeys = append(s.apiKeys, apipb.APIKey_builder{
+		Name:        req.GetName(),
+		Permissions: slices.Clone(req.GetPermissions()),
+		Creator:     "testserver",
+		Expires:     expires,
+		Active:      true,
+	}.Build())
+	return apipb.CreateAPIKeyResponse_builder{
+		Secret:  proto.String(fmt.Sprintf("npsws_sk_test%d", s.newID())),
+		Expires: expires,
+	}.Build(), nil
+}
+
+func (s *Server) UpdateAPIKey(ctx context.Context, req *apipb.UpdateAPIKeyRequest) (*apipb.UpdateAPIKeyResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := slices.IndexFunc(s.apiKeys, func(k *apipb.APIKey) bool { return k.GetName() == req.GetName() })
+	if i < 0 {
+		return nil, status.Errorf(codes.NotFound, "API key %q not found", req.GetName())
+	}
+	s.apiKeys[i].SetPermissions(slices.Clone(req.GetPermissions()))
+	if req.HasExpires() {
+		s.apiKeys[i].SetExpires(req.GetExpires())
+	} else {
+		s.apiKeys[i].ClearExpires()
+	}
+	return &apipb.UpdateAPIKeyResponse{}, nil
+}
+
+func (s *Server) DeleteAPIKey(ctx context.Context, req *apipb.DeleteAPIKeyRequest) (*apipb.DeleteAPIKeyResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := slices.IndexFunc(s.apiKeys, func(k *apipb.APIKey) bool { return k.GetName() == req.GetName() })
+	if i < 0 {
+		return nil, status.Errorf(codes.NotFound, "API key %q not found", req.GetName())
+	}
+	s.apiKeys = slices.Delete(s.apiKeys, i, i+1)
+	return &apipb.DeleteAPIKeyResponse{}, nil
+}
+
+func (s *Server) ListAPIKeys(ctx context.Context, req *apipb.ListAPIKeysRequest) (*apipb.ListAPIKeysResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys, _, _, err := page(s.apiKeys, req.GetFilter(), int(req.GetPage()), int(req.GetPageSize()))
+	if err != nil {
+		return nil, err
+	}
+	return apipb.ListAPIKeysResponse_builder{Keys: keys}.Build(), nil
+}
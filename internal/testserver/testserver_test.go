@@ -0,0 +1,132 @@
+// Copyright 2026 North Pole Security, Inc.
+package testserver
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	apipb "buf.build/gen/go/northpolesec/workshop-api/protocolbuffers/go/workshop/v1"
+)
+
+func TestServerRules(t *testing.T) {
+	ctx := context.Background()
+	s := New()
+	client, stop, err := s.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+
+	create := func(identifier string, policy apipb.Policy) string {
+		t.Helper()
+		resp, err := client.CreateRule(ctx, apipb.CreateRuleRequest_builder{
+			Rule: apipb.Rule_builder{Identifier: identifier, RuleType: apipb.RuleType_SIGNINGID, Policy: policy, Tag: "dev"}.Build(),
+		}.Build())
+		if err != nil {
+			t.Fatalf("CreateRule(%s) = %v", identifier, err)
+		}
+		return resp.GetRuleId()
+	}
+	first := create("platform:com.apple.curl", apipb.Policy_ALLOWLIST)
+	create("platform:com.apple.ls", apipb.Policy_ALLOWLIST)
+	// Creating a rule with the same key upserts it under a new ID.
+	upserted := create("platform:com.apple.curl", apipb.Policy_BLOCKLIST)
+	if upserted == first {
+		t.Errorf("upsert kept rule ID %s", first)
+	}
+
+	rules := s.Rules()
+	if len(rules) != 2 || rules[0].GetPolicy() != apipb.Policy_BLOCKLIST || rules[0].GetRuleId() != upserted {
+		t.Errorf("Rules() = %v, want the upserted curl rule and the ls rule", rules)
+	}
+	if got := s.Tags(); len(got) != 1 || got[0] != "dev" {
+		t.Errorf("Tags() = %v, want [dev]", got)
+	}
+
+	resp, err := client.ListRules(ctx, apipb.ListRulesRequest_builder{
+		Filter:   proto.String(`tag = "dev"`),
+		PageSize: proto.Int32(1),
+		Page:     proto.Int32(2),
+	}.Build())
+	if err != nil {
+		t.Fatalf("ListRules() = %v", err)
+	}
+	if len(resp.GetRules()) != 1 || resp.GetMore() || resp.GetCount() != 2 {
+		t.Errorf("ListRules() page 2 = %v", resp)
+	}
+
+	if _, err := client.DeleteRule(ctx, apipb.DeleteRuleRequest_builder{RuleId: proto.String(first)}.Build()); status.Code(err) != codes.NotFound {
+		t.Errorf("DeleteRule(replaced ID) = %v, want NotFound", err)
+	}
+	if _, err := client.DeleteRule(ctx, apipb.DeleteRuleRequest_builder{RuleId: proto.String(upserted)}.Build()); err != nil {
+		t.Errorf("DeleteRule() = %v", err)
+	}
+	if _, err := client.ListRules(ctx, apipb.ListRulesRequest_builder{Filter: proto.String(`tag = `)}.Build()); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ListRules(bad filter) = %v, want InvalidArgument", err)
+	}
+
+	s.Fail("ListRules", status.Error(codes.Unavailable, "down"))
+	if _, err := client.ListRules(ctx, &apipb.ListRulesRequest{}); status.Code(err) != codes.Unavailable {
+		t.Errorf("ListRules() with a failure set = %v, want Unavailable", err)
+	}
+	s.Fail("ListRules", nil)
+	if _, err := client.ListRules(ctx, &apipb.ListRulesRequest{}); err != nil {
+		t.Errorf("ListRules() after clearing the failure = %v", err)
+	}
+}
+
+func TestServerTagsAndKeys(t *testing.T) {
+	ctx := context.Background()
+	s := New()
+	client, stop, err := s.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+
+	if _, err := client.CreateTag(ctx, apipb.CreateTagRequest_builder{Tag: proto.String("dev")}.Build()); err != nil {
+		t.Fatalf("CreateTag() = %v", err)
+	}
+	if _, err := client.CreateFileAccessRule(ctx, apipb.CreateFileAccessRuleRequest_builder{
+		Rule: apipb.FileAccessRule_builder{Name: "ssh", Tag: "dev"}.Build(),
+	}.Build()); err != nil {
+		t.Fatalf("CreateFileAccessRule() = %v", err)
+	}
+	if _, err := client.RenameTag(ctx, apipb.RenameTagRequest_builder{Tag: proto.String("dev"), NewTag: proto.String("prod")}.Build()); err != nil {
+		t.Fatalf("RenameTag() = %v", err)
+	}
+	if got := s.FileAccessRules(); len(got) != 1 || got[0].GetTag() != "prod" {
+		t.Errorf("FileAccessRules() = %v, want ssh on prod", got)
+	}
+	tags, err := client.ListTags(ctx, apipb.ListTagsRequest_builder{Filter: proto.String(`tag = "prod"`)}.Build())
+	if err != nil {
+		t.Fatalf("ListTags() = %v", err)
+	}
+	if len(tags.GetTags()) != 1 || tags.GetTags()[0].GetFileAccessRuleCount() != 1 {
+		t.Errorf("ListTags() = %v, want prod with one file access rule", tags)
+	}
+
+	key := apipb.CreateAPIKeyRequest_builder{Name: proto.String("ci"), Permissions: []string{"read:rules"}}.Build()
+	if resp, err := client.CreateAPIKey(ctx, key); err != nil || resp.GetSecret() == "" {
+		t.Fatalf("CreateAPIKey() = %v, %v", resp, err)
+	}
+	if _, err := client.CreateAPIKey(ctx, key); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("CreateAPIKey(duplicate) = %v, want AlreadyExists", err)
+	}
+	if got := s.APIKeys(); len(got) != 1 || got[0].GetName() != "ci" {
+		t.Errorf("APIKeys() = %v, want ci", got)
+	}
+	expires := s.APIKeys()[0].GetExpires()
+	update := apipb.UpdateAPIKeyRequest_builder{Name: proto.String("ci"), Permissions: []string{"write:rules"}, Expires: expires}.Build()
+	if _, err := client.UpdateAPIKey(ctx, update); err != nil {
+		t.Fatalf("UpdateAPIKey() = %v", err)
+	}
+	if got := s.APIKeys()[0]; !slices.Equal(got.GetPermissions(), []string{"write:rules"}) || !proto.Equal(got.GetExpires(), expires) {
+		t.Errorf("APIKeys() after update = %v, want write:rules with the same expiry", got)
+	}
+}
@@ -3,8 +3,12 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
+	"fmt"
 	"log"
+	"os"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/northpolesec/terraform-provider-nps/internal/auth"
@@ -20,22 +24,134 @@ var (
 	// https://goreleaser.com/cookbooks/using-main.version/
 )
 
+// Exit statuses of -login that tell wrapper tooling why the user didn't
+// complete it. Any other failure exits with status 1.
+const (
+	exitLoginExpired = 3
+	exitLoginDenied  = 4
+)
+
+func loginExitCode(err error) int {
+	switch {
+	case errors.Is(err, auth.ErrLoginExpired):
+		return exitLoginExpired
+	case errors.Is(err, auth.ErrLoginDenied):
+		return exitLoginDenied
+	}
+	return 1
+}
+
 func main() {
 	var debug bool
 	var loginServer string
+	var logoutServer string
+	var loginJSON bool
+	var loginNoBrowser bool
+	var loginTimeout time.Duration
+	var schemaJSON bool
+	var generateImportsServer string
+	var exportSantaRulesTag string
+	var exportServer string
+	var santaRulesFormat string
+	var migrateFrom string
+	var migrateTag string
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.StringVar(&loginServer, "login", "", "login to the provider using the specified server")
+	flag.StringVar(&logoutServer, "logout", "", "delete the token stored by -login for the specified server")
+	flag.BoolVar(&loginJSON, "login-json", false, "with -login, report progress as JSON lines on stdout instead of opening a browser")
+	flag.BoolVar(&loginNoBrowser, "no-browser", false, "with -login, print the authorization URL without opening a browser")
+	flag.DurationVar(&loginTimeout, "login-timeout", 0, "with -login, give up if the login isn't authorized within this duration, e.g. 5m")
+	flag.BoolVar(&schemaJSON, "schema-json", false, "print the provider, resource, and data source schemas as JSON and exit")
+	flag.StringVar(&exportSantaRulesTag, "export-santa-rules", "", "print the rules for the specified tag on the server given by -server as Santa static rules")
+	flag.StringVar(&exportServer, "server", "", "with -export-santa-rules, the server to export from; required, and WORKSHOP_ENDPOINT is not read")
+	flag.StringVar(&santaRulesFormat, "santa-rules-format", "json", "with -export-santa-rules, the format to print: json for santactl rule --import, or plist for a configuration profile's StaticRules")
+	flag.StringVar(&migrateFrom, "migrate-from", "", "print nps_workshop_rule resources for the rules in the moroz or zentral export named by the argument, e.g. -migrate-from moroz global.toml")
+	flag.StringVar(&migrateTag, "migrate-tag", "global", "with -migrate-from, the tag the migrated rules apply to")
+	flag.StringVar(&generateImportsServer, "generate-imports", "", "print import blocks and resource configurations for the rules, tags, and other resources on the specified server; like -server, it takes the endpoint rather than reading WORKSHOP_ENDPOINT")
 	flag.Parse()
 
+	// The -schema-json flag dumps every schema, including validators and enum
+	// values, so that external policy tooling can stay in sync with the
+	// provider without a Terraform configuration or a Workshop instance.
+	if schemaJSON {
+		b, err := provider.ExportSchemaJSON(context.Background(), version)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		os.Stdout.Write(append(b, '\n'))
+		return
+	}
+
+	// -generate-imports onboards an existing Workshop instance: its output,
+	// saved to a .tf file, imports everything on the next apply. It
+	// authenticates like the provider, with WORKSHOP_API_KEY or a -login token.
+	if generateImportsServer != "" {
+		if err := provider.GenerateImports(context.Background(), os.Stdout, generateImportsServer); err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+
+	// -export-santa-rules writes a tag's rules in the form Santa reads
+	// locally, e.g. for air-gapped machines or to diff against a host.
+	if exportServer != "" && exportSantaRulesTag == "" {
+		log.Fatal("-server requires -export-santa-rules")
+	}
+	if exportSantaRulesTag != "" {
+		if exportServer == "" {
+			log.Fatal("-export-santa-rules requires -server, e.g. -export-santa-rules global -server api.tenant.workshop.cloud")
+		}
+		if err := provider.ExportSantaRules(context.Background(), os.Stdout, exportServer, exportSantaRulesTag, santaRulesFormat); err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+
+	// -migrate-from converts another Santa sync server's rules to
+	// configuration to review and apply, rather than creating them directly.
+	if migrateFrom != "" {
+		if flag.NArg() != 1 {
+			log.Fatal("-migrate-from requires the path of the export, e.g. -migrate-from moroz global.toml")
+		}
+		if err := provider.MigrateRules(os.Stdout, migrateFrom, flag.Arg(0), migrateTag); err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+
 	// Ordinarily a Terraform provider will only start a providerserver. This provider
 	// has a special case for the -login flag that allows the user to login to the
 	// Workshop instance and store the token so that the next time the provider runs
 	// the token will be available.
+	if (loginJSON || loginNoBrowser || loginTimeout != 0) && loginServer == "" {
+		log.Fatal("-login-json, -no-browser, and -login-timeout require -login")
+	}
 	if loginServer != "" {
-		if err := auth.GetAndStoreToken(context.Background(), loginServer); err != nil {
+		err := auth.GetAndStoreToken(context.Background(), loginServer, auth.LoginOptions{
+			JSON:      loginJSON,
+			NoBrowser: loginNoBrowser,
+			Timeout:   loginTimeout,
+		})
+		if err != nil {
+			log.Print(err.Error())
+			os.Exit(loginExitCode(err))
+		}
+		return
+	}
+
+	// -logout removes what -login stored, e.g. when a shared workstation or
+	// CI runner is done with it.
+	if logoutServer != "" {
+		deleted, err := auth.Logout(logoutServer)
+		if err != nil {
 			log.Fatal(err.Error())
 		}
+		if deleted {
+			fmt.Println("Successfully logged out")
+		} else {
+			fmt.Println("Not logged in")
+		}
 		return
 	}
 